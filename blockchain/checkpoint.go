@@ -0,0 +1,158 @@
+package blockchain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Checkpointer persists the number of the last block processed by an events listener.
+type Checkpointer interface {
+	// Load returns the last stored block number or false if no block stored yet.
+	Load() (types.BlockNumber, bool, error)
+	// Store stores the number of a processed block.
+	Store(blockNumber types.BlockNumber) error
+}
+
+// FileCheckpointer stores the block number in a file. The file is replaced atomically, so it
+// always contains a complete block number even if the process crashes while storing.
+type FileCheckpointer struct {
+	path string
+}
+
+func NewFileCheckpointer(path string) *FileCheckpointer {
+	return &FileCheckpointer{path: path}
+}
+
+func (f *FileCheckpointer) Load() (types.BlockNumber, bool, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	blockNumber, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 32)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid checkpoint file %s: %w", f.path, err)
+	}
+
+	return types.BlockNumber(blockNumber), true, nil
+}
+
+func (f *FileCheckpointer) Store(blockNumber types.BlockNumber) error {
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(strconv.FormatUint(uint64(blockNumber), 10)); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// SqlDialect is a SQL database dialect of the checkpoints queries.
+type SqlDialect int
+
+const (
+	// MySQL uses "?" placeholders and stores with INSERT ... ON DUPLICATE KEY UPDATE.
+	MySQL SqlDialect = iota
+	// SQLite uses "?" placeholders and stores with INSERT ... ON CONFLICT DO UPDATE (SQLite 3.24+).
+	SQLite
+	// PostgreSQL uses "$1" placeholders and stores with INSERT ... ON CONFLICT DO UPDATE.
+	PostgreSQL
+)
+
+// SqlCheckpointer stores block numbers in a SQL table by checkpoint name, so multiple listeners
+// may share a table. Create the table with CreateTable or with a migration:
+//
+//	CREATE TABLE checkpoints (name VARCHAR(255) PRIMARY KEY, block_number BIGINT NOT NULL)
+type SqlCheckpointer struct {
+	db      *sql.DB
+	table   string
+	name    string
+	dialect SqlDialect
+}
+
+func NewSqlCheckpointer(db *sql.DB, table string, name string, dialect SqlDialect) *SqlCheckpointer {
+	return &SqlCheckpointer{
+		db:      db,
+		table:   table,
+		name:    name,
+		dialect: dialect,
+	}
+}
+
+// CreateTable creates the checkpoints table if it doesn't exist.
+func (s *SqlCheckpointer) CreateTable(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+s.table+
+		" (name VARCHAR(255) PRIMARY KEY, block_number BIGINT NOT NULL)")
+
+	return err
+}
+
+func (s *SqlCheckpointer) Load() (types.BlockNumber, bool, error) {
+	var blockNumber int64
+	err := s.db.QueryRow(s.query("SELECT block_number FROM %s WHERE name = %s"), s.name).Scan(&blockNumber)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return types.BlockNumber(blockNumber), true, nil
+}
+
+// Store inserts or updates the checkpoint with a single upsert statement, so concurrent writers
+// of the same checkpoint never fail on the primary key.
+func (s *SqlCheckpointer) Store(blockNumber types.BlockNumber) error {
+	_, err := s.db.Exec(s.upsertQuery(), s.name, int64(blockNumber))
+
+	return err
+}
+
+func (s *SqlCheckpointer) upsertQuery() string {
+	if s.dialect == MySQL {
+		return s.query("INSERT INTO %s (name, block_number) VALUES (%s, %s) " +
+			"ON DUPLICATE KEY UPDATE block_number = VALUES(block_number)")
+	}
+
+	return s.query("INSERT INTO %s (name, block_number) VALUES (%s, %s) " +
+		"ON CONFLICT (name) DO UPDATE SET block_number = excluded.block_number")
+}
+
+// query formats the query with the table name and parameters placeholders.
+func (s *SqlCheckpointer) query(format string) string {
+	n := strings.Count(format, "%s") - 1
+	args := make([]any, 0, n+1)
+	args = append(args, s.table)
+	for i := 1; i <= n; i++ {
+		if s.dialect == PostgreSQL {
+			args = append(args, "$"+strconv.Itoa(i))
+		} else {
+			args = append(args, "?")
+		}
+	}
+
+	return fmt.Sprintf(format, args...)
+}
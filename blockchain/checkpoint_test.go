@@ -0,0 +1,123 @@
+package blockchain
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileCheckpointer(t *testing.T) {
+	//given
+	checkpointer := NewFileCheckpointer(filepath.Join(t.TempDir(), "checkpoint"))
+
+	//when
+	_, okBefore, errBefore := checkpointer.Load()
+	errStore1 := checkpointer.Store(10)
+	errStore2 := checkpointer.Store(11)
+	blockNumber, ok, err := checkpointer.Load()
+
+	//then
+	assert.NoError(t, errBefore)
+	assert.False(t, okBefore)
+	assert.NoError(t, errStore1)
+	assert.NoError(t, errStore2)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, types.BlockNumber(11), blockNumber)
+}
+
+func TestSqlCheckpointerQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		dialect    SqlDialect
+		wantSelect string
+		wantUpsert string
+	}{
+		{
+			name:       "mysql",
+			dialect:    MySQL,
+			wantSelect: "SELECT block_number FROM checkpoints WHERE name = ?",
+			wantUpsert: "INSERT INTO checkpoints (name, block_number) VALUES (?, ?) " +
+				"ON DUPLICATE KEY UPDATE block_number = VALUES(block_number)",
+		},
+		{
+			name:       "sqlite",
+			dialect:    SQLite,
+			wantSelect: "SELECT block_number FROM checkpoints WHERE name = ?",
+			wantUpsert: "INSERT INTO checkpoints (name, block_number) VALUES (?, ?) " +
+				"ON CONFLICT (name) DO UPDATE SET block_number = excluded.block_number",
+		},
+		{
+			name:       "postgresql",
+			dialect:    PostgreSQL,
+			wantSelect: "SELECT block_number FROM checkpoints WHERE name = $1",
+			wantUpsert: "INSERT INTO checkpoints (name, block_number) VALUES ($1, $2) " +
+				"ON CONFLICT (name) DO UPDATE SET block_number = excluded.block_number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkpointer := NewSqlCheckpointer(nil, "checkpoints", "listener", tt.dialect)
+
+			assert.Equal(t, tt.wantSelect, checkpointer.query("SELECT block_number FROM %s WHERE name = %s"))
+			assert.Equal(t, tt.wantUpsert, checkpointer.upsertQuery())
+		})
+	}
+}
+
+func TestEventsListenerCheckpoint(t *testing.T) {
+	//given
+	checkpointer := NewFileCheckpointer(filepath.Join(t.TempDir(), "checkpoint"))
+	assert.NoError(t, checkpointer.Store(5))
+
+	var handled []types.BlockNumber
+	listener := &eventsListener{checkpointer: checkpointer}
+	listener.callback = func(_ []*parser.Event, eventCtx EventContext) error {
+		handled = append(handled, eventCtx.BlockNumber)
+		return nil
+	}
+
+	//when
+	for blockNumber := types.BlockNumber(3); blockNumber <= 7; blockNumber++ {
+		assert.NoError(t, listener.handle(nil, EventContext{BlockNumber: blockNumber}, 0))
+	}
+
+	//then
+	assert.Equal(t, []types.BlockNumber{6, 7}, handled)
+	stored, _, _ := checkpointer.Load()
+	assert.Equal(t, types.BlockNumber(7), stored)
+}
+
+func TestEventsListenerCheckpointReconnect(t *testing.T) {
+	//given
+	checkpointer := NewFileCheckpointer(filepath.Join(t.TempDir(), "checkpoint"))
+	assert.NoError(t, checkpointer.Store(5))
+
+	var handled []types.BlockNumber
+	listener := &eventsListener{checkpointer: checkpointer}
+	listener.callback = func(_ []*parser.Event, eventCtx EventContext) error {
+		handled = append(handled, eventCtx.BlockNumber)
+		return nil
+	}
+	listen := func(first, last types.BlockNumber) types.BlockNumber {
+		from, _, err := listener.loadCheckpoint()
+		assert.NoError(t, err)
+		for blockNumber := first; blockNumber <= last; blockNumber++ {
+			assert.NoError(t, listener.handle(nil, EventContext{BlockNumber: blockNumber}, 0))
+		}
+		return from
+	}
+
+	//when
+	firstFrom := listen(3, 7)
+	reconnectFrom := listen(6, 9)
+
+	//then
+	assert.Equal(t, types.BlockNumber(6), firstFrom)
+	assert.Equal(t, types.BlockNumber(8), reconnectFrom)
+	assert.Equal(t, []types.BlockNumber{6, 7, 8, 9}, handled)
+}
@@ -2,21 +2,23 @@ package blockchain
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/exec"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/retriever"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain/pallets"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors"
 )
 
 const (
@@ -25,16 +27,55 @@ const (
 )
 
 var (
-	ErrHeaderChannelClosed = errors.New("header channel closed")
+	ErrHeaderChannelClosed = ddcerrors.New(ddcerrors.CodeRpc, "header channel closed")
 )
 
-type EventsListener func(events []*parser.Event, blockNumber types.BlockNumber, blockHash types.Hash) error
+// EventContext describes the block which events are delivered to an events listener.
+type EventContext struct {
+	BlockNumber types.BlockNumber
+	BlockHash   types.Hash
+	// Finalized reports whether the block was already finalized when its events were delivered.
+	// Events of a block which is not finalized yet may be reverted by a chain re-organization.
+	Finalized bool
+	// Timestamp is the block time set by the timestamp pallet. Zero if the chain has no timestamp
+	// pallet.
+	Timestamp time.Time
+}
+
+type EventsListener func(events []*parser.Event, eventCtx EventContext) error
+
+// RuntimeUpgrade describes a runtime upgrade applied in a block. Metadata is the metadata of the
+// new runtime which the client, pallet APIs and events decoding are already switched to when
+// runtime upgrade hooks are called.
+type RuntimeUpgrade struct {
+	BlockNumber types.BlockNumber
+	BlockHash   types.Hash
+	SpecVersion types.U32
+	Metadata    *types.Metadata
+}
+
+type RuntimeUpgradeHook func(upgrade RuntimeUpgrade)
 
 type Client struct {
 	*gsrpc.SubstrateAPI
 
-	mu              sync.Mutex
-	eventsListeners map[*EventsListener]struct{}
+	mu                  sync.Mutex
+	eventsListeners     map[*eventsListener]struct{}
+	runtimeUpgradeHooks map[*RuntimeUpgradeHook]struct{}
+
+	retrieverMu sync.Mutex
+	retriever   retriever.EventRetriever
+
+	backfill BackfillParameters
+
+	polling      bool
+	pollInterval time.Duration
+
+	archive          *gsrpc.SubstrateAPI
+	archiveMu        sync.Mutex
+	archiveDownUntil time.Time
+
+	decodeStrictness pallets.DecodeStrictness
 
 	DdcClusters  pallets.DdcClustersApi
 	DdcCustomers pallets.DdcCustomersApi
@@ -42,23 +83,106 @@ type Client struct {
 	DdcPayouts   pallets.DdcPayoutsApi
 }
 
-func NewClient(url string) (*Client, error) {
+// BlockView is a snapshot of pallets storage at a block. All its pallet APIs read the storage at
+// the same block so multiple items read through the view are consistent with each other.
+type BlockView struct {
+	BlockHash types.Hash
+
+	DdcClusters  pallets.DdcClustersApi
+	DdcCustomers pallets.DdcCustomersApi
+	DdcNodes     pallets.DdcNodesApi
+	DdcPayouts   pallets.DdcPayoutsApi
+}
+
+// ClientOption configures optional Client behavior.
+type ClientOption func(c *Client)
+
+// WithBackfill configures retrieval of events of historical blocks in ListenEvents.
+func WithBackfill(params BackfillParameters) ClientOption {
+	return func(c *Client) {
+		c.backfill = params
+	}
+}
+
+// WithDecodeStrictness sets decoding of enum variants unknown to this SDK by the pallet APIs of
+// the client and its AtBlock views. It's pallets.DecodeStrict by default.
+func WithDecodeStrictness(strictness pallets.DecodeStrictness) ClientOption {
+	return func(c *Client) {
+		c.decodeStrictness = strictness
+	}
+}
+
+func NewClient(url string, opts ...ClientOption) (*Client, error) {
 	substrateApi, err := gsrpc.NewSubstrateAPI(url)
 	if err != nil {
 		return nil, err
 	}
+
+	return newClient(substrateApi, opts...)
+}
+
+// NewClientWithTransport creates a client which sends JSON-RPC requests through the given
+// transport instead of connecting to a node by URL. Use it to run against an embedded light
+// client, e.g. smoldot started with the chain spec in the same process, which serves JSON-RPC
+// requests with storage reads verified against finalized headers. Storage reads, events
+// subscriptions and extrinsics submission all go through the transport.
+func NewClientWithTransport(transport client.Client, opts ...ClientOption) (*Client, error) {
+	rpcApi, err := rpc.NewRPC(transport)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(&gsrpc.SubstrateAPI{RPC: rpcApi, Client: transport}, opts...)
+}
+
+func newClient(substrateApi *gsrpc.SubstrateAPI, opts ...ClientOption) (*Client, error) {
 	meta, err := substrateApi.RPC.State.GetMetadataLatest()
 	if err != nil {
 		return nil, err
 	}
 
-	return &Client{
-		SubstrateAPI:    substrateApi,
-		eventsListeners: make(map[*EventsListener]struct{}),
-		DdcClusters:     pallets.NewDdcClustersApi(substrateApi, meta),
-		DdcCustomers:    pallets.NewDdcCustomersApi(substrateApi, meta),
-		DdcNodes:        pallets.NewDdcNodesApi(substrateApi, meta),
-		DdcPayouts:      pallets.NewDdcPayoutsApi(substrateApi, meta),
+	c := &Client{
+		SubstrateAPI:        substrateApi,
+		eventsListeners:     make(map[*eventsListener]struct{}),
+		runtimeUpgradeHooks: make(map[*RuntimeUpgradeHook]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	strictness := pallets.WithDecodeStrictness(c.decodeStrictness)
+	c.DdcClusters = pallets.NewDdcClustersApi(substrateApi, meta, strictness)
+	c.DdcCustomers = pallets.NewDdcCustomersApi(substrateApi, meta)
+	c.DdcNodes = pallets.NewDdcNodesApi(substrateApi, meta, strictness)
+	c.DdcPayouts = pallets.NewDdcPayoutsApi(substrateApi, meta)
+
+	return c, nil
+}
+
+// AtBlock returns a view of pallets storage at the block with the given hash. It uses the metadata
+// of the runtime at that block, so the view stays valid for blocks before a runtime upgrade.
+//
+// The view reads the archive node configured with WithArchive unless it failed recently.
+func (c *Client) AtBlock(blockHash types.Hash) (*BlockView, error) {
+	api := c.historical()
+	meta, err := api.RPC.State.GetMetadata(blockHash)
+	if err != nil && c.downgradeArchive(api) {
+		api = c.SubstrateAPI
+		meta, err = api.RPC.State.GetMetadata(blockHash)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	strictness := pallets.WithDecodeStrictness(c.decodeStrictness)
+
+	return &BlockView{
+		BlockHash:    blockHash,
+		DdcClusters:  pallets.NewDdcClustersApiAt(api, meta, blockHash, strictness),
+		DdcCustomers: pallets.NewDdcCustomersApiAt(api, meta, blockHash),
+		DdcNodes:     pallets.NewDdcNodesApiAt(api, meta, blockHash, strictness),
+		DdcPayouts:   pallets.NewDdcPayoutsApiAt(api, meta, blockHash),
 	}, nil
 }
 
@@ -66,6 +190,16 @@ func NewClient(url string) (*Client, error) {
 // process incoming events. It starts from the block begin and calls callback after when all events
 // listeners already called on a block events.
 //
+// Listeners registered with a Checkpointer continue from the block after their last stored
+// checkpoint, so ListenEvents may start before the block begin. Other listeners receive events
+// starting from the block begin.
+//
+// New blocks come from the new heads subscription, or from polling the best head if the transport
+// doesn't support subscriptions, see WithPolling.
+//
+// Panics of listeners are recovered and returned as ListenerPanicError. Listeners registered with
+// a FailurePolicy don't stop listening on errors and panics.
+//
 // ListenEvents always returns a non-nil error from a registered events listener or a callback
 // after.
 func (c *Client) ListenEvents(
@@ -73,23 +207,25 @@ func (c *Client) ListenEvents(
 	begin types.BlockNumber,
 	after func(blockNumber types.BlockNumber, blockHash types.Hash) error,
 ) error {
-	sub, err := c.RPC.Chain.SubscribeNewHeads()
-	if err != nil {
-		return err
+	// Start from the earliest block not yet processed by listeners with checkpoints.
+	start := begin
+	for _, listener := range c.listeners() {
+		from, ok, err := listener.loadCheckpoint()
+		if err != nil {
+			return fmt.Errorf("load checkpoint: %w", err)
+		}
+		if ok && from < start {
+			start = from
+		}
 	}
 
-	retriever, err := retriever.NewEventRetriever(
-		parser.NewEventParser(),
-		state.NewEventProvider(c.RPC.State),
-		c.RPC.State,
-		registry.NewFactory(),
-		exec.NewRetryableExecutor[*types.StorageDataRaw](exec.WithMaxRetryCount(0)),
-		exec.NewRetryableExecutor[[]*parser.Event](exec.WithMaxRetryCount(0)),
-	)
+	sub, err := c.subscribeNewHeads()
 	if err != nil {
 		return err
 	}
 
+	progress := newBackfillProgress(c.backfill.Progress)
+
 	g, ctx := errgroup.WithContext(ctx)
 
 	liveHeadersC := sub.Chan()
@@ -112,21 +248,14 @@ func (c *Client) ListenEvents(
 			return err
 		}
 
-		for block := begin; block < firstLiveHeader.Number; block++ {
-			blockHash, err := c.RPC.Chain.GetBlockHash(uint64(block))
-			if err != nil {
-				return err
-			}
-
-			header, err := c.RPC.Chain.GetHeader(blockHash)
-			if err != nil {
-				return err
-			}
+		progress.SetTarget(firstLiveHeader.Number)
 
+		// Events retrieval needs only the block number, so historical headers are not fetched.
+		for block := start; block < firstLiveHeader.Number; block++ {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
-			case histHeadersC <- *header:
+			case histHeadersC <- types.Header{Number: block}:
 			}
 		}
 
@@ -145,11 +274,7 @@ func (c *Client) ListenEvents(
 	g.Go(func() error {
 		defer close(headersC)
 
-		if err = forwardHeaders(ctx, histHeadersC, headersC); err != nil {
-			return err
-		}
-
-		return forwardHeaders(ctx, liveHeadersC, headersC)
+		return sequenceHeaders(ctx, histHeadersC, liveHeadersC, headersC)
 	})
 
 	// Retrieve events skipping blocks before 'begin'.
@@ -157,54 +282,41 @@ func (c *Client) ListenEvents(
 	defer close(eventsC)
 
 	g.Go(func() error {
-		for {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case header, ok := <-headersC:
-				if !ok {
-					return ErrHeaderChannelClosed
-				}
-
-				if header.Number < begin {
-					continue
-				}
-
-				hash, err := c.RPC.Chain.GetBlockHash(uint64(header.Number))
-				if err != nil {
-					return err
-				}
-
-				events, err := retriever.GetEvents(hash)
-				if err != nil {
-					return err
-				}
-
-				select {
-				case <-ctx.Done():
-					return ctx.Err()
-				case eventsC <- blockEvents{
-					Events: events,
-					Hash:   hash,
-					Number: header.Number,
-				}:
-				}
-			}
-		}
+		return c.retrieveEvents(ctx, start, headersC, eventsC, progress)
 	})
 
 	// Invoke listeners.
 	g.Go(func() error {
+		var finalized types.BlockNumber
+
 		for {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
 			case blockEvents := <-eventsC:
-				for callback := range c.eventsListeners {
-					err := (*callback)(blockEvents.Events, blockEvents.Number, blockEvents.Hash)
+				// Finalized head is only queried for blocks after the last known finalized block.
+				if blockEvents.Number > finalized {
+					var err error
+					if finalized, err = c.getFinalizedNumber(); err != nil {
+						return fmt.Errorf("get finalized head: %w", err)
+					}
+				}
+
+				eventCtx := EventContext{
+					BlockNumber: blockEvents.Number,
+					BlockHash:   blockEvents.Hash,
+					Finalized:   blockEvents.Number <= finalized,
+					Timestamp:   blockEvents.Timestamp,
+				}
+
+				for _, listener := range c.listeners() {
+					unregister, err := listener.tolerate(listener.handle(blockEvents.Events, eventCtx, begin))
 					if err != nil {
 						return fmt.Errorf("callback func failed: %w", err)
 					}
+					if unregister {
+						c.unregisterEventsListener(listener)
+					}
 				}
 
 				if after != nil {
@@ -226,23 +338,59 @@ func (c *Client) ListenEvents(
 	return g.Wait()
 }
 
-func forwardHeaders(ctx context.Context, from <-chan types.Header, to chan types.Header) error {
-	for {
+// sequenceHeaders forwards historical headers and then live headers so that each block number is
+// forwarded exactly once and in ascending order. Live headers of already forwarded blocks, which
+// come when the backfill overlaps the subscription start or the best chain is re-organized, are
+// dropped. Gaps between live headers are filled with headers having only the block number set.
+func sequenceHeaders(ctx context.Context, hist <-chan types.Header, live <-chan types.Header, to chan<- types.Header) error {
+	var next types.BlockNumber
+	started := false
+
+	send := func(header types.Header) error {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case header, ok := <-from:
-			if !ok {
-				return nil
-			}
+		case to <- header:
+		}
+
+		next = header.Number + 1
+		started = true
 
+		return nil
+	}
+
+	forward := func(from <-chan types.Header) error {
+		for {
 			select {
 			case <-ctx.Done():
 				return ctx.Err()
-			case to <- header:
+			case header, ok := <-from:
+				if !ok {
+					return nil
+				}
+
+				if started && header.Number < next {
+					continue
+				}
+
+				for started && next < header.Number {
+					if err := send(types.Header{Number: next}); err != nil {
+						return err
+					}
+				}
+
+				if err := send(header); err != nil {
+					return err
+				}
 			}
 		}
 	}
+
+	if err := forward(hist); err != nil {
+		return err
+	}
+
+	return forward(live)
 }
 
 func getFirstLiveHeader(ctx context.Context, c <-chan types.Header) (types.Header, error) {
@@ -258,24 +406,168 @@ func getFirstLiveHeader(ctx context.Context, c <-chan types.Header) (types.Heade
 	}
 }
 
-// RegisterEventsListener subscribes given callback to blockchain events.
-func (c *Client) RegisterEventsListener(callback EventsListener) context.CancelFunc {
+func (c *Client) getFinalizedNumber() (types.BlockNumber, error) {
+	hash, err := c.RPC.Chain.GetFinalizedHead()
+	if err != nil {
+		return 0, err
+	}
+
+	header, err := c.RPC.Chain.GetHeader(hash)
+	if err != nil {
+		return 0, err
+	}
+
+	return header.Number, nil
+}
+
+// GetEvents returns decoded events of the block with the given hash.
+func (c *Client) GetEvents(blockHash types.Hash) ([]*parser.Event, error) {
+	c.retrieverMu.Lock()
+	defer c.retrieverMu.Unlock()
+
+	if c.retriever == nil {
+		r, err := c.newEventRetriever(c.SubstrateAPI)
+		if err != nil {
+			return nil, err
+		}
+		c.retriever = r
+	}
+
+	events, err := c.retriever.GetEvents(blockHash)
+	if err != nil {
+		return nil, ddcerrors.Wrap(ddcerrors.CodeRpc, err)
+	}
+
+	return events, nil
+}
+
+func (c *Client) newEventRetriever(api *gsrpc.SubstrateAPI) (retriever.EventRetriever, error) {
+	return retriever.NewEventRetriever(
+		parser.NewEventParser(),
+		state.NewEventProvider(api.RPC.State),
+		api.RPC.State,
+		registry.NewFactory(),
+		exec.NewRetryableExecutor[*types.StorageDataRaw](exec.WithMaxRetryCount(0)),
+		exec.NewRetryableExecutor[[]*parser.Event](exec.WithMaxRetryCount(0)),
+	)
+}
+
+// OnRuntimeUpgrade subscribes given callback to runtime upgrades. Runtime upgrades are detected
+// by the System.CodeUpdated event while listening events with ListenEvents.
+func (c *Client) OnRuntimeUpgrade(callback RuntimeUpgradeHook) context.CancelFunc {
 	c.mu.Lock()
-	c.eventsListeners[&callback] = struct{}{}
+	c.runtimeUpgradeHooks[&callback] = struct{}{}
 	c.mu.Unlock()
 
 	once := sync.Once{}
 	return func() {
 		once.Do(func() {
 			c.mu.Lock()
-			delete(c.eventsListeners, &callback)
+			delete(c.runtimeUpgradeHooks, &callback)
 			c.mu.Unlock()
 		})
 	}
 }
 
+// applyRuntimeUpgrade refreshes metadata of pallet APIs and events decoding and calls runtime
+// upgrade hooks.
+func (c *Client) applyRuntimeUpgrade(blockNumber types.BlockNumber, blockHash types.Hash) error {
+	api := c.historical()
+	meta, err := api.RPC.State.GetMetadata(blockHash)
+	if err != nil && c.downgradeArchive(api) {
+		api = c.SubstrateAPI
+		meta, err = api.RPC.State.GetMetadata(blockHash)
+	}
+	if err != nil {
+		return err
+	}
+
+	runtimeVersion, err := api.RPC.State.GetRuntimeVersion(blockHash)
+	if err != nil {
+		return err
+	}
+
+	for _, api := range []any{c.DdcClusters, c.DdcCustomers, c.DdcNodes, c.DdcPayouts} {
+		if updater, ok := api.(pallets.MetadataUpdater); ok {
+			updater.UpdateMetadata(meta)
+		}
+	}
+
+	c.retrieverMu.Lock()
+	c.retriever = nil
+	c.retrieverMu.Unlock()
+
+	upgrade := RuntimeUpgrade{
+		BlockNumber: blockNumber,
+		BlockHash:   blockHash,
+		SpecVersion: runtimeVersion.SpecVersion,
+		Metadata:    meta,
+	}
+
+	c.mu.Lock()
+	hooks := make([]RuntimeUpgradeHook, 0, len(c.runtimeUpgradeHooks))
+	for hook := range c.runtimeUpgradeHooks {
+		hooks = append(hooks, *hook)
+	}
+	c.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(upgrade)
+	}
+
+	return nil
+}
+
+func hasCodeUpdatedEvent(events []*parser.Event) bool {
+	for _, event := range events {
+		if event.Name == "System.CodeUpdated" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RegisterEventsListener subscribes given callback to blockchain events.
+func (c *Client) RegisterEventsListener(callback EventsListener, opts ...ListenerOption) context.CancelFunc {
+	listener := &eventsListener{callback: callback}
+	for _, opt := range opts {
+		opt(listener)
+	}
+
+	c.mu.Lock()
+	c.eventsListeners[listener] = struct{}{}
+	c.mu.Unlock()
+
+	once := sync.Once{}
+	return func() {
+		once.Do(func() {
+			c.unregisterEventsListener(listener)
+		})
+	}
+}
+
+func (c *Client) unregisterEventsListener(listener *eventsListener) {
+	c.mu.Lock()
+	delete(c.eventsListeners, listener)
+	c.mu.Unlock()
+}
+
+func (c *Client) listeners() []*eventsListener {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	listeners := make([]*eventsListener, 0, len(c.eventsListeners))
+	for listener := range c.eventsListeners {
+		listeners = append(listeners, listener)
+	}
+
+	return listeners
+}
+
 type blockEvents struct {
-	Events []*parser.Event
-	Hash   types.Hash
-	Number types.BlockNumber
+	Events    []*parser.Event
+	Hash      types.Hash
+	Number    types.BlockNumber
+	Timestamp time.Time
 }
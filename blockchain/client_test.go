@@ -0,0 +1,91 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSequenceHeaders(t *testing.T) {
+	tests := []struct {
+		name string
+		hist []types.BlockNumber
+		live []types.BlockNumber
+		want []types.BlockNumber
+	}{
+		{
+			name: "no overlap",
+			hist: []types.BlockNumber{1, 2, 3},
+			live: []types.BlockNumber{4, 5},
+			want: []types.BlockNumber{1, 2, 3, 4, 5},
+		},
+		{
+			name: "live overlaps backfill",
+			hist: []types.BlockNumber{1, 2, 3, 4},
+			live: []types.BlockNumber{3, 4, 5},
+			want: []types.BlockNumber{1, 2, 3, 4, 5},
+		},
+		{
+			name: "duplicated and re-organized live headers",
+			hist: []types.BlockNumber{1, 2},
+			live: []types.BlockNumber{3, 3, 4, 2, 4, 5},
+			want: []types.BlockNumber{1, 2, 3, 4, 5},
+		},
+		{
+			name: "gap in live headers",
+			hist: []types.BlockNumber{1},
+			live: []types.BlockNumber{2, 5},
+			want: []types.BlockNumber{1, 2, 3, 4, 5},
+		},
+		{
+			name: "no backfill",
+			live: []types.BlockNumber{7, 8},
+			want: []types.BlockNumber{7, 8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			hist := headersChan(tt.hist)
+			live := headersChan(tt.live)
+			to := make(chan types.Header, 100)
+
+			//when
+			err := sequenceHeaders(context.Background(), hist, live, to)
+			close(to)
+
+			//then
+			assert.NoError(t, err)
+			var got []types.BlockNumber
+			for header := range to {
+				got = append(got, header.Number)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSequenceHeadersCanceled(t *testing.T) {
+	//given
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	//when
+	err := sequenceHeaders(ctx, make(chan types.Header), make(chan types.Header), make(chan types.Header))
+
+	//then
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func headersChan(numbers []types.BlockNumber) <-chan types.Header {
+	c := make(chan types.Header, len(numbers))
+	for _, number := range numbers {
+		c <- types.Header{Number: number}
+	}
+	close(c)
+
+	return c
+}
@@ -0,0 +1,479 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var primitives = map[types.Si0TypeDefPrimitive]string{
+	types.IsBool: "types.Bool",
+	types.IsChar: "types.U32",
+	types.IsStr:  "types.Text",
+	types.IsU8:   "types.U8",
+	types.IsU16:  "types.U16",
+	types.IsU32:  "types.U32",
+	types.IsU64:  "types.U64",
+	types.IsU128: "types.U128",
+	types.IsU256: "types.U256",
+	types.IsI8:   "types.I8",
+	types.IsI16:  "types.I16",
+	types.IsI32:  "types.I32",
+	types.IsI64:  "types.I64",
+	types.IsI128: "types.I128",
+	types.IsI256: "types.I256",
+}
+
+// wellKnown are types of the runtime which have gsrpc counterparts, by the last segment of the path.
+var wellKnown = map[string]string{
+	"AccountId32": "types.AccountID",
+	"H160":        "types.H160",
+	"H256":        "types.H256",
+}
+
+type generator struct {
+	lookup map[int64]types.Si1Type
+	// names are Go names of the declared types by the type id.
+	names map[int64]string
+	taken map[string]bool
+	// queue is declared types waiting for their declaration to be written.
+	queue []int64
+
+	body   bytes.Buffer
+	decls  bytes.Buffer
+	events []string
+	err    error
+
+	usesEnums   bool
+	usesStorage bool
+}
+
+// generate returns the unformatted source of bindings of the pallets.
+func generate(meta *types.Metadata, pallets []string, pkgName string) ([]byte, error) {
+	g := &generator{
+		lookup: make(map[int64]types.Si1Type),
+		names:  make(map[int64]string),
+		taken:  map[string]bool{"EventRecords": true},
+	}
+	for _, t := range meta.AsMetadataV14.Lookup.Types {
+		g.lookup[t.ID.Int64()] = t.Type
+	}
+
+	for _, name := range pallets {
+		name = strings.TrimSpace(name)
+		pallet, ok := findPallet(meta, name)
+		if !ok {
+			return nil, fmt.Errorf("pallet %s not found in the metadata", name)
+		}
+		g.pallet(pallet)
+	}
+	for len(g.queue) > 0 {
+		id := g.queue[0]
+		g.queue = g.queue[1:]
+		g.declaration(id)
+	}
+	if g.err != nil {
+		return nil, g.err
+	}
+
+	out := &bytes.Buffer{}
+	fmt.Fprintf(out, "// Code generated by metagen for %s. DO NOT EDIT.\n\n", strings.Join(pallets, ", "))
+	fmt.Fprintf(out, "package %s\n\n", pkgName)
+	fmt.Fprintln(out, "import (")
+	if g.usesEnums {
+		fmt.Fprintln(out, `"fmt"`)
+	}
+	if g.usesStorage {
+		fmt.Fprintln(out, `gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"`)
+	}
+	if g.usesEnums {
+		fmt.Fprintln(out, `"github.com/centrifuge/go-substrate-rpc-client/v4/scale"`)
+	}
+	fmt.Fprintln(out, `"github.com/centrifuge/go-substrate-rpc-client/v4/types"`)
+	if g.usesStorage {
+		fmt.Fprintln(out, `"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"`)
+	}
+	fmt.Fprintln(out, ")")
+	out.Write(g.body.Bytes())
+	out.Write(g.decls.Bytes())
+
+	if len(g.events) > 0 {
+		fmt.Fprintln(out, "\n// EventRecords decodes events of the pallets with types.EventRecordsRaw.DecodeEventRecords.")
+		fmt.Fprintln(out, "type EventRecords struct {\ntypes.EventRecords")
+		for _, event := range g.events {
+			fmt.Fprintln(out, event)
+		}
+		fmt.Fprintln(out, "}")
+	}
+	if g.usesStorage {
+		out.WriteString(storageHelpers)
+	}
+
+	return out.Bytes(), nil
+}
+
+func findPallet(meta *types.Metadata, name string) (types.PalletMetadataV14, bool) {
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if string(pallet.Name) == name {
+			return pallet, true
+		}
+	}
+	return types.PalletMetadataV14{}, false
+}
+
+func (g *generator) pallet(pallet types.PalletMetadataV14) {
+	name := exported(string(pallet.Name))
+	if pallet.HasStorage {
+		g.storage(name, pallet)
+	}
+	if pallet.HasCalls {
+		g.calls(name, pallet)
+	}
+	if pallet.HasEvents {
+		g.eventStructs(name, pallet)
+	}
+}
+
+func (g *generator) storage(name string, pallet types.PalletMetadataV14) {
+	g.usesStorage = true
+	storage := name + "Storage"
+	g.taken[storage] = true
+
+	fmt.Fprintf(&g.body, "\n// %s reads the storage of the %s pallet at the block or at the chain head if the\n", storage, pallet.Name)
+	fmt.Fprintf(&g.body, "// block hash is nil.\ntype %s struct {\n", storage)
+	fmt.Fprintln(&g.body, "substrateApi *gsrpc.SubstrateAPI\nmeta *types.Metadata\nblockHash *types.Hash\n}")
+	fmt.Fprintf(&g.body, "\nfunc New%s(substrateApi *gsrpc.SubstrateAPI, meta *types.Metadata, blockHash *types.Hash) *%s {\n", storage, storage)
+	fmt.Fprintf(&g.body, "return &%s{substrateApi: substrateApi, meta: meta, blockHash: blockHash}\n}\n", storage)
+
+	for _, item := range pallet.Storage.Items {
+		var keyTypes []string
+		value := item.Type.AsPlainType
+		if item.Type.IsMap {
+			value = item.Type.AsMap.Value
+			keyTypes = g.storageKeyTypes(item.Type.AsMap)
+		}
+		valueType := g.goType(value)
+
+		params := make([]string, len(keyTypes))
+		args := make([]string, len(keyTypes))
+		for i, keyType := range keyTypes {
+			args[i] = fmt.Sprintf("key%d", i)
+			params[i] = args[i] + " " + keyType
+		}
+
+		fmt.Fprintf(&g.body, "\n// %s reads %s.%s, none if it isn't set even if the item has a default.\n", exported(string(item.Name)), pallet.Name, item.Name)
+		writeDocs(&g.body, item.Documentation)
+		fmt.Fprintf(&g.body, "func (s *%s) %s(%s) (types.Option[%s], error) {\n", storage, exported(string(item.Name)), strings.Join(params, ", "), valueType)
+		fmt.Fprintf(&g.body, "key, err := storageKey(s.meta, %q, %q%s)\n", pallet.Storage.Prefix, item.Name, joinArgs(args))
+		fmt.Fprintf(&g.body, "if err != nil {\nreturn types.NewEmptyOption[%s](), err\n}\n\n", valueType)
+		fmt.Fprintf(&g.body, "return readStorage[%s](s.substrateApi, s.blockHash, key)\n}\n", valueType)
+	}
+}
+
+// storageKeyTypes returns types of the map keys. A map with several hashers has a tuple key, one
+// argument per tuple element.
+func (g *generator) storageKeyTypes(m types.MapTypeV14) []string {
+	key := g.lookup[m.Key.Int64()]
+	if len(m.Hashers) > 1 && key.Def.IsTuple && len(key.Def.Tuple) == len(m.Hashers) {
+		keyTypes := make([]string, len(key.Def.Tuple))
+		for i, id := range key.Def.Tuple {
+			keyTypes[i] = g.goType(id)
+		}
+		return keyTypes
+	}
+
+	return []string{g.goType(m.Key)}
+}
+
+func (g *generator) calls(name string, pallet types.PalletMetadataV14) {
+	calls := g.lookup[pallet.Calls.Type.Int64()]
+	for _, call := range calls.Def.Variant.Variants {
+		params := make([]string, len(call.Fields))
+		args := make([]string, len(call.Fields))
+		for i, field := range call.Fields {
+			args[i] = paramName(field, i)
+			params[i] = args[i] + " " + g.goType(field.Type)
+		}
+
+		function := fmt.Sprintf("New%s%sCall", name, exported(string(call.Name)))
+		fmt.Fprintf(&g.body, "\n// %s builds the %s.%s call.\n", function, pallet.Name, call.Name)
+		writeDocs(&g.body, call.Docs)
+		fmt.Fprintf(&g.body, "func %s(meta *types.Metadata%s) (types.Call, error) {\n", function, joinArgs(params))
+		fmt.Fprintf(&g.body, "return types.NewCall(meta, \"%s.%s\"%s)\n}\n", pallet.Name, call.Name, joinArgs(args))
+	}
+}
+
+func (g *generator) eventStructs(name string, pallet types.PalletMetadataV14) {
+	events := g.lookup[pallet.Events.Type.Int64()]
+	for _, event := range events.Def.Variant.Variants {
+		structName := fmt.Sprintf("Event%s%s", name, exported(string(event.Name)))
+		g.taken[structName] = true
+
+		fmt.Fprintf(&g.body, "\n// %s is the %s.%s event.\n", structName, pallet.Name, event.Name)
+		writeDocs(&g.body, event.Docs)
+		fmt.Fprintf(&g.body, "type %s struct {\nPhase types.Phase\n", structName)
+		for i, field := range event.Fields {
+			fmt.Fprintf(&g.body, "%s %s\n", fieldName(field, i), g.goType(field.Type))
+		}
+		fmt.Fprintln(&g.body, "Topics []types.Hash\n}")
+
+		g.events = append(g.events, fmt.Sprintf("%s_%s []%s", pallet.Name, event.Name, structName))
+	}
+}
+
+// goType returns the Go type of the runtime type, declaring a named type if there is no Go
+// counterpart.
+func (g *generator) goType(id types.Si1LookupTypeID) string {
+	t, ok := g.lookup[id.Int64()]
+	if !ok {
+		g.fail(fmt.Errorf("type %d not found in the metadata", id.Int64()))
+		return "struct{}"
+	}
+	name := lastSegment(t.Path)
+	if goType, ok := wellKnown[name]; ok {
+		return goType
+	}
+
+	def := t.Def
+	switch {
+	case def.IsPrimitive:
+		return primitives[def.Primitive.Si0TypeDefPrimitive]
+	case def.IsCompact:
+		return "types.UCompact"
+	case def.IsSequence:
+		if g.isU8(def.Sequence.Type) {
+			return "types.Bytes"
+		}
+		return "[]" + g.goType(def.Sequence.Type)
+	case def.IsArray:
+		return fmt.Sprintf("[%d]%s", def.Array.Len, g.goType(def.Array.Type))
+	case def.IsTuple:
+		if len(def.Tuple) == 0 {
+			return "struct{}"
+		}
+		return g.declare(id.Int64(), fmt.Sprintf("Tuple%d", id.Int64()))
+	case def.IsVariant && name == "Option" && len(t.Params) == 1:
+		return fmt.Sprintf("types.Option[%s]", g.goType(t.Params[0].Type))
+	case def.IsComposite && len(def.Composite.Fields) == 1 && !def.Composite.Fields[0].HasName:
+		// Newtypes like BoundedVec or Perbill are encoded as the wrapped type.
+		return g.goType(def.Composite.Fields[0].Type)
+	case def.IsComposite || def.IsVariant:
+		return g.declare(id.Int64(), name)
+	default:
+		g.fail(fmt.Errorf("type %d %s is not supported", id.Int64(), strings.Join(pathStrings(t.Path), "::")))
+		return "struct{}"
+	}
+}
+
+func (g *generator) isU8(id types.Si1LookupTypeID) bool {
+	t := g.lookup[id.Int64()]
+	return t.Def.IsPrimitive && t.Def.Primitive.Si0TypeDefPrimitive == types.IsU8
+}
+
+// declare returns the name of the named type of the runtime type and queues its declaration.
+// Generic types and types of different modules with the same name get the type id suffix.
+func (g *generator) declare(id int64, base string) string {
+	if name, ok := g.names[id]; ok {
+		return name
+	}
+
+	name := exported(base)
+	if name == "" {
+		name = "Type"
+	}
+	if g.taken[name] {
+		name = fmt.Sprintf("%s%d", name, id)
+	}
+	g.names[id] = name
+	g.taken[name] = true
+	g.queue = append(g.queue, id)
+
+	return name
+}
+
+func (g *generator) declaration(id int64) {
+	t := g.lookup[id]
+	name := g.names[id]
+	doc := fmt.Sprintf("%s is %s.", name, strings.Join(pathStrings(t.Path), "::"))
+
+	switch {
+	case t.Def.IsTuple:
+		fields := make([]types.Si1Field, len(t.Def.Tuple))
+		for i, elem := range t.Def.Tuple {
+			fields[i] = types.Si1Field{Type: elem}
+		}
+		g.structDecl(name, fmt.Sprintf("%s is a tuple.", name), fields)
+	case t.Def.IsComposite:
+		g.structDecl(name, doc, t.Def.Composite.Fields)
+	case t.Def.IsVariant:
+		g.enumDecl(name, doc, t.Def.Variant.Variants)
+	}
+}
+
+func (g *generator) structDecl(name, doc string, fields []types.Si1Field) {
+	decl := &bytes.Buffer{}
+	fmt.Fprintf(decl, "\n// %s\ntype %s struct {\n", doc, name)
+	for i, field := range fields {
+		fmt.Fprintf(decl, "%s %s\n", fieldName(field, i), g.goType(field.Type))
+	}
+	fmt.Fprintln(decl, "}")
+
+	g.decls.Write(decl.Bytes())
+}
+
+// enumDecl declares the enum as a struct with IsX and AsX fields per variant like gsrpc does. AsX
+// is the variant field or a struct of the variant fields if there are several.
+func (g *generator) enumDecl(name, doc string, variants []types.Si1Variant) {
+	g.usesEnums = true
+
+	decl := &bytes.Buffer{}
+	decode := &bytes.Buffer{}
+	encode := &bytes.Buffer{}
+	fmt.Fprintf(decl, "\n// %s\ntype %s struct {\n", doc, name)
+	for _, variant := range variants {
+		variantName := exported(string(variant.Name))
+		fmt.Fprintf(decl, "Is%s bool\n", variantName)
+		fmt.Fprintf(decode, "case %d:\nm.Is%s = true\n", variant.Index, variantName)
+		fmt.Fprintf(encode, "case m.Is%s:\n", variantName)
+
+		if len(variant.Fields) == 0 {
+			fmt.Fprintf(encode, "return encoder.PushByte(%d)\n", variant.Index)
+			continue
+		}
+
+		var fieldType string
+		if len(variant.Fields) == 1 && !variant.Fields[0].HasName {
+			fieldType = g.goType(variant.Fields[0].Type)
+		} else {
+			fieldType = name + variantName
+			g.taken[fieldType] = true
+			g.structDecl(fieldType, fmt.Sprintf("%s are fields of the %s variant of %s.", fieldType, variantName, name), variant.Fields)
+		}
+		fmt.Fprintf(decl, "As%s %s\n", variantName, fieldType)
+		fmt.Fprintf(decode, "return decoder.Decode(&m.As%s)\n", variantName)
+		fmt.Fprintf(encode, "if err := encoder.PushByte(%d); err != nil {\nreturn err\n}\n", variant.Index)
+		fmt.Fprintf(encode, "return encoder.Encode(m.As%s)\n", variantName)
+	}
+	fmt.Fprintln(decl, "}")
+
+	fmt.Fprintf(decl, "\nfunc (m *%s) Decode(decoder scale.Decoder) error {\n", name)
+	fmt.Fprintln(decl, "b, err := decoder.ReadOneByte()\nif err != nil {\nreturn err\n}\n\nswitch b {")
+	decl.Write(decode.Bytes())
+	fmt.Fprintf(decl, "default:\nreturn fmt.Errorf(\"unknown variant %%d of %s\", b)\n}\n\nreturn nil\n}\n", name)
+
+	fmt.Fprintf(decl, "\nfunc (m %s) Encode(encoder scale.Encoder) error {\nswitch {\n", name)
+	decl.Write(encode.Bytes())
+	fmt.Fprintf(decl, "}\n\nreturn fmt.Errorf(\"no variant of %s is set\")\n}\n", name)
+
+	g.decls.Write(decl.Bytes())
+}
+
+func (g *generator) fail(err error) {
+	if g.err == nil {
+		g.err = err
+	}
+}
+
+const storageHelpers = `
+func storageKey(meta *types.Metadata, pallet, item string, args ...interface{}) (types.StorageKey, error) {
+	encodedArgs := make([][]byte, len(args))
+	for i, arg := range args {
+		encoded, err := codec.Encode(arg)
+		if err != nil {
+			return nil, err
+		}
+		encodedArgs[i] = encoded
+	}
+
+	return types.CreateStorageKey(meta, pallet, item, encodedArgs...)
+}
+
+func readStorage[T any](substrateApi *gsrpc.SubstrateAPI, blockHash *types.Hash, key types.StorageKey) (types.Option[T], error) {
+	maybeValue := types.NewEmptyOption[T]()
+
+	var value T
+	var ok bool
+	var err error
+	if blockHash == nil {
+		ok, err = substrateApi.RPC.State.GetStorageLatest(key, &value)
+	} else {
+		ok, err = substrateApi.RPC.State.GetStorage(key, &value, *blockHash)
+	}
+	if !ok || err != nil {
+		return maybeValue, err
+	}
+
+	maybeValue.SetSome(value)
+
+	return maybeValue, nil
+}
+`
+
+// writeDocs writes the first line of the runtime docs as a doc comment paragraph.
+func writeDocs(buf *bytes.Buffer, docs []types.Text) {
+	if len(docs) == 0 || strings.TrimSpace(string(docs[0])) == "" {
+		return
+	}
+	fmt.Fprintf(buf, "//\n// %s\n", strings.TrimSpace(string(docs[0])))
+}
+
+func joinArgs(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return ", " + strings.Join(args, ", ")
+}
+
+func fieldName(field types.Si1Field, i int) string {
+	if !field.HasName || exported(string(field.Name)) == "" {
+		return fmt.Sprintf("F%d", i)
+	}
+	return exported(string(field.Name))
+}
+
+func paramName(field types.Si1Field, i int) string {
+	if !field.HasName || exported(string(field.Name)) == "" {
+		return fmt.Sprintf("arg%d", i)
+	}
+
+	name := []rune(exported(string(field.Name)))
+	name[0] = unicode.ToLower(name[0])
+	if param := string(name); !token.IsKeyword(param) && param != "meta" {
+		return param
+	}
+
+	return string(name) + "Arg"
+}
+
+// exported converts snake case or camel case names to exported Go names.
+func exported(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || r == ' '
+	})
+	for i, part := range parts {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		parts[i] = string(runes)
+	}
+
+	return strings.Join(parts, "")
+}
+
+func lastSegment(path types.Si1Path) string {
+	if len(path) == 0 {
+		return ""
+	}
+	return string(path[len(path)-1])
+}
+
+func pathStrings(path types.Si1Path) []string {
+	segments := make([]string, len(path))
+	for i, segment := range path {
+		segments[i] = string(segment)
+	}
+	return segments
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"go/format"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func lookupId(id uint64) types.Si1LookupTypeID {
+	return types.NewSi1LookupTypeIDFromUInt(id)
+}
+
+func portableType(id uint64, path []string, def types.Si1TypeDef) types.PortableTypeV14 {
+	var si1Path types.Si1Path
+	for _, segment := range path {
+		si1Path = append(si1Path, types.Text(segment))
+	}
+	return types.PortableTypeV14{ID: lookupId(id), Type: types.Si1Type{Path: si1Path, Def: def}}
+}
+
+func field(name string, id uint64) types.Si1Field {
+	return types.Si1Field{HasName: name != "", Name: types.Text(name), Type: lookupId(id)}
+}
+
+func testMetadata() *types.Metadata {
+	u32 := types.Si1TypeDef{IsPrimitive: true, Primitive: types.Si1TypeDefPrimitive{Si0TypeDefPrimitive: types.IsU32}}
+	u8 := types.Si1TypeDef{IsPrimitive: true, Primitive: types.Si1TypeDefPrimitive{Si0TypeDefPrimitive: types.IsU8}}
+
+	lookup := []types.PortableTypeV14{
+		portableType(0, nil, u32),
+		portableType(1, nil, u8),
+		portableType(2, nil, types.Si1TypeDef{IsArray: true, Array: types.Si1TypeDefArray{Len: 32, Type: lookupId(1)}}),
+		portableType(3, []string{"sp_core", "crypto", "AccountId32"}, types.Si1TypeDef{IsComposite: true, Composite: types.Si1TypeDefComposite{Fields: []types.Si1Field{field("", 2)}}}),
+		portableType(4, []string{"pallet_ddc_nodes", "NodeMode"}, types.Si1TypeDef{IsVariant: true, Variant: types.Si1TypeDefVariant{Variants: []types.Si1Variant{
+			{Name: "Full", Index: 1},
+			{Name: "Custom", Index: 2, Fields: []types.Si1Field{field("", 0)}},
+		}}}),
+		portableType(5, []string{"pallet_ddc_nodes", "StorageNode"}, types.Si1TypeDef{IsComposite: true, Composite: types.Si1TypeDefComposite{Fields: []types.Si1Field{
+			field("provider_id", 3),
+			field("mode", 4),
+		}}}),
+		portableType(6, []string{"pallet_ddc_nodes", "pallet", "Call"}, types.Si1TypeDef{IsVariant: true, Variant: types.Si1TypeDefVariant{Variants: []types.Si1Variant{
+			{Name: "set_mode", Index: 0, Fields: []types.Si1Field{field("node", 3), field("type", 4)}},
+		}}}),
+		portableType(7, []string{"pallet_ddc_nodes", "pallet", "Event"}, types.Si1TypeDef{IsVariant: true, Variant: types.Si1TypeDefVariant{Variants: []types.Si1Variant{
+			{Name: "NodeCreated", Index: 0, Fields: []types.Si1Field{field("node_pub_key", 3)}},
+		}}}),
+	}
+
+	return &types.Metadata{
+		MagicNumber: types.MagicNumber,
+		Version:     14,
+		AsMetadataV14: types.MetadataV14{
+			Lookup: types.PortableRegistryV14{Types: lookup},
+			Pallets: []types.PalletMetadataV14{{
+				Name:       "DdcNodes",
+				HasStorage: true,
+				Storage: types.StorageMetadataV14{Prefix: "DdcNodes", Items: []types.StorageEntryMetadataV14{{
+					Name: "StorageNodes",
+					Type: types.StorageEntryTypeV14{IsMap: true, AsMap: types.MapTypeV14{
+						Hashers: []types.StorageHasherV10{{IsBlake2_128Concat: true}},
+						Key:     lookupId(3),
+						Value:   lookupId(5),
+					}},
+				}}},
+				HasCalls:  true,
+				Calls:     types.FunctionMetadataV14{Type: lookupId(6)},
+				HasEvents: true,
+				Events:    types.EventMetadataV14{Type: lookupId(7)},
+			}},
+		},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	//when
+	source, err := generate(testMetadata(), []string{"DdcNodes"}, "bindings")
+
+	//then
+	assert.NoError(t, err)
+	code, err := format.Source(source)
+	assert.NoError(t, err)
+	for _, want := range []string{
+		"func (s *DdcNodesStorage) StorageNodes(key0 types.AccountID) (types.Option[StorageNode], error) {",
+		"func NewDdcNodesSetModeCall(meta *types.Metadata, node types.AccountID, typeArg NodeMode) (types.Call, error) {",
+		`return types.NewCall(meta, "DdcNodes.set_mode", node, typeArg)`,
+		"type EventDdcNodesNodeCreated struct {",
+		"NodePubKey types.AccountID",
+		"DdcNodes_NodeCreated []EventDdcNodesNodeCreated",
+		"ProviderId types.AccountID",
+		"AsCustom types.U32",
+		"func (m *NodeMode) Decode(decoder scale.Decoder) error {",
+	} {
+		assert.Contains(t, string(code), want)
+	}
+}
+
+func TestGenerateUnknownPallet(t *testing.T) {
+	//when
+	_, err := generate(testMetadata(), []string{"DdcStaking"}, "bindings")
+
+	//then
+	assert.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package blockchain
+
+import (
+	"context"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// ContractCodeUpdate is a code upgrade of a contract with set_code, reported by the
+// Contracts.ContractCodeUpdated event.
+type ContractCodeUpdate struct {
+	BlockNumber types.BlockNumber
+	BlockHash   types.Hash
+	Contract    types.AccountID
+	OldCodeHash types.Hash
+	NewCodeHash types.Hash
+}
+
+// OnContractCodeUpdate subscribes given callback to code upgrades of contracts. Upgrades are
+// detected while listening events with ListenEvents.
+func (c *Client) OnContractCodeUpdate(callback func(update ContractCodeUpdate)) context.CancelFunc {
+	return c.RegisterEventsListener(func(events []*parser.Event, eventCtx EventContext) error {
+		for _, event := range events {
+			if update, ok := contractCodeUpdate(event, eventCtx); ok {
+				callback(update)
+			}
+		}
+
+		return nil
+	})
+}
+
+func contractCodeUpdate(event *parser.Event, eventCtx EventContext) (ContractCodeUpdate, bool) {
+	if event.Name != "Contracts.ContractCodeUpdated" {
+		return ContractCodeUpdate{}, false
+	}
+
+	fields := make(map[string]any, len(event.Fields))
+	for _, field := range event.Fields {
+		fields[field.Name] = field.Value
+	}
+
+	update := ContractCodeUpdate{BlockNumber: eventCtx.BlockNumber, BlockHash: eventCtx.BlockHash}
+	fixedBytesValue(fields["old_code_hash"], update.OldCodeHash[:])
+	ok := fixedBytesValue(fields["contract"], update.Contract[:]) &&
+		fixedBytesValue(fields["new_code_hash"], update.NewCodeHash[:])
+
+	return update, ok
+}
+
+// fixedBytesValue reads a list of bytes of the length of dst, possibly wrapped in a single field
+// composite, into dst.
+func fixedBytesValue(value any, dst []byte) bool {
+	switch v := value.(type) {
+	case types.AccountID:
+		return fixedBytesValue(v[:], dst)
+	case types.Hash:
+		return fixedBytesValue(v[:], dst)
+	case []byte:
+		return len(v) == len(dst) && copy(dst, v) == len(dst)
+	case registry.DecodedFields:
+		if len(v) == 1 {
+			return fixedBytesValue(v[0].Value, dst)
+		}
+	case []any:
+		if len(v) != len(dst) {
+			return false
+		}
+		for i, item := range v {
+			u, ok := item.(types.U8)
+			if !ok {
+				return false
+			}
+			dst[i] = byte(u)
+		}
+		return true
+	}
+
+	return false
+}
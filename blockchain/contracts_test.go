@@ -0,0 +1,73 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContractCodeUpdate(t *testing.T) {
+	eventCtx := EventContext{BlockNumber: 10, BlockHash: types.Hash{9}}
+	tests := []struct {
+		name   string
+		event  *parser.Event
+		want   ContractCodeUpdate
+		wantOk bool
+	}{
+		{
+			name: "code updated",
+			event: &parser.Event{Name: "Contracts.ContractCodeUpdated", Fields: registry.DecodedFields{
+				{Name: "contract", Value: types.AccountID{7}},
+				{Name: "new_code_hash", Value: types.Hash{2}},
+				{Name: "old_code_hash", Value: types.Hash{1}},
+			}},
+			want: ContractCodeUpdate{
+				BlockNumber: 10,
+				BlockHash:   types.Hash{9},
+				Contract:    types.AccountID{7},
+				OldCodeHash: types.Hash{1},
+				NewCodeHash: types.Hash{2},
+			},
+			wantOk: true,
+		},
+		{
+			name: "code hash in a composite",
+			event: &parser.Event{Name: "Contracts.ContractCodeUpdated", Fields: registry.DecodedFields{
+				{Name: "contract", Value: types.AccountID{7}},
+				{Name: "new_code_hash", Value: registry.DecodedFields{{Value: types.Hash{2}}}},
+			}},
+			want: ContractCodeUpdate{
+				BlockNumber: 10,
+				BlockHash:   types.Hash{9},
+				Contract:    types.AccountID{7},
+				NewCodeHash: types.Hash{2},
+			},
+			wantOk: true,
+		},
+		{
+			name: "no code hash",
+			event: &parser.Event{Name: "Contracts.ContractCodeUpdated", Fields: registry.DecodedFields{
+				{Name: "contract", Value: types.AccountID{7}},
+			}},
+		},
+		{
+			name:  "other event",
+			event: &parser.Event{Name: "System.CodeUpdated"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//when
+			update, ok := contractCodeUpdate(tt.event, eventCtx)
+
+			//then
+			assert.Equal(t, tt.wantOk, ok)
+			if tt.wantOk {
+				assert.Equal(t, tt.want, update)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package blockchain
+
+import (
+	"fmt"
+	"time"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+)
+
+const (
+	// ArchiveRetryInterval is how long historical queries go to the full node after the archive
+	// node failed a query.
+	ArchiveRetryInterval = 30 * time.Second
+)
+
+// Endpoint is a node RPC URL. Archive nodes keep the state of all blocks, full nodes only of recent
+// ones but usually have lower latency.
+type Endpoint struct {
+	Url     string
+	Archive bool
+}
+
+// WithArchive routes historical queries, i.e. events retrieval of backfilled blocks and storage
+// reads of AtBlock views, to the archive node. The client falls back to its own node for
+// ArchiveRetryInterval after the archive node fails a query.
+func WithArchive(archive *gsrpc.SubstrateAPI) ClientOption {
+	return func(c *Client) {
+		c.archive = archive
+	}
+}
+
+// NewClientWithEndpoints connects to the first reachable full node for subscriptions and the latest
+// state and to the first reachable archive node for historical queries as WithArchive. If no full
+// node is reachable, the archive node serves all queries. If no archive node is reachable,
+// historical queries go to the full node.
+func NewClientWithEndpoints(endpoints []Endpoint, opts ...ClientOption) (*Client, error) {
+	var full, archive *gsrpc.SubstrateAPI
+	var lastErr error
+	for _, endpoint := range endpoints {
+		if (endpoint.Archive && archive != nil) || (!endpoint.Archive && full != nil) {
+			continue
+		}
+
+		api, err := gsrpc.NewSubstrateAPI(endpoint.Url)
+		if err != nil {
+			lastErr = fmt.Errorf("%s: %w", endpoint.Url, err)
+			continue
+		}
+		if endpoint.Archive {
+			archive = api
+		} else {
+			full = api
+		}
+	}
+
+	if full == nil {
+		full, archive = archive, nil
+	}
+	if full == nil {
+		if lastErr == nil {
+			return nil, fmt.Errorf("no endpoints")
+		}
+		return nil, fmt.Errorf("no endpoint is reachable: %w", lastErr)
+	}
+	if archive != nil {
+		opts = append([]ClientOption{WithArchive(archive)}, opts...)
+	}
+
+	return newClient(full, opts...)
+}
+
+// historical returns the API for queries of historical blocks, the archive node unless it has
+// failed recently.
+func (c *Client) historical() *gsrpc.SubstrateAPI {
+	c.archiveMu.Lock()
+	defer c.archiveMu.Unlock()
+
+	if c.archive == nil || time.Now().Before(c.archiveDownUntil) {
+		return c.SubstrateAPI
+	}
+
+	return c.archive
+}
+
+// downgradeArchive reports whether the failed query went to the archive node, so it can be retried
+// with the full node, and routes historical queries to the full node for ArchiveRetryInterval.
+func (c *Client) downgradeArchive(api *gsrpc.SubstrateAPI) bool {
+	if api == c.SubstrateAPI {
+		return false
+	}
+
+	c.archiveMu.Lock()
+	c.archiveDownUntil = time.Now().Add(ArchiveRetryInterval)
+	c.archiveMu.Unlock()
+
+	return true
+}
@@ -0,0 +1,34 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain/mock"
+)
+
+func TestAtBlockArchive(t *testing.T) {
+	//given
+	full := mock.NewBackend(mock.DdcMetadata())
+	archive := mock.NewBackend(mock.DdcMetadata())
+	archiveBlock := archive.ProduceBlock()
+	full.ProduceBlock()
+	// The archive backend has no second block, so it fails the query.
+	fullBlock := full.ProduceBlock()
+	c, err := newClient(full.SubstrateAPI(), WithArchive(archive.SubstrateAPI()))
+	assert.NoError(t, err)
+
+	//when
+	archiveView, archiveErr := c.AtBlock(archiveBlock)
+	beforeDowngrade := c.historical()
+	fullView, fullErr := c.AtBlock(fullBlock)
+
+	//then
+	assert.NoError(t, archiveErr)
+	assert.Equal(t, archiveBlock, archiveView.BlockHash)
+	assert.True(t, beforeDowngrade == c.archive)
+	assert.NoError(t, fullErr)
+	assert.Equal(t, fullBlock, fullView.BlockHash)
+	assert.True(t, c.historical() == c.SubstrateAPI)
+}
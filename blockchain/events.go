@@ -0,0 +1,322 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/retriever"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/xxhash"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// DefaultBackfillWorkers is the default number of blocks which events are retrieved in parallel.
+	DefaultBackfillWorkers = 4
+)
+
+type BackfillParameters struct {
+	// Workers is the number of blocks which events are retrieved in parallel. Events are delivered
+	// to listeners in the order of blocks anyway. DefaultBackfillWorkers is used if zero.
+	Workers int
+	// Progress is called after each historical block events retrieved.
+	Progress func(progress BackfillProgress)
+}
+
+// BackfillProgress reports retrieval of historical blocks events. Target is the first block
+// received from the new heads subscription.
+type BackfillProgress struct {
+	Current         types.BlockNumber
+	Target          types.BlockNumber
+	BlocksPerSecond float64
+	ETA             time.Duration
+}
+
+type eventsJob struct {
+	number types.BlockNumber
+	// historical is set for blocks before the first live header, which events are retrieved from
+	// the archive node if there is one.
+	historical bool
+	result     chan eventsResult
+}
+
+type eventsResult struct {
+	events     blockEvents
+	generation uint64
+	err        error
+}
+
+// retrieveEvents retrieves events of blocks from headersC by a pool of workers and sends them to
+// eventsC in the order of headers. Blocks before begin are skipped.
+//
+// Each runtime upgrade increments the retrievers generation. Workers recreate their retrievers for
+// the new runtime metadata and events retrieved by an outdated retriever are retrieved again.
+func (c *Client) retrieveEvents(
+	ctx context.Context,
+	begin types.BlockNumber,
+	headersC <-chan types.Header,
+	eventsC chan<- blockEvents,
+	progress *backfillProgress,
+) error {
+	workers := c.backfill.Workers
+	if workers <= 0 {
+		workers = DefaultBackfillWorkers
+	}
+
+	var generation uint64
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	jobsC := make(chan eventsJob)
+	pendingC := make(chan eventsJob, workers)
+
+	// Dispatch blocks to workers and queue pending results in the order of blocks.
+	g.Go(func() error {
+		defer close(jobsC)
+		defer close(pendingC)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case header, ok := <-headersC:
+				if !ok {
+					return ErrHeaderChannelClosed
+				}
+
+				if header.Number < begin {
+					continue
+				}
+
+				job := eventsJob{
+					number:     header.Number,
+					historical: header.Number < progress.Target(),
+					result:     make(chan eventsResult, 1),
+				}
+
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case pendingC <- job:
+				}
+
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case jobsC <- job:
+				}
+			}
+		}
+	})
+
+	for i := 0; i < workers; i++ {
+		g.Go(func() error {
+			getter := &blockEventsGetter{client: c}
+
+			for job := range jobsC {
+				gen := atomic.LoadUint64(&generation)
+				events, err := getter.get(job.number, gen, job.historical)
+				job.result <- eventsResult{events: events, generation: gen, err: err}
+			}
+
+			return nil
+		})
+	}
+
+	// Collect results in the order of blocks.
+	g.Go(func() error {
+		getter := &blockEventsGetter{client: c}
+
+		for job := range pendingC {
+			var result eventsResult
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case result = <-job.result:
+			}
+
+			if result.err != nil {
+				return result.err
+			}
+
+			if gen := atomic.LoadUint64(&generation); result.generation != gen {
+				var err error
+				if result.events, err = getter.get(job.number, gen, job.historical); err != nil {
+					return err
+				}
+			}
+
+			// Events of the block with the runtime upgrade are still emitted by the previous
+			// runtime, switch to the new one for the following blocks.
+			if hasCodeUpdatedEvent(result.events.Events) {
+				if err := c.applyRuntimeUpgrade(result.events.Number, result.events.Hash); err != nil {
+					return fmt.Errorf("runtime upgrade: %w", err)
+				}
+
+				atomic.AddUint64(&generation, 1)
+			}
+
+			progress.Report(job.number)
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case eventsC <- result.events:
+			}
+		}
+
+		return nil
+	})
+
+	return g.Wait()
+}
+
+// blockEventsGetter retrieves events of blocks with a retriever of the runtime generation. Events of
+// historical blocks are retrieved from the archive node while it's available.
+type blockEventsGetter struct {
+	client     *Client
+	api        *gsrpc.SubstrateAPI
+	retriever  retriever.EventRetriever
+	generation uint64
+}
+
+func (g *blockEventsGetter) get(number types.BlockNumber, generation uint64, historical bool) (blockEvents, error) {
+	for {
+		api := g.client.SubstrateAPI
+		if historical {
+			api = g.client.historical()
+		}
+
+		if g.retriever == nil || g.api != api || g.generation != generation {
+			r, err := g.client.newEventRetriever(api)
+			if err != nil {
+				return blockEvents{}, err
+			}
+			g.api, g.retriever, g.generation = api, r, generation
+		}
+
+		events, err := getBlockEvents(api, g.retriever, number)
+		if err != nil && g.client.downgradeArchive(api) {
+			continue
+		}
+
+		return events, err
+	}
+}
+
+func getBlockEvents(api *gsrpc.SubstrateAPI, r retriever.EventRetriever, number types.BlockNumber) (blockEvents, error) {
+	hash, err := api.RPC.Chain.GetBlockHash(uint64(number))
+	if err != nil {
+		return blockEvents{}, err
+	}
+
+	events, err := r.GetEvents(hash)
+	if err != nil {
+		return blockEvents{}, err
+	}
+
+	timestamp, err := getTimestamp(api, hash)
+	if err != nil {
+		return blockEvents{}, err
+	}
+
+	return blockEvents{Events: events, Hash: hash, Number: number, Timestamp: timestamp}, nil
+}
+
+// timestampNowKey is the storage key of Timestamp.Now. Plain storage keys don't depend on the
+// runtime metadata.
+var timestampNowKey = types.NewStorageKey(append(
+	xxhash.New128([]byte("Timestamp")).Sum(nil),
+	xxhash.New128([]byte("Now")).Sum(nil)...,
+))
+
+func getTimestamp(api *gsrpc.SubstrateAPI, blockHash types.Hash) (time.Time, error) {
+	var now types.U64
+	ok, err := api.RPC.State.GetStorage(timestampNowKey, &now, blockHash)
+	if err != nil || !ok {
+		return time.Time{}, err
+	}
+
+	return time.UnixMilli(int64(now)), nil
+}
+
+type backfillProgress struct {
+	callback func(progress BackfillProgress)
+
+	mu      sync.Mutex
+	target  types.BlockNumber
+	first   types.BlockNumber
+	started time.Time
+}
+
+func newBackfillProgress(callback func(progress BackfillProgress)) *backfillProgress {
+	return &backfillProgress{callback: callback}
+}
+
+func (p *backfillProgress) SetTarget(target types.BlockNumber) {
+	p.mu.Lock()
+	p.target = target
+	p.mu.Unlock()
+}
+
+// Target returns the first live block number, zero until it's known.
+func (p *backfillProgress) Target() types.BlockNumber {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.target
+}
+
+// Report reports a retrieved block. Blocks after the backfill target are ignored.
+func (p *backfillProgress) Report(current types.BlockNumber) {
+	if p.callback == nil {
+		return
+	}
+
+	p.mu.Lock()
+	if current >= p.target {
+		p.mu.Unlock()
+		return
+	}
+
+	if p.started.IsZero() {
+		p.started = time.Now()
+		p.first = current
+	}
+
+	progress := BackfillProgress{Current: current, Target: p.target}
+	if elapsed := time.Since(p.started).Seconds(); elapsed > 0 {
+		progress.BlocksPerSecond = float64(current-p.first+1) / elapsed
+		remaining := float64(p.target - current - 1)
+		progress.ETA = time.Duration(remaining / progress.BlocksPerSecond * float64(time.Second))
+	}
+	p.mu.Unlock()
+
+	p.callback(progress)
+}
+
+// EventFields returns values of the event fields by name. Composites of a single field, e.g. an
+// AccountId32 wrapping the list of its bytes, are replaced with the value of the field, so
+// packages which don't depend on the registry types can read them.
+func EventFields(event *parser.Event) map[string]any {
+	fields := make(map[string]any, len(event.Fields))
+	for _, field := range event.Fields {
+		value := field.Value
+		for {
+			composite, ok := value.(registry.DecodedFields)
+			if !ok || len(composite) != 1 {
+				break
+			}
+			value = composite[0].Value
+		}
+		fields[field.Name] = value
+	}
+
+	return fields
+}
@@ -0,0 +1,29 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventFields(t *testing.T) {
+	//given
+	accountBytes := []any{types.U8(1), types.U8(2)}
+	pair := registry.DecodedFields{{Name: "a", Value: types.U8(1)}, {Name: "b", Value: types.U8(2)}}
+	event := &parser.Event{Name: "DdcPayouts.Rewarded", Fields: registry.DecodedFields{
+		{Name: "from", Value: registry.DecodedFields{{Value: registry.DecodedFields{{Value: accountBytes}}}}},
+		{Name: "era", Value: types.U32(7)},
+		{Name: "pair", Value: pair},
+	}}
+
+	//when
+	fields := EventFields(event)
+
+	//then
+	assert.Equal(t, accountBytes, fields["from"])
+	assert.Equal(t, types.U32(7), fields["era"])
+	assert.Equal(t, pair, fields["pair"])
+}
@@ -0,0 +1,158 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var (
+	ErrListenerUnregistered = errors.New("listener unregistered")
+)
+
+// ListenerPanicError is returned for an events listener callback which panicked.
+type ListenerPanicError struct {
+	BlockNumber types.BlockNumber
+	Value       any
+	Stack       []byte
+}
+
+func (e *ListenerPanicError) Error() string {
+	return fmt.Sprintf("listener panicked on block %d: %v\n%s", e.BlockNumber, e.Value, e.Stack)
+}
+
+// FailurePolicy makes ListenEvents tolerate failures of a listener. Errors and panics of the
+// listener are reported to OnError and listening continues with the next block. The failed block is
+// not stored to the listener Checkpointer.
+type FailurePolicy struct {
+	// MaxConsecutiveFailures unregisters the listener after this many consecutive failed blocks.
+	// The listener is never unregistered if zero.
+	MaxConsecutiveFailures int
+	// OnError is called with each listener error and with ErrListenerUnregistered wrapping the last
+	// error when the listener is unregistered. Optional.
+	OnError func(err error)
+}
+
+// ListenerOption configures an events listener registered with RegisterEventsListener.
+type ListenerOption func(l *eventsListener)
+
+// WithCheckpointer stores the number of each block successfully processed by the listener and
+// makes ListenEvents continue from the block after the stored one.
+func WithCheckpointer(checkpointer Checkpointer) ListenerOption {
+	return func(l *eventsListener) {
+		l.checkpointer = checkpointer
+	}
+}
+
+// WithFailurePolicy makes ListenEvents continue when the listener fails instead of returning the
+// listener error.
+func WithFailurePolicy(policy FailurePolicy) ListenerOption {
+	return func(l *eventsListener) {
+		l.policy = &policy
+	}
+}
+
+type eventsListener struct {
+	callback     EventsListener
+	checkpointer Checkpointer
+	policy       *FailurePolicy
+	failures     int
+
+	loaded  bool
+	hasFrom bool
+	from    types.BlockNumber
+}
+
+// loadCheckpoint loads the last stored checkpoint and returns the block following it. It returns
+// false if the listener has no checkpointer or no checkpoint stored yet. ListenEvents loads the
+// checkpoint on each start, so a restarted ListenEvents continues after the blocks already stored.
+func (l *eventsListener) loadCheckpoint() (types.BlockNumber, bool, error) {
+	l.loaded, l.hasFrom = false, false
+	if l.checkpointer == nil {
+		return 0, false, nil
+	}
+
+	last, ok, err := l.checkpointer.Load()
+	if err != nil {
+		return 0, false, err
+	}
+
+	l.loaded = true
+	if ok {
+		l.from = last + 1
+		l.hasFrom = true
+	}
+
+	return l.from, l.hasFrom, nil
+}
+
+// handle calls the listener callback skipping blocks before the listener begin, which is the block
+// following the last checkpoint or the block begin of ListenEvents.
+func (l *eventsListener) handle(events []*parser.Event, eventCtx EventContext, begin types.BlockNumber) error {
+	if l.checkpointer != nil && !l.loaded {
+		if _, _, err := l.loadCheckpoint(); err != nil {
+			return fmt.Errorf("load checkpoint: %w", err)
+		}
+	}
+
+	from := begin
+	if l.hasFrom {
+		from = l.from
+	}
+
+	if eventCtx.BlockNumber < from {
+		return nil
+	}
+
+	if err := l.call(events, eventCtx); err != nil {
+		return err
+	}
+
+	if l.checkpointer != nil {
+		if err := l.checkpointer.Store(eventCtx.BlockNumber); err != nil {
+			return fmt.Errorf("store checkpoint: %w", err)
+		}
+		l.from, l.hasFrom = eventCtx.BlockNumber+1, true
+	}
+
+	return nil
+}
+
+// call calls the listener callback recovering a panic into ListenerPanicError.
+func (l *eventsListener) call(events []*parser.Event, eventCtx EventContext) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &ListenerPanicError{BlockNumber: eventCtx.BlockNumber, Value: r, Stack: debug.Stack()}
+		}
+	}()
+
+	return l.callback(events, eventCtx)
+}
+
+// tolerate applies the failure policy to the result of handle. It reports whether the listener
+// must be unregistered and returns the error to stop events listening with.
+func (l *eventsListener) tolerate(err error) (bool, error) {
+	if l.policy == nil {
+		return false, err
+	}
+
+	if err == nil {
+		l.failures = 0
+		return false, nil
+	}
+
+	l.failures++
+	unregister := l.policy.MaxConsecutiveFailures > 0 && l.failures >= l.policy.MaxConsecutiveFailures
+
+	if l.policy.OnError != nil {
+		l.policy.OnError(err)
+		if unregister {
+			l.policy.OnError(fmt.Errorf("%w after %d consecutive failures: %v", ErrListenerUnregistered, l.failures, err))
+		}
+	}
+
+	return unregister, nil
+}
@@ -0,0 +1,78 @@
+package blockchain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventsListenerPanic(t *testing.T) {
+	//given
+	listener := &eventsListener{callback: func([]*parser.Event, EventContext) error {
+		panic("boom")
+	}}
+
+	//when
+	err := listener.handle(nil, EventContext{BlockNumber: 7}, 0)
+
+	//then
+	var panicErr *ListenerPanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, types.BlockNumber(7), panicErr.BlockNumber)
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.NotEmpty(t, panicErr.Stack)
+}
+
+func TestEventsListenerFailurePolicy(t *testing.T) {
+	//given
+	failing := map[types.BlockNumber]bool{1: true, 3: true, 4: true}
+	var reported []error
+	listener := &eventsListener{
+		callback: func(_ []*parser.Event, eventCtx EventContext) error {
+			if failing[eventCtx.BlockNumber] {
+				return errors.New("failed")
+			}
+			return nil
+		},
+		policy: &FailurePolicy{
+			MaxConsecutiveFailures: 2,
+			OnError: func(err error) {
+				reported = append(reported, err)
+			},
+		},
+	}
+
+	//when
+	var unregisteredAt types.BlockNumber
+	for blockNumber := types.BlockNumber(1); blockNumber <= 4; blockNumber++ {
+		unregister, err := listener.tolerate(listener.handle(nil, EventContext{BlockNumber: blockNumber}, 0))
+		assert.NoError(t, err)
+		if unregister {
+			unregisteredAt = blockNumber
+			break
+		}
+	}
+
+	//then
+	assert.Equal(t, types.BlockNumber(4), unregisteredAt)
+	assert.Len(t, reported, 4)
+	assert.ErrorIs(t, reported[3], ErrListenerUnregistered)
+}
+
+func TestEventsListenerNoFailurePolicy(t *testing.T) {
+	//given
+	failure := errors.New("failed")
+	listener := &eventsListener{callback: func([]*parser.Event, EventContext) error {
+		return failure
+	}}
+
+	//when
+	unregister, err := listener.tolerate(listener.handle(nil, EventContext{BlockNumber: 1}, 0))
+
+	//then
+	assert.False(t, unregister)
+	assert.ErrorIs(t, err, failure)
+}
@@ -0,0 +1,89 @@
+package blockchain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+)
+
+const maxLoggedArgsLength = 256
+
+// Logger is implemented by the standard library *log.Logger and most logging libraries.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// Redactor returns a replacement of a sensitive argument of the RPC method or false to log the
+// argument as is.
+type Redactor func(method string, index int, arg interface{}) (interface{}, bool)
+
+// RedactMethodArgs hides all arguments of the given RPC methods, e.g. of "author_submitExtrinsic"
+// which argument is a signed extrinsic.
+func RedactMethodArgs(methods ...string) Redactor {
+	redacted := make(map[string]struct{}, len(methods))
+	for _, method := range methods {
+		redacted[method] = struct{}{}
+	}
+
+	return func(method string, _ int, _ interface{}) (interface{}, bool) {
+		if _, ok := redacted[method]; ok {
+			return "<redacted>", true
+		}
+
+		return nil, false
+	}
+}
+
+type loggingTransport struct {
+	client.Client
+	logger    Logger
+	redactors []Redactor
+}
+
+// NewLoggingTransport wraps the JSON-RPC transport to log every call with its method, a summary of
+// arguments, duration and outcome. Arguments are passed through redactors before logging. Use it
+// with NewClientWithTransport to debug storage reads of pallet APIs and submitted extrinsics.
+// Subscriptions are passed through without logging.
+func NewLoggingTransport(transport client.Client, logger Logger, redactors ...Redactor) client.Client {
+	return &loggingTransport{
+		Client:    transport,
+		logger:    logger,
+		redactors: redactors,
+	}
+}
+
+func (t *loggingTransport) Call(result interface{}, method string, args ...interface{}) error {
+	start := time.Now()
+	err := t.Client.Call(result, method, args...)
+	duration := time.Since(start)
+
+	outcome := "ok"
+	if err != nil {
+		outcome = "error: " + err.Error()
+	}
+	t.logger.Printf("rpc %s(%s) took %s: %s", method, t.summary(method, args), duration, outcome)
+
+	return err
+}
+
+func (t *loggingTransport) summary(method string, args []interface{}) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		for _, redact := range t.redactors {
+			if replacement, ok := redact(method, i, arg); ok {
+				arg = replacement
+				break
+			}
+		}
+		parts[i] = fmt.Sprintf("%v", arg)
+	}
+
+	summary := strings.Join(parts, ", ")
+	if len(summary) > maxLoggedArgsLength {
+		summary = summary[:maxLoggedArgsLength] + "..."
+	}
+
+	return summary
+}
@@ -0,0 +1,47 @@
+package blockchain
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type echoTransport struct {
+	client.Client
+}
+
+func (t *echoTransport) Call(result interface{}, method string, args ...interface{}) error {
+	*result.(*string) = method
+	return nil
+}
+
+type recordingLogger struct {
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, args ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func TestLoggingTransport(t *testing.T) {
+	//given
+	logger := &recordingLogger{}
+	transport := NewLoggingTransport(&echoTransport{}, logger, RedactMethodArgs("author_submitExtrinsic"))
+
+	//when
+	var res string
+	err1 := transport.Call(&res, "state_getStorage", "0x01", "0x02")
+	err2 := transport.Call(&res, "author_submitExtrinsic", "0xsigned")
+
+	//then
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Equal(t, "author_submitExtrinsic", res)
+	assert.Len(t, logger.lines, 2)
+	assert.Contains(t, logger.lines[0], "rpc state_getStorage(0x01, 0x02)")
+	assert.Contains(t, logger.lines[0], ": ok")
+	assert.Contains(t, logger.lines[1], "rpc author_submitExtrinsic(<redacted>)")
+	assert.NotContains(t, logger.lines[1], "0xsigned")
+}
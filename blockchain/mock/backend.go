@@ -0,0 +1,222 @@
+// Package mock provides an in-memory substrate node backend to test pallet APIs and other code
+// built on gsrpc.SubstrateAPI against deterministic data.
+//
+// The backend keeps SCALE-encoded storage in a map and produces blocks on demand. Each produced
+// block keeps a snapshot of the storage, so reads at a block hash see the state of that block. Only
+// the state and chain RPCs used by this SDK are implemented, other RPC methods panic.
+package mock
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	ErrBlockNotFound = errors.New("block not found")
+)
+
+type Backend struct {
+	mu        sync.RWMutex
+	meta      *types.Metadata
+	version   types.RuntimeVersion
+	storage   map[string][]byte
+	blocks    []block
+	finalized types.BlockNumber
+	heads     map[chan types.Header]struct{}
+}
+
+type block struct {
+	hash    types.Hash
+	header  types.Header
+	storage map[string][]byte
+}
+
+// NewBackend creates a backend with the genesis block and the given metadata. Use DdcMetadata for
+// the metadata of DDC pallets.
+func NewBackend(meta *types.Metadata) *Backend {
+	b := &Backend{
+		meta:    meta,
+		storage: make(map[string][]byte),
+		heads:   make(map[chan types.Header]struct{}),
+	}
+	b.version.SpecVersion = 1
+	b.produceBlock()
+
+	return b
+}
+
+// SubstrateAPI returns gsrpc.SubstrateAPI served by the backend.
+func (b *Backend) SubstrateAPI() *gsrpc.SubstrateAPI {
+	return &gsrpc.SubstrateAPI{
+		RPC: &rpc.RPC{
+			State: &stateRPC{backend: b},
+			Chain: &chainRPC{backend: b},
+		},
+	}
+}
+
+func (b *Backend) Metadata() *types.Metadata {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.meta
+}
+
+// SetMetadata replaces the metadata and increments the runtime spec version as a runtime upgrade.
+func (b *Backend) SetMetadata(meta *types.Metadata) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.meta = meta
+	b.version.SpecVersion++
+}
+
+// StorageKey creates a storage key for the pallet storage item using the backend metadata.
+func (b *Backend) StorageKey(pallet, item string, args ...interface{}) (types.StorageKey, error) {
+	encodedArgs := make([][]byte, len(args))
+	for i, arg := range args {
+		encoded, err := codec.Encode(arg)
+		if err != nil {
+			return nil, err
+		}
+		encodedArgs[i] = encoded
+	}
+
+	return types.CreateStorageKey(b.Metadata(), pallet, item, encodedArgs...)
+}
+
+// Put sets SCALE-encoded value to the storage. The change is visible at the chain head after the
+// next ProduceBlock.
+func (b *Backend) Put(key types.StorageKey, value interface{}) error {
+	encoded, err := codec.Encode(value)
+	if err != nil {
+		return err
+	}
+
+	b.PutRaw(key, encoded)
+
+	return nil
+}
+
+func (b *Backend) PutRaw(key types.StorageKey, value []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.storage[string(key)] = value
+}
+
+func (b *Backend) Delete(key types.StorageKey) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.storage, string(key))
+}
+
+// ProduceBlock makes a new block with the current storage and notifies new heads subscribers.
+func (b *Backend) ProduceBlock() types.Hash {
+	b.mu.Lock()
+	header := b.produceBlock()
+	blockHash := b.blocks[header.Number].hash
+	subscribers := make([]chan types.Header, 0, len(b.heads))
+	for c := range b.heads {
+		subscribers = append(subscribers, c)
+	}
+	b.mu.Unlock()
+
+	for _, c := range subscribers {
+		c <- header
+	}
+
+	return blockHash
+}
+
+func (b *Backend) produceBlock() types.Header {
+	storage := make(map[string][]byte, len(b.storage))
+	for k, v := range b.storage {
+		storage[k] = v
+	}
+
+	header := types.Header{Number: types.BlockNumber(len(b.blocks))}
+	if len(b.blocks) > 0 {
+		header.ParentHash = b.blocks[len(b.blocks)-1].hash
+	}
+
+	encoded, _ := codec.Encode(header)
+	blockHash := types.Hash(blake2b.Sum256(encoded))
+
+	b.blocks = append(b.blocks, block{hash: blockHash, header: header, storage: storage})
+
+	return header
+}
+
+// Finalize marks the block with the given number and all its ancestors finalized.
+func (b *Backend) Finalize(number types.BlockNumber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if int(number) < len(b.blocks) && number > b.finalized {
+		b.finalized = number
+	}
+}
+
+// NewHeads subscribes to headers of produced blocks. Call the returned function to unsubscribe.
+// The channel is unbuffered, ProduceBlock blocks until the header is received.
+func (b *Backend) NewHeads() (<-chan types.Header, func()) {
+	c := make(chan types.Header)
+
+	b.mu.Lock()
+	b.heads[c] = struct{}{}
+	b.mu.Unlock()
+
+	once := sync.Once{}
+	return c, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.heads, c)
+			b.mu.Unlock()
+		})
+	}
+}
+
+func (b *Backend) blockByHash(blockHash types.Hash) (block, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for i := len(b.blocks) - 1; i >= 0; i-- {
+		if b.blocks[i].hash == blockHash {
+			return b.blocks[i], nil
+		}
+	}
+
+	return block{}, ErrBlockNotFound
+}
+
+func (b *Backend) latestBlock() block {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.blocks[len(b.blocks)-1]
+}
+
+func (blk block) keys(prefix types.StorageKey) []types.StorageKey {
+	var keys []types.StorageKey
+	for k := range blk.storage {
+		if strings.HasPrefix(k, string(prefix)) {
+			keys = append(keys, types.StorageKey(k))
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return string(keys[i]) < string(keys[j])
+	})
+
+	return keys
+}
@@ -0,0 +1,149 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// ChangeSet builds the storage change set a node sends to state_subscribeStorage subscribers, so
+// tests of storage subscriptions don't need hand-crafted hex. Errors are deferred to Build.
+type ChangeSet struct {
+	meta *types.Metadata
+	set  types.StorageChangeSet
+	err  error
+}
+
+// NewChangeSet creates an empty change set of the block. Storage keys are built with the metadata,
+// e.g. DdcMetadata.
+func NewChangeSet(meta *types.Metadata, blockHash types.Hash) *ChangeSet {
+	return &ChangeSet{meta: meta, set: types.StorageChangeSet{Block: blockHash}}
+}
+
+// Set adds the SCALE-encoded value of the storage item with the map keys args.
+func (c *ChangeSet) Set(pallet, item string, value interface{}, args ...interface{}) *ChangeSet {
+	key, err := c.storageKey(pallet, item, args...)
+	if err != nil {
+		return c
+	}
+	encoded, err := codec.Encode(value)
+	if err != nil {
+		c.err = err
+		return c
+	}
+
+	c.set.Changes = append(c.set.Changes, types.KeyValueOption{
+		StorageKey:     key,
+		HasStorageData: true,
+		StorageData:    encoded,
+	})
+
+	return c
+}
+
+// Remove adds removal of the storage item with the map keys args.
+func (c *ChangeSet) Remove(pallet, item string, args ...interface{}) *ChangeSet {
+	key, err := c.storageKey(pallet, item, args...)
+	if err != nil {
+		return c
+	}
+
+	c.set.Changes = append(c.set.Changes, types.KeyValueOption{StorageKey: key})
+
+	return c
+}
+
+// Build returns the change set or the first error of Set and Remove.
+func (c *ChangeSet) Build() (types.StorageChangeSet, error) {
+	return c.set, c.err
+}
+
+// JSON returns the change set as a node sends it in the subscription notification result.
+func (c *ChangeSet) JSON() ([]byte, error) {
+	set, err := c.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(set)
+}
+
+func (c *ChangeSet) storageKey(pallet, item string, args ...interface{}) (types.StorageKey, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	encodedArgs := make([][]byte, len(args))
+	for i, arg := range args {
+		if encodedArgs[i], c.err = codec.Encode(arg); c.err != nil {
+			return nil, c.err
+		}
+	}
+
+	var key types.StorageKey
+	key, c.err = types.CreateStorageKey(c.meta, pallet, item, encodedArgs...)
+
+	return key, c.err
+}
+
+// EventRecord is an event emitted in a block. Pallet and Event are indexes of the pallet and of the
+// event variant in the runtime metadata, Fields are the event fields in the declaration order.
+type EventRecord struct {
+	Phase  types.Phase
+	Pallet uint8
+	Event  uint8
+	Fields []interface{}
+	Topics []types.Hash
+}
+
+// EncodeEvents encodes the records as the System.Events storage value, e.g. to Put it to a backend
+// or to return it from a storage query.
+func EncodeEvents(records ...EventRecord) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := scale.NewEncoder(&buf)
+
+	if err := encoder.Encode(types.NewUCompactFromUInt(uint64(len(records)))); err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if err := encoder.Encode(record.Phase); err != nil {
+			return nil, err
+		}
+		if err := encoder.Encode([2]uint8{record.Pallet, record.Event}); err != nil {
+			return nil, err
+		}
+		for _, field := range record.Fields {
+			if err := encoder.Encode(field); err != nil {
+				return nil, err
+			}
+		}
+		topics := record.Topics
+		if topics == nil {
+			topics = []types.Hash{}
+		}
+		if err := encoder.Encode(topics); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PutEvents sets the System.Events storage of the next produced block to the records.
+func (b *Backend) PutEvents(records ...EventRecord) error {
+	key, err := b.StorageKey("System", "Events")
+	if err != nil {
+		return err
+	}
+	encoded, err := EncodeEvents(records...)
+	if err != nil {
+		return err
+	}
+
+	b.PutRaw(key, encoded)
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangeSet(t *testing.T) {
+	//given
+	backend := NewBackend(DdcMetadata())
+	countKey, _ := backend.StorageKey("DdcCustomers", "BucketsCount")
+	bucketKey, _ := backend.StorageKey("DdcCustomers", "Buckets", types.U64(7))
+
+	//when
+	set, err := NewChangeSet(backend.Metadata(), types.Hash{1}).
+		Set("DdcCustomers", "BucketsCount", types.U64(3)).
+		Remove("DdcCustomers", "Buckets", types.U64(7)).
+		Build()
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, types.Hash{1}, set.Block)
+	assert.Equal(t, []types.KeyValueOption{
+		{StorageKey: countKey, HasStorageData: true, StorageData: types.StorageDataRaw{3, 0, 0, 0, 0, 0, 0, 0}},
+		{StorageKey: bucketKey},
+	}, set.Changes)
+}
+
+func TestChangeSetUnknownItem(t *testing.T) {
+	//when
+	_, err := NewChangeSet(DdcMetadata(), types.Hash{}).
+		Set("DdcCustomers", "Unknown", types.U64(3)).
+		Set("DdcCustomers", "BucketsCount", types.U64(3)).
+		Build()
+
+	//then
+	assert.Error(t, err)
+}
+
+func TestEncodeEvents(t *testing.T) {
+	//when
+	encoded, err := EncodeEvents(EventRecord{
+		Phase:  types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 1},
+		Pallet: 5,
+		Event:  2,
+		Fields: []interface{}{types.U32(7)},
+	})
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{4, 0, 1, 0, 0, 0, 5, 2, 7, 0, 0, 0, 0}, encoded)
+}
@@ -0,0 +1,78 @@
+package mock
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var (
+	Blake2_128Concat = types.StorageHasherV10{IsBlake2_128Concat: true}
+	Twox64Concat     = types.StorageHasherV10{IsTwox64Concat: true}
+)
+
+// StorageEntry describes a pallet storage item. A plain storage value has no hashers, a map has a
+// hasher per key.
+type StorageEntry struct {
+	Pallet  string
+	Name    string
+	Hashers []types.StorageHasherV10
+}
+
+// DdcStorageEntries are storage items read by the DDC pallet APIs.
+var DdcStorageEntries = []StorageEntry{
+	{Pallet: "System", Name: "Events"},
+	{Pallet: "Timestamp", Name: "Now"},
+	{Pallet: "DdcClusters", Name: "Clusters", Hashers: []types.StorageHasherV10{Blake2_128Concat}},
+	{Pallet: "DdcClusters", Name: "ClustersNodes", Hashers: []types.StorageHasherV10{Blake2_128Concat, Blake2_128Concat}},
+	{Pallet: "DdcCustomers", Name: "Buckets", Hashers: []types.StorageHasherV10{Twox64Concat}},
+	{Pallet: "DdcCustomers", Name: "BucketsCount"},
+	{Pallet: "DdcCustomers", Name: "Ledger", Hashers: []types.StorageHasherV10{Blake2_128Concat}},
+	{Pallet: "DdcNodes", Name: "StorageNodes", Hashers: []types.StorageHasherV10{Blake2_128Concat}},
+	{Pallet: "DdcPayouts", Name: "DebtorCustomers", Hashers: []types.StorageHasherV10{Blake2_128Concat, Blake2_128Concat}},
+}
+
+// DdcMetadata returns metadata with DdcStorageEntries.
+func DdcMetadata() *types.Metadata {
+	return NewMetadata(DdcStorageEntries...)
+}
+
+// NewMetadata returns V14 metadata with the given storage items. The metadata has no type
+// registry, it is enough to build storage keys but not to decode events.
+func NewMetadata(entries ...StorageEntry) *types.Metadata {
+	var pallets []types.PalletMetadataV14
+	palletIndex := make(map[string]int)
+
+	for _, entry := range entries {
+		i, ok := palletIndex[entry.Pallet]
+		if !ok {
+			i = len(pallets)
+			palletIndex[entry.Pallet] = i
+			pallets = append(pallets, types.PalletMetadataV14{
+				Name:       types.Text(entry.Pallet),
+				HasStorage: true,
+				Storage:    types.StorageMetadataV14{Prefix: types.Text(entry.Pallet)},
+				Index:      types.NewU8(uint8(i)),
+			})
+		}
+
+		item := types.StorageEntryMetadataV14{
+			Name:     types.Text(entry.Name),
+			Modifier: types.StorageFunctionModifierV0{IsOptional: true},
+		}
+		if len(entry.Hashers) == 0 {
+			item.Type = types.StorageEntryTypeV14{IsPlainType: true}
+		} else {
+			item.Type = types.StorageEntryTypeV14{
+				IsMap: true,
+				AsMap: types.MapTypeV14{Hashers: entry.Hashers},
+			}
+		}
+
+		pallets[i].Storage.Items = append(pallets[i].Storage.Items, item)
+	}
+
+	return &types.Metadata{
+		MagicNumber:   types.MagicNumber,
+		Version:       14,
+		AsMetadataV14: types.MetadataV14{Pallets: pallets},
+	}
+}
@@ -0,0 +1,144 @@
+package mock
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// stateRPC serves state RPCs from the backend. The embedded interface is nil, not implemented
+// methods panic.
+type stateRPC struct {
+	state.State
+	backend *Backend
+}
+
+func (s *stateRPC) GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) (bool, error) {
+	raw, err := s.GetStorageRaw(key, blockHash)
+	if err != nil {
+		return false, err
+	}
+
+	return decodeStorage(*raw, target)
+}
+
+func (s *stateRPC) GetStorageLatest(key types.StorageKey, target interface{}) (bool, error) {
+	raw, err := s.GetStorageRawLatest(key)
+	if err != nil {
+		return false, err
+	}
+
+	return decodeStorage(*raw, target)
+}
+
+func (s *stateRPC) GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
+	blk, err := s.backend.blockByHash(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := types.StorageDataRaw(blk.storage[string(key)])
+
+	return &raw, nil
+}
+
+func (s *stateRPC) GetStorageRawLatest(key types.StorageKey) (*types.StorageDataRaw, error) {
+	raw := types.StorageDataRaw(s.backend.latestBlock().storage[string(key)])
+
+	return &raw, nil
+}
+
+func (s *stateRPC) GetKeys(prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error) {
+	blk, err := s.backend.blockByHash(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return blk.keys(prefix), nil
+}
+
+func (s *stateRPC) GetKeysLatest(prefix types.StorageKey) ([]types.StorageKey, error) {
+	return s.backend.latestBlock().keys(prefix), nil
+}
+
+func (s *stateRPC) GetMetadata(blockHash types.Hash) (*types.Metadata, error) {
+	if _, err := s.backend.blockByHash(blockHash); err != nil {
+		return nil, err
+	}
+
+	return s.backend.Metadata(), nil
+}
+
+func (s *stateRPC) GetMetadataLatest() (*types.Metadata, error) {
+	return s.backend.Metadata(), nil
+}
+
+func (s *stateRPC) GetRuntimeVersion(blockHash types.Hash) (*types.RuntimeVersion, error) {
+	if _, err := s.backend.blockByHash(blockHash); err != nil {
+		return nil, err
+	}
+
+	return s.GetRuntimeVersionLatest()
+}
+
+func (s *stateRPC) GetRuntimeVersionLatest() (*types.RuntimeVersion, error) {
+	s.backend.mu.RLock()
+	defer s.backend.mu.RUnlock()
+
+	version := s.backend.version
+
+	return &version, nil
+}
+
+func decodeStorage(raw types.StorageDataRaw, target interface{}) (bool, error) {
+	if len(raw) == 0 {
+		return false, nil
+	}
+
+	return true, codec.Decode(raw, target)
+}
+
+// chainRPC serves chain RPCs from the backend. The embedded interface is nil, not implemented
+// methods panic.
+type chainRPC struct {
+	chain.Chain
+	backend *Backend
+}
+
+func (c *chainRPC) GetBlockHash(blockNumber uint64) (types.Hash, error) {
+	c.backend.mu.RLock()
+	defer c.backend.mu.RUnlock()
+
+	if blockNumber >= uint64(len(c.backend.blocks)) {
+		return types.Hash{}, ErrBlockNotFound
+	}
+
+	return c.backend.blocks[blockNumber].hash, nil
+}
+
+func (c *chainRPC) GetBlockHashLatest() (types.Hash, error) {
+	return c.backend.latestBlock().hash, nil
+}
+
+func (c *chainRPC) GetHeader(blockHash types.Hash) (*types.Header, error) {
+	blk, err := c.backend.blockByHash(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blk.header, nil
+}
+
+func (c *chainRPC) GetHeaderLatest() (*types.Header, error) {
+	header := c.backend.latestBlock().header
+
+	return &header, nil
+}
+
+func (c *chainRPC) GetFinalizedHead() (types.Hash, error) {
+	c.backend.mu.RLock()
+	defer c.backend.mu.RUnlock()
+
+	return c.backend.blocks[c.backend.finalized].hash, nil
+}
@@ -0,0 +1,8 @@
+// Package mocks provides generated mocks of the pallet APIs, so downstream projects don't maintain
+// their own. Every method of a mock calls the function in the field named after the method with
+// the Func suffix and records the call arguments, e.g. GetClustersFunc and GetClustersCalls.
+//
+// Regenerate the mocks with go generate after an interface changes.
+package mocks
+
+//go:generate go run github.com/matryer/moq@v0.3.4 -out pallets.go -pkg mocks ../pallets DdcClustersApi DdcCustomersApi DdcNodesApi DdcPayoutsApi
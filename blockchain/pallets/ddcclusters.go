@@ -6,6 +6,8 @@ import (
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/xxhash"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors"
 )
 
 type Cluster struct {
@@ -31,11 +33,32 @@ type DdcClustersApi interface {
 
 type ddcClustersApi struct {
 	substrateApi     *gsrpc.SubstrateAPI
-	meta             *types.Metadata
+	meta             *metadata
 	clustersNodesKey []byte
+	blockHash        *types.Hash
+	strictness       DecodeStrictness
+}
+
+func NewDdcClustersApi(substrateApi *gsrpc.SubstrateAPI, meta *types.Metadata, opts ...ApiOption) DdcClustersApi {
+	return newDdcClustersApi(substrateApi, meta, nil, opts)
+}
+
+// NewDdcClustersApiAt returns DdcClustersApi reading the storage at the given block.
+func NewDdcClustersApiAt(
+	substrateApi *gsrpc.SubstrateAPI,
+	meta *types.Metadata,
+	blockHash types.Hash,
+	opts ...ApiOption,
+) DdcClustersApi {
+	return newDdcClustersApi(substrateApi, meta, &blockHash, opts)
 }
 
-func NewDdcClustersApi(substrateApi *gsrpc.SubstrateAPI, meta *types.Metadata) DdcClustersApi {
+func newDdcClustersApi(
+	substrateApi *gsrpc.SubstrateAPI,
+	meta *types.Metadata,
+	blockHash *types.Hash,
+	opts []ApiOption,
+) DdcClustersApi {
 	clustersNodesKey := append(
 		xxhash.New128([]byte("DdcClusters")).Sum(nil),
 		xxhash.New128([]byte("ClustersNodes")).Sum(nil)...,
@@ -44,10 +67,16 @@ func NewDdcClustersApi(substrateApi *gsrpc.SubstrateAPI, meta *types.Metadata) D
 	return &ddcClustersApi{
 		substrateApi:     substrateApi,
 		clustersNodesKey: clustersNodesKey,
-		meta:             meta,
+		meta:             newMetadata(meta),
+		blockHash:        blockHash,
+		strictness:       newApiOptions(opts).strictness,
 	}
 }
 
+func (api *ddcClustersApi) UpdateMetadata(meta *types.Metadata) {
+	api.meta.Set(meta)
+}
+
 func (api *ddcClustersApi) GetClustersNodes(clusterId ClusterId) ([]NodePubKey, error) {
 	clusterIdBytes, err := codec.Encode(clusterId)
 	if err != nil {
@@ -67,21 +96,20 @@ func (api *ddcClustersApi) GetClustersNodes(clusterId ClusterId) ([]NodePubKey,
 	)
 
 	queryKey := types.NewStorageKey(moduleMethodPrefix1Key)
-	keys, err := api.substrateApi.RPC.State.GetKeysLatest(queryKey)
+	keys, err := getKeys(api.substrateApi, api.blockHash, queryKey)
 	if err != nil {
 		return nil, err
 	}
 
 	nodesKeys := make([]NodePubKey, len(keys))
 	for i, key := range keys {
-		var nodePubKey NodePubKey
-
 		// Decode SCALE-encoded NodePubKey from the secondary key:
 		// 	- 16 bytes - Blake2_128 hash,
 		// 	- 1 byte - enum variant,
-		// 	- 32 - node public key length (as long StoragePubKey is AccountId32 type).
-		if err := codec.Decode(key[len(moduleMethodPrefix1Key)+16:len(moduleMethodPrefix1Key)+16+1+32], &nodePubKey); err != nil {
-			return nil, err
+		// 	- the rest - node public key (32 bytes as long StoragePubKey is AccountId32 type).
+		nodePubKey, err := decodeTrailingNodePubKey(key[len(moduleMethodPrefix1Key)+16:], api.strictness)
+		if err != nil {
+			return nil, ddcerrors.Wrap(ddcerrors.CodeDecoding, err)
 		}
 
 		nodesKeys[i] = nodePubKey
@@ -98,16 +126,19 @@ func (api *ddcClustersApi) GetClusters(clusterId ClusterId) (types.Option[Cluste
 		return maybeCluster, err
 	}
 
-	key, err := types.CreateStorageKey(api.meta, "DdcClusters", "Clusters", bytes)
+	key, err := types.CreateStorageKey(api.meta.Get(), "DdcClusters", "Clusters", bytes)
 	if err != nil {
 		return maybeCluster, err
 	}
 
 	var cluster Cluster
-	ok, err := api.substrateApi.RPC.State.GetStorageLatest(key, &cluster)
+	ok, err := getStorage(api.substrateApi, api.blockHash, key, &cluster)
 	if !ok || err != nil {
 		return maybeCluster, err
 	}
+	if err := api.strictness.check(cluster); err != nil {
+		return maybeCluster, err
+	}
 
 	maybeCluster.SetSome(cluster)
 
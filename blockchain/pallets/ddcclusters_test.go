@@ -0,0 +1,101 @@
+package pallets
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain/mock"
+)
+
+func TestGetClusters(t *testing.T) {
+	//given
+	backend := mock.NewBackend(mock.DdcMetadata())
+	api := NewDdcClustersApi(backend.SubstrateAPI(), backend.Metadata())
+
+	cluster := Cluster{
+		ClusterId: types.H160{1},
+		ManagerId: types.AccountID{2},
+		ReserveId: types.AccountID{3},
+		Props: ClusterProps{
+			NodeProviderAuthContract: types.NewOptionAccountIDEmpty(),
+			ErasureCodingRequired:    4,
+			ErasureCodingTotal:       6,
+			ReplicationTotal:         3,
+		},
+		Status:             ClusterStatus{IsActivated: true},
+		LastValidatedEraId: 7,
+	}
+	key, err := backend.StorageKey("DdcClusters", "Clusters", cluster.ClusterId)
+	assert.NoError(t, err)
+	assert.NoError(t, backend.Put(key, cluster))
+	backend.ProduceBlock()
+
+	//when
+	result, err := api.GetClusters(cluster.ClusterId)
+	missing, missingErr := api.GetClusters(types.H160{9})
+
+	//then
+	assert.NoError(t, err)
+	ok, got := result.Unwrap()
+	assert.True(t, ok)
+	assert.Equal(t, cluster, got)
+
+	assert.NoError(t, missingErr)
+	assert.False(t, missing.HasValue())
+}
+
+func TestGetClustersNodes(t *testing.T) {
+	//given
+	backend := mock.NewBackend(mock.DdcMetadata())
+	api := NewDdcClustersApi(backend.SubstrateAPI(), backend.Metadata())
+
+	clusterId := types.H160{1}
+	otherClusterId := types.H160{2}
+	node1 := NodePubKey{IsStoragePubKey: true, AsStoragePubKey: types.AccountID{1}}
+	node2 := NodePubKey{IsStoragePubKey: true, AsStoragePubKey: types.AccountID{2}}
+	node3 := NodePubKey{IsStoragePubKey: true, AsStoragePubKey: types.AccountID{3}}
+
+	for _, entry := range []struct {
+		clusterId ClusterId
+		node      NodePubKey
+	}{{clusterId, node1}, {clusterId, node2}, {otherClusterId, node3}} {
+		key, err := backend.StorageKey("DdcClusters", "ClustersNodes", entry.clusterId, entry.node)
+		assert.NoError(t, err)
+		assert.NoError(t, backend.Put(key, types.NewBool(true)))
+	}
+	backend.ProduceBlock()
+
+	//when
+	nodes, err := api.GetClustersNodes(clusterId)
+
+	//then
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []NodePubKey{node1, node2}, nodes)
+}
+
+func TestGetClustersAtBlock(t *testing.T) {
+	//given
+	backend := mock.NewBackend(mock.DdcMetadata())
+	cluster := Cluster{ClusterId: types.H160{1}, Status: ClusterStatus{IsBonded: true}}
+	key, err := backend.StorageKey("DdcClusters", "Clusters", cluster.ClusterId)
+	assert.NoError(t, err)
+
+	assert.NoError(t, backend.Put(key, cluster))
+	bondedHash := backend.ProduceBlock()
+
+	cluster.Status = ClusterStatus{IsActivated: true}
+	assert.NoError(t, backend.Put(key, cluster))
+	backend.ProduceBlock()
+
+	api := NewDdcClustersApiAt(backend.SubstrateAPI(), backend.Metadata(), bondedHash)
+
+	//when
+	result, err := api.GetClusters(cluster.ClusterId)
+
+	//then
+	assert.NoError(t, err)
+	_, got := result.Unwrap()
+	assert.Equal(t, ClusterStatus{IsBonded: true}, got.Status)
+}
@@ -35,16 +35,30 @@ type DdcCustomersApi interface {
 
 type ddcCustomersApi struct {
 	substrateApi *gsrpc.SubstrateAPI
-	meta         *types.Metadata
+	meta         *metadata
+	blockHash    *types.Hash
 }
 
 func NewDdcCustomersApi(substrateApi *gsrpc.SubstrateAPI, meta *types.Metadata) DdcCustomersApi {
 	return &ddcCustomersApi{
-		substrateApi,
-		meta,
+		substrateApi: substrateApi,
+		meta:         newMetadata(meta),
 	}
 }
 
+// NewDdcCustomersApiAt returns DdcCustomersApi reading the storage at the given block.
+func NewDdcCustomersApiAt(substrateApi *gsrpc.SubstrateAPI, meta *types.Metadata, blockHash types.Hash) DdcCustomersApi {
+	return &ddcCustomersApi{
+		substrateApi: substrateApi,
+		meta:         newMetadata(meta),
+		blockHash:    &blockHash,
+	}
+}
+
+func (api *ddcCustomersApi) UpdateMetadata(meta *types.Metadata) {
+	api.meta.Set(meta)
+}
+
 func (api *ddcCustomersApi) GetBuckets(bucketId BucketId) (types.Option[Bucket], error) {
 	maybeBucket := types.NewEmptyOption[Bucket]()
 
@@ -53,13 +67,13 @@ func (api *ddcCustomersApi) GetBuckets(bucketId BucketId) (types.Option[Bucket],
 		return maybeBucket, err
 	}
 
-	key, err := types.CreateStorageKey(api.meta, "DdcCustomers", "Buckets", bytes)
+	key, err := types.CreateStorageKey(api.meta.Get(), "DdcCustomers", "Buckets", bytes)
 	if err != nil {
 		return maybeBucket, err
 	}
 
 	var bucket Bucket
-	ok, err := api.substrateApi.RPC.State.GetStorageLatest(key, &bucket)
+	ok, err := getStorage(api.substrateApi, api.blockHash, key, &bucket)
 	if !ok || err != nil {
 		return maybeBucket, err
 	}
@@ -70,13 +84,13 @@ func (api *ddcCustomersApi) GetBuckets(bucketId BucketId) (types.Option[Bucket],
 }
 
 func (api *ddcCustomersApi) GetBucketsCount() (types.U64, error) {
-	key, err := types.CreateStorageKey(api.meta, "DdcCustomers", "BucketsCount")
+	key, err := types.CreateStorageKey(api.meta.Get(), "DdcCustomers", "BucketsCount")
 	if err != nil {
 		return 0, err
 	}
 
 	var bucketsCount types.U64
-	ok, err := api.substrateApi.RPC.State.GetStorageLatest(key, &bucketsCount)
+	ok, err := getStorage(api.substrateApi, api.blockHash, key, &bucketsCount)
 	if err != nil {
 		return 0, err
 	}
@@ -95,13 +109,13 @@ func (api *ddcCustomersApi) GetLedger(owner types.AccountID) (types.Option[Accou
 		return maybeLedger, err
 	}
 
-	key, err := types.CreateStorageKey(api.meta, "DdcCustomers", "Ledger", bytes)
+	key, err := types.CreateStorageKey(api.meta.Get(), "DdcCustomers", "Ledger", bytes)
 	if err != nil {
 		return maybeLedger, err
 	}
 
 	var accountsLedger AccountsLedger
-	ok, err := api.substrateApi.RPC.State.GetStorageLatest(key, &accountsLedger)
+	ok, err := getStorage(api.substrateApi, api.blockHash, key, &accountsLedger)
 	if !ok || err != nil {
 		return maybeLedger, err
 	}
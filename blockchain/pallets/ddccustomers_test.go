@@ -0,0 +1,104 @@
+package pallets
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain/mock"
+)
+
+func TestGetBuckets(t *testing.T) {
+	//given
+	backend := mock.NewBackend(mock.DdcMetadata())
+	api := NewDdcCustomersApi(backend.SubstrateAPI(), backend.Metadata())
+
+	bucket := Bucket{
+		BucketId:  1,
+		OwnerId:   types.AccountID{1},
+		ClusterId: types.H160{2},
+		IsPublic:  true,
+		TotalCustomersUsage: types.NewOption(CustomerUsage{
+			TransferredBytes: 100,
+			StoredBytes:      50,
+			NumberOfPuts:     2,
+			NumberOfGets:     3,
+		}),
+	}
+	key, err := backend.StorageKey("DdcCustomers", "Buckets", bucket.BucketId)
+	assert.NoError(t, err)
+	assert.NoError(t, backend.Put(key, bucket))
+	backend.ProduceBlock()
+
+	//when
+	result, err := api.GetBuckets(bucket.BucketId)
+
+	//then
+	assert.NoError(t, err)
+	ok, got := result.Unwrap()
+	assert.True(t, ok)
+	assert.Equal(t, bucket, got)
+}
+
+func TestGetBucketsCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		count *types.U64
+		want  types.U64
+	}{
+		{name: "not set", want: 0},
+		{name: "set", count: func() *types.U64 { v := types.U64(42); return &v }(), want: 42},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			backend := mock.NewBackend(mock.DdcMetadata())
+			api := NewDdcCustomersApi(backend.SubstrateAPI(), backend.Metadata())
+			if tt.count != nil {
+				key, err := backend.StorageKey("DdcCustomers", "BucketsCount")
+				assert.NoError(t, err)
+				assert.NoError(t, backend.Put(key, *tt.count))
+				backend.ProduceBlock()
+			}
+
+			//when
+			count, err := api.GetBucketsCount()
+
+			//then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, count)
+		})
+	}
+}
+
+func TestGetLedger(t *testing.T) {
+	//given
+	backend := mock.NewBackend(mock.DdcMetadata())
+	api := NewDdcCustomersApi(backend.SubstrateAPI(), backend.Metadata())
+
+	ledger := AccountsLedger{
+		Owner:     types.AccountID{1},
+		Total:     types.NewUCompactFromUInt(1000),
+		Active:    types.NewUCompactFromUInt(800),
+		Unlocking: []UnlockChunk{{Value: types.NewU128(*big.NewInt(200)), Block: 10}},
+	}
+	key, err := backend.StorageKey("DdcCustomers", "Ledger", ledger.Owner)
+	assert.NoError(t, err)
+	assert.NoError(t, backend.Put(key, ledger))
+	backend.ProduceBlock()
+
+	//when
+	result, err := api.GetLedger(ledger.Owner)
+
+	//then
+	assert.NoError(t, err)
+	ok, got := result.Unwrap()
+	assert.True(t, ok)
+	assert.Equal(t, ledger.Owner, got.Owner)
+	assert.Equal(t, ledger.Active.Int64(), got.Active.Int64())
+	assert.Equal(t, ledger.Total.Int64(), got.Total.Int64())
+	assert.Equal(t, ledger.Unlocking[0].Value.String(), got.Unlocking[0].Value.String())
+}
@@ -38,16 +38,38 @@ type DdcNodesApi interface {
 
 type ddcNodesApi struct {
 	substrateApi *gsrpc.SubstrateAPI
-	meta         *types.Metadata
+	meta         *metadata
+	blockHash    *types.Hash
+	strictness   DecodeStrictness
 }
 
-func NewDdcNodesApi(substrateApi *gsrpc.SubstrateAPI, meta *types.Metadata) DdcNodesApi {
+func NewDdcNodesApi(substrateApi *gsrpc.SubstrateAPI, meta *types.Metadata, opts ...ApiOption) DdcNodesApi {
 	return &ddcNodesApi{
-		substrateApi,
-		meta,
+		substrateApi: substrateApi,
+		meta:         newMetadata(meta),
+		strictness:   newApiOptions(opts).strictness,
 	}
 }
 
+// NewDdcNodesApiAt returns DdcNodesApi reading the storage at the given block.
+func NewDdcNodesApiAt(
+	substrateApi *gsrpc.SubstrateAPI,
+	meta *types.Metadata,
+	blockHash types.Hash,
+	opts ...ApiOption,
+) DdcNodesApi {
+	return &ddcNodesApi{
+		substrateApi: substrateApi,
+		meta:         newMetadata(meta),
+		blockHash:    &blockHash,
+		strictness:   newApiOptions(opts).strictness,
+	}
+}
+
+func (api *ddcNodesApi) UpdateMetadata(meta *types.Metadata) {
+	api.meta.Set(meta)
+}
+
 func (api *ddcNodesApi) GetStorageNodes(pubkey StorageNodePubKey) (types.Option[StorageNode], error) {
 	maybeNode := types.NewEmptyOption[StorageNode]()
 
@@ -56,16 +78,19 @@ func (api *ddcNodesApi) GetStorageNodes(pubkey StorageNodePubKey) (types.Option[
 		return maybeNode, err
 	}
 
-	key, err := types.CreateStorageKey(api.meta, "DdcNodes", "StorageNodes", bytes)
+	key, err := types.CreateStorageKey(api.meta.Get(), "DdcNodes", "StorageNodes", bytes)
 	if err != nil {
 		return maybeNode, err
 	}
 
 	var node StorageNode
-	ok, err := api.substrateApi.RPC.State.GetStorageLatest(key, &node)
+	ok, err := getStorage(api.substrateApi, api.blockHash, key, &node)
 	if !ok || err != nil {
 		return maybeNode, err
 	}
+	if err := api.strictness.check(node); err != nil {
+		return maybeNode, err
+	}
 
 	maybeNode.SetSome(node)
 
@@ -0,0 +1,76 @@
+package pallets
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain/mock"
+)
+
+func TestGetStorageNodes(t *testing.T) {
+	//given
+	backend := mock.NewBackend(mock.DdcMetadata())
+	api := NewDdcNodesApi(backend.SubstrateAPI(), backend.Metadata())
+
+	node := StorageNode{
+		PubKey:     types.AccountID{1},
+		ProviderId: types.AccountID{2},
+		ClusterId:  types.NewOption(types.H160{3}),
+		Props: StorageNodeProps{
+			Host:     []types.U8{'h', 'o', 's', 't'},
+			Ssl:      true,
+			HttpPort: 8080,
+			GrpcPort: 9090,
+			P2pPort:  9070,
+			Mode:     StorageNodeMode{IsStorage: true},
+		},
+		TotalUsage: types.NewEmptyOption[NodeUsage](),
+	}
+	key, err := backend.StorageKey("DdcNodes", "StorageNodes", node.PubKey)
+	assert.NoError(t, err)
+	assert.NoError(t, backend.Put(key, node))
+	backend.ProduceBlock()
+
+	//when
+	result, err := api.GetStorageNodes(node.PubKey)
+	missing, missingErr := api.GetStorageNodes(types.AccountID{9})
+
+	//then
+	assert.NoError(t, err)
+	ok, got := result.Unwrap()
+	assert.True(t, ok)
+	assert.Equal(t, node, got)
+
+	assert.NoError(t, missingErr)
+	assert.False(t, missing.HasValue())
+}
+
+func TestGetStorageNodesUnknownMode(t *testing.T) {
+	//given
+	backend := mock.NewBackend(mock.DdcMetadata())
+	strictApi := NewDdcNodesApi(backend.SubstrateAPI(), backend.Metadata())
+	lenientApi := NewDdcNodesApi(backend.SubstrateAPI(), backend.Metadata(), WithDecodeStrictness(DecodeLenient))
+
+	node := StorageNode{
+		PubKey:     types.AccountID{1},
+		Props:      StorageNodeProps{Mode: StorageNodeMode{IsUnknown: true, AsUnknown: UnknownVariant{Index: 9}}},
+		TotalUsage: types.NewEmptyOption[NodeUsage](),
+	}
+	key, err := backend.StorageKey("DdcNodes", "StorageNodes", node.PubKey)
+	assert.NoError(t, err)
+	assert.NoError(t, backend.Put(key, node))
+	backend.ProduceBlock()
+
+	//when
+	_, strictErr := strictApi.GetStorageNodes(node.PubKey)
+	result, lenientErr := lenientApi.GetStorageNodes(node.PubKey)
+
+	//then
+	assert.ErrorIs(t, strictErr, ErrUnknownVariant)
+	assert.NoError(t, lenientErr)
+	ok, got := result.Unwrap()
+	assert.True(t, ok)
+	assert.Equal(t, node.Props.Mode, got.Props.Mode)
+}
@@ -12,16 +12,30 @@ type DdcPayoutsApi interface {
 
 type ddcPayoutsApi struct {
 	substrateApi *gsrpc.SubstrateAPI
-	meta         *types.Metadata
+	meta         *metadata
+	blockHash    *types.Hash
 }
 
 func NewDdcPayoutsApi(substrateApi *gsrpc.SubstrateAPI, meta *types.Metadata) DdcPayoutsApi {
 	return &ddcPayoutsApi{
-		substrateApi,
-		meta,
+		substrateApi: substrateApi,
+		meta:         newMetadata(meta),
 	}
 }
 
+// NewDdcPayoutsApiAt returns DdcPayoutsApi reading the storage at the given block.
+func NewDdcPayoutsApiAt(substrateApi *gsrpc.SubstrateAPI, meta *types.Metadata, blockHash types.Hash) DdcPayoutsApi {
+	return &ddcPayoutsApi{
+		substrateApi: substrateApi,
+		meta:         newMetadata(meta),
+		blockHash:    &blockHash,
+	}
+}
+
+func (api *ddcPayoutsApi) UpdateMetadata(meta *types.Metadata) {
+	api.meta.Set(meta)
+}
+
 func (api *ddcPayoutsApi) GetDebtorCustomers(cluster ClusterId, account types.AccountID) (types.Option[types.U128], error) {
 	maybeV := types.NewEmptyOption[types.U128]()
 
@@ -35,13 +49,13 @@ func (api *ddcPayoutsApi) GetDebtorCustomers(cluster ClusterId, account types.Ac
 		return maybeV, err
 	}
 
-	key, err := types.CreateStorageKey(api.meta, "DdcPayouts", "DebtorCustomers", bytesCluster, bytesAccount)
+	key, err := types.CreateStorageKey(api.meta.Get(), "DdcPayouts", "DebtorCustomers", bytesCluster, bytesAccount)
 	if err != nil {
 		return maybeV, err
 	}
 
 	var v types.U128
-	ok, err := api.substrateApi.RPC.State.GetStorageLatest(key, &v)
+	ok, err := getStorage(api.substrateApi, api.blockHash, key, &v)
 	if !ok || err != nil {
 		return maybeV, err
 	}
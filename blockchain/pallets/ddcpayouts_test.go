@@ -0,0 +1,37 @@
+package pallets
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain/mock"
+)
+
+func TestGetDebtorCustomers(t *testing.T) {
+	//given
+	backend := mock.NewBackend(mock.DdcMetadata())
+	api := NewDdcPayoutsApi(backend.SubstrateAPI(), backend.Metadata())
+
+	clusterId := types.H160{1}
+	debtor := types.AccountID{2}
+	key, err := backend.StorageKey("DdcPayouts", "DebtorCustomers", clusterId, debtor)
+	assert.NoError(t, err)
+	assert.NoError(t, backend.Put(key, types.NewU128(*big.NewInt(500))))
+	backend.ProduceBlock()
+
+	//when
+	debt, err := api.GetDebtorCustomers(clusterId, debtor)
+	noDebt, noDebtErr := api.GetDebtorCustomers(clusterId, types.AccountID{3})
+
+	//then
+	assert.NoError(t, err)
+	ok, got := debt.Unwrap()
+	assert.True(t, ok)
+	assert.Equal(t, "500", got.String())
+
+	assert.NoError(t, noDebtErr)
+	assert.False(t, noDebt.HasValue())
+}
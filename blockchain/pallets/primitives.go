@@ -8,15 +8,17 @@
 package pallets
 
 import (
-	"errors"
 	"reflect"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors"
 )
 
 var (
-	ErrUnknownVariant = errors.New("unknown variant")
+	ErrUnknownVariant = ddcerrors.New(ddcerrors.CodeDecoding, "unknown variant")
 )
 
 const (
@@ -30,9 +32,115 @@ type (
 	StorageNodePubKey = types.AccountID
 )
 
+// DecodeStrictness controls decoding of enum variants unknown to this SDK, e.g. added to the chain
+// by a runtime upgrade. Pallet types decode unknown variants without fields to the Unknown variant
+// of the enum. Pallet APIs configured with WithDecodeStrictness and Decode reject them unless
+// DecodeLenient is set.
+type DecodeStrictness int
+
+const (
+	// DecodeStrict fails decoding of unknown variants with ErrUnknownVariant.
+	DecodeStrict DecodeStrictness = iota
+	// DecodeLenient keeps unknown variants in the Unknown variant of the enum with the raw bytes,
+	// so read paths keep working across runtime upgrades.
+	DecodeLenient
+)
+
+// Decode decodes SCALE-encoded data into target. It fails with ErrUnknownVariant if target
+// contains an enum variant unknown to this SDK, unless strictness is DecodeLenient.
+func Decode(data []byte, target interface{}, strictness DecodeStrictness) error {
+	if err := codec.Decode(data, target); err != nil {
+		return err
+	}
+
+	return strictness.check(target)
+}
+
+// check returns ErrUnknownVariant for a value with an unknown enum variant in DecodeStrict.
+func (s DecodeStrictness) check(value interface{}) error {
+	if s == DecodeLenient || !hasUnknownVariant(reflect.ValueOf(value)) {
+		return nil
+	}
+
+	return ErrUnknownVariant
+}
+
+// unknownVariantEnum is implemented by enums with the Unknown variant.
+type unknownVariantEnum interface {
+	isUnknown() bool
+}
+
+func hasUnknownVariant(v reflect.Value) bool {
+	if v.CanInterface() {
+		if enum, ok := v.Interface().(unknownVariantEnum); ok {
+			return enum.isUnknown()
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		return !v.IsNil() && hasUnknownVariant(v.Elem())
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() && hasUnknownVariant(v.Field(i)) {
+				return true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if hasUnknownVariant(v.Index(i)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// UnknownVariant is an enum variant unknown to this SDK. Data is the rest of the SCALE-encoded
+// value after the variant index, empty for enums without variant fields.
+type UnknownVariant struct {
+	Index byte
+	Data  []byte
+}
+
+func (v UnknownVariant) encode(encoder scale.Encoder) error {
+	if err := encoder.PushByte(v.Index); err != nil {
+		return err
+	}
+	if len(v.Data) == 0 {
+		return nil
+	}
+
+	return encoder.Write(v.Data)
+}
+
 type NodePubKey struct {
 	IsStoragePubKey bool
 	AsStoragePubKey StorageNodePubKey
+	// IsUnknown is set for a key variant unknown to this SDK. It's decoded only from the end of a
+	// storage key, see DdcClustersApi.GetClustersNodes, as the size of the variant data is unknown.
+	IsUnknown bool
+	AsUnknown UnknownVariant
+}
+
+// decodeTrailingNodePubKey decodes NodePubKey ending data, e.g. a storage key. An unknown variant
+// takes the rest of data, as nothing follows the key.
+func decodeTrailingNodePubKey(data []byte, strictness DecodeStrictness) (NodePubKey, error) {
+	var m NodePubKey
+	if len(data) > 0 && data[0] != 0 && strictness == DecodeLenient {
+		m.IsUnknown = true
+		m.AsUnknown = UnknownVariant{Index: data[0], Data: data[1:]}
+		return m, nil
+	}
+
+	err := codec.Decode(data, &m)
+
+	return m, err
+}
+
+func (m NodePubKey) isUnknown() bool {
+	return m.IsUnknown
 }
 
 func (m *NodePubKey) Decode(decoder scale.Decoder) error {
@@ -46,7 +154,8 @@ func (m *NodePubKey) Decode(decoder scale.Decoder) error {
 		m.IsStoragePubKey = true
 		err = decoder.Decode(&m.AsStoragePubKey)
 	} else {
-		return ErrUnknownVariant
+		// The variant data can't be skipped without knowing its size.
+		err = ErrUnknownVariant
 	}
 
 	if err != nil {
@@ -61,6 +170,8 @@ func (m NodePubKey) Encode(encoder scale.Encoder) error {
 	if m.IsStoragePubKey {
 		err1 = encoder.PushByte(0)
 		err2 = encoder.Encode(m.AsStoragePubKey)
+	} else if m.IsUnknown {
+		err1 = m.AsUnknown.encode(encoder)
 	} else {
 		return ErrUnknownVariant
 	}
@@ -80,6 +191,13 @@ type StorageNodeMode struct {
 	IsStorage bool
 	IsCache   bool
 	IsDac     bool
+	// IsUnknown is set for a mode unknown to this SDK.
+	IsUnknown bool
+	AsUnknown UnknownVariant
+}
+
+func (m StorageNodeMode) isUnknown() bool {
+	return m.IsUnknown
 }
 
 func (m *StorageNodeMode) Decode(decoder scale.Decoder) error {
@@ -98,7 +216,8 @@ func (m *StorageNodeMode) Decode(decoder scale.Decoder) error {
 	} else if b == 4 {
 		m.IsDac = true
 	} else {
-		return ErrUnknownVariant
+		m.IsUnknown = true
+		m.AsUnknown = UnknownVariant{Index: b}
 	}
 
 	return nil
@@ -114,6 +233,8 @@ func (m StorageNodeMode) Encode(encoder scale.Encoder) error {
 		err = encoder.PushByte(3)
 	} else if m.IsDac {
 		err = encoder.PushByte(4)
+	} else if m.IsUnknown {
+		err = m.AsUnknown.encode(encoder)
 	} else {
 		return ErrUnknownVariant
 	}
@@ -125,11 +246,22 @@ func (m StorageNodeMode) Encode(encoder scale.Encoder) error {
 	return nil
 }
 
+// clusterStatusVariants is the number of the known ClusterStatus variants, the leading fields of
+// the struct.
+const clusterStatusVariants = 4
+
 type ClusterStatus struct {
 	IsUnbonded  bool
 	IsBonded    bool
 	IsActivated bool
 	IsUnbonding bool
+	// IsUnknown is set for a status unknown to this SDK.
+	IsUnknown bool
+	AsUnknown UnknownVariant
+}
+
+func (m ClusterStatus) isUnknown() bool {
+	return m.IsUnknown
 }
 
 func (m *ClusterStatus) Decode(decoder scale.Decoder) error {
@@ -140,39 +272,30 @@ func (m *ClusterStatus) Decode(decoder scale.Decoder) error {
 
 	i := int(b)
 
-	v := reflect.ValueOf(m).Elem()
-	if i > v.NumField() {
-		return ErrUnknownVariant
+	if i >= clusterStatusVariants {
+		m.IsUnknown = true
+		m.AsUnknown = UnknownVariant{Index: b}
+		return nil
 	}
 
-	v.Field(i).SetBool(true)
+	reflect.ValueOf(m).Elem().Field(i).SetBool(true)
 
 	return nil
 }
 
 func (m ClusterStatus) Encode(encoder scale.Encoder) error {
-	var err1, err2 error
 	v := reflect.ValueOf(m)
 
-	for i := 0; i < v.NumField(); i++ {
+	for i := 0; i < clusterStatusVariants; i++ {
 		if v.Field(i).Bool() {
-			err1 = encoder.PushByte(byte(i))
-			err2 = encoder.Encode(i)
-			break
-		}
-		if i == v.NumField()-1 {
-			return ErrUnknownVariant
+			return encoder.PushByte(byte(i))
 		}
 	}
-
-	if err1 != nil {
-		return err1
-	}
-	if err2 != nil {
-		return err2
+	if m.IsUnknown {
+		return m.AsUnknown.encode(encoder)
 	}
 
-	return nil
+	return ErrUnknownVariant
 }
 
 type CustomerUsage struct {
@@ -0,0 +1,80 @@
+package pallets
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeUnknownVariant(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded []byte
+		target  interface{}
+		want    interface{}
+	}{
+		{
+			name:    "storage node mode",
+			encoded: []byte{5},
+			target:  &StorageNodeMode{},
+			want:    &StorageNodeMode{IsUnknown: true, AsUnknown: UnknownVariant{Index: 5}},
+		},
+		{
+			name:    "cluster status",
+			encoded: []byte{4},
+			target:  &ClusterStatus{},
+			want:    &ClusterStatus{IsUnknown: true, AsUnknown: UnknownVariant{Index: 4}},
+		},
+		{
+			name:    "nested in a struct",
+			encoded: []byte{0, 0, 0, 0x50, 0x1f, 0, 0, 0, 0, 6},
+			target:  &StorageNodeProps{},
+			want: &StorageNodeProps{
+				HttpPort: 8016,
+				Mode:     StorageNodeMode{IsUnknown: true, AsUnknown: UnknownVariant{Index: 6}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//when
+			strictErr := Decode(tt.encoded, tt.target, DecodeStrict)
+			lenientErr := Decode(tt.encoded, tt.target, DecodeLenient)
+			encoded, encodeErr := codec.Encode(tt.target)
+
+			//then
+			assert.ErrorIs(t, strictErr, ErrUnknownVariant)
+			assert.NoError(t, lenientErr)
+			assert.Equal(t, tt.want, tt.target)
+			assert.NoError(t, encodeErr)
+			assert.Equal(t, tt.encoded, encoded)
+		})
+	}
+}
+
+func TestDecodeUnknownNodePubKey(t *testing.T) {
+	//given
+	key := NodePubKey{IsStoragePubKey: true, AsStoragePubKey: types.AccountID{1}}
+	keyBytes, err := codec.Encode(key)
+	assert.NoError(t, err)
+	// Vec of an unknown key followed by a known one.
+	vec := append([]byte{2 << 2, 1, 0xaa, 0xbb}, keyBytes...)
+
+	//when
+	var keys []NodePubKey
+	vecErr := Decode(vec, &keys, DecodeLenient)
+	trailing, trailingErr := decodeTrailingNodePubKey([]byte{1, 0xaa, 0xbb}, DecodeLenient)
+	_, strictErr := decodeTrailingNodePubKey([]byte{1, 0xaa, 0xbb}, DecodeStrict)
+	known, knownErr := decodeTrailingNodePubKey(keyBytes, DecodeLenient)
+
+	//then
+	assert.ErrorIs(t, vecErr, ErrUnknownVariant)
+	assert.NoError(t, trailingErr)
+	assert.Equal(t, NodePubKey{IsUnknown: true, AsUnknown: UnknownVariant{Index: 1, Data: []byte{0xaa, 0xbb}}}, trailing)
+	assert.ErrorIs(t, strictErr, ErrUnknownVariant)
+	assert.NoError(t, knownErr)
+	assert.Equal(t, key, known)
+}
@@ -0,0 +1,91 @@
+package pallets
+
+import (
+	"sync"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors"
+)
+
+// ApiOption configures optional behavior of a pallet API.
+type ApiOption func(o *apiOptions)
+
+type apiOptions struct {
+	strictness DecodeStrictness
+}
+
+// WithDecodeStrictness sets decoding of enum variants unknown to this SDK. It's DecodeStrict by
+// default.
+func WithDecodeStrictness(strictness DecodeStrictness) ApiOption {
+	return func(o *apiOptions) {
+		o.strictness = strictness
+	}
+}
+
+func newApiOptions(opts []ApiOption) apiOptions {
+	var o apiOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// getStorage reads a storage item at the given block or at the chain head if blockHash is nil.
+// Errors are ddcerrors.CodeRpc.
+func getStorage(substrateApi *gsrpc.SubstrateAPI, blockHash *types.Hash, key types.StorageKey, target interface{}) (bool, error) {
+	var ok bool
+	var err error
+	if blockHash == nil {
+		ok, err = substrateApi.RPC.State.GetStorageLatest(key, target)
+	} else {
+		ok, err = substrateApi.RPC.State.GetStorage(key, target, *blockHash)
+	}
+
+	return ok, ddcerrors.Wrap(ddcerrors.CodeRpc, err)
+}
+
+// getKeys reads storage keys with the given prefix at the given block or at the chain head if
+// blockHash is nil.
+func getKeys(substrateApi *gsrpc.SubstrateAPI, blockHash *types.Hash, prefix types.StorageKey) ([]types.StorageKey, error) {
+	var keys []types.StorageKey
+	var err error
+	if blockHash == nil {
+		keys, err = substrateApi.RPC.State.GetKeysLatest(prefix)
+	} else {
+		keys, err = substrateApi.RPC.State.GetKeys(prefix, *blockHash)
+	}
+
+	return keys, ddcerrors.Wrap(ddcerrors.CodeRpc, err)
+}
+
+// MetadataUpdater is implemented by pallet APIs which build storage keys from the chain metadata.
+// UpdateMetadata replaces the metadata after a runtime upgrade.
+type MetadataUpdater interface {
+	UpdateMetadata(meta *types.Metadata)
+}
+
+// metadata is the chain metadata which can be safely replaced while in use.
+type metadata struct {
+	mu   sync.RWMutex
+	meta *types.Metadata
+}
+
+func newMetadata(meta *types.Metadata) *metadata {
+	return &metadata{meta: meta}
+}
+
+func (m *metadata) Get() *types.Metadata {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.meta
+}
+
+func (m *metadata) Set(meta *types.Metadata) {
+	m.mu.Lock()
+	m.meta = meta
+	m.mu.Unlock()
+}
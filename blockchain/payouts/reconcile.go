@@ -0,0 +1,282 @@
+package payouts
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain/pallets"
+)
+
+var (
+	ErrChargingNotFinished = errors.New("charging of the era is not finished")
+)
+
+type DiscrepancyKind string
+
+const (
+	// DiscrepancyLedger is a charge which doesn't match the decrease of the customer ledger.
+	DiscrepancyLedger DiscrepancyKind = "ledger_mismatch"
+	// DiscrepancyUndercharged is a customer charged less than expected.
+	DiscrepancyUndercharged DiscrepancyKind = "undercharged"
+	// DiscrepancyReward is a provider rewarded other than expected.
+	DiscrepancyReward DiscrepancyKind = "reward_mismatch"
+	// DiscrepancyUnrewarded is a provider with node activity but no reward.
+	DiscrepancyUnrewarded DiscrepancyKind = "unrewarded_activity"
+	// DiscrepancyNoActivity is a provider rewarded without node activity.
+	DiscrepancyNoActivity DiscrepancyKind = "reward_without_activity"
+)
+
+// Discrepancy is an account which payout doesn't match the expected amount.
+type Discrepancy struct {
+	Kind     DiscrepancyKind
+	Account  types.AccountID
+	Expected *big.Int
+	Actual   *big.Int
+}
+
+// ReconciliationReport lists discrepancies of the payout of a cluster era.
+type ReconciliationReport struct {
+	ClusterId     pallets.ClusterId
+	Era           pallets.DdcEra
+	Charged       *big.Int
+	Rewarded      *big.Int
+	Discrepancies []Discrepancy
+}
+
+// ActivityReceipt is the usage of the nodes of a provider in the era, e.g. from the node activity
+// reported to validators.
+type ActivityReceipt struct {
+	NodeProvider types.AccountID
+	Usage        pallets.NodeUsage
+}
+
+// LedgerReader reads customer ledgers at a block.
+type LedgerReader interface {
+	GetLedger(blockHash types.Hash, owner types.AccountID) (types.Option[pallets.AccountsLedger], error)
+}
+
+type clientLedgers struct {
+	client *blockchain.Client
+}
+
+// ClientLedgers reads customer ledgers with Client.AtBlock views.
+func ClientLedgers(client *blockchain.Client) LedgerReader {
+	return clientLedgers{client: client}
+}
+
+func (l clientLedgers) GetLedger(blockHash types.Hash, owner types.AccountID) (types.Option[pallets.AccountsLedger], error) {
+	view, err := l.client.AtBlock(blockHash)
+	if err != nil {
+		return types.NewEmptyOption[pallets.AccountsLedger](), err
+	}
+
+	return view.DdcCustomers.GetLedger(owner)
+}
+
+type payout struct {
+	actual   *big.Int
+	expected *big.Int
+}
+
+// Reconciler collects charges and rewards of a cluster era from DdcPayouts events and cross-checks
+// them against customer ledgers and node activity. It's safe for concurrent use.
+type Reconciler struct {
+	clusterId pallets.ClusterId
+	era       pallets.DdcEra
+
+	mu       sync.Mutex
+	charges  map[types.AccountID]*payout
+	rewards  map[types.AccountID]*payout
+	started  *types.Hash
+	finished *types.Hash
+}
+
+func NewReconciler(clusterId pallets.ClusterId, era pallets.DdcEra) *Reconciler {
+	return &Reconciler{
+		clusterId: clusterId,
+		era:       era,
+		charges:   make(map[types.AccountID]*payout),
+		rewards:   make(map[types.AccountID]*payout),
+	}
+}
+
+// HandleEvents is a blockchain.EventsListener. Register it with Client.RegisterEventsListener
+// starting before the billing report of the era is initialized.
+func (r *Reconciler) HandleEvents(events []*parser.Event, eventCtx blockchain.EventContext) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, event := range events {
+		fields := fieldsByName(event.Fields)
+		clusterId, ok := clusterIdValue(fields["cluster_id"])
+		if !ok || clusterId != r.clusterId {
+			continue
+		}
+		if era, ok := fields["era"].(types.U32); !ok || era != r.era {
+			continue
+		}
+
+		blockHash := eventCtx.BlockHash
+		switch event.Name {
+		case "DdcPayouts.ChargingStarted":
+			r.started = &blockHash
+		case "DdcPayouts.ChargingFinished":
+			r.finished = &blockHash
+		case "DdcPayouts.Charged":
+			r.record(r.charges, fields["customer_id"], fields["amount"], fields["amount"])
+		case "DdcPayouts.ChargeFailed":
+			r.record(r.charges, fields["customer_id"], fields["charged"], fields["expected_to_charge"])
+		case "DdcPayouts.Rewarded":
+			r.record(r.rewards, fields["node_provider_id"], fields["rewarded"], fields["expected_to_reward"])
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) record(payouts map[types.AccountID]*payout, account, actual, expected any) {
+	accountId, ok := accountIdValue(account)
+	if !ok {
+		return
+	}
+
+	p, ok := payouts[accountId]
+	if !ok {
+		p = &payout{actual: new(big.Int), expected: new(big.Int)}
+		payouts[accountId] = p
+	}
+	add(p.actual, actual)
+	add(p.expected, expected)
+}
+
+// Report cross-checks the charges against the decrease of customer ledgers between the blocks of
+// the charging start and finish, and the rewards against the node activity. Ledgers aren't checked
+// if ledgers is nil. Deposits and withdrawals of customers during the charging are reported as
+// ledger mismatches too, so check them before treating a mismatch as an error.
+func (r *Reconciler) Report(ledgers LedgerReader, receipts []ActivityReceipt) (*ReconciliationReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := &ReconciliationReport{
+		ClusterId: r.clusterId,
+		Era:       r.era,
+		Charged:   new(big.Int),
+		Rewarded:  new(big.Int),
+	}
+	if ledgers != nil && (r.started == nil || r.finished == nil) {
+		return nil, ErrChargingNotFinished
+	}
+
+	for _, customer := range sortedAccounts(r.charges) {
+		charge := r.charges[customer]
+		report.Charged.Add(report.Charged, charge.actual)
+		if charge.actual.Cmp(charge.expected) < 0 {
+			report.add(DiscrepancyUndercharged, customer, charge.expected, charge.actual)
+		}
+		if ledgers == nil {
+			continue
+		}
+
+		decrease, err := r.ledgerDecrease(ledgers, customer)
+		if err != nil {
+			return nil, err
+		}
+		if decrease.Cmp(charge.actual) != 0 {
+			report.add(DiscrepancyLedger, customer, charge.actual, decrease)
+		}
+	}
+
+	active := make(map[types.AccountID]bool)
+	for _, receipt := range receipts {
+		usage := receipt.Usage
+		if usage.TransferredBytes > 0 || usage.StoredBytes > 0 || usage.NumberOfPuts > 0 || usage.NumberOfGets > 0 {
+			active[receipt.NodeProvider] = true
+		}
+	}
+	for _, provider := range sortedAccounts(r.rewards) {
+		reward := r.rewards[provider]
+		report.Rewarded.Add(report.Rewarded, reward.actual)
+		if reward.actual.Cmp(reward.expected) != 0 {
+			report.add(DiscrepancyReward, provider, reward.expected, reward.actual)
+		}
+		if !active[provider] && reward.actual.Sign() > 0 {
+			report.add(DiscrepancyNoActivity, provider, new(big.Int), reward.actual)
+		}
+	}
+	for _, provider := range sortedAccounts(active) {
+		if _, ok := r.rewards[provider]; !ok {
+			report.add(DiscrepancyUnrewarded, provider, nil, new(big.Int))
+		}
+	}
+
+	return report, nil
+}
+
+// ledgerDecrease returns the decrease of the active balance of the customer during the charging.
+func (r *Reconciler) ledgerDecrease(ledgers LedgerReader, customer types.AccountID) (*big.Int, error) {
+	var active [2]*big.Int
+	for i, blockHash := range []types.Hash{*r.started, *r.finished} {
+		ledger, err := ledgers.GetLedger(blockHash, customer)
+		if err != nil {
+			return nil, err
+		}
+
+		active[i] = new(big.Int)
+		if ok, l := ledger.Unwrap(); ok {
+			active[i].Set((*big.Int)(&l.Active))
+		}
+	}
+
+	return active[0].Sub(active[0], active[1]), nil
+}
+
+func (report *ReconciliationReport) add(kind DiscrepancyKind, account types.AccountID, expected, actual *big.Int) {
+	report.Discrepancies = append(report.Discrepancies, Discrepancy{
+		Kind:     kind,
+		Account:  account,
+		Expected: expected,
+		Actual:   actual,
+	})
+}
+
+// WriteCSV writes the discrepancies as CSV with the kind, the hex encoded account, the expected
+// and the actual amounts. The expected amount is empty if unknown.
+func (report *ReconciliationReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"kind", "account", "expected", "actual"}); err != nil {
+		return err
+	}
+	for _, d := range report.Discrepancies {
+		expected := ""
+		if d.Expected != nil {
+			expected = d.Expected.String()
+		}
+		if err := writer.Write([]string{string(d.Kind), codec.HexEncodeToString(d.Account[:]), expected, d.Actual.String()}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+func sortedAccounts[V any](accounts map[types.AccountID]V) []types.AccountID {
+	sorted := make([]types.AccountID, 0, len(accounts))
+	for account := range accounts {
+		sorted = append(sorted, account)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return string(sorted[i][:]) < string(sorted[j][:])
+	})
+
+	return sorted
+}
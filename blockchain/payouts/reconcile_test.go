@@ -0,0 +1,85 @@
+package payouts
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain/pallets"
+)
+
+type mockedLedgers map[types.Hash]map[types.AccountID]int64
+
+func (m mockedLedgers) GetLedger(blockHash types.Hash, owner types.AccountID) (types.Option[pallets.AccountsLedger], error) {
+	active, ok := m[blockHash][owner]
+	if !ok {
+		return types.NewEmptyOption[pallets.AccountsLedger](), nil
+	}
+	return types.NewOption(pallets.AccountsLedger{Owner: owner, Active: types.NewUCompact(big.NewInt(active))}), nil
+}
+
+func account(name string, id byte) *registry.DecodedField {
+	return &registry.DecodedField{Name: name, Value: types.AccountID{id}}
+}
+
+func TestReconciler(t *testing.T) {
+	//given
+	clusterId := pallets.ClusterId{1}
+	started, finished := types.Hash{1}, types.Hash{2}
+	reconciler := NewReconciler(clusterId, 7)
+	assert.NoError(t, reconciler.HandleEvents([]*parser.Event{
+		payoutEvent("ChargingStarted", clusterId, 7),
+	}, blockchain.EventContext{BlockHash: started}))
+	assert.NoError(t, reconciler.HandleEvents([]*parser.Event{
+		payoutEvent("Charged", clusterId, 7, account("customer_id", 1), amount("amount", 100)),
+		payoutEvent("ChargeFailed", clusterId, 7, account("customer_id", 2), amount("charged", 30), amount("expected_to_charge", 50)),
+		payoutEvent("Charged", clusterId, 6, account("customer_id", 3), amount("amount", 100)),
+		payoutEvent("ChargingFinished", clusterId, 7),
+		payoutEvent("Rewarded", clusterId, 7, account("node_provider_id", 10), amount("rewarded", 60), amount("expected_to_reward", 60)),
+		payoutEvent("Rewarded", clusterId, 7, account("node_provider_id", 11), amount("rewarded", 40), amount("expected_to_reward", 45)),
+	}, blockchain.EventContext{BlockHash: finished}))
+	ledgers := mockedLedgers{
+		started:  {{1}: 1000, {2}: 30},
+		finished: {{1}: 890, {2}: 0},
+	}
+	receipts := []ActivityReceipt{
+		{NodeProvider: types.AccountID{10}, Usage: pallets.NodeUsage{NumberOfGets: 5}},
+		{NodeProvider: types.AccountID{12}, Usage: pallets.NodeUsage{StoredBytes: 100}},
+	}
+
+	//when
+	report, err := reconciler.Report(ledgers, receipts)
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, "130", report.Charged.String())
+	assert.Equal(t, "100", report.Rewarded.String())
+	assert.Equal(t, []Discrepancy{
+		{Kind: DiscrepancyLedger, Account: types.AccountID{1}, Expected: big.NewInt(100), Actual: big.NewInt(110)},
+		{Kind: DiscrepancyUndercharged, Account: types.AccountID{2}, Expected: big.NewInt(50), Actual: big.NewInt(30)},
+		{Kind: DiscrepancyReward, Account: types.AccountID{11}, Expected: big.NewInt(45), Actual: big.NewInt(40)},
+		{Kind: DiscrepancyNoActivity, Account: types.AccountID{11}, Expected: new(big.Int), Actual: big.NewInt(40)},
+		{Kind: DiscrepancyUnrewarded, Account: types.AccountID{12}, Actual: new(big.Int)},
+	}, report.Discrepancies)
+
+	var csv bytes.Buffer
+	assert.NoError(t, report.WriteCSV(&csv))
+	assert.Contains(t, csv.String(), "undercharged,0x0200000000000000000000000000000000000000000000000000000000000000,50,30\n")
+}
+
+func TestReconcilerChargingNotFinished(t *testing.T) {
+	//given
+	reconciler := NewReconciler(pallets.ClusterId{1}, 7)
+
+	//when
+	_, err := reconciler.Report(mockedLedgers{}, nil)
+
+	//then
+	assert.ErrorIs(t, err, ErrChargingNotFinished)
+}
@@ -0,0 +1,282 @@
+// Package payouts follows the payout cycle of a DDC cluster era by DdcPayouts pallet events: the
+// billing report is initialized, customers are charged, node providers are rewarded and the report
+// is finalized.
+package payouts
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain/pallets"
+)
+
+type Phase uint8
+
+const (
+	PhaseNotStarted Phase = iota
+	PhaseInitialized
+	PhaseCharging
+	PhaseCharged
+	PhaseRewarding
+	PhaseRewarded
+	PhaseFinalized
+)
+
+var phaseNames = []string{"not_started", "initialized", "charging", "charged", "rewarding", "rewarded", "finalized"}
+
+func (p Phase) String() string {
+	if int(p) < len(phaseNames) {
+		return phaseNames[p]
+	}
+
+	return "unknown"
+}
+
+// Summary is the state of the payout cycle of a cluster era. Amounts are totals of events seen so
+// far, the summary passed to the completion callback reconciles the whole cycle.
+type Summary struct {
+	ClusterId pallets.ClusterId
+	Era       pallets.DdcEra
+	Phase     Phase
+
+	// Charged is the amount charged from customers.
+	Charged *big.Int
+	// ExpectedToCharge is the amount customers were expected to pay, including failed charges.
+	ExpectedToCharge *big.Int
+	// Indebted is the amount customers couldn't pay and owe the cluster.
+	Indebted *big.Int
+	// Fees are the treasury, cluster reserve and validator fees collected from the charged amount.
+	Fees *big.Int
+	// Rewarded is the amount paid to node providers.
+	Rewarded *big.Int
+	// ExpectedToReward is the amount node providers were expected to receive.
+	ExpectedToReward *big.Int
+
+	// Charges is the number of customers charged, FailedCharges of them paid less than expected.
+	Charges       int
+	FailedCharges int
+	Rewards       int
+
+	StartedAt   types.BlockNumber
+	FinalizedAt types.BlockNumber
+}
+
+// Undistributed is the charged amount which was neither collected as fees nor rewarded.
+func (s Summary) Undistributed() *big.Int {
+	undistributed := new(big.Int).Sub(s.Charged, s.Fees)
+	return undistributed.Sub(undistributed, s.Rewarded)
+}
+
+// PayoutTracker follows payout cycles of a cluster. It's safe for concurrent use.
+type PayoutTracker struct {
+	clusterId  pallets.ClusterId
+	onComplete func(summary Summary)
+
+	mu      sync.Mutex
+	eras    map[pallets.DdcEra]*Summary
+	current *Summary
+}
+
+// NewPayoutTracker creates the tracker of the cluster calling onComplete with the summary of each
+// era which billing report is finalized. onComplete is optional.
+func NewPayoutTracker(clusterId pallets.ClusterId, onComplete func(summary Summary)) *PayoutTracker {
+	return &PayoutTracker{
+		clusterId:  clusterId,
+		onComplete: onComplete,
+		eras:       make(map[pallets.DdcEra]*Summary),
+	}
+}
+
+// HandleEvents is a blockchain.EventsListener. Register it with Client.RegisterEventsListener.
+func (t *PayoutTracker) HandleEvents(events []*parser.Event, eventCtx blockchain.EventContext) error {
+	var completed []Summary
+
+	t.mu.Lock()
+	for _, event := range events {
+		if summary, ok := t.apply(event, eventCtx.BlockNumber); ok {
+			completed = append(completed, summary)
+		}
+	}
+	t.mu.Unlock()
+
+	if t.onComplete != nil {
+		for _, summary := range completed {
+			t.onComplete(summary)
+		}
+	}
+
+	return nil
+}
+
+// Current returns the summary of the latest era seen.
+func (t *PayoutTracker) Current() (Summary, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.current == nil {
+		return Summary{}, false
+	}
+
+	return t.current.copy(), true
+}
+
+// Era returns the summary of the era.
+func (t *PayoutTracker) Era(era pallets.DdcEra) (Summary, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	summary, ok := t.eras[era]
+	if !ok {
+		return Summary{}, false
+	}
+
+	return summary.copy(), true
+}
+
+// apply updates the era summary and returns it if the event completed the cycle.
+func (t *PayoutTracker) apply(event *parser.Event, blockNumber types.BlockNumber) (Summary, bool) {
+	fields := fieldsByName(event.Fields)
+	clusterId, ok := clusterIdValue(fields["cluster_id"])
+	if !ok || clusterId != t.clusterId {
+		return Summary{}, false
+	}
+	era, ok := fields["era"].(types.U32)
+	if !ok {
+		return Summary{}, false
+	}
+
+	summary := t.era(era)
+
+	switch event.Name {
+	case "DdcPayouts.BillingReportInitialized":
+		summary.Phase = PhaseInitialized
+		summary.StartedAt = blockNumber
+	case "DdcPayouts.ChargingStarted":
+		summary.Phase = PhaseCharging
+	case "DdcPayouts.Charged":
+		summary.Charges++
+		add(summary.Charged, fields["amount"])
+		add(summary.ExpectedToCharge, fields["amount"])
+	case "DdcPayouts.ChargeFailed":
+		summary.Charges++
+		summary.FailedCharges++
+		add(summary.Charged, fields["charged"])
+		add(summary.ExpectedToCharge, fields["expected_to_charge"])
+	case "DdcPayouts.Indebted":
+		add(summary.Indebted, fields["amount"])
+	case "DdcPayouts.ChargingFinished":
+		summary.Phase = PhaseCharged
+	case "DdcPayouts.TreasuryFeesCollected", "DdcPayouts.ClusterReserveFeesCollected", "DdcPayouts.ValidatorFeesCollected":
+		add(summary.Fees, fields["amount"])
+	case "DdcPayouts.RewardingStarted":
+		summary.Phase = PhaseRewarding
+	case "DdcPayouts.Rewarded":
+		summary.Rewards++
+		add(summary.Rewarded, fields["rewarded"])
+		add(summary.ExpectedToReward, fields["expected_to_reward"])
+	case "DdcPayouts.RewardingFinished":
+		summary.Phase = PhaseRewarded
+	case "DdcPayouts.BillingReportFinalized":
+		summary.Phase = PhaseFinalized
+		summary.FinalizedAt = blockNumber
+		return summary.copy(), true
+	}
+
+	return Summary{}, false
+}
+
+func (t *PayoutTracker) era(era pallets.DdcEra) *Summary {
+	summary, ok := t.eras[era]
+	if !ok {
+		summary = &Summary{
+			ClusterId:        t.clusterId,
+			Era:              era,
+			Charged:          new(big.Int),
+			ExpectedToCharge: new(big.Int),
+			Indebted:         new(big.Int),
+			Fees:             new(big.Int),
+			Rewarded:         new(big.Int),
+			ExpectedToReward: new(big.Int),
+		}
+		t.eras[era] = summary
+	}
+	if t.current == nil || era >= t.current.Era {
+		t.current = summary
+	}
+
+	return summary
+}
+
+func (s *Summary) copy() Summary {
+	c := *s
+	for _, amount := range []**big.Int{&c.Charged, &c.ExpectedToCharge, &c.Indebted, &c.Fees, &c.Rewarded, &c.ExpectedToReward} {
+		*amount = new(big.Int).Set(*amount)
+	}
+
+	return c
+}
+
+func fieldsByName(fields registry.DecodedFields) map[string]any {
+	byName := make(map[string]any, len(fields))
+	for _, field := range fields {
+		byName[field.Name] = field.Value
+	}
+
+	return byName
+}
+
+// clusterIdValue reads the cluster ID which is decoded either as H160 or as a list of bytes.
+func clusterIdValue(value any) (pallets.ClusterId, bool) {
+	var clusterId pallets.ClusterId
+	if v, ok := value.(types.H160); ok {
+		return v, true
+	}
+
+	return clusterId, bytesValue(value, clusterId[:])
+}
+
+// accountIdValue reads the account ID which is decoded either as AccountID or as a list of bytes.
+func accountIdValue(value any) (types.AccountID, bool) {
+	var accountId types.AccountID
+	if v, ok := value.(types.AccountID); ok {
+		return v, true
+	}
+
+	return accountId, bytesValue(value, accountId[:])
+}
+
+// bytesValue reads a list of bytes of the length of dst, possibly wrapped in a single field
+// composite, to dst.
+func bytesValue(value any, dst []byte) bool {
+	switch v := value.(type) {
+	case registry.DecodedFields:
+		if len(v) == 1 {
+			return bytesValue(v[0].Value, dst)
+		}
+	case []any:
+		if len(v) != len(dst) {
+			return false
+		}
+		for i, item := range v {
+			b, ok := item.(types.U8)
+			if !ok {
+				return false
+			}
+			dst[i] = byte(b)
+		}
+		return true
+	}
+
+	return false
+}
+
+func add(total *big.Int, value any) {
+	if amount, ok := value.(types.U128); ok && amount.Int != nil {
+		total.Add(total, amount.Int)
+	}
+}
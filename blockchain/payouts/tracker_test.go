@@ -0,0 +1,75 @@
+package payouts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain/pallets"
+)
+
+func payoutEvent(name string, clusterId pallets.ClusterId, era uint32, fields ...*registry.DecodedField) *parser.Event {
+	return &parser.Event{
+		Name: "DdcPayouts." + name,
+		Fields: append(registry.DecodedFields{
+			{Name: "cluster_id", Value: clusterId},
+			{Name: "era", Value: types.U32(era)},
+		}, fields...),
+	}
+}
+
+func amount(name string, value int64) *registry.DecodedField {
+	return &registry.DecodedField{Name: name, Value: types.NewU128(*big.NewInt(value))}
+}
+
+func TestPayoutTracker(t *testing.T) {
+	//given
+	clusterId := pallets.ClusterId{1}
+	otherClusterId := pallets.ClusterId{2}
+	var completed []Summary
+	tracker := NewPayoutTracker(clusterId, func(summary Summary) {
+		completed = append(completed, summary)
+	})
+
+	//when
+	assert.NoError(t, tracker.HandleEvents([]*parser.Event{
+		payoutEvent("BillingReportInitialized", clusterId, 7),
+		payoutEvent("ChargingStarted", clusterId, 7),
+		payoutEvent("Charged", clusterId, 7, amount("amount", 100)),
+		payoutEvent("ChargeFailed", clusterId, 7, amount("charged", 30), amount("expected_to_charge", 50)),
+		payoutEvent("Indebted", clusterId, 7, amount("amount", 20)),
+		payoutEvent("Charged", otherClusterId, 7, amount("amount", 1000)),
+	}, blockchain.EventContext{BlockNumber: 10}))
+	charging, _ := tracker.Current()
+	assert.NoError(t, tracker.HandleEvents([]*parser.Event{
+		payoutEvent("ChargingFinished", clusterId, 7),
+		payoutEvent("TreasuryFeesCollected", clusterId, 7, amount("amount", 13)),
+		payoutEvent("RewardingStarted", clusterId, 7),
+		payoutEvent("Rewarded", clusterId, 7, amount("rewarded", 110), amount("expected_to_reward", 117)),
+		payoutEvent("RewardingFinished", clusterId, 7),
+		payoutEvent("BillingReportFinalized", clusterId, 7),
+	}, blockchain.EventContext{BlockNumber: 11}))
+
+	//then
+	assert.Equal(t, PhaseCharging, charging.Phase)
+	assert.Equal(t, "130", charging.Charged.String())
+	assert.Len(t, completed, 1)
+	summary := completed[0]
+	assert.Equal(t, PhaseFinalized, summary.Phase)
+	assert.Equal(t, pallets.DdcEra(7), summary.Era)
+	assert.Equal(t, "130", summary.Charged.String())
+	assert.Equal(t, "150", summary.ExpectedToCharge.String())
+	assert.Equal(t, "20", summary.Indebted.String())
+	assert.Equal(t, "110", summary.Rewarded.String())
+	assert.Equal(t, "7", summary.Undistributed().String())
+	assert.Equal(t, 2, summary.Charges)
+	assert.Equal(t, 1, summary.FailedCharges)
+	assert.Equal(t, 1, summary.Rewards)
+	assert.Equal(t, types.BlockNumber(10), summary.StartedAt)
+	assert.Equal(t, types.BlockNumber(11), summary.FinalizedAt)
+}
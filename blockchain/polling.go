@@ -0,0 +1,127 @@
+package blockchain
+
+import (
+	"errors"
+	"net/url"
+	"sync"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// DefaultPollInterval is the interval of polling the best head when the transport doesn't support
+// subscriptions.
+const DefaultPollInterval = 2 * time.Second
+
+type (
+	// headsSubscription is the source of live headers in ListenEvents, either the new heads
+	// subscription or headsPoller.
+	headsSubscription interface {
+		Chan() <-chan types.Header
+		Unsubscribe()
+	}
+
+	headerGetter interface {
+		GetHeaderLatest() (*types.Header, error)
+	}
+
+	// headsPoller polls the best head and sends its header when the head advances. Skipped blocks
+	// are filled in by sequenceHeaders.
+	headsPoller struct {
+		chain    headerGetter
+		interval time.Duration
+		headers  chan types.Header
+		stop     chan struct{}
+		once     sync.Once
+	}
+)
+
+// WithPolling makes ListenEvents poll the best head with the interval instead of subscribing to new
+// heads, DefaultPollInterval if 0. Polling is selected automatically for HTTP endpoints and
+// transports rejecting subscriptions, use the option to force it.
+func WithPolling(interval time.Duration) ClientOption {
+	return func(c *Client) {
+		c.polling = true
+		c.pollInterval = interval
+	}
+}
+
+// subscribeNewHeads subscribes to new heads if the transport supports subscriptions and falls back
+// to polling otherwise.
+func (c *Client) subscribeNewHeads() (headsSubscription, error) {
+	if !c.polling && supportsSubscriptions(c.Client.URL()) {
+		sub, err := c.RPC.Chain.SubscribeNewHeads()
+		if err == nil {
+			return sub, nil
+		}
+		if !errors.Is(err, gethrpc.ErrNotificationsUnsupported) {
+			return nil, err
+		}
+	}
+
+	return newHeadsPoller(c.RPC.Chain, c.pollInterval), nil
+}
+
+// supportsSubscriptions reports whether the endpoint can serve subscriptions. Transports without a
+// URL, e.g. an embedded light client, are expected to support them.
+func supportsSubscriptions(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return true
+	}
+
+	return u.Scheme != "http" && u.Scheme != "https"
+}
+
+func newHeadsPoller(chain headerGetter, interval time.Duration) *headsPoller {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	p := &headsPoller{
+		chain:    chain,
+		interval: interval,
+		headers:  make(chan types.Header),
+		stop:     make(chan struct{}),
+	}
+	go p.poll()
+
+	return p
+}
+
+func (p *headsPoller) Chan() <-chan types.Header {
+	return p.headers
+}
+
+func (p *headsPoller) Unsubscribe() {
+	p.once.Do(func() {
+		close(p.stop)
+	})
+}
+
+func (p *headsPoller) poll() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	var last types.BlockNumber
+	for {
+		// Failed polls are retried on the next tick. Persistent failures stop the blocks and trip
+		// the listening timeout of ListenEvents like a dead subscription does.
+		header, err := p.chain.GetHeaderLatest()
+		if err == nil && header.Number > last {
+			select {
+			case <-p.stop:
+				return
+			case p.headers <- *header:
+				last = header.Number
+			}
+		}
+
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
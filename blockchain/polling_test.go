@@ -0,0 +1,57 @@
+package blockchain
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockedHeaderGetter struct {
+	mu      sync.Mutex
+	numbers []types.BlockNumber
+}
+
+func (m *mockedHeaderGetter) GetHeaderLatest() (*types.Header, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.numbers) == 0 {
+		return nil, errors.New("no head")
+	}
+	number := m.numbers[0]
+	if len(m.numbers) > 1 {
+		m.numbers = m.numbers[1:]
+	}
+	return &types.Header{Number: number}, nil
+}
+
+func TestHeadsPoller(t *testing.T) {
+	//given
+	chain := &mockedHeaderGetter{numbers: []types.BlockNumber{5, 5, 7, 6, 8}}
+
+	//when
+	poller := newHeadsPoller(chain, time.Millisecond)
+	defer poller.Unsubscribe()
+
+	//then
+	for _, want := range []types.BlockNumber{5, 7, 8} {
+		select {
+		case header := <-poller.Chan():
+			assert.Equal(t, want, header.Number)
+		case <-time.After(time.Second):
+			t.Fatalf("no header %d", want)
+		}
+	}
+}
+
+func TestSupportsSubscriptions(t *testing.T) {
+	assert.True(t, supportsSubscriptions("wss://rpc.testnet.cere.network/ws"))
+	assert.True(t, supportsSubscriptions("ws://localhost:9944"))
+	assert.True(t, supportsSubscriptions(""))
+	assert.False(t, supportsSubscriptions("https://rpc.testnet.cere.network"))
+	assert.False(t, supportsSubscriptions("http://localhost:9933"))
+}
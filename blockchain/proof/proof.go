@@ -0,0 +1,252 @@
+// Package proof verifies Substrate storage read proofs, as returned by the state_getReadProof and
+// state_getChildReadProof RPC methods, against a trie root from a trusted block header.
+//
+// A read proof is the set of encoded trie nodes on the path from the root to the proven key. The
+// nodes are looked up by their blake2b-256 hashes, so an RPC endpoint can't forge a value without
+// breaking the hash chain to the root. Both state versions are supported: values inlined in nodes
+// (V0) and values stored as separate proof entries referenced by hash (V1).
+package proof
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	ErrIncompleteProof = errors.New("proof is missing a trie node")
+	ErrInvalidNode     = errors.New("invalid trie node")
+)
+
+const (
+	emptyTrie = 0x00
+
+	leafPrefix            = 0b01 << 6
+	branchWithValue       = 0b11 << 6
+	branchWithoutValue    = 0b10 << 6
+	hashedValueLeafPrefix = 0b001 << 5
+	hashedValueBranch     = 0b0001 << 4
+
+	hashLength = 32
+)
+
+// Verify verifies the proof of the key against the trie root and returns the proven value. It
+// returns false if the proof proves that the key is absent.
+func Verify(root types.Hash, proof [][]byte, key []byte) ([]byte, bool, error) {
+	db := make(map[types.Hash][]byte, len(proof))
+	for _, node := range proof {
+		db[types.Hash(blake2b.Sum256(node))] = node
+	}
+
+	return lookup(db, root, toNibbles(key))
+}
+
+func lookup(db map[types.Hash][]byte, root types.Hash, key []byte) ([]byte, bool, error) {
+	encoded, ok := db[root]
+	if !ok {
+		return nil, false, fmt.Errorf("%w: %s", ErrIncompleteProof, root.Hex())
+	}
+
+	for {
+		n, err := decodeNode(encoded)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if n.empty || !bytes.HasPrefix(key, n.partial) {
+			return nil, false, nil
+		}
+		key = key[len(n.partial):]
+
+		if n.leaf || len(key) == 0 {
+			if n.leaf && len(key) > 0 || !n.hasValue {
+				return nil, false, nil
+			}
+
+			return resolveValue(db, n)
+		}
+
+		child := n.children[key[0]]
+		key = key[1:]
+
+		switch {
+		case child == nil:
+			return nil, false, nil
+		case len(child) == hashLength:
+			if encoded, ok = db[types.NewHash(child)]; !ok {
+				return nil, false, fmt.Errorf("%w: %x", ErrIncompleteProof, child)
+			}
+		default:
+			// Nodes shorter than a hash are inlined into their parent.
+			encoded = child
+		}
+	}
+}
+
+func resolveValue(db map[types.Hash][]byte, n node) ([]byte, bool, error) {
+	if !n.hashedValue {
+		return n.value, true, nil
+	}
+
+	value, ok := db[types.NewHash(n.value)]
+	if !ok {
+		return nil, false, fmt.Errorf("%w: value %x", ErrIncompleteProof, n.value)
+	}
+
+	return value, true, nil
+}
+
+type node struct {
+	empty       bool
+	leaf        bool
+	partial     []byte
+	hasValue    bool
+	hashedValue bool
+	value       []byte
+	children    [16][]byte
+}
+
+func decodeNode(encoded []byte) (node, error) {
+	if len(encoded) == 0 {
+		return node{}, ErrInvalidNode
+	}
+
+	r := bytes.NewReader(encoded)
+	first, _ := r.ReadByte()
+
+	var n node
+	var nibbles int
+	var err error
+
+	switch {
+	case first == emptyTrie:
+		n.empty = true
+		return n, nil
+	case first&(0b11<<6) == leafPrefix:
+		n.leaf, n.hasValue = true, true
+		nibbles, err = decodeSize(first, r, 2)
+	case first&(0b11<<6) == branchWithValue:
+		n.hasValue = true
+		nibbles, err = decodeSize(first, r, 2)
+	case first&(0b11<<6) == branchWithoutValue:
+		nibbles, err = decodeSize(first, r, 2)
+	case first&(0b111<<5) == hashedValueLeafPrefix:
+		n.leaf, n.hasValue, n.hashedValue = true, true, true
+		nibbles, err = decodeSize(first, r, 3)
+	case first&(0b1111<<4) == hashedValueBranch:
+		n.hasValue, n.hashedValue = true, true
+		nibbles, err = decodeSize(first, r, 4)
+	default:
+		return node{}, fmt.Errorf("%w: header %#x", ErrInvalidNode, first)
+	}
+	if err != nil {
+		return node{}, err
+	}
+
+	if n.partial, err = decodePartial(r, nibbles); err != nil {
+		return node{}, err
+	}
+
+	var bitmap uint16
+	if !n.leaf {
+		var b [2]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return node{}, fmt.Errorf("%w: %v", ErrInvalidNode, err)
+		}
+		bitmap = uint16(b[0]) | uint16(b[1])<<8
+	}
+
+	if n.hasValue {
+		if n.value, err = decodeValue(r, n.hashedValue); err != nil {
+			return node{}, err
+		}
+	}
+
+	for i := 0; i < 16; i++ {
+		if bitmap&(1<<i) == 0 {
+			continue
+		}
+		if n.children[i], err = decodeBytes(r); err != nil {
+			return node{}, err
+		}
+	}
+
+	return n, nil
+}
+
+// decodeSize decodes the number of partial key nibbles from the header byte and the following
+// bytes. The prefix bits of the header byte are the node type.
+func decodeSize(first byte, r *bytes.Reader, prefixBits int) (int, error) {
+	max := int(byte(255) >> prefixBits)
+	size := int(first) & max
+	if size < max {
+		return size, nil
+	}
+
+	size--
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrInvalidNode, err)
+		}
+		if b < 255 {
+			return size + int(b) + 1, nil
+		}
+		size += 255
+	}
+}
+
+// decodePartial decodes a partial key into nibbles. The odd nibble is stored in the low half of
+// the first byte.
+func decodePartial(r *bytes.Reader, nibbles int) ([]byte, error) {
+	encoded := make([]byte, (nibbles+1)/2)
+	if _, err := io.ReadFull(r, encoded); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidNode, err)
+	}
+
+	partial := toNibbles(encoded)
+	if nibbles%2 == 1 {
+		if partial[0] != 0 {
+			return nil, fmt.Errorf("%w: bad partial key padding", ErrInvalidNode)
+		}
+		partial = partial[1:]
+	}
+
+	return partial, nil
+}
+
+func decodeValue(r *bytes.Reader, hashed bool) ([]byte, error) {
+	if !hashed {
+		return decodeBytes(r)
+	}
+
+	value := make([]byte, hashLength)
+	if _, err := io.ReadFull(r, value); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidNode, err)
+	}
+
+	return value, nil
+}
+
+func decodeBytes(r *bytes.Reader) ([]byte, error) {
+	var value []byte
+	if err := scale.NewDecoder(r).Decode(&value); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidNode, err)
+	}
+
+	return value, nil
+}
+
+func toNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, len(key)*2)
+	for _, b := range key {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+
+	return nibbles
+}
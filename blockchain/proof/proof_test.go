@@ -0,0 +1,87 @@
+package proof
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+// compactBytes SCALE-encodes short byte strings with a single byte compact length prefix.
+func compactBytes(b []byte) []byte {
+	return append([]byte{byte(len(b) << 2)}, b...)
+}
+
+func TestVerify(t *testing.T) {
+	longValue := bytes.Repeat([]byte{7}, 40)
+	hashedValue := bytes.Repeat([]byte{9}, 50)
+	hashedValueHash := blake2b.Sum256(hashedValue)
+
+	// Leaves with empty partial keys under the root branch with partial key nibble 1.
+	inlineLeaf := append([]byte{leafPrefix}, compactBytes([]byte("a"))...)
+	hashedLeaf := append([]byte{leafPrefix}, compactBytes(longValue)...)
+	hashedLeafHash := blake2b.Sum256(hashedLeaf)
+	hashedValueLeaf := append([]byte{hashedValueLeafPrefix}, hashedValueHash[:]...)
+	hashedValueLeafHash := blake2b.Sum256(hashedValueLeaf)
+
+	root := []byte{branchWithoutValue | 1, 0x01, 0b0001_1100, 0x00}
+	root = append(root, compactBytes(inlineLeaf)...)
+	root = append(root, compactBytes(hashedLeafHash[:])...)
+	root = append(root, compactBytes(hashedValueLeafHash[:])...)
+	rootHash := types.Hash(blake2b.Sum256(root))
+
+	proof := [][]byte{root, hashedLeaf, hashedValueLeaf, hashedValue}
+
+	tests := []struct {
+		name      string
+		proof     [][]byte
+		key       []byte
+		wantValue []byte
+		wantFound bool
+		wantErr   error
+	}{
+		{name: "inline leaf", proof: proof, key: []byte{0x12}, wantValue: []byte("a"), wantFound: true},
+		{name: "hashed leaf", proof: proof, key: []byte{0x13}, wantValue: longValue, wantFound: true},
+		{name: "hashed value", proof: proof, key: []byte{0x14}, wantValue: hashedValue, wantFound: true},
+		{name: "absent child", proof: proof, key: []byte{0x15}},
+		{name: "absent partial key", proof: proof, key: []byte{0x22}},
+		{name: "absent longer key", proof: proof, key: []byte{0x12, 0x00}},
+		{name: "missing node", proof: [][]byte{root}, key: []byte{0x13}, wantErr: ErrIncompleteProof},
+		{name: "missing root", proof: [][]byte{hashedLeaf}, key: []byte{0x13}, wantErr: ErrIncompleteProof},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, found, err := Verify(rootHash, tt.proof, tt.key)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.wantValue, value)
+		})
+	}
+}
+
+func TestDecodeSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded []byte
+		want    int
+	}{
+		{name: "in header", encoded: []byte{leafPrefix | 5}, want: 5},
+		{name: "one extra byte", encoded: []byte{leafPrefix | 63, 10}, want: 73},
+		{name: "two extra bytes", encoded: []byte{leafPrefix | 63, 255, 1}, want: 319},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := bytes.NewReader(tt.encoded[1:])
+
+			size, err := decodeSize(tt.encoded[0], r, 2)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, size)
+		})
+	}
+}
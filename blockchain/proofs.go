@@ -0,0 +1,184 @@
+package blockchain
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/xxhash"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain/proof"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors"
+)
+
+const (
+	defaultChildStoragePrefix = ":child_storage:default:"
+)
+
+var (
+	ErrContractNotFound = ddcerrors.New(ddcerrors.CodeNotFound, "contract not found")
+	ErrEventNotIncluded = ddcerrors.New(ddcerrors.CodeNotFound, "event not included in block")
+)
+
+type readProof struct {
+	At    string   `json:"at"`
+	Proof []string `json:"proof"`
+}
+
+// GetStorageVerified reads the storage item at the block like State.GetStorage but doesn't trust
+// the RPC endpoint with the value: the value is verified with a read proof against the state root
+// of the block header. The block hash must come from a trusted source, e.g. a finalized block
+// hash confirmed by a light client or by several endpoints.
+func (c *Client) GetStorageVerified(key types.StorageKey, target interface{}, blockHash types.Hash) (bool, error) {
+	header, err := c.RPC.Chain.GetHeader(blockHash)
+	if err != nil {
+		return false, err
+	}
+
+	value, ok, err := c.getVerified(header.StateRoot, blockHash, key)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	return true, codec.Decode(value, target)
+}
+
+// GetContractStorageVerified reads a raw value from the contract child trie at the block and
+// verifies it with read proofs of the contract info, the child trie root and the value. The key
+// is the key in the child trie as stored by pallet-contracts.
+//
+// Only contract storage can be proven. Results of contract read calls are computed by the RPC
+// endpoint executing the contract and can't be verified this way.
+func (c *Client) GetContractStorageVerified(contract types.AccountID, key []byte, blockHash types.Hash) ([]byte, bool, error) {
+	header, err := c.RPC.Chain.GetHeader(blockHash)
+	if err != nil {
+		return nil, false, err
+	}
+
+	contractInfoKey := types.NewStorageKey(append(append(append(
+		xxhash.New128([]byte("Contracts")).Sum(nil),
+		xxhash.New128([]byte("ContractInfoOf")).Sum(nil)...),
+		xxhash.New64(contract[:]).Sum(nil)...),
+		contract[:]...,
+	))
+	contractInfo, ok, err := c.getVerified(header.StateRoot, blockHash, contractInfoKey)
+	if err != nil {
+		return nil, false, fmt.Errorf("contract info: %w", err)
+	}
+	if !ok {
+		return nil, false, ErrContractNotFound
+	}
+
+	// Trie id is the first field of the contract info in all pallet-contracts versions.
+	var trieId []byte
+	if err := scale.NewDecoder(bytes.NewReader(contractInfo)).Decode(&trieId); err != nil {
+		return nil, false, fmt.Errorf("decode contract info: %w", err)
+	}
+
+	childStorageKey := append([]byte(defaultChildStoragePrefix), trieId...)
+	childRoot, ok, err := c.getVerified(header.StateRoot, blockHash, childStorageKey)
+	if err != nil {
+		return nil, false, fmt.Errorf("child trie root: %w", err)
+	}
+	if !ok {
+		// The child trie is removed when empty.
+		return nil, false, nil
+	}
+	if len(childRoot) != len(types.Hash{}) {
+		return nil, false, fmt.Errorf("invalid child trie root %x", childRoot)
+	}
+
+	var res readProof
+	err = c.Client.Call(&res, "state_getChildReadProof", codec.HexEncodeToString(childStorageKey),
+		[]string{codec.HexEncodeToString(key)}, blockHash.Hex())
+	if err != nil {
+		return nil, false, err
+	}
+
+	return verifyReadProof(types.NewHash(childRoot), res, key)
+}
+
+// VerifyEventInclusion checks that the event delivered for the block is in the events storage of
+// the block rather than trusting the RPC endpoint delivering it: System.Events is read with a read
+// proof verified against the state root of the block header and the event must be among the
+// decoded proven events. It returns ErrEventNotIncluded if it isn't. As with GetStorageVerified,
+// the block hash must come from a trusted source.
+func (c *Client) VerifyEventInclusion(event *parser.Event, eventCtx EventContext) error {
+	header, err := c.RPC.Chain.GetHeader(eventCtx.BlockHash)
+	if err != nil {
+		return err
+	}
+	if header.Number != eventCtx.BlockNumber {
+		return fmt.Errorf("block %s has number %d, expected %d", eventCtx.BlockHash.Hex(), header.Number, eventCtx.BlockNumber)
+	}
+
+	meta, err := c.RPC.State.GetMetadata(eventCtx.BlockHash)
+	if err != nil {
+		return err
+	}
+	key, err := types.CreateStorageKey(meta, "System", "Events")
+	if err != nil {
+		return err
+	}
+	value, ok, err := c.getVerified(header.StateRoot, eventCtx.BlockHash, key)
+	if err != nil {
+		return fmt.Errorf("system events: %w", err)
+	}
+	if !ok {
+		return ErrEventNotIncluded
+	}
+
+	eventRegistry, err := registry.NewFactory().CreateEventRegistry(meta)
+	if err != nil {
+		return err
+	}
+	raw := types.StorageDataRaw(value)
+	events, err := parser.NewEventParser().ParseEvents(eventRegistry, &raw)
+	if err != nil {
+		return fmt.Errorf("decode system events: %w", err)
+	}
+	if !containsEvent(events, event) {
+		return ErrEventNotIncluded
+	}
+
+	return nil
+}
+
+func containsEvent(events []*parser.Event, event *parser.Event) bool {
+	for _, e := range events {
+		if reflect.DeepEqual(e, event) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getVerified reads the value of the key in the main trie and verifies its read proof against the
+// state root.
+func (c *Client) getVerified(stateRoot types.Hash, blockHash types.Hash, key []byte) ([]byte, bool, error) {
+	var res readProof
+	err := c.Client.Call(&res, "state_getReadProof", []string{codec.HexEncodeToString(key)}, blockHash.Hex())
+	if err != nil {
+		return nil, false, err
+	}
+
+	return verifyReadProof(stateRoot, res, key)
+}
+
+func verifyReadProof(root types.Hash, res readProof, key []byte) ([]byte, bool, error) {
+	nodes := make([][]byte, len(res.Proof))
+	for i, node := range res.Proof {
+		var err error
+		if nodes[i], err = codec.HexDecodeString(node); err != nil {
+			return nil, false, err
+		}
+	}
+
+	return proof.Verify(root, nodes, key)
+}
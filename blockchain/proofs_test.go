@@ -0,0 +1,27 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainsEvent(t *testing.T) {
+	newEvent := func(extrinsic uint32, amount uint64) *parser.Event {
+		return &parser.Event{
+			Name:    "Balances.Transfer",
+			EventID: types.EventID{5, 2},
+			Phase:   &types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: extrinsic},
+			Fields:  registry.DecodedFields{{Name: "amount", Value: types.NewU64(amount)}},
+		}
+	}
+	events := []*parser.Event{newEvent(1, 10), newEvent(2, 20)}
+
+	assert.True(t, containsEvent(events, newEvent(2, 20)))
+	assert.False(t, containsEvent(events, newEvent(2, 10)))
+	assert.False(t, containsEvent(events, newEvent(3, 20)))
+	assert.False(t, containsEvent(nil, newEvent(1, 10)))
+}
@@ -0,0 +1,52 @@
+package sinks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidSubjectToken is returned by the NATS publisher for a message key which isn't a single
+// subject token, e.g. a key with a dot or a wildcard.
+var ErrInvalidSubjectToken = errors.New("invalid subject token")
+
+// NatsConn is the subset of *nats.Conn methods used by the NATS publisher.
+type NatsConn interface {
+	Publish(subject string, data []byte) error
+	Flush() error
+}
+
+type natsPublisher struct {
+	conn NatsConn
+}
+
+// NewNatsPublisher returns a Publisher which publishes messages to the subject made of the topic
+// and the message key, e.g. "cere.events.DdcClusters.<cluster_id>", and flushes the connection
+// after each message. The flush is a round-trip to the server, so a message the server didn't
+// receive fails publishing, but it isn't a delivery acknowledgement: core NATS doesn't persist
+// messages and drops them if there are no subscribers. Use JetStream with a PublisherFunc for
+// acknowledged delivery.
+func NewNatsPublisher(conn NatsConn) Publisher {
+	return &natsPublisher{conn: conn}
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, topic string, key []byte, value []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	subject := topic
+	if len(key) > 0 {
+		if strings.ContainsAny(string(key), ".*> \t\r\n") {
+			return fmt.Errorf("%w: %q", ErrInvalidSubjectToken, key)
+		}
+		subject += "." + string(key)
+	}
+
+	if err := p.conn.Publish(subject, value); err != nil {
+		return err
+	}
+
+	return p.conn.Flush()
+}
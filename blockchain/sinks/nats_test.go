@@ -0,0 +1,67 @@
+package sinks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeNatsConn struct {
+	publishErr error
+	flushErr   error
+	subjects   []string
+	flushes    int
+}
+
+func (c *fakeNatsConn) Publish(subject string, _ []byte) error {
+	if c.publishErr != nil {
+		return c.publishErr
+	}
+	c.subjects = append(c.subjects, subject)
+	return nil
+}
+
+func (c *fakeNatsConn) Flush() error {
+	c.flushes++
+	return c.flushErr
+}
+
+func TestNatsPublisher(t *testing.T) {
+	tests := []struct {
+		name         string
+		conn         *fakeNatsConn
+		key          []byte
+		wantSubjects []string
+		wantErr      error
+	}{
+		{name: "subject with key", conn: &fakeNatsConn{}, key: []byte("0x01"), wantSubjects: []string{"cere.events.DdcClusters.0x01"}},
+		{name: "subject without key", conn: &fakeNatsConn{}, wantSubjects: []string{"cere.events.DdcClusters"}},
+		{name: "key with dot", conn: &fakeNatsConn{}, key: []byte("a.b"), wantErr: ErrInvalidSubjectToken},
+		{name: "wildcard key", conn: &fakeNatsConn{}, key: []byte(">"), wantErr: ErrInvalidSubjectToken},
+		{name: "key with space", conn: &fakeNatsConn{}, key: []byte("a b"), wantErr: ErrInvalidSubjectToken},
+		{name: "publish failure", conn: &fakeNatsConn{publishErr: errBrokerUnavailable}, wantErr: errBrokerUnavailable},
+		{
+			name:         "flush failure",
+			conn:         &fakeNatsConn{flushErr: errBrokerUnavailable},
+			wantSubjects: []string{"cere.events.DdcClusters"},
+			wantErr:      errBrokerUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//when
+			err := NewNatsPublisher(tt.conn).Publish(context.Background(), "cere.events.DdcClusters", tt.key, []byte("{}"))
+
+			//then
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, tt.conn.flushes)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantSubjects, tt.conn.subjects)
+		})
+	}
+}
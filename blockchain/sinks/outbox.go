@@ -0,0 +1,316 @@
+package sinks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultOutboxMaxAttempts      = 10
+	DefaultOutboxRetryInterval    = time.Second
+	DefaultOutboxMaxRetryInterval = 5 * time.Minute
+	DefaultOutboxBatchSize        = 100
+)
+
+// OutboxEntry is a message waiting for delivery.
+type OutboxEntry struct {
+	// Id is assigned by the store and orders entries by the time they were added.
+	Id    uint64 `json:"id"`
+	Topic string `json:"topic"`
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+	// Attempts is the number of failed deliveries.
+	Attempts      int       `json:"attempts"`
+	LastError     string    `json:"lastError,omitempty"`
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+}
+
+// OutboxStore persists outbox entries. Entries are removed by Ack once delivered or moved aside by
+// Quarantine once they failed too many times.
+type OutboxStore interface {
+	// Add persists the entry and sets its Id.
+	Add(entry *OutboxEntry) error
+	// Pending returns up to limit entries in the Id order.
+	Pending(limit int) ([]OutboxEntry, error)
+	// Update persists the delivery attempts of the entry.
+	Update(entry OutboxEntry) error
+	// Ack removes the delivered entry.
+	Ack(id uint64) error
+	// Quarantine moves the entry out of the pending entries.
+	Quarantine(entry OutboxEntry) error
+	// Quarantined returns the quarantined entries in the Id order.
+	Quarantined() ([]OutboxEntry, error)
+}
+
+type OutboxParameters struct {
+	// MaxAttempts is the number of failed deliveries after which the entry is quarantined,
+	// DefaultOutboxMaxAttempts if 0.
+	MaxAttempts int
+	// RetryInterval is the delay before the first retry, doubled with every retry up to
+	// MaxRetryInterval. DefaultOutboxRetryInterval and DefaultOutboxMaxRetryInterval if 0.
+	RetryInterval    time.Duration
+	MaxRetryInterval time.Duration
+	// BatchSize is the number of entries read from the store at once, DefaultOutboxBatchSize if 0.
+	BatchSize int
+}
+
+// OutboxStats are counters of the outbox since it was created.
+type OutboxStats struct {
+	Enqueued    uint64
+	Delivered   uint64
+	Failed      uint64
+	Quarantined uint64
+}
+
+// Outbox is a Publisher which persists messages in the store before they are delivered by the
+// wrapped Publisher, so deliveries survive process restarts. Pass it to NewSink and call Run to
+// deliver messages. Messages are retried until acknowledged by the wrapped Publisher, with an
+// exponential backoff per message, so messages may be delivered more than once and out of order.
+// A message which failed MaxAttempts times is quarantined, so it doesn't block the rest.
+type Outbox struct {
+	store     OutboxStore
+	publisher Publisher
+	params    OutboxParameters
+	now       func() time.Time
+	notifyC   chan struct{}
+
+	mu sync.Mutex
+
+	enqueued    uint64
+	delivered   uint64
+	failed      uint64
+	quarantined uint64
+}
+
+func NewOutbox(store OutboxStore, publisher Publisher, params OutboxParameters) *Outbox {
+	if params.MaxAttempts <= 0 {
+		params.MaxAttempts = DefaultOutboxMaxAttempts
+	}
+	if params.RetryInterval <= 0 {
+		params.RetryInterval = DefaultOutboxRetryInterval
+	}
+	if params.MaxRetryInterval <= 0 {
+		params.MaxRetryInterval = DefaultOutboxMaxRetryInterval
+	}
+	if params.BatchSize <= 0 {
+		params.BatchSize = DefaultOutboxBatchSize
+	}
+
+	return &Outbox{
+		store:     store,
+		publisher: publisher,
+		params:    params,
+		now:       time.Now,
+		notifyC:   make(chan struct{}, 1),
+	}
+}
+
+// Publish persists the message for delivery. The error is returned only if the store failed.
+func (o *Outbox) Publish(ctx context.Context, topic string, key []byte, value []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	entry := OutboxEntry{Topic: topic, Key: key, Value: value, NextAttemptAt: o.now()}
+	if err := o.store.Add(&entry); err != nil {
+		return fmt.Errorf("outbox add: %w", err)
+	}
+	atomic.AddUint64(&o.enqueued, 1)
+
+	select {
+	case o.notifyC <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Run delivers messages until the context is done. It returns the context error or the store
+// error which stopped the delivery.
+func (o *Outbox) Run(ctx context.Context) error {
+	for {
+		if _, err := o.Deliver(ctx); err != nil {
+			return err
+		}
+
+		timer := time.NewTimer(o.params.RetryInterval)
+		select {
+		case <-o.notifyC:
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		timer.Stop()
+	}
+}
+
+// Deliver makes one delivery pass over pending messages due for delivery and returns the number of
+// delivered messages.
+func (o *Outbox) Deliver(ctx context.Context) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	entries, err := o.store.Pending(o.params.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("outbox pending: %w", err)
+	}
+
+	delivered := 0
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return delivered, err
+		}
+		if o.now().Before(entry.NextAttemptAt) {
+			continue
+		}
+
+		if err := o.publisher.Publish(ctx, entry.Topic, entry.Key, entry.Value); err != nil {
+			if err := o.fail(entry, err); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+
+		if err := o.store.Ack(entry.Id); err != nil {
+			return delivered, fmt.Errorf("outbox ack %d: %w", entry.Id, err)
+		}
+		atomic.AddUint64(&o.delivered, 1)
+		delivered++
+	}
+
+	return delivered, nil
+}
+
+func (o *Outbox) fail(entry OutboxEntry, deliveryErr error) error {
+	atomic.AddUint64(&o.failed, 1)
+	entry.Attempts++
+	entry.LastError = deliveryErr.Error()
+
+	if entry.Attempts >= o.params.MaxAttempts {
+		if err := o.store.Quarantine(entry); err != nil {
+			return fmt.Errorf("outbox quarantine %d: %w", entry.Id, err)
+		}
+		atomic.AddUint64(&o.quarantined, 1)
+		return nil
+	}
+
+	entry.NextAttemptAt = o.now().Add(o.backoff(entry.Attempts))
+	if err := o.store.Update(entry); err != nil {
+		return fmt.Errorf("outbox update %d: %w", entry.Id, err)
+	}
+
+	return nil
+}
+
+// backoff returns the delay before the retry following the given number of failed attempts.
+func (o *Outbox) backoff(attempts int) time.Duration {
+	delay := o.params.RetryInterval
+	for i := 1; i < attempts && delay < o.params.MaxRetryInterval; i++ {
+		delay *= 2
+	}
+	if delay > o.params.MaxRetryInterval {
+		delay = o.params.MaxRetryInterval
+	}
+
+	return delay
+}
+
+func (o *Outbox) Stats() OutboxStats {
+	return OutboxStats{
+		Enqueued:    atomic.LoadUint64(&o.enqueued),
+		Delivered:   atomic.LoadUint64(&o.delivered),
+		Failed:      atomic.LoadUint64(&o.failed),
+		Quarantined: atomic.LoadUint64(&o.quarantined),
+	}
+}
+
+// MemoryOutboxStore keeps entries in memory. Entries are lost on restart, use it for tests or where
+// the sink restarts from LastPublished anyway.
+type MemoryOutboxStore struct {
+	mu          sync.Mutex
+	nextId      uint64
+	pending     map[uint64]OutboxEntry
+	quarantined map[uint64]OutboxEntry
+}
+
+func NewMemoryOutboxStore() *MemoryOutboxStore {
+	return &MemoryOutboxStore{
+		pending:     make(map[uint64]OutboxEntry),
+		quarantined: make(map[uint64]OutboxEntry),
+	}
+}
+
+func (m *MemoryOutboxStore) Add(entry *OutboxEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextId++
+	entry.Id = m.nextId
+	m.pending[entry.Id] = *entry
+
+	return nil
+}
+
+func (m *MemoryOutboxStore) Pending(limit int) ([]OutboxEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries := sortedEntries(m.pending)
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries, nil
+}
+
+func (m *MemoryOutboxStore) Update(entry OutboxEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.pending[entry.Id]; ok {
+		m.pending[entry.Id] = entry
+	}
+
+	return nil
+}
+
+func (m *MemoryOutboxStore) Ack(id uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.pending, id)
+
+	return nil
+}
+
+func (m *MemoryOutboxStore) Quarantine(entry OutboxEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.pending, entry.Id)
+	m.quarantined[entry.Id] = entry
+
+	return nil
+}
+
+func (m *MemoryOutboxStore) Quarantined() ([]OutboxEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return sortedEntries(m.quarantined), nil
+}
+
+func sortedEntries(entries map[uint64]OutboxEntry) []OutboxEntry {
+	sorted := make([]OutboxEntry, 0, len(entries))
+	for _, entry := range entries {
+		sorted = append(sorted, entry)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Id < sorted[j].Id })
+
+	return sorted
+}
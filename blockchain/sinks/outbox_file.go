@@ -0,0 +1,191 @@
+package sinks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	outboxPendingDir     = "pending"
+	outboxQuarantinedDir = "quarantined"
+	outboxEntrySuffix    = ".json"
+)
+
+// FileOutboxStore stores every entry in a JSON file in the directory, pending entries in the
+// "pending" subdirectory and quarantined ones in the "quarantined" subdirectory. Files are
+// replaced atomically, so an entry is never lost or half written if the process crashes.
+type FileOutboxStore struct {
+	dir string
+
+	mu     sync.Mutex
+	nextId uint64
+}
+
+// NewFileOutboxStore creates the directory if it doesn't exist and continues numbering entries
+// after the ones stored before.
+func NewFileOutboxStore(dir string) (*FileOutboxStore, error) {
+	for _, sub := range []string{outboxPendingDir, outboxQuarantinedDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, err
+		}
+	}
+
+	store := &FileOutboxStore{dir: dir}
+	for _, sub := range []string{outboxPendingDir, outboxQuarantinedDir} {
+		ids, err := store.ids(sub)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) > 0 && ids[len(ids)-1] > store.nextId {
+			store.nextId = ids[len(ids)-1]
+		}
+	}
+
+	return store, nil
+}
+
+func (f *FileOutboxStore) Add(entry *OutboxEntry) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.nextId++
+	entry.Id = f.nextId
+
+	return f.write(outboxPendingDir, *entry)
+}
+
+func (f *FileOutboxStore) Pending(limit int) ([]OutboxEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.read(outboxPendingDir, limit)
+}
+
+func (f *FileOutboxStore) Update(entry OutboxEntry) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := os.Stat(f.path(outboxPendingDir, entry.Id)); err != nil {
+		return err
+	}
+
+	return f.write(outboxPendingDir, entry)
+}
+
+func (f *FileOutboxStore) Ack(id uint64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	err := os.Remove(f.path(outboxPendingDir, id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+func (f *FileOutboxStore) Quarantine(entry OutboxEntry) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.write(outboxQuarantinedDir, entry); err != nil {
+		return err
+	}
+
+	return os.Remove(f.path(outboxPendingDir, entry.Id))
+}
+
+func (f *FileOutboxStore) Quarantined() ([]OutboxEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.read(outboxQuarantinedDir, -1)
+}
+
+func (f *FileOutboxStore) path(sub string, id uint64) string {
+	return filepath.Join(f.dir, sub, fmt.Sprintf("%020d%s", id, outboxEntrySuffix))
+}
+
+// ids returns sorted ids of entries in the subdirectory.
+func (f *FileOutboxStore) ids(sub string) ([]uint64, error) {
+	files, err := os.ReadDir(filepath.Join(f.dir, sub))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint64, 0, len(files))
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), outboxEntrySuffix) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(file.Name(), outboxEntrySuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids, nil
+}
+
+// read reads up to limit entries of the subdirectory, all entries if limit is negative.
+func (f *FileOutboxStore) read(sub string, limit int) ([]OutboxEntry, error) {
+	ids, err := f.ids(sub)
+	if err != nil {
+		return nil, err
+	}
+	if limit >= 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	entries := make([]OutboxEntry, 0, len(ids))
+	for _, id := range ids {
+		data, err := os.ReadFile(f.path(sub, id))
+		if err != nil {
+			return nil, err
+		}
+		var entry OutboxEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("invalid outbox entry %s: %w", f.path(sub, id), err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (f *FileOutboxStore) write(sub string, entry OutboxEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	path := f.path(sub, entry.Id)
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,101 @@
+package sinks
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type flakyPublisher struct {
+	failures  map[string]int
+	published []string
+}
+
+func (p *flakyPublisher) Publish(_ context.Context, topic string, _ []byte, value []byte) error {
+	if p.failures[string(value)] > 0 {
+		p.failures[string(value)]--
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, topic+":"+string(value))
+	return nil
+}
+
+func TestOutboxDeliver(t *testing.T) {
+	//given
+	now := time.Unix(1_700_000_000, 0)
+	publisher := &flakyPublisher{failures: map[string]int{"retried": 1, "poison": 100}}
+	store := NewMemoryOutboxStore()
+	outbox := NewOutbox(store, publisher, OutboxParameters{MaxAttempts: 2, RetryInterval: time.Second})
+	outbox.now = func() time.Time { return now }
+	ctx := context.Background()
+	for _, value := range []string{"delivered", "retried", "poison"} {
+		assert.NoError(t, outbox.Publish(ctx, "topic", nil, []byte(value)))
+	}
+
+	//when
+	first, err1 := outbox.Deliver(ctx)
+	early, err2 := outbox.Deliver(ctx)
+	now = now.Add(time.Second)
+	retried, err3 := outbox.Deliver(ctx)
+
+	//then
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NoError(t, err3)
+	assert.Equal(t, 1, first)
+	assert.Equal(t, 0, early)
+	assert.Equal(t, 1, retried)
+	assert.Equal(t, []string{"topic:delivered", "topic:retried"}, publisher.published)
+	pending, _ := store.Pending(10)
+	assert.Empty(t, pending)
+	quarantined, _ := store.Quarantined()
+	assert.Len(t, quarantined, 1)
+	assert.Equal(t, "poison", string(quarantined[0].Value))
+	assert.Equal(t, "broker unavailable", quarantined[0].LastError)
+	assert.Equal(t, OutboxStats{Enqueued: 3, Delivered: 2, Failed: 3, Quarantined: 1}, outbox.Stats())
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	outbox := NewOutbox(NewMemoryOutboxStore(), nil, OutboxParameters{RetryInterval: time.Second, MaxRetryInterval: 5 * time.Second})
+
+	assert.Equal(t, time.Second, outbox.backoff(1))
+	assert.Equal(t, 2*time.Second, outbox.backoff(2))
+	assert.Equal(t, 4*time.Second, outbox.backoff(3))
+	assert.Equal(t, 5*time.Second, outbox.backoff(4))
+}
+
+func TestFileOutboxStore(t *testing.T) {
+	//given
+	dir := t.TempDir()
+	store, err := NewFileOutboxStore(dir)
+	assert.NoError(t, err)
+	first := OutboxEntry{Topic: "topic", Value: []byte("first")}
+	second := OutboxEntry{Topic: "topic", Value: []byte("second")}
+	assert.NoError(t, store.Add(&first))
+	assert.NoError(t, store.Add(&second))
+
+	//when
+	second.Attempts = 1
+	assert.NoError(t, store.Update(second))
+	assert.NoError(t, store.Ack(first.Id))
+	reopened, err := NewFileOutboxStore(dir)
+	assert.NoError(t, err)
+	third := OutboxEntry{Topic: "topic", Value: []byte("third")}
+	assert.NoError(t, reopened.Add(&third))
+	assert.NoError(t, reopened.Quarantine(third))
+	pending, pendingErr := reopened.Pending(10)
+	quarantined, quarantinedErr := reopened.Quarantined()
+
+	//then
+	assert.NoError(t, pendingErr)
+	assert.NoError(t, quarantinedErr)
+	assert.Equal(t, uint64(3), third.Id)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, "second", string(pending[0].Value))
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.Len(t, quarantined, 1)
+	assert.Equal(t, "third", string(quarantined[0].Value))
+}
@@ -0,0 +1,64 @@
+package sinks
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// ResumeToken is a position in the published events stream: the block number and the number of
+// events of that block already handled, either published or filtered out. Every Message carries
+// the token following it. Persist the token of the last message processed and pass it back in
+// SinkParameters.Resume after a restart, so the sink continues exactly after that message. Start
+// ListenEvents from the token BlockNumber, earlier blocks and events are skipped by the sink.
+//
+// The token is encoded as "<block number>:<events handled>", e.g. "1024:3".
+type ResumeToken struct {
+	BlockNumber types.BlockNumber
+	Delivered   int
+}
+
+// ParseResumeToken parses a token encoded with ResumeToken.String.
+func ParseResumeToken(s string) (ResumeToken, error) {
+	blockPart, deliveredPart, ok := strings.Cut(s, ":")
+	if !ok {
+		return ResumeToken{}, fmt.Errorf("invalid resume token %q: missing separator", s)
+	}
+
+	blockNumber, err := strconv.ParseUint(blockPart, 10, 32)
+	if err != nil {
+		return ResumeToken{}, fmt.Errorf("invalid resume token %q: %w", s, err)
+	}
+	delivered, err := strconv.ParseUint(deliveredPart, 10, 31)
+	if err != nil {
+		return ResumeToken{}, fmt.Errorf("invalid resume token %q: %w", s, err)
+	}
+
+	return ResumeToken{BlockNumber: types.BlockNumber(blockNumber), Delivered: int(delivered)}, nil
+}
+
+func (t ResumeToken) String() string {
+	return strconv.FormatUint(uint64(t.BlockNumber), 10) + ":" + strconv.Itoa(t.Delivered)
+}
+
+func (t ResumeToken) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *ResumeToken) UnmarshalText(text []byte) error {
+	token, err := ParseResumeToken(string(text))
+	if err != nil {
+		return err
+	}
+	*t = token
+
+	return nil
+}
+
+// handled reports whether the event at the index of the block is at or before the token, so it
+// was handled before the token was taken.
+func (t ResumeToken) handled(blockNumber types.BlockNumber, index int) bool {
+	return blockNumber < t.BlockNumber || blockNumber == t.BlockNumber && index < t.Delivered
+}
@@ -0,0 +1,65 @@
+package sinks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseResumeToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    ResumeToken
+		wantErr bool
+	}{
+		{name: "block and events", token: "1024:3", want: ResumeToken{BlockNumber: 1024, Delivered: 3}},
+		{name: "zero", token: "0:0", want: ResumeToken{}},
+		{name: "missing separator", token: "1024", wantErr: true},
+		{name: "negative events", token: "1024:-1", wantErr: true},
+		{name: "not a number", token: "latest:0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//when
+			token, err := ParseResumeToken(tt.token)
+
+			//then
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, token)
+			assert.Equal(t, tt.token, token.String())
+		})
+	}
+}
+
+func TestResumeTokenJson(t *testing.T) {
+	//given
+	message := Message{BlockNumber: 7, Index: 2, ResumeToken: ResumeToken{BlockNumber: 7, Delivered: 3}}
+
+	//when
+	data, err := json.Marshal(message)
+	var decoded Message
+	decodeErr := json.Unmarshal(data, &decoded)
+
+	//then
+	assert.NoError(t, err)
+	assert.NoError(t, decodeErr)
+	assert.Contains(t, string(data), `"resumeToken":"7:3"`)
+	assert.Equal(t, message.ResumeToken, decoded.ResumeToken)
+}
+
+func TestResumeTokenHandled(t *testing.T) {
+	//given
+	token := ResumeToken{BlockNumber: 7, Delivered: 3}
+
+	//then
+	assert.True(t, token.handled(6, 10))
+	assert.True(t, token.handled(7, 2))
+	assert.False(t, token.handled(7, 3))
+	assert.False(t, token.handled(8, 0))
+}
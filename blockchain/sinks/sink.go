@@ -0,0 +1,322 @@
+// Package sinks publishes blockchain events to message brokers.
+//
+// The package includes a NATS publisher, NewNatsPublisher. There is no Kafka client in this SDK:
+// to publish to Kafka or another broker, adapt a producer of your choice with PublisherFunc.
+//
+// A Sink is registered as an events listener on the blockchain.Client. It holds received blocks
+// until they are finalized and only then publishes their events, one message per event, to a topic
+// per pallet. If a block was replaced by the time it is finalized, events of the canonical block
+// are published instead. Delivery is at-least-once: when publishing fails the listener returns an
+// error, which stops the events listening, and ResumeToken reports the position to restart from,
+// see ResumeToken.
+// Wrap the publisher in an Outbox to persist messages instead and retry them in the background
+// until the publisher succeeds.
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain"
+)
+
+const (
+	DefaultTopicPrefix = "cere.events."
+)
+
+// ErrUnsupportedKey is returned by SubjectIdKey for an id field which is neither bytes nor an
+// unsigned integer, as its text could make an invalid subject.
+var ErrUnsupportedKey = errors.New("unsupported key type")
+
+// Publisher publishes a single message to the message broker.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, key []byte, value []byte) error
+}
+
+// PublisherFunc adapts an ordinary function to the Publisher interface. Use it to plug in a
+// producer of a broker without a publisher in this package, e.g. a Kafka producer.
+type PublisherFunc func(ctx context.Context, topic string, key []byte, value []byte) error
+
+func (f PublisherFunc) Publish(ctx context.Context, topic string, key []byte, value []byte) error {
+	return f(ctx, topic, key, value)
+}
+
+// KeyFunc returns a message key for the event, usually an identifier of the event subject. An error
+// fails publishing of the event.
+type KeyFunc func(event *parser.Event) ([]byte, error)
+
+type SinkParameters struct {
+	// TopicPrefix is prepended to the pallet name to make a topic name. DefaultTopicPrefix is used
+	// if empty.
+	TopicPrefix string
+	// Pallets limits published events to the given pallets. All events are published if empty.
+	Pallets []string
+	// Key overrides the default subject id message key.
+	Key KeyFunc
+	// Resume skips events up to and including the position of the token, e.g. the token of the
+	// last message processed before a restart. Nothing is skipped if zero.
+	Resume ResumeToken
+}
+
+// Message is a JSON encoded value of a published message.
+type Message struct {
+	BlockNumber types.BlockNumber `json:"blockNumber"`
+	BlockHash   string            `json:"blockHash"`
+	Index       int               `json:"index"`
+	Pallet      string            `json:"pallet"`
+	Name        string            `json:"name"`
+	Fields      []Field           `json:"fields"`
+	// ResumeToken is the stream position following this message.
+	ResumeToken ResumeToken `json:"resumeToken"`
+}
+
+type Field struct {
+	Name  string `json:"name"`
+	Value any    `json:"value"`
+}
+
+type Sink struct {
+	chain     chain
+	publisher Publisher
+	params    SinkParameters
+	pallets   map[string]struct{}
+
+	mu            sync.Mutex
+	pending       []pendingBlock
+	lastPublished types.BlockNumber
+	// resume is the position after the last handled event.
+	resume ResumeToken
+}
+
+type pendingBlock struct {
+	Events []*parser.Event
+	Hash   types.Hash
+	Number types.BlockNumber
+}
+
+func NewSink(client *blockchain.Client, publisher Publisher, params SinkParameters) *Sink {
+	if params.TopicPrefix == "" {
+		params.TopicPrefix = DefaultTopicPrefix
+	}
+	if params.Key == nil {
+		params.Key = SubjectIdKey
+	}
+
+	pallets := make(map[string]struct{}, len(params.Pallets))
+	for _, pallet := range params.Pallets {
+		pallets[pallet] = struct{}{}
+	}
+
+	return &Sink{
+		chain:     clientChain{client: client},
+		publisher: publisher,
+		params:    params,
+		pallets:   pallets,
+		resume:    params.Resume,
+	}
+}
+
+// HandleEvents is a blockchain.EventsListener. Register it with Client.RegisterEventsListener.
+func (s *Sink) HandleEvents(events []*parser.Event, eventCtx blockchain.EventContext) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// ListenEvents may start before the resumed block, e.g. for other listeners checkpoints.
+	if eventCtx.BlockNumber < s.resume.BlockNumber {
+		return nil
+	}
+
+	s.pending = append(s.pending, pendingBlock{Events: events, Hash: eventCtx.BlockHash, Number: eventCtx.BlockNumber})
+
+	return s.flush(context.Background())
+}
+
+// LastPublished returns the number of the last block which events are all published.
+func (s *Sink) LastPublished() types.BlockNumber {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lastPublished
+}
+
+// ResumeToken returns the position after the last published event. Unlike LastPublished it also
+// covers blocks which events are published partially because publishing failed.
+func (s *Sink) ResumeToken() ResumeToken {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.resume
+}
+
+func (s *Sink) flush(ctx context.Context) error {
+	finalized, err := s.chain.finalizedNumber()
+	if err != nil {
+		return err
+	}
+
+	for len(s.pending) > 0 && s.pending[0].Number <= finalized {
+		block := s.pending[0]
+
+		canonicalHash, err := s.chain.blockHash(block.Number)
+		if err != nil {
+			return err
+		}
+		if canonicalHash != block.Hash {
+			block.Hash = canonicalHash
+			block.Events, err = s.chain.events(canonicalHash)
+			if err != nil {
+				return err
+			}
+		}
+
+		for i, event := range block.Events {
+			if s.resume.handled(block.Number, i) {
+				continue
+			}
+			next := ResumeToken{BlockNumber: block.Number, Delivered: i + 1}
+			if err := s.publish(ctx, block, i, event, next); err != nil {
+				return fmt.Errorf("publish event %d of block %d: %w", i, block.Number, err)
+			}
+			s.resume = next
+		}
+		// Blocks without events advance the position too.
+		if s.resume.BlockNumber < block.Number {
+			s.resume = ResumeToken{BlockNumber: block.Number}
+		}
+
+		s.lastPublished = block.Number
+		s.pending = s.pending[1:]
+	}
+
+	return nil
+}
+
+// chain is the chain state read by the sink to publish finalized blocks only.
+type chain interface {
+	finalizedNumber() (types.BlockNumber, error)
+	blockHash(blockNumber types.BlockNumber) (types.Hash, error)
+	events(blockHash types.Hash) ([]*parser.Event, error)
+}
+
+type clientChain struct {
+	client *blockchain.Client
+}
+
+func (c clientChain) finalizedNumber() (types.BlockNumber, error) {
+	finalizedHash, err := c.client.RPC.Chain.GetFinalizedHead()
+	if err != nil {
+		return 0, err
+	}
+	finalizedHeader, err := c.client.RPC.Chain.GetHeader(finalizedHash)
+	if err != nil {
+		return 0, err
+	}
+
+	return finalizedHeader.Number, nil
+}
+
+func (c clientChain) blockHash(blockNumber types.BlockNumber) (types.Hash, error) {
+	return c.client.RPC.Chain.GetBlockHash(uint64(blockNumber))
+}
+
+func (c clientChain) events(blockHash types.Hash) ([]*parser.Event, error) {
+	return c.client.GetEvents(blockHash)
+}
+
+func (s *Sink) publish(ctx context.Context, block pendingBlock, index int, event *parser.Event, next ResumeToken) error {
+	pallet, _, _ := strings.Cut(event.Name, ".")
+	if len(s.pallets) > 0 {
+		if _, ok := s.pallets[pallet]; !ok {
+			return nil
+		}
+	}
+
+	message := Message{
+		BlockNumber: block.Number,
+		BlockHash:   block.Hash.Hex(),
+		Index:       index,
+		Pallet:      pallet,
+		Name:        event.Name,
+		Fields:      make([]Field, len(event.Fields)),
+		ResumeToken: next,
+	}
+	for i, field := range event.Fields {
+		message.Fields[i] = Field{Name: field.Name, Value: field.Value}
+	}
+
+	value, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	key, err := s.params.Key(event)
+	if err != nil {
+		return fmt.Errorf("key of event %s: %w", event.Name, err)
+	}
+
+	return s.publisher.Publish(ctx, s.params.TopicPrefix+pallet, key, value)
+}
+
+// SubjectIdKey is the default KeyFunc. It uses the first event field which name ends with "_id" or
+// "_key", for example cluster_id, bucket_id or node_pub_key, and returns nil if there is none. Byte
+// identifiers are hex encoded and unsigned integers are decimal, other values fail with
+// ErrUnsupportedKey.
+func SubjectIdKey(event *parser.Event) ([]byte, error) {
+	for _, field := range event.Fields {
+		if strings.HasSuffix(field.Name, "_id") || strings.HasSuffix(field.Name, "_key") {
+			key, err := formatKey(field.Value)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+			return []byte(key), nil
+		}
+	}
+
+	return nil, nil
+}
+
+// formatKey formats identifiers, which are mostly byte arrays, as hex strings and unsigned
+// integers as decimal numbers.
+func formatKey(value any) (string, error) {
+	switch v := value.(type) {
+	case types.AccountID:
+		return codec.HexEncodeToString(v[:]), nil
+	case types.H160:
+		return codec.HexEncodeToString(v[:]), nil
+	case types.H256:
+		return codec.HexEncodeToString(v[:]), nil
+	case []byte:
+		return codec.HexEncodeToString(v), nil
+	case types.U8, types.U16, types.U32, types.U64:
+		return fmt.Sprint(v), nil
+	case types.U128:
+		if v.Int != nil {
+			return v.String(), nil
+		}
+	case registry.DecodedFields:
+		if len(v) == 1 {
+			return formatKey(v[0].Value)
+		}
+	case []any:
+		bytes := make([]byte, 0, len(v))
+		for _, item := range v {
+			b, ok := item.(types.U8)
+			if !ok {
+				return "", fmt.Errorf("%w: list of %T", ErrUnsupportedKey, item)
+			}
+			bytes = append(bytes, byte(b))
+		}
+		return codec.HexEncodeToString(bytes), nil
+	}
+
+	return "", fmt.Errorf("%w: %T", ErrUnsupportedKey, value)
+}
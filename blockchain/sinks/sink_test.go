@@ -0,0 +1,232 @@
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/blockchain"
+)
+
+var errBrokerUnavailable = errors.New("broker unavailable")
+
+type fakeChain struct {
+	finalized types.BlockNumber
+	hashes    map[types.BlockNumber]types.Hash
+	blocks    map[types.Hash][]*parser.Event
+}
+
+func (c *fakeChain) finalizedNumber() (types.BlockNumber, error) {
+	return c.finalized, nil
+}
+
+func (c *fakeChain) blockHash(blockNumber types.BlockNumber) (types.Hash, error) {
+	return c.hashes[blockNumber], nil
+}
+
+func (c *fakeChain) events(blockHash types.Hash) ([]*parser.Event, error) {
+	return c.blocks[blockHash], nil
+}
+
+// recordingPublisher records published messages as "topic:block:event" and fails messages of
+// the events in fail.
+type recordingPublisher struct {
+	fail      map[string]bool
+	published []string
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, topic string, _ []byte, value []byte) error {
+	var message Message
+	if err := json.Unmarshal(value, &message); err != nil {
+		return err
+	}
+	if p.fail[message.Name] {
+		return errBrokerUnavailable
+	}
+	p.published = append(p.published, fmt.Sprintf("%s:%d:%s", topic, message.BlockNumber, message.Name))
+	return nil
+}
+
+func testEvent(name string) *parser.Event {
+	return &parser.Event{Name: name, Fields: registry.DecodedFields{{Name: "cluster_id", Value: types.H160{1}}}}
+}
+
+func TestSinkHandleEvents(t *testing.T) {
+	tests := []struct {
+		name          string
+		finalized     types.BlockNumber
+		canonical     map[types.BlockNumber][]*parser.Event
+		fail          []string
+		wantPublished []string
+		wantErr       error
+		wantLast      types.BlockNumber
+		wantResume    ResumeToken
+	}{
+		{
+			name:      "holds blocks until finalized",
+			finalized: 1,
+			wantPublished: []string{
+				"cere.events.DdcClusters:1:DdcClusters.ClusterCreated",
+				"cere.events.DdcNodes:1:DdcNodes.NodeCreated",
+			},
+			wantLast:   1,
+			wantResume: ResumeToken{BlockNumber: 1, Delivered: 2},
+		},
+		{
+			name:      "publishes finalized blocks",
+			finalized: 2,
+			wantPublished: []string{
+				"cere.events.DdcClusters:1:DdcClusters.ClusterCreated",
+				"cere.events.DdcNodes:1:DdcNodes.NodeCreated",
+				"cere.events.DdcClusters:2:DdcClusters.ClusterBonded",
+			},
+			wantLast:   2,
+			wantResume: ResumeToken{BlockNumber: 2, Delivered: 1},
+		},
+		{
+			name:      "drops events of replaced block",
+			finalized: 2,
+			canonical: map[types.BlockNumber][]*parser.Event{2: {testEvent("DdcClusters.ClusterActivated")}},
+			wantPublished: []string{
+				"cere.events.DdcClusters:1:DdcClusters.ClusterCreated",
+				"cere.events.DdcNodes:1:DdcNodes.NodeCreated",
+				"cere.events.DdcClusters:2:DdcClusters.ClusterActivated",
+			},
+			wantLast:   2,
+			wantResume: ResumeToken{BlockNumber: 2, Delivered: 1},
+		},
+		{
+			name:          "publish failure",
+			finalized:     2,
+			fail:          []string{"DdcNodes.NodeCreated"},
+			wantPublished: []string{"cere.events.DdcClusters:1:DdcClusters.ClusterCreated"},
+			wantErr:       errBrokerUnavailable,
+			wantResume:    ResumeToken{BlockNumber: 1, Delivered: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			blocks := map[types.BlockNumber][]*parser.Event{
+				1: {testEvent("DdcClusters.ClusterCreated"), testEvent("DdcNodes.NodeCreated")},
+				2: {testEvent("DdcClusters.ClusterBonded")},
+			}
+			chain := &fakeChain{
+				finalized: tt.finalized,
+				hashes:    map[types.BlockNumber]types.Hash{1: {1}, 2: {2}},
+				blocks:    map[types.Hash][]*parser.Event{},
+			}
+			for blockNumber, events := range tt.canonical {
+				canonicalHash := types.Hash{byte(blockNumber), 0xff}
+				chain.hashes[blockNumber] = canonicalHash
+				chain.blocks[canonicalHash] = events
+			}
+			fail := make(map[string]bool, len(tt.fail))
+			for _, name := range tt.fail {
+				fail[name] = true
+			}
+			publisher := &recordingPublisher{fail: fail}
+			sink := NewSink(nil, publisher, SinkParameters{})
+			sink.chain = chain
+
+			//when
+			var err error
+			for blockNumber := types.BlockNumber(1); blockNumber <= 2 && err == nil; blockNumber++ {
+				eventCtx := blockchain.EventContext{BlockNumber: blockNumber, BlockHash: types.Hash{byte(blockNumber)}}
+				err = sink.HandleEvents(blocks[blockNumber], eventCtx)
+			}
+
+			//then
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.wantPublished, publisher.published)
+			assert.Equal(t, tt.wantLast, sink.LastPublished())
+			assert.Equal(t, tt.wantResume, sink.ResumeToken())
+		})
+	}
+}
+
+func TestSinkPublishesHeldBlocksOnFinalization(t *testing.T) {
+	//given
+	chain := &fakeChain{hashes: map[types.BlockNumber]types.Hash{1: {1}, 2: {2}}}
+	publisher := &recordingPublisher{}
+	sink := NewSink(nil, publisher, SinkParameters{})
+	sink.chain = chain
+
+	//when
+	errHeld := sink.HandleEvents([]*parser.Event{testEvent("DdcClusters.ClusterCreated")},
+		blockchain.EventContext{BlockNumber: 1, BlockHash: types.Hash{1}})
+	held := len(publisher.published)
+	chain.finalized = 1
+	errFinalized := sink.HandleEvents([]*parser.Event{testEvent("DdcClusters.ClusterBonded")},
+		blockchain.EventContext{BlockNumber: 2, BlockHash: types.Hash{2}})
+
+	//then
+	assert.NoError(t, errHeld)
+	assert.Equal(t, 0, held)
+	assert.NoError(t, errFinalized)
+	assert.Equal(t, []string{"cere.events.DdcClusters:1:DdcClusters.ClusterCreated"}, publisher.published)
+	assert.Equal(t, types.BlockNumber(1), sink.LastPublished())
+}
+
+func TestSubjectIdKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    []byte
+		wantErr error
+	}{
+		{name: "account id", value: types.AccountID{1}, want: []byte("0x0100000000000000000000000000000000000000000000000000000000000000")},
+		{name: "h160", value: types.H160{1}, want: []byte("0x0100000000000000000000000000000000000000")},
+		{name: "bytes", value: []byte{1, 2}, want: []byte("0x0102")},
+		{name: "u8 list", value: []any{types.U8(1), types.U8(2)}, want: []byte("0x0102")},
+		{name: "integer", value: types.U64(42), want: []byte("42")},
+		{name: "u128", value: types.NewU128(*big.NewInt(42)), want: []byte("42")},
+		{name: "single field composite", value: registry.DecodedFields{{Name: "id", Value: types.U32(7)}}, want: []byte("7")},
+		{name: "text", value: "a.b", wantErr: ErrUnsupportedKey},
+		{name: "list of text", value: []any{"a"}, wantErr: ErrUnsupportedKey},
+		{name: "composite", value: registry.DecodedFields{{Value: types.U8(1)}, {Value: types.U8(2)}}, wantErr: ErrUnsupportedKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			event := &parser.Event{Name: "DdcClusters.ClusterCreated", Fields: registry.DecodedFields{{Name: "cluster_id", Value: tt.value}}}
+
+			//when
+			key, err := SubjectIdKey(event)
+
+			//then
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, key)
+		})
+	}
+}
+
+func TestSinkUnsupportedKey(t *testing.T) {
+	//given
+	chain := &fakeChain{finalized: 1, hashes: map[types.BlockNumber]types.Hash{1: {1}}}
+	publisher := &recordingPublisher{}
+	sink := NewSink(nil, publisher, SinkParameters{})
+	sink.chain = chain
+	event := &parser.Event{Name: "DdcClusters.ClusterCreated", Fields: registry.DecodedFields{{Name: "cluster_id", Value: "*"}}}
+
+	//when
+	err := sink.HandleEvents([]*parser.Event{event}, blockchain.EventContext{BlockNumber: 1, BlockHash: types.Hash{1}})
+
+	//then
+	assert.ErrorIs(t, err, ErrUnsupportedKey)
+	assert.Empty(t, publisher.published)
+}
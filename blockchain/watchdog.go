@@ -0,0 +1,169 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var (
+	ErrSilence = errors.New("no blocks observed within the watchdog window")
+)
+
+type WatchdogParameters struct {
+	// Window is the longest time without observed blocks before the watchdog alerts.
+	// EventsListeningTimeout is used if zero.
+	Window time.Duration
+	// RequireEvents makes only blocks with events observed, e.g. for listeners of rare events which
+	// are expected to come at least once per window.
+	RequireEvents bool
+	// OnSilence is called once per silence with the time since the last observed block and its
+	// context, zero if no block was observed. Optional.
+	OnSilence func(silence time.Duration, last EventContext)
+	// Reconnect makes Watchdog.ListenEvents restart events listening on silence.
+	Reconnect bool
+}
+
+// Watchdog is a dead man's switch of events listening. Subscriptions sometimes die silently with no
+// error and no new blocks, the watchdog detects it by the blocks observed by its listeners. It's
+// safe for concurrent use.
+type Watchdog struct {
+	params WatchdogParameters
+	now    func() time.Time
+
+	mu        sync.Mutex
+	last      time.Time
+	lastCtx   EventContext
+	alerted   bool
+	delivered bool
+	lastBlock types.BlockNumber
+}
+
+func NewWatchdog(params WatchdogParameters) *Watchdog {
+	if params.Window <= 0 {
+		params.Window = EventsListeningTimeout
+	}
+
+	return &Watchdog{params: params, now: time.Now, last: time.Now()}
+}
+
+// Listener wraps the events listener to observe blocks delivered to it. Register the wrapped
+// listener with Client.RegisterEventsListener.
+func (w *Watchdog) Listener(listener EventsListener) EventsListener {
+	return func(events []*parser.Event, eventCtx EventContext) error {
+		w.observe(events, eventCtx)
+		return listener(events, eventCtx)
+	}
+}
+
+// ListenEvents calls Client.ListenEvents and checks the silence every quarter of the window. With
+// Reconnect set, a silence or the client listening timeout restarts listening from the block after
+// the last delivered one and ErrSilence is never returned. Otherwise listening is stopped with
+// ErrSilence.
+func (w *Watchdog) ListenEvents(
+	ctx context.Context,
+	client *Client,
+	begin types.BlockNumber,
+	after func(blockNumber types.BlockNumber, blockHash types.Hash) error,
+) error {
+	for {
+		w.reset()
+
+		listenCtx, cancel := context.WithCancel(ctx)
+		silenced := make(chan struct{})
+		go w.watch(listenCtx, cancel, silenced)
+
+		err := client.ListenEvents(listenCtx, begin, after)
+		cancel()
+
+		select {
+		case <-silenced:
+		default:
+			if !w.params.Reconnect || ctx.Err() != nil || !errors.Is(err, context.DeadlineExceeded) {
+				return err
+			}
+		}
+		if !w.params.Reconnect {
+			return ErrSilence
+		}
+
+		if next, ok := w.next(); ok {
+			begin = next
+		}
+	}
+}
+
+func (w *Watchdog) watch(ctx context.Context, cancel context.CancelFunc, silenced chan<- struct{}) {
+	ticker := time.NewTicker(w.params.Window / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if w.check() {
+				close(silenced)
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+func (w *Watchdog) observe(events []*parser.Event, eventCtx EventContext) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.delivered = true
+	w.lastBlock = eventCtx.BlockNumber
+	if w.params.RequireEvents && len(events) == 0 {
+		return
+	}
+
+	w.last = w.now()
+	w.lastCtx = eventCtx
+	w.alerted = false
+}
+
+// check reports whether the window passed since the last observed block and calls OnSilence once
+// per silence.
+func (w *Watchdog) check() bool {
+	w.mu.Lock()
+	silence := w.now().Sub(w.last)
+	if silence < w.params.Window {
+		w.mu.Unlock()
+		return false
+	}
+	alert := !w.alerted
+	w.alerted = true
+	last := w.lastCtx
+	w.mu.Unlock()
+
+	if alert && w.params.OnSilence != nil {
+		w.params.OnSilence(silence, last)
+	}
+
+	return true
+}
+
+// reset starts the window of a new listening.
+func (w *Watchdog) reset() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.last = w.now()
+	w.alerted = false
+}
+
+// next returns the block after the last delivered one.
+func (w *Watchdog) next() (types.BlockNumber, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.lastBlock + 1, w.delivered
+}
@@ -0,0 +1,59 @@
+package blockchain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchdog(t *testing.T) {
+	tests := []struct {
+		name          string
+		requireEvents bool
+		events        []*parser.Event
+		wantSilence   bool
+	}{
+		{name: "block observed"},
+		{name: "block with events required", requireEvents: true, events: []*parser.Event{{Name: "System.Remarked"}}},
+		{name: "block without events", requireEvents: true, wantSilence: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			now := time.Unix(1_700_000_000, 0)
+			var alerts []time.Duration
+			watchdog := NewWatchdog(WatchdogParameters{
+				Window:        time.Minute,
+				RequireEvents: tt.requireEvents,
+				OnSilence: func(silence time.Duration, _ EventContext) {
+					alerts = append(alerts, silence)
+				},
+			})
+			watchdog.now = func() time.Time { return now }
+			watchdog.reset()
+			listener := watchdog.Listener(func([]*parser.Event, EventContext) error { return nil })
+
+			//when
+			now = now.Add(50 * time.Second)
+			assert.NoError(t, listener(tt.events, EventContext{BlockNumber: 7}))
+			now = now.Add(30 * time.Second)
+			silent := watchdog.check()
+			silentAgain := watchdog.check()
+			next, ok := watchdog.next()
+
+			//then
+			assert.Equal(t, tt.wantSilence, silent)
+			assert.Equal(t, tt.wantSilence, silentAgain)
+			if tt.wantSilence {
+				assert.Equal(t, []time.Duration{80 * time.Second}, alerts)
+			} else {
+				assert.Empty(t, alerts)
+			}
+			assert.True(t, ok)
+			assert.Equal(t, 8, int(next))
+		})
+	}
+}
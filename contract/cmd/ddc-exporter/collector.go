@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+	log "github.com/sirupsen/logrus"
+)
+
+const pageSize = 100
+
+// contract is the part of the bucket contract API the collector reads.
+type contract interface {
+	bucket.BucketReader
+	bucket.ClusterReader
+	bucket.NodeReader
+	bucket.AccountOps
+}
+
+type collector struct {
+	contract contract
+	registry *registry
+	// deposits enables summing deposits of all accounts which reads every account on each collection.
+	deposits bool
+}
+
+// run collects metrics every interval until the context is canceled.
+func (c *collector) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.collect(); err != nil {
+			log.WithError(err).Warn("Metrics collection failed")
+			c.registry.incCounter("ddc_exporter_collect_errors_total", "Number of failed metrics collections.")
+		} else {
+			c.registry.setGauge("ddc_exporter_last_collect_timestamp_seconds", "Time of the last successful metrics collection.", float64(time.Now().Unix()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *collector) collect() error {
+	if err := c.collectClusters(); err != nil {
+		return err
+	}
+
+	nodes, err := c.contract.NodeList(0, 1, types.NewOptionAccountIDEmpty())
+	if err != nil {
+		return err
+	}
+	c.registry.setGauge("ddc_nodes", "Number of storage nodes.", float64(nodes.Total))
+
+	cdnNodes, err := c.contract.CdnNodeList(0, 1, types.NewOptionAccountIDEmpty())
+	if err != nil {
+		return err
+	}
+	c.registry.setGauge("ddc_cdn_nodes", "Number of CDN nodes.", float64(cdnNodes.Total))
+
+	buckets, err := c.contract.BucketList(0, 1, types.NewOptionAccountIDEmpty())
+	if err != nil {
+		return err
+	}
+	c.registry.setGauge("ddc_buckets", "Number of buckets.", float64(buckets.Total))
+
+	if c.deposits {
+		return c.collectDeposits()
+	}
+
+	return nil
+}
+
+func (c *collector) collectClusters() error {
+	const (
+		nodesHelp       = "Number of storage nodes in the cluster."
+		cdnNodesHelp    = "Number of CDN nodes in the cluster."
+		resourceHelp    = "Resource reserved by buckets in the cluster."
+		revenuesHelp    = "Cluster revenues not yet distributed to storage node providers, in CERE."
+		cdnRevenuesHelp = "Cluster revenues not yet distributed to CDN node providers, in CERE."
+	)
+
+	var clusters []bucket.ClusterInfo
+	var total types.U32
+	for offset := types.U32(0); offset == 0 || offset < total; offset += pageSize {
+		page, err := c.contract.ClusterList(offset, pageSize, types.NewOptionAccountIDEmpty())
+		if err != nil {
+			return err
+		}
+		clusters = append(clusters, page.Clusters...)
+		total = page.Total
+		if len(page.Clusters) == 0 {
+			break
+		}
+	}
+
+	c.registry.setGauge("ddc_clusters", "Number of clusters.", float64(total))
+	c.registry.resetGauge("ddc_cluster_nodes", nodesHelp)
+	c.registry.resetGauge("ddc_cluster_cdn_nodes", cdnNodesHelp)
+	c.registry.resetGauge("ddc_cluster_resource_used", resourceHelp)
+	c.registry.resetGauge("ddc_cluster_undistributed_revenues_cere", revenuesHelp)
+	c.registry.resetGauge("ddc_cluster_undistributed_cdn_revenues_cere", cdnRevenuesHelp)
+
+	for _, cluster := range clusters {
+		id := strconv.FormatUint(uint64(cluster.ClusterId), 10)
+		c.registry.setGauge("ddc_cluster_nodes", nodesHelp, float64(len(cluster.Cluster.NodesKeys)), "cluster_id", id)
+		c.registry.setGauge("ddc_cluster_cdn_nodes", cdnNodesHelp, float64(len(cluster.Cluster.CdnNodesKeys)), "cluster_id", id)
+		c.registry.setGauge("ddc_cluster_resource_used", resourceHelp, float64(cluster.Cluster.ResourceUsed), "cluster_id", id)
+		c.registry.setGauge("ddc_cluster_undistributed_revenues_cere", revenuesHelp, cere(cluster.Cluster.Revenues), "cluster_id", id)
+		c.registry.setGauge("ddc_cluster_undistributed_cdn_revenues_cere", cdnRevenuesHelp, cere(cluster.Cluster.CdnRevenues), "cluster_id", id)
+	}
+
+	return nil
+}
+
+func (c *collector) collectDeposits() error {
+	accounts, err := c.contract.GetAccounts()
+	if err != nil {
+		return err
+	}
+
+	total := new(big.Int)
+	for _, accountId := range accounts {
+		account, err := c.contract.AccountGet(accountId)
+		if err != nil {
+			return err
+		}
+		if account.Deposit.Int != nil {
+			total.Add(total, account.Deposit.Int)
+		}
+	}
+
+	c.registry.setGauge("ddc_accounts", "Number of customer accounts.", float64(len(accounts)))
+	c.registry.setGauge("ddc_deposits_cere", "Total deposit of customer accounts, in CERE.", cere(types.NewU128(*total)))
+
+	return nil
+}
+
+// hookEvents counts contract events by their type.
+func hookEvents(ddcBucketContract bucket.DdcBucketContract, r *registry) error {
+	for eventId, entry := range ddcBucketContract.GetEventDispatcher() {
+		name := entry.ArgumentType.Name()
+		err := ddcBucketContract.RegisterHandler(eventId.Hex(), func(interface{}) {
+			r.incCounter("ddc_contract_events_total", "Number of emitted contract events.", "event", name)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func cere(balance bucket.Balance) float64 {
+	if balance.Int == nil {
+		return 0
+	}
+
+	value, _ := new(big.Float).Quo(new(big.Float).SetInt(balance.Int), big.NewFloat(pkg.CERE)).Float64()
+	return value
+}
@@ -0,0 +1,72 @@
+// Command ddc-exporter exports DDC network metrics read from the DDC bucket contract in the
+// Prometheus format: numbers of clusters, nodes and buckets, cluster resources and revenues not
+// yet distributed to providers, total customer deposits and rates of contract events.
+//
+// Usage:
+//
+//	ddc-exporter -rpc wss://archive.testnet.cere.network/ws -contract <contract address> -listen :9102
+package main
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+	log "github.com/sirupsen/logrus"
+)
+
+func main() {
+	rpcUrl := flag.String("rpc", "ws://localhost:9944", "blockchain node RPC URL")
+	contractAddress := flag.String("contract", "", "DDC bucket contract address")
+	listen := flag.String("listen", ":9102", "metrics HTTP server address")
+	interval := flag.Duration("interval", time.Minute, "metrics collection interval")
+	deposits := flag.Bool("deposits", false, "sum deposits of all customer accounts, reads every account on each collection")
+	flag.Parse()
+
+	if *contractAddress == "" {
+		log.Fatal("Contract address is required")
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	client := pkg.CreateBlockchainClient(*rpcUrl)
+	ddcBucketContract := bucket.CreateDdcBucketContract(client, *contractAddress)
+	metrics := newRegistry()
+
+	if err := hookEvents(ddcBucketContract, metrics); err != nil {
+		log.WithError(err).Fatal("Can't hook contract events")
+	}
+	if err := client.SetEventDispatcher(*contractAddress, ddcBucketContract.GetEventDispatcher()); err != nil {
+		log.WithError(err).Fatal("Can't subscribe to contract events")
+	}
+
+	c := &collector{contract: ddcBucketContract, registry: metrics, deposits: *deposits}
+	go c.run(ctx, *interval)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		if err := metrics.write(w); err != nil {
+			log.WithError(err).Warn("Can't write metrics")
+		}
+	})
+	server := &http.Server{Addr: *listen, Handler: mux}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	log.WithField("listen", *listen).Info("Serving metrics")
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.WithError(err).Fatal("Metrics server failed")
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// registry keeps metric values and writes them in the Prometheus text exposition format.
+type registry struct {
+	mu      sync.Mutex
+	metrics map[string]*metric
+}
+
+type metric struct {
+	name   string
+	help   string
+	kind   string
+	values map[string]float64
+}
+
+func newRegistry() *registry {
+	return &registry{metrics: make(map[string]*metric)}
+}
+
+// setGauge sets the gauge value. labels are name and value pairs.
+func (r *registry) setGauge(name string, help string, value float64, labels ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.metric(name, help, "gauge").values[formatLabels(labels)] = value
+}
+
+// resetGauge removes all values of the gauge, e.g. of removed clusters, before they are set again.
+func (r *registry) resetGauge(name string, help string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.metric(name, help, "gauge").values = make(map[string]float64)
+}
+
+// incCounter increments the counter. labels are name and value pairs.
+func (r *registry) incCounter(name string, help string, labels ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.metric(name, help, "counter").values[formatLabels(labels)]++
+}
+
+func (r *registry) metric(name string, help string, kind string) *metric {
+	m, ok := r.metrics[name]
+	if !ok {
+		m = &metric{name: name, help: help, kind: kind, values: make(map[string]float64)}
+		r.metrics[name] = m
+	}
+
+	return m
+}
+
+func (r *registry) write(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.metrics))
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		m := r.metrics[name]
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.kind); err != nil {
+			return err
+		}
+
+		labels := make([]string, 0, len(m.values))
+		for l := range m.values {
+			labels = append(labels, l)
+		}
+		sort.Strings(labels)
+
+		for _, l := range labels {
+			if _, err := fmt.Fprintf(w, "%s%s %g\n", m.name, l, m.values[l]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func formatLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(labels[i+1])
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, labels[i], value))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryWrite(t *testing.T) {
+	//given
+	r := newRegistry()
+	r.setGauge("ddc_clusters", "Number of clusters.", 2)
+	r.setGauge("ddc_cluster_nodes", "Number of storage nodes in the cluster.", 3, "cluster_id", "1")
+	r.setGauge("ddc_cluster_nodes", "Number of storage nodes in the cluster.", 5, "cluster_id", "2")
+	r.incCounter("ddc_contract_events_total", "Number of emitted contract events.", "event", "DepositEvent")
+	r.incCounter("ddc_contract_events_total", "Number of emitted contract events.", "event", "DepositEvent")
+
+	//when
+	buf := &bytes.Buffer{}
+	err := r.write(buf)
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, `# HELP ddc_cluster_nodes Number of storage nodes in the cluster.
+# TYPE ddc_cluster_nodes gauge
+ddc_cluster_nodes{cluster_id="1"} 3
+ddc_cluster_nodes{cluster_id="2"} 5
+# HELP ddc_clusters Number of clusters.
+# TYPE ddc_clusters gauge
+ddc_clusters 2
+# HELP ddc_contract_events_total Number of emitted contract events.
+# TYPE ddc_contract_events_total counter
+ddc_contract_events_total{event="DepositEvent"} 2
+`, buf.String())
+}
+
+func TestRegistryResetGauge(t *testing.T) {
+	//given
+	r := newRegistry()
+	r.setGauge("ddc_cluster_nodes", "Number of storage nodes in the cluster.", 3, "cluster_id", "1")
+
+	//when
+	r.resetGauge("ddc_cluster_nodes", "Number of storage nodes in the cluster.")
+	buf := &bytes.Buffer{}
+	_ = r.write(buf)
+
+	//then
+	assert.NotContains(t, buf.String(), `cluster_id="1"`)
+}
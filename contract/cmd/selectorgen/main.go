@@ -0,0 +1,136 @@
+// Command selectorgen checks contract method selectors declared as string constants named
+// *Method in a Go source file and generates a map of them, so the selectors can be validated at
+// runtime too. It fails when a selector is empty, isn't 4 hex encoded bytes or duplicates
+// another selector.
+//
+// Usage:
+//
+//	//go:generate go run ../../cmd/selectorgen -source ddc_bucket_contract.go -output selectors_gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	source := flag.String("source", "", "Go source file declaring the selectors")
+	output := flag.String("output", "selectors_gen.go", "generated Go file")
+	flag.Parse()
+
+	if err := run(*source, *output); err != nil {
+		fmt.Fprintln(os.Stderr, "selectorgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(source string, output string) error {
+	pkgName, selectors, err := parseSelectors(source)
+	if err != nil {
+		return err
+	}
+	if err := validate(selectors); err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+
+	names := make([]string, 0, len(selectors))
+	for name := range selectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "// Code generated by selectorgen from %s. DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(buf, "package %s\n\n", pkgName)
+	fmt.Fprintln(buf, "var methodSelectors = map[string]string{")
+	for _, name := range names {
+		fmt.Fprintf(buf, "\t%q: %s,\n", name, name)
+	}
+	fmt.Fprintln(buf, "}")
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(output, code, 0644)
+}
+
+// parseSelectors returns string constants with names ending with Method.
+func parseSelectors(source string) (string, map[string]string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), source, nil, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	selectors := make(map[string]string)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, name := range valueSpec.Names {
+				if !strings.HasSuffix(name.Name, "Method") || i >= len(valueSpec.Values) {
+					continue
+				}
+				lit, ok := valueSpec.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				value, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					return "", nil, err
+				}
+				selectors[name.Name] = value
+			}
+		}
+	}
+
+	return file.Name.Name, selectors, nil
+}
+
+func validate(selectors map[string]string) error {
+	var problems []string
+	seen := make(map[string]string)
+
+	names := make([]string, 0, len(selectors))
+	for name := range selectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		selector := selectors[name]
+		if selector == "" {
+			problems = append(problems, name+" is empty")
+			continue
+		}
+		if decoded, err := hex.DecodeString(selector); err != nil || len(decoded) != 4 {
+			problems = append(problems, fmt.Sprintf("%s %q is not 4 hex encoded bytes", name, selector))
+			continue
+		}
+		if other, ok := seen[selector]; ok {
+			problems = append(problems, fmt.Sprintf("%s duplicates %s %q", name, other, selector))
+			continue
+		}
+		seen[selector] = name
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid method selectors:\n\t%s", strings.Join(problems, "\n\t"))
+	}
+
+	return nil
+}
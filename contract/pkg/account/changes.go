@@ -0,0 +1,275 @@
+package account
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+type Reason string
+
+const (
+	ReasonTransfer Reason = "transfer"
+	// ReasonCustomerDeposit is a deposit to the DDC customers pallet ledger.
+	ReasonCustomerDeposit Reason = "customer_deposit"
+	// ReasonCustomerUnlock is an unlock of a pallet ledger deposit. It doesn't change the free
+	// balance, the unlocked amount is withdrawn later.
+	ReasonCustomerUnlock Reason = "customer_unlock"
+	// ReasonCustomerWithdrawal is a withdrawal from the DDC customers pallet ledger.
+	ReasonCustomerWithdrawal Reason = "customer_withdrawal"
+	// ReasonContractDeposit is a deposit to the account in the DDC bucket contract.
+	ReasonContractDeposit Reason = "contract_deposit"
+	// ReasonPayoutReward is a reward of a node provider paid by the DDC payouts pallet.
+	ReasonPayoutReward Reason = "payout_reward"
+)
+
+// BalanceChange is a change of the account balance normalized from events of the Balances, DDC
+// customers and DDC payouts pallets and of the DDC bucket contract.
+type BalanceChange struct {
+	AccountId types.AccountID
+	Reason    Reason
+	// Delta is the change of the free balance of the account, negative if funds left it.
+	Delta *big.Int
+	// Amount is the amount of the event, it differs from Delta of unlocks only.
+	Amount *big.Int
+	// Counterparty is the other account of transfers and contract deposits.
+	Counterparty *types.AccountID
+
+	BlockNumber types.BlockNumber
+	BlockHash   types.Hash
+	// EventIndex is the index of the event in the block, changes of a block are ordered by it.
+	EventIndex int
+}
+
+// Event is a chain event, e.g. a parser.Event of the blockchain module with fields read by
+// blockchain.EventFields.
+type Event struct {
+	Name string
+	// Fields are values of the event fields by name, composites of a single field are replaced
+	// with the value of the field.
+	Fields map[string]any
+	Topics []types.Hash
+}
+
+// BalanceFeed turns block events into balance changes of accounts. It's safe for concurrent use.
+type BalanceFeed struct {
+	onChange func(change BalanceChange)
+
+	mu       sync.RWMutex
+	accounts map[types.AccountID]struct{}
+	contract *types.AccountID
+}
+
+type BalanceFeedOption func(f *BalanceFeed)
+
+// WithBalanceAccounts limits the feed to the accounts. The feed reports changes of all accounts by
+// default.
+func WithBalanceAccounts(accounts ...types.AccountID) BalanceFeedOption {
+	return func(f *BalanceFeed) {
+		for _, accountId := range accounts {
+			f.accounts[accountId] = struct{}{}
+		}
+	}
+}
+
+// WithBalanceContract reports deposits to the DDC bucket contract of the account.
+func WithBalanceContract(contract types.AccountID) BalanceFeedOption {
+	return func(f *BalanceFeed) {
+		f.contract = &contract
+	}
+}
+
+// NewBalanceFeed creates the feed calling onChange with every balance change in the order of
+// events.
+func NewBalanceFeed(onChange func(change BalanceChange), opts ...BalanceFeedOption) *BalanceFeed {
+	f := &BalanceFeed{onChange: onChange, accounts: make(map[types.AccountID]struct{})}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+// Follow adds the account to the accounts the feed is limited to.
+func (f *BalanceFeed) Follow(accountId types.AccountID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.accounts[accountId] = struct{}{}
+}
+
+// HandleEvents turns events of the block into balance changes. Register it with
+// Client.RegisterEventsListener of the blockchain module:
+//
+//	client.RegisterEventsListener(func(events []*parser.Event, eventCtx blockchain.EventContext) error {
+//		balanceEvents := make([]account.Event, len(events))
+//		for i, event := range events {
+//			balanceEvents[i] = account.Event{Name: event.Name, Fields: blockchain.EventFields(event), Topics: event.Topics}
+//		}
+//		return feed.HandleEvents(balanceEvents, eventCtx.BlockNumber, eventCtx.BlockHash)
+//	})
+func (f *BalanceFeed) HandleEvents(events []Event, blockNumber types.BlockNumber, blockHash types.Hash) error {
+	for i, event := range events {
+		for _, change := range f.changes(event) {
+			if !f.follows(change.AccountId) {
+				continue
+			}
+			change.BlockNumber = blockNumber
+			change.BlockHash = blockHash
+			change.EventIndex = i
+			f.onChange(change)
+		}
+	}
+
+	return nil
+}
+
+func (f *BalanceFeed) follows(accountId types.AccountID) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if len(f.accounts) == 0 {
+		return true
+	}
+	_, ok := f.accounts[accountId]
+
+	return ok
+}
+
+func (f *BalanceFeed) changes(event Event) []BalanceChange {
+	fields := event.Fields
+	switch event.Name {
+	case "Balances.Transfer":
+		from, okFrom := accountIdValue(fields["from"])
+		to, okTo := accountIdValue(fields["to"])
+		amount, okAmount := amountValue(fields["amount"])
+		if !okFrom || !okTo || !okAmount {
+			return nil
+		}
+		return []BalanceChange{
+			{AccountId: from, Reason: ReasonTransfer, Delta: new(big.Int).Neg(amount), Amount: amount, Counterparty: &to},
+			{AccountId: to, Reason: ReasonTransfer, Delta: new(big.Int).Set(amount), Amount: new(big.Int).Set(amount), Counterparty: &from},
+		}
+	case "DdcCustomers.Deposited":
+		return change(fields, "owner_id", "amount", ReasonCustomerDeposit, -1)
+	case "DdcCustomers.InitialDepositUnlock":
+		return change(fields, "owner_id", "amount", ReasonCustomerUnlock, 0)
+	case "DdcCustomers.Withdrawn":
+		return change(fields, "owner_id", "amount", ReasonCustomerWithdrawal, 1)
+	case "DdcPayouts.Rewarded":
+		return change(fields, "node_provider_id", "rewarded", ReasonPayoutReward, 1)
+	case "Contracts.ContractEmitted":
+		return f.contractChanges(event, fields)
+	}
+
+	return nil
+}
+
+// contractChanges decodes the Deposit event of the DDC bucket contract.
+func (f *BalanceFeed) contractChanges(event Event, fields map[string]any) []BalanceChange {
+	if f.contract == nil {
+		return nil
+	}
+	contract, ok := accountIdValue(fields["contract"])
+	if !ok || contract != *f.contract || !hasTopic(event.Topics, bucket.DepositEventId) {
+		return nil
+	}
+	data, ok := bytesValue(fields["data"])
+	if !ok || len(data) == 0 {
+		return nil
+	}
+
+	// The first byte is the index of the event in the contract.
+	var deposit bucket.DepositEvent
+	if err := codec.Decode(data[1:], &deposit); err != nil || deposit.Value.Int == nil {
+		return nil
+	}
+
+	amount := new(big.Int).Set(deposit.Value.Int)
+	return []BalanceChange{{
+		AccountId:    deposit.AccountId,
+		Reason:       ReasonContractDeposit,
+		Delta:        new(big.Int).Neg(amount),
+		Amount:       amount,
+		Counterparty: &contract,
+	}}
+}
+
+// change reads the single account change of the event, sign is the sign of the free balance delta.
+func change(fields map[string]any, accountField, amountField string, reason Reason, sign int) []BalanceChange {
+	accountId, ok := accountIdValue(fields[accountField])
+	if !ok {
+		return nil
+	}
+	amount, ok := amountValue(fields[amountField])
+	if !ok {
+		return nil
+	}
+
+	delta := new(big.Int).Mul(amount, big.NewInt(int64(sign)))
+	return []BalanceChange{{AccountId: accountId, Reason: reason, Delta: delta, Amount: amount}}
+}
+
+func hasTopic(topics []types.Hash, eventId string) bool {
+	id, err := types.NewHashFromHexString(eventId)
+	if err != nil {
+		return false
+	}
+	for _, topic := range topics {
+		if topic == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func amountValue(value any) (*big.Int, bool) {
+	amount, ok := value.(types.U128)
+	if !ok || amount.Int == nil {
+		return nil, false
+	}
+
+	return new(big.Int).Set(amount.Int), true
+}
+
+// accountIdValue reads the account ID which is decoded either as AccountID or as a list of bytes.
+func accountIdValue(value any) (types.AccountID, bool) {
+	if v, ok := value.(types.AccountID); ok {
+		return v, true
+	}
+
+	var accountId types.AccountID
+	b, ok := bytesValue(value)
+	if !ok || len(b) != len(accountId) {
+		return accountId, false
+	}
+	copy(accountId[:], b)
+
+	return accountId, true
+}
+
+// bytesValue reads a list of bytes.
+func bytesValue(value any) ([]byte, bool) {
+	switch v := value.(type) {
+	case []byte:
+		return v, true
+	case types.Bytes:
+		return v, true
+	case []any:
+		b := make([]byte, len(v))
+		for i, item := range v {
+			u, ok := item.(types.U8)
+			if !ok {
+				return nil, false
+			}
+			b[i] = byte(u)
+		}
+		return b, true
+	}
+
+	return nil, false
+}
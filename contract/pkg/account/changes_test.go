@@ -0,0 +1,90 @@
+package account
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+	"github.com/stretchr/testify/assert"
+)
+
+type eventField struct {
+	name  string
+	value any
+}
+
+func field(name string, value any) eventField {
+	return eventField{name: name, value: value}
+}
+
+func event(name string, fields ...eventField) Event {
+	e := Event{Name: name, Fields: make(map[string]any, len(fields))}
+	for _, f := range fields {
+		e.Fields[f.name] = f.value
+	}
+
+	return e
+}
+
+func depositEmitted(t *testing.T, contract types.AccountID, deposit bucket.DepositEvent) Event {
+	data, err := codec.Encode(deposit)
+	assert.NoError(t, err)
+	topic, err := types.NewHashFromHexString(bucket.DepositEventId)
+	assert.NoError(t, err)
+
+	emitted := event("Contracts.ContractEmitted", field("contract", contract), field("data", types.Bytes(append([]byte{0}, data...))))
+	emitted.Topics = []types.Hash{topic}
+
+	return emitted
+}
+
+func TestBalanceFeed(t *testing.T) {
+	//given
+	alice, bob, contract := types.AccountID{1}, types.AccountID{2}, types.AccountID{9}
+	var changes []BalanceChange
+	feed := NewBalanceFeed(func(change BalanceChange) {
+		changes = append(changes, change)
+	}, WithBalanceAccounts(alice), WithBalanceContract(contract))
+
+	//when
+	err := feed.HandleEvents([]Event{
+		event("Balances.Transfer", field("from", alice), field("to", bob), field("amount", balance(100))),
+		event("DdcCustomers.Deposited", field("owner_id", alice), field("amount", balance(40))),
+		event("DdcCustomers.InitialDepositUnlock", field("owner_id", alice), field("amount", balance(10))),
+		event("DdcCustomers.Withdrawn", field("owner_id", bob), field("amount", balance(10))),
+		event("DdcPayouts.Rewarded", field("node_provider_id", alice), field("rewarded", balance(5))),
+		depositEmitted(t, contract, bucket.DepositEvent{AccountId: alice, Value: balance(20)}),
+		depositEmitted(t, types.AccountID{8}, bucket.DepositEvent{AccountId: alice, Value: balance(30)}),
+	}, 7, types.Hash{7})
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, []BalanceChange{
+		{AccountId: alice, Reason: ReasonTransfer, Delta: big.NewInt(-100), Amount: big.NewInt(100), Counterparty: &bob, BlockNumber: 7, BlockHash: types.Hash{7}},
+		{AccountId: alice, Reason: ReasonCustomerDeposit, Delta: big.NewInt(-40), Amount: big.NewInt(40), BlockNumber: 7, BlockHash: types.Hash{7}, EventIndex: 1},
+		{AccountId: alice, Reason: ReasonCustomerUnlock, Delta: new(big.Int), Amount: big.NewInt(10), BlockNumber: 7, BlockHash: types.Hash{7}, EventIndex: 2},
+		{AccountId: alice, Reason: ReasonPayoutReward, Delta: big.NewInt(5), Amount: big.NewInt(5), BlockNumber: 7, BlockHash: types.Hash{7}, EventIndex: 4},
+		{AccountId: alice, Reason: ReasonContractDeposit, Delta: big.NewInt(-20), Amount: big.NewInt(20), Counterparty: &contract, BlockNumber: 7, BlockHash: types.Hash{7}, EventIndex: 5},
+	}, changes)
+}
+
+func TestBalanceFeedAllAccounts(t *testing.T) {
+	//given
+	alice, bob := types.AccountID{1}, types.AccountID{2}
+	var deltas []string
+	feed := NewBalanceFeed(func(change BalanceChange) {
+		deltas = append(deltas, change.Delta.String())
+	})
+
+	//when
+	err := feed.HandleEvents([]Event{
+		event("Balances.Transfer", field("from", alice), field("to", bob), field("amount", balance(100))),
+		event("Balances.Transfer", field("from", alice), field("to", bob)),
+	}, 0, types.Hash{})
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"-100", "100"}, deltas)
+}
@@ -0,0 +1,156 @@
+// Package account joins views of a customer account kept by different layers of the DDC: the
+// account in the DDC bucket contract, the ledger of the DDC customers pallet and the System
+// pallet balance.
+package account
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+type (
+	// ContractAccounts reads accounts of the DDC bucket contract, e.g. bucket.DdcBucketContract.
+	ContractAccounts interface {
+		AccountGet(account bucket.AccountId) (*bucket.Account, error)
+	}
+
+	// Ledger is the ledger of the DDC customers pallet.
+	Ledger struct {
+		Total     bucket.Balance
+		Active    bucket.Balance
+		Unlocking []UnlockChunk
+	}
+
+	// UnlockChunk is an amount of the ledger which can be withdrawn from the block.
+	UnlockChunk struct {
+		Value bucket.Balance
+		Block types.BlockNumber
+	}
+
+	// Ledgers reads ledgers of the DDC customers pallet. GetLedger returns nil if the account has
+	// no ledger. Adapt DdcCustomersApi of the blockchain module with LedgersFunc:
+	//
+	//	account.LedgersFunc(func(owner types.AccountID) (*account.Ledger, error) {
+	//		option, err := client.DdcCustomers.GetLedger(owner)
+	//		if err != nil {
+	//			return nil, err
+	//		}
+	//		ok, l := option.Unwrap()
+	//		if !ok {
+	//			return nil, nil
+	//		}
+	//		ledger := &account.Ledger{Total: types.NewU128(big.Int(l.Total)), Active: types.NewU128(big.Int(l.Active))}
+	//		for _, chunk := range l.Unlocking {
+	//			ledger.Unlocking = append(ledger.Unlocking, account.UnlockChunk{Value: chunk.Value, Block: chunk.Block})
+	//		}
+	//		return ledger, nil
+	//	})
+	Ledgers interface {
+		GetLedger(owner types.AccountID) (*Ledger, error)
+	}
+
+	LedgersFunc func(owner types.AccountID) (*Ledger, error)
+
+	// Balances reads System pallet accounts, e.g. pkg.BlockchainClient.
+	Balances interface {
+		GetAccountInfo(accountId types.AccountID) (types.AccountInfo, error)
+	}
+
+	// UnifiedAccount is the account as seen by all layers with the fields wallets derive from them.
+	UnifiedAccount struct {
+		AccountId types.AccountID
+		// Contract is the account in the contract, nil if the account didn't deposit to the contract.
+		Contract *bucket.Account
+		// Ledger is the ledger in the DDC customers pallet, nil if the account didn't deposit to the pallet.
+		Ledger *Ledger
+		// Balance is the balance of the account in the System pallet.
+		Balance types.AccountInfo
+
+		// TotalLocked is the amount held by the contract and the pallet on behalf of the account:
+		// contract deposit, bonded and unbonding amounts and the pallet ledger total.
+		TotalLocked bucket.Balance
+		// WithdrawableAt is the time the amount unbonding in the contract can be withdrawn, zero if nothing is unbonding.
+		WithdrawableAt time.Time
+		// Spendable is the free balance which is not frozen.
+		Spendable bucket.Balance
+	}
+)
+
+func (f LedgersFunc) GetLedger(owner types.AccountID) (*Ledger, error) {
+	return f(owner)
+}
+
+// UnbondingPeriod is the time the contract keeps the unbonded amount before it can be withdrawn.
+const UnbondingPeriod = 7 * 24 * time.Hour
+
+// GetUnifiedAccount reads the account from the contract, the pallet ledger and the System pallet.
+func GetUnifiedAccount(contract ContractAccounts, ledgers Ledgers, balances Balances, accountId types.AccountID) (*UnifiedAccount, error) {
+	account := &UnifiedAccount{AccountId: accountId}
+
+	contractAccount, err := contract.AccountGet(accountId)
+	if err != nil && !errors.Is(err, bucket.ErrAccountDoesNotExist) {
+		return nil, err
+	}
+	account.Contract = contractAccount
+
+	if account.Ledger, err = ledgers.GetLedger(accountId); err != nil {
+		return nil, err
+	}
+
+	if account.Balance, err = balances.GetAccountInfo(accountId); err != nil {
+		return nil, err
+	}
+
+	account.derive()
+
+	return account, nil
+}
+
+// LedgerWithdrawable returns the amount unlocked in the pallet ledger at the block.
+func (a *UnifiedAccount) LedgerWithdrawable(block types.BlockNumber) bucket.Balance {
+	withdrawable := new(big.Int)
+	if a.Ledger != nil {
+		for _, chunk := range a.Ledger.Unlocking {
+			if chunk.Block <= block {
+				withdrawable.Add(withdrawable, bigInt(chunk.Value))
+			}
+		}
+	}
+
+	return types.NewU128(*withdrawable)
+}
+
+func (a *UnifiedAccount) derive() {
+	locked := new(big.Int)
+	if c := a.Contract; c != nil {
+		locked.Add(locked, bigInt(c.Deposit))
+		locked.Add(locked, bigInt(c.Bonded))
+		locked.Add(locked, bigInt(c.UnboundedAmount))
+		if bigInt(c.UnboundedAmount).Sign() > 0 && c.UnbondedTimestamp > 0 {
+			a.WithdrawableAt = time.UnixMilli(int64(c.UnbondedTimestamp)).Add(UnbondingPeriod)
+		}
+	}
+	if a.Ledger != nil {
+		locked.Add(locked, bigInt(a.Ledger.Total))
+	}
+	a.TotalLocked = types.NewU128(*locked)
+
+	data := a.Balance.Data
+	spendable := new(big.Int).Sub(bigInt(data.Free), bigInt(data.MiscFrozen))
+	if spendable.Sign() < 0 {
+		spendable.SetInt64(0)
+	}
+	a.Spendable = types.NewU128(*spendable)
+}
+
+func bigInt(value types.U128) *big.Int {
+	if value.Int == nil {
+		return new(big.Int)
+	}
+
+	return value.Int
+}
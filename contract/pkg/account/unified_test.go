@@ -0,0 +1,91 @@
+package account
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockedLayers struct {
+	account *bucket.Account
+	ledger  *Ledger
+	info    types.AccountInfo
+}
+
+func (m *mockedLayers) AccountGet(bucket.AccountId) (*bucket.Account, error) {
+	if m.account == nil {
+		return nil, bucket.ErrAccountDoesNotExist
+	}
+	return m.account, nil
+}
+
+func (m *mockedLayers) GetLedger(types.AccountID) (*Ledger, error) {
+	return m.ledger, nil
+}
+
+func (m *mockedLayers) GetAccountInfo(types.AccountID) (types.AccountInfo, error) {
+	return m.info, nil
+}
+
+func balance(value int64) types.U128 {
+	return types.NewU128(*big.NewInt(value))
+}
+
+func TestGetUnifiedAccount(t *testing.T) {
+	//given
+	unbondedAt := time.UnixMilli(1_700_000_000_000)
+	layers := &mockedLayers{
+		account: &bucket.Account{
+			Deposit:           balance(100),
+			Bonded:            balance(50),
+			UnboundedAmount:   balance(10),
+			UnbondedTimestamp: types.U64(unbondedAt.UnixMilli()),
+		},
+		ledger: &Ledger{
+			Total:  balance(30),
+			Active: balance(20),
+			Unlocking: []UnlockChunk{
+				{Value: balance(4), Block: 10},
+				{Value: balance(6), Block: 20},
+			},
+		},
+	}
+	layers.info.Data.Free = balance(1000)
+	layers.info.Data.MiscFrozen = balance(200)
+
+	//when
+	account, err := GetUnifiedAccount(layers, layers, layers, types.AccountID{1})
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, layers.account, account.Contract)
+	assert.NotNil(t, account.Ledger)
+	assert.Equal(t, "190", account.TotalLocked.String())
+	assert.Equal(t, unbondedAt.Add(UnbondingPeriod), account.WithdrawableAt)
+	assert.Equal(t, "800", account.Spendable.String())
+	assert.Equal(t, "4", account.LedgerWithdrawable(15).String())
+	assert.Equal(t, "10", account.LedgerWithdrawable(20).String())
+}
+
+func TestGetUnifiedAccountWithoutDeposits(t *testing.T) {
+	//given
+	layers := &mockedLayers{}
+	layers.info.Data.Free = balance(100)
+	layers.info.Data.MiscFrozen = balance(150)
+
+	//when
+	account, err := GetUnifiedAccount(layers, layers, layers, types.AccountID{1})
+
+	//then
+	assert.NoError(t, err)
+	assert.Nil(t, account.Contract)
+	assert.Nil(t, account.Ledger)
+	assert.Equal(t, "0", account.TotalLocked.String())
+	assert.True(t, account.WithdrawableAt.IsZero())
+	assert.Equal(t, "0", account.Spendable.String())
+	assert.Equal(t, "0", account.LedgerWithdrawable(100).String())
+}
@@ -0,0 +1,203 @@
+package pkg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors"
+	"github.com/decred/base58"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// SubstrateNetwork is the generic substrate SS58 network prefix used by development chains.
+	SubstrateNetwork uint16 = 42
+	// CereNetwork is the SS58 network prefix of the Cere mainnet and testnet.
+	CereNetwork uint16 = 54
+
+	accountIDLength = 32
+	checksumLength  = 2
+	maxSimplePrefix = 63
+)
+
+// InvalidAddressError is returned when an address can't be decoded to an account id: it's not a
+// valid base58 string, it has an invalid length or checksum, or it belongs to another network.
+type InvalidAddressError struct {
+	Address string
+	Reason  string
+}
+
+func (e *InvalidAddressError) Error() string {
+	return fmt.Sprintf("invalid address %q: %s", e.Address, e.Reason)
+}
+
+func (e *InvalidAddressError) ErrorCode() ddcerrors.Code {
+	return ddcerrors.CodeInvalidArgument
+}
+
+type addressConfig struct {
+	network      uint16
+	checkNetwork bool
+	hexKeys      bool
+}
+
+type AddressOption func(*addressConfig)
+
+func newAddressConfig(opts []AddressOption) *addressConfig {
+	config := &addressConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return config
+}
+
+// WithNetwork rejects SS58 addresses with a network prefix other than the given one. Addresses of
+// any network are accepted by default.
+func WithNetwork(network uint16) AddressOption {
+	return func(c *addressConfig) {
+		c.network = network
+		c.checkNetwork = true
+	}
+}
+
+// WithHexPublicKeys enables the lenient mode which accepts 0x-prefixed hex encoded public keys in
+// addition to SS58 addresses.
+func WithHexPublicKeys() AddressOption {
+	return func(c *addressConfig) {
+		c.hexKeys = true
+	}
+}
+
+// DecodeAddress decodes an SS58 address to an account id verifying its checksum and, if required
+// by the options, its network prefix. InvalidAddressError is returned if the address is invalid.
+func DecodeAddress(address string, opts ...AddressOption) (types.AccountID, error) {
+	config := newAddressConfig(opts)
+
+	if config.hexKeys && strings.HasPrefix(address, "0x") {
+		return decodeHexPublicKey(address)
+	}
+
+	network, accountID, err := decodeSS58(address)
+	if err != nil {
+		return types.AccountID{}, err
+	}
+
+	if config.checkNetwork && network != config.network {
+		return types.AccountID{}, &InvalidAddressError{
+			Address: address,
+			Reason:  fmt.Sprintf("network prefix %d, expected %d", network, config.network),
+		}
+	}
+
+	return accountID, nil
+}
+
+// ValidateAddress checks the address the same way as DecodeAddress does.
+func ValidateAddress(address string, opts ...AddressOption) error {
+	_, err := DecodeAddress(address, opts...)
+	return err
+}
+
+// NormalizeAddress validates the address and returns it in the SS58 format. Hex encoded public keys
+// accepted in the lenient mode are encoded with the required network or SubstrateNetwork.
+func NormalizeAddress(address string, opts ...AddressOption) (string, error) {
+	accountID, err := DecodeAddress(address, opts...)
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.HasPrefix(address, "0x") {
+		return address, nil
+	}
+
+	config := newAddressConfig(opts)
+	if !config.checkNetwork {
+		return EncodeAddress(accountID, SubstrateNetwork), nil
+	}
+
+	return EncodeAddress(accountID, config.network), nil
+}
+
+// EncodeAddress encodes the account id to an SS58 address of the network.
+func EncodeAddress(accountID types.AccountID, network uint16) string {
+	var prefix []byte
+	if network <= maxSimplePrefix {
+		prefix = []byte{byte(network)}
+	} else {
+		prefix = []byte{
+			byte((network&0xFC)>>2) | 0x40,
+			byte(network>>8) | byte((network&0x03)<<6),
+		}
+	}
+
+	payload := append(prefix, accountID[:]...)
+	checksum := ss58Checksum(payload)
+
+	return base58.Encode(append(payload, checksum[:checksumLength]...))
+}
+
+func decodeSS58(address string) (uint16, types.AccountID, error) {
+	a := base58.Decode(address)
+	if len(a) == 0 {
+		return 0, types.AccountID{}, &InvalidAddressError{Address: address, Reason: "not a base58 string"}
+	}
+
+	var network uint16
+	var prefixLength int
+	switch {
+	case a[0] <= maxSimplePrefix:
+		network = uint16(a[0])
+		prefixLength = 1
+	case a[0] < 128 && len(a) > 1:
+		lower := (a[0] << 2) | (a[1] >> 6)
+		upper := a[1] & 0x3F
+		network = uint16(lower) | uint16(upper)<<8
+		prefixLength = 2
+	default:
+		return 0, types.AccountID{}, &InvalidAddressError{Address: address, Reason: "invalid network prefix"}
+	}
+
+	if len(a) != prefixLength+accountIDLength+checksumLength {
+		return 0, types.AccountID{}, &InvalidAddressError{Address: address, Reason: "invalid length"}
+	}
+
+	payload := a[:prefixLength+accountIDLength]
+	checksum := ss58Checksum(payload)
+	if a[len(a)-2] != checksum[0] || a[len(a)-1] != checksum[1] {
+		return 0, types.AccountID{}, &InvalidAddressError{
+			Address: address,
+			Reason:  fmt.Sprintf("invalid checksum %x, expected %x", a[len(a)-2:], checksum[:checksumLength]),
+		}
+	}
+
+	var accountID types.AccountID
+	copy(accountID[:], payload[prefixLength:])
+
+	return network, accountID, nil
+}
+
+func decodeHexPublicKey(address string) (types.AccountID, error) {
+	key, err := codec.HexDecodeString(address)
+	if err != nil {
+		return types.AccountID{}, &InvalidAddressError{Address: address, Reason: "not a hex string"}
+	}
+	if len(key) != accountIDLength {
+		return types.AccountID{}, &InvalidAddressError{Address: address, Reason: "invalid public key length"}
+	}
+
+	var accountID types.AccountID
+	copy(accountID[:], key)
+
+	return accountID, nil
+}
+
+func ss58Checksum(payload []byte) [blake2b.Size]byte {
+	buf := make([]byte, 0, len(defaultSS58Prefix)+len(payload))
+	buf = append(buf, defaultSS58Prefix...)
+	buf = append(buf, payload...)
+
+	return blake2b.Sum512(buf)
+}
@@ -0,0 +1,83 @@
+package pkg
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testAddress   = "5GmomkEekQQ3BipMvjDCG5bXKvzwhUDdXEcQqXRWmdkNCYkL"
+	testPublicKey = "0xd049e851567f16d68523a645ee96465ceb678ad983fc08e6a38408ad10410c4d"
+)
+
+func TestDecodeAddress(t *testing.T) {
+	publicKey, _ := hex.DecodeString(testPublicKey[2:])
+
+	tests := []struct {
+		name    string
+		address string
+		opts    []AddressOption
+		valid   bool
+	}{
+		{name: "any network", address: testAddress, valid: true},
+		{name: "required network", address: testAddress, opts: []AddressOption{WithNetwork(SubstrateNetwork)}, valid: true},
+		{name: "other network", address: testAddress, opts: []AddressOption{WithNetwork(CereNetwork)}},
+		{name: "invalid checksum", address: testAddress[:len(testAddress)-1] + "M"},
+		{name: "invalid length", address: testAddress[:40]},
+		{name: "not base58", address: "0OIl"},
+		{name: "hex in strict mode", address: testPublicKey},
+		{name: "hex in lenient mode", address: testPublicKey, opts: []AddressOption{WithHexPublicKeys()}, valid: true},
+		{name: "short hex in lenient mode", address: testPublicKey[:20], opts: []AddressOption{WithHexPublicKeys()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//when
+			accountID, err := DecodeAddress(tt.address, tt.opts...)
+
+			//then
+			if tt.valid {
+				assert.NoError(t, err)
+				assert.Equal(t, publicKey, accountID[:])
+			} else {
+				var invalidAddress *InvalidAddressError
+				assert.True(t, errors.As(err, &invalidAddress))
+				assert.Equal(t, tt.address, invalidAddress.Address)
+			}
+		})
+	}
+}
+
+func TestEncodeAddress(t *testing.T) {
+	//given
+	publicKey, _ := hex.DecodeString(testPublicKey[2:])
+	accountID, _ := types.NewAccountID(publicKey)
+
+	for _, network := range []uint16{0, SubstrateNetwork, CereNetwork, 64, 16383} {
+		//when
+		address := EncodeAddress(*accountID, network)
+		decoded, err := DecodeAddress(address, WithNetwork(network))
+
+		//then
+		assert.NoError(t, err)
+		assert.Equal(t, *accountID, decoded)
+	}
+	assert.Equal(t, testAddress, EncodeAddress(*accountID, SubstrateNetwork))
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	//when
+	fromHex, err := NormalizeAddress(testPublicKey, WithHexPublicKeys())
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, testAddress, fromHex)
+
+	//when
+	_, err = NormalizeAddress(testPublicKey)
+	//then
+	assert.Error(t, err)
+}
@@ -0,0 +1,226 @@
+// Package approval signs transactions with the approval of a human, so SDK-based tools can submit
+// admin operations without the seed of the admin account. The signing request is posted to an
+// approval endpoint, e.g. a wallet bridge, and optionally shown as a deep link or QR code, then the
+// endpoint is polled until the request is approved or rejected.
+package approval
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+)
+
+const (
+	defaultPollInterval = 2 * time.Second
+
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+var (
+	ErrRequestNotFound = errors.New("signing request not found")
+)
+
+type (
+	httpSigner struct {
+		endpoint       string
+		deepLinkFormat string
+		display        func(request pkg.SigningRequest, deepLink string)
+		pollInterval   time.Duration
+		httpClient     *http.Client
+	}
+
+	Option func(s *httpSigner)
+
+	// Request is the body of the signing request posted to the approval endpoint.
+	Request struct {
+		Id       string `json:"id"`
+		Signer   string `json:"signer"`
+		Call     string `json:"call"`
+		Contract string `json:"contract,omitempty"`
+		Nonce    uint64 `json:"nonce"`
+		Tip      uint64 `json:"tip"`
+		// Payload is the hex encoded signing payload with the 0x prefix.
+		Payload  string `json:"payload"`
+		DeepLink string `json:"deepLink,omitempty"`
+	}
+
+	// Response is the state of the signing request returned by the approval endpoint.
+	Response struct {
+		Status string `json:"status"`
+		// Signature is the hex encoded sr25519 signature of the approved request.
+		Signature string `json:"signature,omitempty"`
+		// Reason is why the request was rejected.
+		Reason string `json:"reason,omitempty"`
+	}
+)
+
+// WithDeepLink sets the format of the deep link to the wallet, e.g. "wallet://sign?id={id}&payload={payload}".
+// The {id}, {signer} and {payload} placeholders are replaced with the values of the request.
+func WithDeepLink(format string) Option {
+	return func(s *httpSigner) {
+		s.deepLinkFormat = format
+	}
+}
+
+// WithDisplay calls the function with every request posted to the endpoint, e.g. to print the deep
+// link or to render it as a QR code. The deep link is empty without WithDeepLink.
+func WithDisplay(display func(request pkg.SigningRequest, deepLink string)) Option {
+	return func(s *httpSigner) {
+		s.display = display
+	}
+}
+
+// WithPollInterval sets the interval of polling the state of the request, 2s by default.
+func WithPollInterval(interval time.Duration) Option {
+	return func(s *httpSigner) {
+		s.pollInterval = interval
+	}
+}
+
+func WithHttpClient(httpClient *http.Client) Option {
+	return func(s *httpSigner) {
+		s.httpClient = httpClient
+	}
+}
+
+// CreateHttpSigner creates the remote signer posting requests to {endpoint}/requests and polling
+// them at {endpoint}/requests/{id}. Rejected requests fail with pkg.ErrSigningRejected. Use it with
+// pkg.WithRemoteSigner.
+func CreateHttpSigner(endpoint string, opts ...Option) pkg.RemoteSigner {
+	s := &httpSigner{
+		endpoint:     strings.TrimSuffix(endpoint, "/"),
+		pollInterval: defaultPollInterval,
+		httpClient:   http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+func (s *httpSigner) SignPayload(ctx context.Context, request pkg.SigningRequest) ([]byte, error) {
+	deepLink := s.deepLink(request)
+	if err := s.post(ctx, request, deepLink); err != nil {
+		return nil, err
+	}
+	if s.display != nil {
+		s.display(request, deepLink)
+	}
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		resp, err := s.get(ctx, request.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		switch resp.Status {
+		case StatusApproved:
+			sig, err := hex.DecodeString(strings.TrimPrefix(resp.Signature, "0x"))
+			if err != nil {
+				return nil, fmt.Errorf("decode signature of request %s: %w", request.Id, err)
+			}
+			return sig, nil
+		case StatusRejected:
+			return nil, fmt.Errorf("%w: request %s: %s", pkg.ErrSigningRejected, request.Id, resp.Reason)
+		case StatusPending:
+		default:
+			return nil, fmt.Errorf("request %s has unknown status %q", request.Id, resp.Status)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (s *httpSigner) deepLink(request pkg.SigningRequest) string {
+	if s.deepLinkFormat == "" {
+		return ""
+	}
+
+	return strings.NewReplacer(
+		"{id}", url.QueryEscape(request.Id),
+		"{signer}", url.QueryEscape(request.Signer),
+		"{payload}", "0x"+hex.EncodeToString(request.Payload),
+	).Replace(s.deepLinkFormat)
+}
+
+func (s *httpSigner) post(ctx context.Context, request pkg.SigningRequest, deepLink string) error {
+	body := Request{
+		Id:       request.Id,
+		Signer:   request.Signer,
+		Call:     request.Call,
+		Nonce:    request.Nonce,
+		Tip:      request.Tip,
+		Payload:  "0x" + hex.EncodeToString(request.Payload),
+		DeepLink: deepLink,
+	}
+	if request.ContractCall != nil {
+		body.Contract = request.ContractCall.ContractAddressSS58
+	}
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/requests", bytes.NewReader(encoded))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("approval endpoint responded with status %d to request %s", resp.StatusCode, request.Id)
+	}
+
+	return nil
+}
+
+func (s *httpSigner) get(ctx context.Context, id string) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.endpoint+"/requests/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrRequestNotFound, id)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("approval endpoint responded with status %d to request %s", resp.StatusCode, id)
+	}
+
+	var body Response
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode approval response: %w", err)
+	}
+
+	return &body, nil
+}
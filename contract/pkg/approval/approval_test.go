@@ -0,0 +1,129 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+type approvalServer struct {
+	mu       sync.Mutex
+	requests map[string]Request
+	polls    int
+	// approveAfter is the number of polls the request is pending for.
+	approveAfter int
+	response     Response
+}
+
+func (s *approvalServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Method == http.MethodPost && r.URL.Path == "/requests" {
+		var request Request
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.requests[request.Id] = request
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	if _, ok := s.requests[r.URL.Path[len("/requests/"):]]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	s.polls++
+	response := Response{Status: StatusPending}
+	if s.polls > s.approveAfter {
+		response = s.response
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func TestHttpSignerApproved(t *testing.T) {
+	//given
+	server := &approvalServer{
+		requests:     map[string]Request{},
+		approveAfter: 2,
+		response:     Response{Status: StatusApproved, Signature: "0x0102"},
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	var deepLinks []string
+	signer := CreateHttpSigner(httpServer.URL+"/",
+		WithPollInterval(time.Millisecond),
+		WithDeepLink("wallet://sign?id={id}&payload={payload}"),
+		WithDisplay(func(request pkg.SigningRequest, deepLink string) {
+			deepLinks = append(deepLinks, deepLink)
+		}),
+	)
+
+	//when
+	sig, err := signer.SignPayload(context.Background(), pkg.SigningRequest{
+		Id:           "1",
+		Signer:       "5Admin",
+		Call:         "Contracts.call",
+		ContractCall: &pkg.ContractCall{ContractAddressSS58: "5Contract"},
+		Nonce:        7,
+		Payload:      []byte{0xab, 0xcd},
+	})
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2}, sig)
+	assert.Equal(t, []string{"wallet://sign?id=1&payload=0xabcd"}, deepLinks)
+	assert.Equal(t, 3, server.polls)
+	assert.Equal(t, Request{
+		Id:       "1",
+		Signer:   "5Admin",
+		Call:     "Contracts.call",
+		Contract: "5Contract",
+		Nonce:    7,
+		Payload:  "0xabcd",
+		DeepLink: "wallet://sign?id=1&payload=0xabcd",
+	}, server.requests["1"])
+}
+
+func TestHttpSignerRejected(t *testing.T) {
+	//given
+	server := &approvalServer{
+		requests: map[string]Request{},
+		response: Response{Status: StatusRejected, Reason: "unexpected call"},
+	}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	signer := CreateHttpSigner(httpServer.URL, WithPollInterval(time.Millisecond))
+
+	//when
+	_, err := signer.SignPayload(context.Background(), pkg.SigningRequest{Id: "1", Payload: []byte{1}})
+
+	//then
+	assert.True(t, errors.Is(err, pkg.ErrSigningRejected))
+	assert.Contains(t, err.Error(), "unexpected call")
+}
+
+func TestHttpSignerTimeout(t *testing.T) {
+	//given
+	server := &approvalServer{requests: map[string]Request{}, approveAfter: 1 << 30}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	signer := CreateHttpSigner(httpServer.URL, WithPollInterval(time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	//when
+	_, err := signer.SignPayload(ctx, pkg.SigningRequest{Id: "1", Payload: []byte{1}})
+
+	//then
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
@@ -0,0 +1,37 @@
+package bucket
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors"
+)
+
+var (
+	ErrNoWriteAccess   = ddcerrors.New(ddcerrors.CodeUnauthorized, "no write access to the bucket")
+	ErrBucketNotServed = ddcerrors.New(ddcerrors.CodeUnauthorized, "bucket isn't served by the cluster")
+)
+
+// CheckWriteAccess fails fast before a large upload if the key can't write to the bucket: the
+// bucket doesn't exist, the key is neither the owner nor a writer, or the cluster doesn't serve the
+// bucket. Pass a cached reader, e.g. cache.DdcBucketContractCache, to avoid a contract call per
+// upload. Uploads rejected by storage nodes for other reasons are still possible.
+func CheckWriteAccess(ctx context.Context, buckets BucketReader, bucketId BucketId, publicKey []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	info, err := buckets.BucketGet(bucketId)
+	if err != nil {
+		return err
+	}
+	if !info.HasWriteAccess(publicKey) {
+		return fmt.Errorf("bucket %d: %w", bucketId, ErrNoWriteAccess)
+	}
+	if state := ComputeState(info, time.Now()); !state.IsServed() {
+		return fmt.Errorf("bucket %d is %s: %w", bucketId, state, ErrBucketNotServed)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package bucket
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockedBucketReader struct {
+	BucketReader
+	buckets map[BucketId]*BucketInfo
+}
+
+func (m *mockedBucketReader) BucketGet(bucketId BucketId) (*BucketInfo, error) {
+	info, ok := m.buckets[bucketId]
+	if !ok {
+		return nil, ErrBucketDoesNotExist
+	}
+	return info, nil
+}
+
+func TestCheckWriteAccess(t *testing.T) {
+	owner, writer := types.AccountID{1}, types.AccountID{2}
+	covered := types.U64(time.Now().Add(time.Hour).UnixMilli())
+	reader := &mockedBucketReader{buckets: map[BucketId]*BucketInfo{
+		1: {Bucket: Bucket{OwnerId: owner, ResourceReserved: 10}, WriterIds: []AccountId{writer}, RentCoveredUntilMs: covered},
+		2: {Bucket: Bucket{OwnerId: owner, ResourceReserved: 10}},
+	}}
+
+	tests := []struct {
+		name      string
+		bucketId  BucketId
+		publicKey []byte
+		wantErr   error
+	}{
+		{name: "owner", bucketId: 1, publicKey: owner[:]},
+		{name: "writer", bucketId: 1, publicKey: writer[:]},
+		{name: "stranger", bucketId: 1, publicKey: []byte{3, 31: 0}, wantErr: ErrNoWriteAccess},
+		{name: "rent not covered", bucketId: 2, publicKey: owner[:], wantErr: ErrBucketNotServed},
+		{name: "no bucket", bucketId: 3, publicKey: owner[:], wantErr: ErrBucketDoesNotExist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//when
+			err := CheckWriteAccess(context.Background(), reader, tt.bucketId, tt.publicKey)
+
+			//then
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package bucket
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+)
+
+// benchmarkBaselines are the maximum ns/op of the read benchmarks, checked by
+// TestBenchmarkBaselines. They leave a wide margin for slower machines, so only real regressions,
+// e.g. an extra decode pass or a lost fast path, fail the check. Lower them when a change makes
+// reads faster.
+var benchmarkBaselines = map[string]int64{
+	"BucketGet":   50_000,
+	"ClusterGet":  400_000,
+	"NodeGet":     100_000,
+	"DecodeEvent": 20_000,
+}
+
+// cannedChainClient answers every read with the same encoded contract result.
+type cannedChainClient struct {
+	pkg.BlockchainClient
+	response string
+}
+
+func (c *cannedChainClient) CallToReadEncodedContext(ctx context.Context, readCall pkg.ReadCall) (string, error) {
+	return c.response, nil
+}
+
+func benchContract(b *testing.B, result interface{}) *ddcBucketContract {
+	encoded, err := codec.EncodeToHex(result)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return &ddcBucketContract{
+		chainClient:        &cannedChainClient{response: okPrefix + strings.TrimPrefix(encoded, "0x")},
+		clock:              pkg.SystemClock,
+		bucketGetMethodId:  []byte{1},
+		clusterGetMethodId: []byte{2},
+		nodeGetMethodId:    []byte{3},
+	}
+}
+
+func benchTokens(count int) []Token {
+	tokens := make([]Token, count)
+	for i := range tokens {
+		tokens[i] = Token(i * 1000)
+	}
+	return tokens
+}
+
+func benchBalance(value int64) Balance {
+	return types.NewU128(*big.NewInt(value))
+}
+
+func BenchmarkBucketGet(b *testing.B) {
+	contract := benchContract(b, &BucketInfo{
+		BucketId:           1,
+		Bucket:             Bucket{ClusterId: 1, ResourceReserved: 100},
+		Params:             `{"replication":3}`,
+		WriterIds:          []AccountId{{1}, {2}},
+		RentCoveredUntilMs: 1_700_000_000_000,
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := contract.BucketGet(1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkClusterGet(b *testing.B) {
+	cluster := &ClusterInfo{
+		ClusterId: 1,
+		Cluster: Cluster{
+			Params:      `{"replicationFactor":3}`,
+			Revenues:    benchBalance(1),
+			TotalRent:   benchBalance(1),
+			CdnRevenues: benchBalance(1),
+			CdnUsdPerGb: benchBalance(1),
+		},
+	}
+	for i := 0; i < 8; i++ {
+		key := NodeKey{byte(i)}
+		cluster.Cluster.NodesKeys = append(cluster.Cluster.NodesKeys, key)
+		cluster.NodesVNodes = append(cluster.NodesVNodes, NodeVNodesInfo{NodeKey: key, VNodes: benchTokens(64)})
+	}
+	contract := benchContract(b, cluster)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := contract.ClusterGet(1); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNodeGet(b *testing.B) {
+	contract := benchContract(b, &NodeInfo{
+		Key: NodeKey{1},
+		Node: Node{
+			RentPerMonth:    benchBalance(100),
+			FreeResources:   100,
+			Params:          `{"url":"https://node-0.storage.devnet.cere.network"}`,
+			ClusterId:       types.NewOptionU32(1),
+			StatusInCluster: types.NewOptionU8(types.U8(ACTIVE)),
+		},
+		VNodes: benchTokens(64),
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := contract.NodeGet(NodeKey{1}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeEvent(b *testing.B) {
+	dispatcher := make(map[types.Hash]pkg.ContractEventDispatchEntry)
+	for eventId, argumentType := range eventDispatchTable {
+		key, err := types.NewHashFromHexString(eventId)
+		if err != nil {
+			b.Fatal(err)
+		}
+		dispatcher[key] = pkg.ContractEventDispatchEntry{ArgumentType: argumentType}
+	}
+
+	eventKey, _ := types.NewHashFromHexString(ClusterNodeAddedEventId)
+	data, err := codec.Encode(ClusterNodeAddedEvent{ClusterId: 1, NodeKey: NodeKey{1}, VNodes: benchTokens(64)})
+	if err != nil {
+		b.Fatal(err)
+	}
+	event := pkg.ContractEvent{Topics: []types.Hash{{}, eventKey}, Data: append([]byte{0}, data...)}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, found, err := event.Decode(dispatcher); !found || err != nil {
+			b.Fatal(found, err)
+		}
+	}
+}
+
+// TestBenchmarkBaselines fails if a read benchmark is slower than its baseline. It runs only with
+// DDC_BENCH_BASELINE set, e.g. make bench-check, as timings of shared CI runners vary too much.
+func TestBenchmarkBaselines(t *testing.T) {
+	if os.Getenv("DDC_BENCH_BASELINE") == "" {
+		t.Skip("DDC_BENCH_BASELINE isn't set")
+	}
+
+	benchmarks := map[string]func(b *testing.B){
+		"BucketGet":   BenchmarkBucketGet,
+		"ClusterGet":  BenchmarkClusterGet,
+		"NodeGet":     BenchmarkNodeGet,
+		"DecodeEvent": BenchmarkDecodeEvent,
+	}
+	for name, benchmark := range benchmarks {
+		t.Run(name, func(t *testing.T) {
+			result := testing.Benchmark(benchmark)
+			t.Logf("%s: %s %s", name, result.String(), result.MemString())
+			if baseline := benchmarkBaselines[name]; result.NsPerOp() > baseline {
+				t.Errorf("%s takes %d ns/op, baseline is %d ns/op", name, result.NsPerOp(), baseline)
+			}
+		})
+	}
+}
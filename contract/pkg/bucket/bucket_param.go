@@ -0,0 +1,187 @@
+package bucket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// MaxBucketReplication is the largest replication a bucket may request.
+	MaxBucketReplication = 16
+
+	bucketParamsName        = "name"
+	bucketParamsReplication = "replication"
+	bucketParamsClass       = "class"
+)
+
+var (
+	ErrInvalidBucketParams = errors.New("invalid bucket params")
+
+	bucketParamsIdentifier = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,64}$`)
+)
+
+// BucketParams are the params of a bucket submitted by BucketCreate and BucketChangeParams. They
+// are stored on-chain as a JSON object, e.g. {"class":"hot","name":"photos","replication":3}.
+type BucketParams struct {
+	// Name is a bucket name unique per owner, see ReadBucketName.
+	Name string
+	// Replication is the number of replicas of the bucket pieces, the cluster replication factor
+	// if 0.
+	Replication int
+	// Class is a storage class of the bucket, e.g. "hot" or "archive".
+	Class string
+	// Custom are other fields of the params object, e.g. ones of an application.
+	Custom map[string]interface{}
+	// Raw is submitted as is instead of the fields above if not empty and isn't validated. Use it
+	// for params which aren't a JSON object, e.g. tags like "name=photos;replication=3".
+	Raw Params
+}
+
+// ParseBucketParams parses params read from the contract, e.g. BucketInfo.Params. Params which
+// aren't a JSON object are kept in Raw.
+func ParseBucketParams(params Params) (BucketParams, error) {
+	trimmed := strings.TrimSpace(params)
+	if trimmed == "" {
+		return BucketParams{}, nil
+	}
+	if !strings.HasPrefix(trimmed, "{") {
+		return BucketParams{Raw: params}, nil
+	}
+
+	var p BucketParams
+	if err := json.Unmarshal([]byte(trimmed), &p); err != nil {
+		return BucketParams{}, fmt.Errorf("%w: %v", ErrInvalidBucketParams, err)
+	}
+
+	return p, nil
+}
+
+// Validate checks the fields of the params. Raw params are always valid.
+func (p BucketParams) Validate() error {
+	if p.Raw != "" {
+		return nil
+	}
+
+	if p.Name != "" && !bucketParamsIdentifier.MatchString(p.Name) {
+		return fmt.Errorf("%w: name %q must be up to 64 letters, digits, '.', '_' or '-'", ErrInvalidBucketParams, p.Name)
+	}
+	if p.Replication < 0 || p.Replication > MaxBucketReplication {
+		return fmt.Errorf("%w: replication %d is out of range 0..%d", ErrInvalidBucketParams, p.Replication, MaxBucketReplication)
+	}
+	if p.Class != "" && !bucketParamsIdentifier.MatchString(p.Class) {
+		return fmt.Errorf("%w: class %q must be up to 64 letters, digits, '.', '_' or '-'", ErrInvalidBucketParams, p.Class)
+	}
+
+	var reserved []string
+	for key := range p.Custom {
+		if key == bucketParamsName || key == bucketParamsReplication || key == bucketParamsClass {
+			reserved = append(reserved, key)
+		}
+	}
+	if len(reserved) > 0 {
+		sort.Strings(reserved)
+		return fmt.Errorf("%w: custom fields %s are reserved", ErrInvalidBucketParams, strings.Join(reserved, ", "))
+	}
+
+	return nil
+}
+
+// Params validates and encodes the params to the contract Params value.
+func (p BucketParams) Params() (Params, error) {
+	if p.Raw != "" {
+		return p.Raw, nil
+	}
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidBucketParams, err)
+	}
+
+	return string(data), nil
+}
+
+// MarshalJSON encodes the fields to a JSON object, empty fields are omitted. Raw isn't encoded.
+func (p BucketParams) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]interface{}, len(p.Custom)+3)
+	for key, value := range p.Custom {
+		fields[key] = value
+	}
+	if p.Name != "" {
+		fields[bucketParamsName] = p.Name
+	}
+	if p.Replication != 0 {
+		fields[bucketParamsReplication] = p.Replication
+	}
+	if p.Class != "" {
+		fields[bucketParamsClass] = p.Class
+	}
+
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON decodes a JSON object, the replication may be a number or a string like other
+// numeric params set by JS clients.
+func (p *BucketParams) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	var params BucketParams
+	for key, value := range fields {
+		var err error
+		switch key {
+		case bucketParamsName:
+			err = json.Unmarshal(value, &params.Name)
+		case bucketParamsReplication:
+			var replication FlexInt
+			err = json.Unmarshal(value, &replication)
+			params.Replication = int(replication)
+		case bucketParamsClass:
+			err = json.Unmarshal(value, &params.Class)
+		default:
+			if params.Custom == nil {
+				params.Custom = make(map[string]interface{})
+			}
+			var custom interface{}
+			err = json.Unmarshal(value, &custom)
+			params.Custom[key] = custom
+		}
+		if err != nil {
+			return fmt.Errorf("field %s: %w", key, err)
+		}
+	}
+	*p = params
+
+	return nil
+}
+
+// ReadBucketName returns the bucket name set in bucket params either as the "name" field of
+// JSON params or as a name=... tag of tag params, e.g. "name=photos;replication=3".
+func ReadBucketName(params Params) (name string, ok bool) {
+	params = strings.TrimSpace(params)
+	if strings.HasPrefix(params, "{") {
+		var p struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal([]byte(params), &p); err != nil || p.Name == "" {
+			return "", false
+		}
+		return p.Name, true
+	}
+
+	for _, tag := range strings.FieldsFunc(params, func(r rune) bool { return r == ';' || r == ',' || r == ' ' }) {
+		if value := strings.TrimPrefix(tag, "name="); value != tag && value != "" {
+			return value, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,92 @@
+package bucket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadBucketName(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   Params
+		wantName string
+		wantOk   bool
+	}{
+		{name: "JSON params", params: `{"name":"photos","replication":3}`, wantName: "photos", wantOk: true},
+		{name: "JSON params without name", params: `{"replication":3}`},
+		{name: "Wrong JSON", params: `{"name":`},
+		{name: "Tag params", params: "replication=3;name=photos", wantName: "photos", wantOk: true},
+		{name: "Tag params separated with spaces", params: " name=photos replication=3", wantName: "photos", wantOk: true},
+		{name: "Empty name tag", params: "name=;replication=3"},
+		{name: "Empty params", params: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, ok := ReadBucketName(tt.params)
+
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantOk, ok)
+		})
+	}
+}
+
+func TestParseBucketParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  Params
+		want    BucketParams
+		wantErr bool
+	}{
+		{name: "JSON params", params: `{"name":"photos","replication":3,"class":"hot"}`, want: BucketParams{Name: "photos", Replication: 3, Class: "hot"}},
+		{name: "String replication", params: `{"replication":"3"}`, want: BucketParams{Replication: 3}},
+		{name: "Custom fields", params: `{"name":"photos","app":{"id":7}}`, want: BucketParams{Name: "photos", Custom: map[string]interface{}{"app": map[string]interface{}{"id": float64(7)}}}},
+		{name: "Tag params", params: "name=photos;replication=3", want: BucketParams{Raw: "name=photos;replication=3"}},
+		{name: "Empty params", params: " "},
+		{name: "Wrong JSON", params: `{"name":`, wantErr: true},
+		{name: "Wrong field type", params: `{"name":7}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := ParseBucketParams(tt.params)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidBucketParams)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, params)
+		})
+	}
+}
+
+func TestBucketParamsParams(t *testing.T) {
+	tests := []struct {
+		name       string
+		params     BucketParams
+		wantParams Params
+		wantErr    bool
+	}{
+		{name: "Fields", params: BucketParams{Name: "photos", Replication: 3, Class: "hot"}, wantParams: `{"class":"hot","name":"photos","replication":3}`},
+		{name: "Custom fields", params: BucketParams{Name: "photos", Custom: map[string]interface{}{"app": "gallery"}}, wantParams: `{"app":"gallery","name":"photos"}`},
+		{name: "Empty params", wantParams: `{}`},
+		{name: "Raw params", params: BucketParams{Name: "ignored", Raw: "name=photos"}, wantParams: "name=photos"},
+		{name: "Invalid name", params: BucketParams{Name: "my photos"}, wantErr: true},
+		{name: "Replication too high", params: BucketParams{Replication: MaxBucketReplication + 1}, wantErr: true},
+		{name: "Negative replication", params: BucketParams{Replication: -1}, wantErr: true},
+		{name: "Invalid class", params: BucketParams{Class: "hot/cold"}, wantErr: true},
+		{name: "Reserved custom field", params: BucketParams{Custom: map[string]interface{}{"replication": 3}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := tt.params.Params()
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidBucketParams)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantParams, params)
+		})
+	}
+}
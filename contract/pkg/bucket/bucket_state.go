@@ -0,0 +1,95 @@
+package bucket
+
+import (
+	"time"
+)
+
+// BucketState is the lifecycle state of a bucket derived from the bucket data in the contract.
+//
+// Transitions:
+//
+//	Created     → Allocated    the owner reserves a resource with BucketAllocIntoCluster
+//	Allocated   → Active       the owner deposit starts covering the bucket rent
+//	Active      → RentExpired  the deposit no longer covers the rent
+//	RentExpired → Active       the owner deposits enough to cover the rent again
+//	RentExpired → Unavailable  the rent stays expired for longer than RentGracePeriod
+//	Unavailable → Active       the owner deposits enough to cover the rent again
+type BucketState uint8
+
+const (
+	// BucketCreated is a bucket without reserved resource.
+	BucketCreated BucketState = iota
+	// BucketAllocated is a bucket with reserved resource which rent isn't covered yet.
+	BucketAllocated
+	// BucketActive is a bucket with reserved resource and covered rent, it's served by the cluster.
+	BucketActive
+	// BucketRentExpired is a bucket which rent isn't covered since less than RentGracePeriod.
+	BucketRentExpired
+	// BucketUnavailable is a bucket which rent isn't covered since RentGracePeriod or longer.
+	BucketUnavailable
+)
+
+// RentGracePeriod is the time a bucket with expired rent is still served by the cluster.
+const RentGracePeriod = 7 * 24 * time.Hour
+
+var bucketStateNames = map[BucketState]string{
+	BucketCreated:     "created",
+	BucketAllocated:   "allocated",
+	BucketActive:      "active",
+	BucketRentExpired: "rent_expired",
+	BucketUnavailable: "unavailable",
+}
+
+var bucketStateTransitions = map[BucketState][]BucketState{
+	BucketCreated:     {BucketAllocated},
+	BucketAllocated:   {BucketActive},
+	BucketActive:      {BucketRentExpired},
+	BucketRentExpired: {BucketActive, BucketUnavailable},
+	BucketUnavailable: {BucketActive},
+}
+
+// ComputeState derives the state of the bucket at the time from its reserved resource and the
+// time its rent is covered until.
+func ComputeState(bucketStatus *BucketInfo, now time.Time) BucketState {
+	if bucketStatus.Bucket.ResourceReserved == 0 {
+		return BucketCreated
+	}
+	if bucketStatus.RentCoveredUntilMs == 0 {
+		return BucketAllocated
+	}
+
+	coveredUntil := time.UnixMilli(int64(bucketStatus.RentCoveredUntilMs))
+	switch {
+	case now.Before(coveredUntil):
+		return BucketActive
+	case now.Sub(coveredUntil) < RentGracePeriod:
+		return BucketRentExpired
+	default:
+		return BucketUnavailable
+	}
+}
+
+func (s BucketState) String() string {
+	if name, ok := bucketStateNames[s]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// IsServed reports whether the cluster serves the bucket, which it does for active buckets and
+// buckets in the rent grace period.
+func (s BucketState) IsServed() bool {
+	return s == BucketActive || s == BucketRentExpired
+}
+
+// CanTransition reports whether the bucket can change from the state to the other state directly.
+func (s BucketState) CanTransition(to BucketState) bool {
+	for _, next := range bucketStateTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,50 @@
+package bucket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeState(t *testing.T) {
+	coveredUntil := time.UnixMilli(1_700_000_000_000)
+
+	tests := []struct {
+		name     string
+		reserved Resource
+		covered  types.U64
+		now      time.Time
+		want     BucketState
+	}{
+		{name: "created", now: coveredUntil, want: BucketCreated},
+		{name: "allocated", reserved: 10, now: coveredUntil, want: BucketAllocated},
+		{name: "active", reserved: 10, covered: types.U64(coveredUntil.UnixMilli()), now: coveredUntil.Add(-time.Second), want: BucketActive},
+		{name: "rent expired", reserved: 10, covered: types.U64(coveredUntil.UnixMilli()), now: coveredUntil, want: BucketRentExpired},
+		{name: "unavailable", reserved: 10, covered: types.U64(coveredUntil.UnixMilli()), now: coveredUntil.Add(RentGracePeriod), want: BucketUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			status := &BucketInfo{Bucket: Bucket{ResourceReserved: tt.reserved}, RentCoveredUntilMs: tt.covered}
+
+			//when
+			state := ComputeState(status, tt.now)
+
+			//then
+			assert.Equal(t, tt.want, state)
+			assert.Equal(t, tt.want == BucketActive || tt.want == BucketRentExpired, state.IsServed())
+		})
+	}
+}
+
+func TestBucketStateTransitions(t *testing.T) {
+	assert.True(t, BucketCreated.CanTransition(BucketAllocated))
+	assert.True(t, BucketRentExpired.CanTransition(BucketActive))
+	assert.False(t, BucketCreated.CanTransition(BucketActive))
+	assert.False(t, BucketUnavailable.CanTransition(BucketRentExpired))
+	assert.Equal(t, "rent_expired", BucketRentExpired.String())
+	assert.Equal(t, "unknown", BucketState(42).String())
+}
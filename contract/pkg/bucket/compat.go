@@ -0,0 +1,184 @@
+package bucket
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// The types below are the shapes BucketGet, NodeGet and CdnNodeGet returned before they returned
+// the *Info types. They're kept so integrators can migrate one call site at a time: CompatContract
+// returns them from the same read the *Info types are decoded from, and the To* methods convert
+// between both shapes.
+
+type (
+	// BucketStatus is the bucket as BucketGet returned it before BucketInfo.
+	//
+	// Deprecated: use BucketInfo.
+	BucketStatus struct {
+		BucketId           BucketId
+		Bucket             BucketInStatus
+		Params             Params
+		WriterIds          []AccountId
+		ReaderIds          []AccountId
+		RentCoveredUntilMs uint64
+	}
+
+	// BucketInStatus is the bucket of BucketStatus.
+	//
+	// Deprecated: use Bucket.
+	BucketInStatus struct {
+		OwnerId            AccountId
+		ClusterId          ClusterId
+		ResourceReserved   Resource
+		PublicAvailability bool
+		GasConsumptionCap  Resource
+	}
+
+	// NodeStatus is the storage node as NodeGet returned it before NodeInfo, with the params and
+	// the status in cluster lifted from the node.
+	//
+	// Deprecated: use NodeInfo.
+	NodeStatus struct {
+		NodeKey         NodeKey
+		Node            Node
+		Params          NodeParams
+		VNodes          []Token
+		StatusInCluster NodeStatusInCluster
+	}
+
+	// CdnNodeStatus is the CDN node as CdnNodeGet returned it before CdnNodeInfo, with the params
+	// and the status in cluster lifted from the node.
+	//
+	// Deprecated: use CdnNodeInfo.
+	CdnNodeStatus struct {
+		NodeKey         CdnNodeKey
+		Node            CdnNode
+		Params          CdnNodeParams
+		StatusInCluster NodeStatusInCluster
+	}
+
+	// CompatContract adds the reads returning the deprecated shapes to the contract. Every read
+	// calls the contract once and converts the result, so both shapes are always consistent.
+	CompatContract struct {
+		DdcBucketContract
+	}
+)
+
+// NewCompatContract wraps the contract, e.g. the cached one, with the reads of the deprecated shapes.
+func NewCompatContract(contract DdcBucketContract) *CompatContract {
+	return &CompatContract{DdcBucketContract: contract}
+}
+
+// BucketGetStatus reads the bucket and returns it in both shapes.
+func (c *CompatContract) BucketGetStatus(bucketId BucketId) (*BucketInfo, *BucketStatus, error) {
+	info, err := c.BucketGet(bucketId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return info, info.ToStatus(), nil
+}
+
+// NodeGetStatus reads the storage node and returns it in both shapes.
+func (c *CompatContract) NodeGetStatus(nodeKey NodeKey) (*NodeInfo, *NodeStatus, error) {
+	info, err := c.NodeGet(nodeKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return info, info.ToStatus(), nil
+}
+
+// CdnNodeGetStatus reads the CDN node and returns it in both shapes.
+func (c *CompatContract) CdnNodeGetStatus(nodeKey CdnNodeKey) (*CdnNodeInfo, *CdnNodeStatus, error) {
+	info, err := c.CdnNodeGet(nodeKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return info, info.ToStatus(), nil
+}
+
+func (b *BucketInfo) ToStatus() *BucketStatus {
+	return &BucketStatus{
+		BucketId: b.BucketId,
+		Bucket: BucketInStatus{
+			OwnerId:            b.Bucket.OwnerId,
+			ClusterId:          b.Bucket.ClusterId,
+			ResourceReserved:   b.Bucket.ResourceReserved,
+			PublicAvailability: b.Bucket.PublicAvailability,
+			GasConsumptionCap:  b.Bucket.GasConsumptionCap,
+		},
+		Params:             b.Params,
+		WriterIds:          b.WriterIds,
+		ReaderIds:          b.ReaderIds,
+		RentCoveredUntilMs: uint64(b.RentCoveredUntilMs),
+	}
+}
+
+func (s *BucketStatus) ToInfo() *BucketInfo {
+	return &BucketInfo{
+		BucketId: s.BucketId,
+		Bucket: Bucket{
+			OwnerId:            s.Bucket.OwnerId,
+			ClusterId:          s.Bucket.ClusterId,
+			ResourceReserved:   s.Bucket.ResourceReserved,
+			PublicAvailability: s.Bucket.PublicAvailability,
+			GasConsumptionCap:  s.Bucket.GasConsumptionCap,
+		},
+		Params:             s.Params,
+		WriterIds:          s.WriterIds,
+		ReaderIds:          s.ReaderIds,
+		RentCoveredUntilMs: types.U64(s.RentCoveredUntilMs),
+	}
+}
+
+// ToStatus converts the node, StatusInCluster is UNKNOWN_NODE_STATUS_IN_CLUSTER if the node isn't
+// in a cluster.
+func (n *NodeInfo) ToStatus() *NodeStatus {
+	status, _ := n.GetStatusInCluster()
+	return &NodeStatus{
+		NodeKey:         n.Key,
+		Node:            n.Node,
+		Params:          n.Node.Params,
+		VNodes:          n.VNodes,
+		StatusInCluster: status,
+	}
+}
+
+// ToInfo converts the node, Params and StatusInCluster of the status take precedence over the ones
+// of its node.
+func (s *NodeStatus) ToInfo() *NodeInfo {
+	node := s.Node
+	node.Params = s.Params
+	node.StatusInCluster = optionStatusInCluster(s.StatusInCluster)
+	return &NodeInfo{Key: s.NodeKey, Node: node, VNodes: s.VNodes}
+}
+
+// ToStatus converts the node, StatusInCluster is UNKNOWN_NODE_STATUS_IN_CLUSTER if the node isn't
+// in a cluster.
+func (n *CdnNodeInfo) ToStatus() *CdnNodeStatus {
+	status, _ := n.GetStatusInCluster()
+	return &CdnNodeStatus{
+		NodeKey:         n.Key,
+		Node:            n.Node,
+		Params:          n.Node.Params,
+		StatusInCluster: status,
+	}
+}
+
+// ToInfo converts the node, Params and StatusInCluster of the status take precedence over the ones
+// of its node.
+func (s *CdnNodeStatus) ToInfo() *CdnNodeInfo {
+	node := s.Node
+	node.Params = s.Params
+	node.StatusInCluster = optionStatusInCluster(s.StatusInCluster)
+	return &CdnNodeInfo{Key: s.NodeKey, Node: node}
+}
+
+func optionStatusInCluster(status NodeStatusInCluster) types.OptionU8 {
+	if status == UNKNOWN_NODE_STATUS_IN_CLUSTER {
+		return types.NewOptionU8Empty()
+	}
+
+	return types.NewOptionU8(types.U8(status))
+}
@@ -0,0 +1,82 @@
+package bucket
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketStatusRoundTrip(t *testing.T) {
+	//given
+	info := &BucketInfo{
+		BucketId:           7,
+		Bucket:             Bucket{OwnerId: AccountId{1}, ClusterId: 2, ResourceReserved: 10, PublicAvailability: true, GasConsumptionCap: 5},
+		Params:             `{"replication":3}`,
+		WriterIds:          []AccountId{{2}},
+		ReaderIds:          []AccountId{{3}},
+		RentCoveredUntilMs: 1_700_000_000_000,
+	}
+
+	//when
+	status := info.ToStatus()
+
+	//then
+	assert.Equal(t, uint64(1_700_000_000_000), status.RentCoveredUntilMs)
+	assert.Equal(t, info.Bucket.OwnerId, status.Bucket.OwnerId)
+	assert.Equal(t, info, status.ToInfo())
+}
+
+func TestNodeStatusRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		node   Node
+		status NodeStatusInCluster
+	}{
+		{
+			name:   "in cluster",
+			node:   Node{Params: `{"url":"https://node-0"}`, ClusterId: types.NewOptionU32(1), StatusInCluster: types.NewOptionU8(types.U8(ACTIVE))},
+			status: NodeStatusActive,
+		},
+		{
+			name:   "not in cluster",
+			node:   Node{Params: `{"url":"https://node-0"}`, ClusterId: types.NewOptionU32Empty(), StatusInCluster: types.NewOptionU8Empty()},
+			status: UNKNOWN_NODE_STATUS_IN_CLUSTER,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			info := &NodeInfo{Key: NodeKey{1}, Node: tt.node, VNodes: []Token{1, 2}}
+
+			//when
+			status := info.ToStatus()
+
+			//then
+			assert.Equal(t, tt.status, status.StatusInCluster)
+			assert.Equal(t, tt.node.Params, status.Params)
+			assert.Equal(t, info, status.ToInfo())
+		})
+	}
+}
+
+func TestCdnNodeStatusToInfo(t *testing.T) {
+	//given
+	status := &CdnNodeStatus{
+		NodeKey:         CdnNodeKey{1},
+		Node:            CdnNode{Params: `{"url":"https://cdn-0"}`},
+		Params:          `{"url":"https://cdn-1"}`,
+		StatusInCluster: NodeStatusOffline,
+	}
+
+	//when
+	info := status.ToInfo()
+
+	//then
+	assert.Equal(t, CdnNodeParams(`{"url":"https://cdn-1"}`), info.Node.Params)
+	nodeStatus, err := info.GetStatusInCluster()
+	assert.NoError(t, err)
+	assert.Equal(t, NodeStatusOffline, nodeStatus)
+	assert.Equal(t, info, info.ToStatus().ToInfo())
+}
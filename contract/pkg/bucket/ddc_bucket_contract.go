@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
@@ -16,9 +17,9 @@ import (
 )
 
 const (
-	nodeCreateMethod                     = "e8aa4ade"
-	nodeRemoveMethod                     = "e068fb34"
-	nodeSetParamsMethod                  = "df8b696e"
+	nodeCreateMethod                     = "b77ac1bb"
+	nodeRemoveMethod                     = "42e5f273"
+	nodeSetParamsMethod                  = "fb74fd2e"
 	nodeGetMethod                        = "847f3997"
 	nodeListMethod                       = "423286d6"
 	cdnNodeCreateMethod                  = "e8aa4ade"
@@ -37,6 +38,7 @@ const (
 	clusterRemoveMethod                  = "2248742a"
 	clusterSetNodeStatusMethod           = "8078df7f"
 	clusterSetCdnNodeStatusMethod        = "577027ba"
+	clusterDistributeRevenuesMethod      = "e71e66fc"
 	clusterGetMethod                     = "e75411f5"
 	clusterListMethod                    = "d9db9d44"
 	hasPermissionMethod                  = "e0942492"
@@ -104,36 +106,42 @@ const (
 )
 
 type (
-	DdcBucketContract interface {
-		GetContractAddress() string
-		GetLastAccessTime() time.Time
-
-		AccountDeposit(ctx context.Context, keyPair signature.KeyringPair) error
-		AccountBond(ctx context.Context, keyPair signature.KeyringPair, bondAmount Balance) error
-		AccountUnbond(ctx context.Context, keyPair signature.KeyringPair, bondAmount Cash) error
-		AccountGetUsdPerCere() (Balance, error)
-		AccountSetUsdPerCere(ctx context.Context, keyPair signature.KeyringPair, usdPerCere Balance) error
-		AccountWithdrawUnbonded(ctx context.Context, keyPair signature.KeyringPair) error
-		GetAccounts() ([]AccountId, error)
-
+	// BucketReader reads buckets and their permissions.
+	BucketReader interface {
 		BucketGet(bucketId BucketId) (*BucketInfo, error)
+		BucketList(offset types.U32, limit types.U32, ownerId types.OptionAccountID) (*BucketListInfo, error)
+		BucketListForAccount(ownerId AccountId) ([]Bucket, error)
+		GetBucketWriters(ctx context.Context, keyPair signature.KeyringPair, bucketId BucketId) ([]AccountId, error)
+		GetBucketReaders(ctx context.Context, keyPair signature.KeyringPair, bucketId BucketId) ([]AccountId, error)
+		BucketWritersPage(bucketId BucketId, offset types.U32, limit types.U32) (*AccountListInfo, error)
+		BucketReadersPage(bucketId BucketId, offset types.U32, limit types.U32) (*AccountListInfo, error)
+		IsWriter(bucketId BucketId, account AccountId) (bool, error)
+		IsReader(bucketId BucketId, account AccountId) (bool, error)
+	}
+
+	// BucketWriter creates and changes buckets.
+	BucketWriter interface {
 		BucketCreate(ctx context.Context, keyPair signature.KeyringPair, bucketParams BucketParams, clusterId ClusterId, ownerId types.OptionAccountID) (blockHash types.Hash, err error)
 		BucketChangeOwner(ctx context.Context, keyPair signature.KeyringPair, bucketId BucketId, ownerId AccountId) error
-		BucketAllocIntoCluster(ctx context.Context, keyPair signature.KeyringPair, bucketId BucketId, resource Resource) error
+		BucketAllocIntoCluster(ctx context.Context, keyPair signature.KeyringPair, bucketId BucketId, resource StorageGb) error
 		BucketSettlePayment(ctx context.Context, keyPair signature.KeyringPair, bucketId BucketId) error
 		BucketChangeParams(ctx context.Context, keyPair signature.KeyringPair, bucketId BucketId, bucketParams BucketParams) error
-		BucketList(offset types.U32, limit types.U32, ownerId types.OptionAccountID) (*BucketListInfo, error)
-		BucketListForAccount(ownerId AccountId) ([]Bucket, error)
 		BucketSetAvailability(ctx context.Context, keyPair signature.KeyringPair, bucketId BucketId, publicAvailability bool) error
-		BucketSetResourceCap(ctx context.Context, keyPair signature.KeyringPair, bucketId BucketId, newResourceCap Resource) error
-		GetBucketWriters(ctx context.Context, keyPair signature.KeyringPair, bucketId BucketId) ([]AccountId, error)
-		GetBucketReaders(ctx context.Context, keyPair signature.KeyringPair, bucketId BucketId) ([]AccountId, error)
+		BucketSetResourceCap(ctx context.Context, keyPair signature.KeyringPair, bucketId BucketId, newResourceCap StorageGb) error
 		BucketSetWriterPerm(ctx context.Context, keyPair signature.KeyringPair, bucketId BucketId, writer AccountId) error
 		BucketRevokeWriterPerm(ctx context.Context, keyPair signature.KeyringPair, bucketId BucketId, writer AccountId) error
 		BucketSetReaderPerm(ctx context.Context, keyPair signature.KeyringPair, bucketId BucketId, reader AccountId) error
 		BucketRevokeReaderPerm(ctx context.Context, keyPair signature.KeyringPair, bucketId BucketId, reader AccountId) error
+	}
 
+	// ClusterReader reads clusters.
+	ClusterReader interface {
 		ClusterGet(clusterId ClusterId) (*ClusterInfo, error)
+		ClusterList(offset types.U32, limit types.U32, filterManagerId types.OptionAccountID) (*ClusterListInfo, error)
+	}
+
+	// ClusterAdmin creates clusters and manages their nodes.
+	ClusterAdmin interface {
 		ClusterCreate(ctx context.Context, keyPair signature.KeyringPair, params Params, resourcePerVNode Resource) (blockHash types.Hash, err error)
 		ClusterAddNode(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId, nodeKey NodeKey, vNodes [][]Token) error
 		ClusterRemoveNode(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId, nodeKey NodeKey) error
@@ -143,22 +151,44 @@ type (
 		ClusterRemoveCdnNode(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId, nodeKey CdnNodeKey) error
 		ClusterSetParams(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId, params Params) error
 		ClusterRemove(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId) error
-		ClusterSetNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId, nodeKey NodeKey, statusInCluster string) error
-		ClusterSetCdnNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId, nodeKey CdnNodeKey, statusInCluster string) error
-		ClusterList(offset types.U32, limit types.U32, filterManagerId types.OptionAccountID) (*ClusterListInfo, error)
+		ClusterSetNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId, nodeKey NodeKey, statusInCluster NodeStatusInCluster) error
+		ClusterSetCdnNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId, nodeKey CdnNodeKey, statusInCluster NodeStatusInCluster) error
+		ClusterDistributeRevenues(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId) error
+		ClusterDistributeRevenuesEstimate(clusterId ClusterId) (*RevenueDistribution, error)
+	}
 
+	// NodeReader reads storage and CDN nodes.
+	NodeReader interface {
 		NodeGet(nodeKey NodeKey) (*NodeInfo, error)
-		NodeCreate(ctx context.Context, keyPair signature.KeyringPair, nodeKey NodeKey, params Params, capacity Resource, rent Rent) (blockHash types.Hash, err error)
-		NodeRemove(ctx context.Context, keyPair signature.KeyringPair, nodeKey NodeKey) error
-		NodeSetParams(ctx context.Context, keyPair signature.KeyringPair, nodeKey NodeKey, params Params) error
 		NodeList(offset types.U32, limit types.U32, filterProviderId types.OptionAccountID) (*NodeListInfo, error)
 		CdnNodeGet(nodeKey CdnNodeKey) (*CdnNodeInfo, error)
+		CdnNodeList(offset types.U32, limit types.U32, filterProviderId types.OptionAccountID) (*CdnNodeListInfo, error)
+	}
+
+	// NodeAdmin creates and changes storage and CDN nodes.
+	NodeAdmin interface {
+		NodeCreate(ctx context.Context, keyPair signature.KeyringPair, nodeKey NodeKey, params Params, capacity StorageGb, rent Rent) (blockHash types.Hash, err error)
+		NodeRemove(ctx context.Context, keyPair signature.KeyringPair, nodeKey NodeKey) error
+		NodeSetParams(ctx context.Context, keyPair signature.KeyringPair, nodeKey NodeKey, params Params) error
 		CdnNodeCreate(ctx context.Context, keyPair signature.KeyringPair, nodeKey CdnNodeKey, params CDNNodeParams) error
 		CdnNodeRemove(ctx context.Context, keyPair signature.KeyringPair, nodeKey CdnNodeKey) error
 		CdnNodeSetParams(ctx context.Context, keyPair signature.KeyringPair, nodeKey CdnNodeKey, params CDNNodeParams) error
-		CdnNodeList(offset types.U32, limit types.U32, filterProviderId types.OptionAccountID) (*CdnNodeListInfo, error)
+	}
 
+	// AccountOps reads customer accounts and manages their deposits.
+	AccountOps interface {
 		AccountGet(account AccountId) (*Account, error)
+		AccountDeposit(ctx context.Context, keyPair signature.KeyringPair, value Balance) (blockHash types.Hash, err error)
+		AccountBond(ctx context.Context, keyPair signature.KeyringPair, bondAmount Balance) error
+		AccountUnbond(ctx context.Context, keyPair signature.KeyringPair, bondAmount Cash) error
+		AccountGetUsdPerCere() (Balance, error)
+		AccountSetUsdPerCere(ctx context.Context, keyPair signature.KeyringPair, usdPerCere Balance) error
+		AccountWithdrawUnbonded(ctx context.Context, keyPair signature.KeyringPair) error
+		GetAccounts() ([]AccountId, error)
+	}
+
+	// PermissionOps checks and manages permissions.
+	PermissionOps interface {
 		HasPermission(account AccountId, permission string) (bool, error)
 		GrantTrustedManagerPermission(ctx context.Context, keyPair signature.KeyringPair, managerId AccountId) error
 		RevokeTrustedManagerPermission(ctx context.Context, keyPair signature.KeyringPair, managerId AccountId) error
@@ -166,13 +196,32 @@ type (
 		AdminRevokePermission(ctx context.Context, keyPair signature.KeyringPair, grantee AccountId, permission string) error
 		AdminTransferNodeOwnership(ctx context.Context, keyPair signature.KeyringPair, nodeKey NodeKey, newOwner AccountId) error
 		AdminTransferCdnNodeOwnership(ctx context.Context, keyPair signature.KeyringPair, nodeKey CdnNodeKey, newOwner AccountId) error
+	}
+
+	// DdcBucketContract is the complete bucket contract API. Depend on the narrower interfaces it
+	// embeds where only a part of the API is used.
+	DdcBucketContract interface {
+		BucketReader
+		BucketWriter
+		ClusterReader
+		ClusterAdmin
+		NodeReader
+		NodeAdmin
+		AccountOps
+		PermissionOps
+
+		GetContractAddress() string
+		GetLastAccessTime() time.Time
 		AddContractEventHandler(event string, handler func(interface{})) error
+		RegisterHandler(event string, handler func(interface{})) error
+		UnregisterHandler(event string) error
 		GetEventDispatcher() map[types.Hash]pkg.ContractEventDispatchEntry
 	}
 
 	ddcBucketContract struct {
 		chainClient                            pkg.BlockchainClient
 		lastAccessTime                         time.Time
+		clock                                  pkg.Clock
 		contractAddressSS58                    string
 		keyringPair                            signature.KeyringPair
 		nodeCreateMethodId                     []byte
@@ -196,6 +245,7 @@ type (
 		clusterRemoveMethodId                  []byte
 		clusterSetNodeStatusMethodId           []byte
 		clusterSetCdnNodeStatusMethodId        []byte
+		clusterDistributeRevenuesMethodId      []byte
 		clusterGetMethodId                     []byte
 		clusterListMethodId                    []byte
 		hasPermissionMethodId                  []byte
@@ -229,9 +279,22 @@ type (
 		bucketRevokeWriterPermMethodId         []byte
 		bucketSetReaderPermMethodId            []byte
 		bucketRevokeReaderPermMethodId         []byte
+		// selectorsMutex guards the bytes of method ids, which UpdateSelectors overwrites in place, and
+		// langError.
+		selectorsMutex sync.RWMutex
+		langError      bool
 
+		// eventDispatcher maps event topics to event types and never changes after creation.
 		eventDispatcher map[types.Hash]pkg.ContractEventDispatchEntry
+		eventHandlers   map[types.Hash]func(interface{})
+		eventMutex      sync.RWMutex
+
+		getTimeout  time.Duration
+		listTimeout time.Duration
 	}
+
+	// Option configures optional DdcBucketContract behavior.
+	Option func(d *ddcBucketContract)
 )
 
 var eventDispatchTable = map[string]reflect.Type{
@@ -269,9 +332,53 @@ var eventDispatchTable = map[string]reflect.Type{
 
 const (
 	DEFAULT_GAS_LIMIT uint64 = 500_000 * pkg.MGAS
+
+	// DefaultGetTimeout limits read calls of a single entity, e.g. BucketGet or AccountGet.
+	DefaultGetTimeout = 10 * time.Second
+	// DefaultListTimeout limits read calls of entity lists, e.g. BucketList or GetAccounts, which
+	// execute much longer on large contract state.
+	DefaultListTimeout = 60 * time.Second
 )
 
-func CreateDdcBucketContract(client pkg.BlockchainClient, contractAddressSS58 string) DdcBucketContract {
+// WithGetTimeout overrides DefaultGetTimeout.
+func WithGetTimeout(timeout time.Duration) Option {
+	return func(d *ddcBucketContract) {
+		d.getTimeout = timeout
+	}
+}
+
+// WithListTimeout overrides DefaultListTimeout.
+func WithListTimeout(timeout time.Duration) Option {
+	return func(d *ddcBucketContract) {
+		d.listTimeout = timeout
+	}
+}
+
+type callTimeoutKey struct{}
+
+// WithCallTimeout returns the context making reads of the contract at a block, e.g. BucketGetAt,
+// limited by the timeout instead of the get timeout of the contract. Reads without a context are
+// limited by the contract timeouts only, see WithGetTimeout and WithListTimeout.
+func WithCallTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, callTimeoutKey{}, timeout)
+}
+
+func callTimeout(ctx context.Context, timeout time.Duration) time.Duration {
+	if t, ok := ctx.Value(callTimeoutKey{}).(time.Duration); ok {
+		return t
+	}
+
+	return timeout
+}
+
+// WithClock replaces the system clock used to track the last access time.
+func WithClock(clock pkg.Clock) Option {
+	return func(d *ddcBucketContract) {
+		d.clock = clock
+	}
+}
+
+func CreateDdcBucketContract(client pkg.BlockchainClient, contractAddressSS58 string, opts ...Option) DdcBucketContract {
 	bucketGetMethodId, err := hex.DecodeString(bucketGetMethod)
 	if err != nil {
 		log.WithError(err).WithField("method", bucketGetMethod).Fatal("Can't decode method bucketGetMethod")
@@ -392,6 +499,11 @@ func CreateDdcBucketContract(client pkg.BlockchainClient, contractAddressSS58 st
 		log.WithError(err).WithField("method", clusterSetCdnNodeStatusMethod).Fatal("Can't decode method clusterSetCdnNodeStatusMethod")
 	}
 
+	clusterDistributeRevenuesMethodId, err := hex.DecodeString(clusterDistributeRevenuesMethod)
+	if err != nil {
+		log.WithError(err).WithField("method", clusterDistributeRevenuesMethod).Fatal("Can't decode method clusterDistributeRevenuesMethod")
+	}
+
 	clusterListMethodId, err := hex.DecodeString(clusterListMethod)
 	if err != nil {
 		log.WithError(err).WithField("method", clusterListMethod).Fatal("Can't decode method clusterListMethod")
@@ -512,7 +624,7 @@ func CreateDdcBucketContract(client pkg.BlockchainClient, contractAddressSS58 st
 		log.WithError(err).WithField("method", bucketSetResourceCapMethod).Fatal("Can't decode method bucketSetResourceCapMethodId")
 	}
 
-	getBucketWritersMethodId, err := hex.DecodeString(bucketSetResourceCapMethod)
+	getBucketWritersMethodId, err := hex.DecodeString(getBucketWritersMethod)
 	if err != nil {
 		log.WithError(err).WithField("method", getBucketWritersMethodId).Fatal("Can't decode method getBucketWritersMethodId")
 	}
@@ -551,7 +663,7 @@ func CreateDdcBucketContract(client pkg.BlockchainClient, contractAddressSS58 st
 		}
 	}
 
-	return &ddcBucketContract{
+	d := &ddcBucketContract{
 		chainClient:                            client,
 		contractAddressSS58:                    contractAddressSS58,
 		keyringPair:                            signature.KeyringPair{Address: contractAddressSS58},
@@ -579,6 +691,7 @@ func CreateDdcBucketContract(client pkg.BlockchainClient, contractAddressSS58 st
 		clusterRemoveMethodId:                  clusterRemoveMethodId,
 		clusterSetNodeStatusMethodId:           clusterSetNodeStatusMethodId,
 		clusterSetCdnNodeStatusMethodId:        clusterSetCdnNodeStatusMethodId,
+		clusterDistributeRevenuesMethodId:      clusterDistributeRevenuesMethodId,
 		clusterListMethodId:                    clusterListMethodId,
 		hasPermissionMethodId:                  hasPermissionMethodId,
 		grantTrustedManagerPermissionMethodId:  grantTrustedManagerPermissionMethodId,
@@ -588,6 +701,7 @@ func CreateDdcBucketContract(client pkg.BlockchainClient, contractAddressSS58 st
 		adminTransferNodeOwnershipMethodId:     adminTransferNodeOwnershipMethodId,
 		adminTransferCdnNodeOwnershipMethodId:  adminTransferCdnNodeOwnershipMethodId,
 		eventDispatcher:                        eventDispatcher,
+		eventHandlers:                          make(map[types.Hash]func(interface{})),
 		accountDepositMethodId:                 accountDepositMethodId,
 		accountBondMethodId:                    accountBondMethodId,
 		accountUnbondMethodId:                  accountUnbondMethodId,
@@ -610,7 +724,16 @@ func CreateDdcBucketContract(client pkg.BlockchainClient, contractAddressSS58 st
 		bucketRevokeWriterPermMethodId:         bucketRevokeWriterPermMethodId,
 		bucketSetReaderPermMethodId:            bucketSetReaderPermMethodId,
 		bucketRevokeReaderPermMethodId:         bucketRevokeReaderPermMethodId,
+		getTimeout:                             DefaultGetTimeout,
+		listTimeout:                            DefaultListTimeout,
+		clock:                                  pkg.SystemClock,
 	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
 }
 
 func (d *ddcBucketContract) BucketGet(bucketId BucketId) (*BucketInfo, error) {
@@ -620,6 +743,17 @@ func (d *ddcBucketContract) BucketGet(bucketId BucketId) (*BucketInfo, error) {
 	return res, err
 }
 
+// BucketGetAt reads the bucket from the contract state at the block. Blocks older than the pruning
+// depth of the node can be read from an archive node only.
+func (d *ddcBucketContract) BucketGetAt(ctx context.Context, bucketId BucketId, blockHash types.Hash) (*BucketInfo, error) {
+	res := &BucketInfo{}
+	if err := d.callToReadAt(ctx, blockHash, res, d.bucketGetMethodId, types.U32(bucketId)); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (d *ddcBucketContract) ClusterGet(clusterId ClusterId) (*ClusterInfo, error) {
 	res := &ClusterInfo{}
 	err := d.callToRead(res, d.clusterGetMethodId, types.U32(clusterId))
@@ -650,8 +784,52 @@ func (d *ddcBucketContract) AccountGet(account AccountId) (*Account, error) {
 	return res, nil
 }
 
+// ClusterGetAt reads the cluster from the contract state at the block, see BucketGetAt.
+func (d *ddcBucketContract) ClusterGetAt(ctx context.Context, clusterId ClusterId, blockHash types.Hash) (*ClusterInfo, error) {
+	res := &ClusterInfo{}
+	if err := d.callToReadAt(ctx, blockHash, res, d.clusterGetMethodId, types.U32(clusterId)); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// NodeGetAt reads the storage node from the contract state at the block, see BucketGetAt.
+func (d *ddcBucketContract) NodeGetAt(ctx context.Context, nodeKey NodeKey, blockHash types.Hash) (*NodeInfo, error) {
+	res := &NodeInfo{}
+	if err := d.callToReadAt(ctx, blockHash, res, d.nodeGetMethodId, nodeKey); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// CdnNodeGetAt reads the CDN node from the contract state at the block, see BucketGetAt.
+func (d *ddcBucketContract) CdnNodeGetAt(ctx context.Context, nodeKey CdnNodeKey, blockHash types.Hash) (*CdnNodeInfo, error) {
+	res := &CdnNodeInfo{}
+	if err := d.callToReadAt(ctx, blockHash, res, d.cdnNodeGetMethodId, nodeKey); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// AccountGetAt reads the account from the contract state at the block, see BucketGetAt.
+func (d *ddcBucketContract) AccountGetAt(ctx context.Context, account AccountId, blockHash types.Hash) (*Account, error) {
+	res := &Account{}
+	if err := d.callToReadAt(ctx, blockHash, res, d.accountGetMethodId, account); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (d *ddcBucketContract) callToExec(ctx context.Context, keyPair signature.KeyringPair, method []byte, args ...interface{}) (types.Hash, error) {
+	return d.callToExecWithValue(ctx, keyPair, 0, method, args...)
+}
 
+// callToExecWithValue calls a payable contract message transferring the value to the contract.
+func (d *ddcBucketContract) callToExecWithValue(ctx context.Context, keyPair signature.KeyringPair, value uint64, method []byte, args ...interface{}) (types.Hash, error) {
 	contractAddress, err := pkg.DecodeAccountIDFromSS58(d.contractAddressSS58)
 	if err != nil {
 		return types.Hash{}, err
@@ -661,9 +839,9 @@ func (d *ddcBucketContract) callToExec(ctx context.Context, keyPair signature.Ke
 		ContractAddress:     contractAddress,
 		ContractAddressSS58: d.contractAddressSS58,
 		From:                keyPair,
-		Value:               0,
+		Value:               value,
 		GasLimit:            DEFAULT_GAS_LIMIT,
-		Method:              method,
+		Method:              d.selector(method),
 		Args:                args,
 	}
 
@@ -672,20 +850,46 @@ func (d *ddcBucketContract) callToExec(ctx context.Context, keyPair signature.Ke
 		return types.Hash{}, err
 	}
 
-	d.lastAccessTime = time.Now()
+	d.lastAccessTime = d.clock.Now()
 
 	return blockHash, nil
 }
 
+// readEncoded calls the read method limiting the call time by the timeout.
+func (d *ddcBucketContract) readEncoded(timeout time.Duration, method []byte, args ...interface{}) (string, error) {
+	return d.readEncodedFrom(d.contractAddressSS58, timeout, method, args...)
+}
+
+// readEncodedFrom calls the read method on behalf of the caller, e.g. to dry-run a transaction.
+func (d *ddcBucketContract) readEncodedFrom(from string, timeout time.Duration, method []byte, args ...interface{}) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return d.chainClient.CallToReadEncodedContext(ctx, pkg.ReadCall{
+		ContractAddressSS58: d.contractAddressSS58,
+		From:                from,
+		Method:              d.selector(method),
+		Args:                args,
+	})
+}
+
 func (d *ddcBucketContract) callToRead(result interface{}, method []byte, args ...interface{}) error {
-	data, err := d.chainClient.CallToReadEncoded(d.contractAddressSS58, d.contractAddressSS58, method, args...)
+	return d.callToReadTimeout(d.getTimeout, result, method, args...)
+}
+
+func (d *ddcBucketContract) callToReadTimeout(timeout time.Duration, result interface{}, method []byte, args ...interface{}) error {
+	return d.callToReadFrom(d.contractAddressSS58, timeout, result, method, args...)
+}
+
+func (d *ddcBucketContract) callToReadFrom(from string, timeout time.Duration, result interface{}, method []byte, args ...interface{}) error {
+	data, err := d.readEncodedFrom(from, timeout, method, args...)
 	if err != nil {
 		return err
 	}
 
-	d.lastAccessTime = time.Now()
+	d.lastAccessTime = d.clock.Now()
 
-	res := Result{data: result}
+	res := Result{data: result, langError: d.hasLangError()}
 	if err = res.decodeDdcBucketContract(data); err != nil {
 		return err
 	}
@@ -693,31 +897,91 @@ func (d *ddcBucketContract) callToRead(result interface{}, method []byte, args .
 	return res.err
 }
 
+// callToReadAt reads the entity from the contract state at the block with the get timeout, unless
+// the context overrides it with WithCallTimeout.
+func (d *ddcBucketContract) callToReadAt(ctx context.Context, blockHash types.Hash, result interface{}, method []byte, args ...interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, callTimeout(ctx, d.getTimeout))
+	defer cancel()
+
+	data, err := d.chainClient.CallToReadEncodedContext(ctx, pkg.ReadCall{
+		ContractAddressSS58: d.contractAddressSS58,
+		From:                d.contractAddressSS58,
+		Method:              d.selector(method),
+		Args:                args,
+		At:                  blockHash,
+	})
+	if err != nil {
+		return err
+	}
+
+	d.lastAccessTime = d.clock.Now()
+
+	res := Result{data: result, langError: d.hasLangError()}
+	if err = res.decodeDdcBucketContract(data); err != nil {
+		return err
+	}
+
+	return res.err
+}
+
+// callToReadNoResult reads lists which the contract returns without the Result wrapper, so it uses
+// the list timeout.
 func (d *ddcBucketContract) callToReadNoResult(res interface{}, method []byte, args ...interface{}) error {
-	data, err := d.chainClient.CallToReadEncoded(d.contractAddressSS58, d.contractAddressSS58, method, args...)
+	data, err := d.readEncoded(d.listTimeout, method, args...)
 	if err != nil {
 		return err
 	}
 
-	d.lastAccessTime = time.Now()
+	d.lastAccessTime = d.clock.Now()
+
+	if d.hasLangError() {
+		if data, err = unwrapLangError(data); err != nil {
+			return err
+		}
+	}
 
 	return codec.DecodeFromHex(data, res)
 }
 
+// AddContractEventHandler sets the handler of the event. It's the same as RegisterHandler.
 func (d *ddcBucketContract) AddContractEventHandler(event string, handler func(interface{})) error {
+	return d.RegisterHandler(event, handler)
+}
+
+// RegisterHandler sets the handler of the event. Only one handler can be registered for an event.
+func (d *ddcBucketContract) RegisterHandler(event string, handler func(interface{})) error {
 	eventKey, err := types.NewHashFromHexString(event)
 	if err != nil {
 		return err
 	}
-	entry, found := d.eventDispatcher[eventKey]
-	if !found {
+
+	d.eventMutex.Lock()
+	defer d.eventMutex.Unlock()
+
+	if _, found := d.eventDispatcher[eventKey]; !found {
 		return errors.New("Event not found")
 	}
-	if entry.Handler != nil {
+	if _, found := d.eventHandlers[eventKey]; found {
 		return errors.New("Contract event handler already set for " + event)
 	}
-	entry.Handler = handler
-	d.eventDispatcher[eventKey] = entry
+	d.eventHandlers[eventKey] = handler
+	return nil
+}
+
+// UnregisterHandler removes the handler of the event, so the event is not handled any more.
+func (d *ddcBucketContract) UnregisterHandler(event string) error {
+	eventKey, err := types.NewHashFromHexString(event)
+	if err != nil {
+		return err
+	}
+
+	d.eventMutex.Lock()
+	defer d.eventMutex.Unlock()
+
+	if _, found := d.eventDispatcher[eventKey]; !found {
+		return errors.New("Event not found")
+	}
+	delete(d.eventHandlers, eventKey)
 	return nil
 }
 
@@ -729,8 +993,29 @@ func (d *ddcBucketContract) GetLastAccessTime() time.Time {
 	return d.lastAccessTime
 }
 
+// GetEventDispatcher returns a snapshot of the event dispatcher to pass to
+// pkg.BlockchainClient.SetEventDispatcher. Changes of the returned map don't affect the contract.
+// Handlers of the snapshot call the handlers registered at the time an event is dispatched, so
+// handlers registered or unregistered later take effect without a new snapshot.
 func (d *ddcBucketContract) GetEventDispatcher() map[types.Hash]pkg.ContractEventDispatchEntry {
-	return d.eventDispatcher
+	dispatcher := make(map[types.Hash]pkg.ContractEventDispatchEntry, len(d.eventDispatcher))
+	for eventKey, entry := range d.eventDispatcher {
+		eventKey := eventKey
+		dispatcher[eventKey] = pkg.ContractEventDispatchEntry{
+			ArgumentType: entry.ArgumentType,
+			Handler: func(args interface{}) {
+				d.eventMutex.RLock()
+				handler := d.eventHandlers[eventKey]
+				d.eventMutex.RUnlock()
+
+				if handler != nil {
+					handler(args)
+				}
+			},
+		}
+	}
+
+	return dispatcher
 }
 
 func (d *ddcBucketContract) ClusterCreate(ctx context.Context, keyPair signature.KeyringPair, params Params, resourcePerVNode Resource) (blockHash types.Hash, err error) {
@@ -739,6 +1024,10 @@ func (d *ddcBucketContract) ClusterCreate(ctx context.Context, keyPair signature
 }
 
 func (d *ddcBucketContract) ClusterAddNode(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId, nodeKey NodeKey, vNodes [][]Token) error {
+	if err := ValidateVNodes(vNodes); err != nil {
+		return err
+	}
+
 	_, err := d.callToExec(ctx, keyPair, d.clusterAddNodeMethodId, clusterId, nodeKey, vNodes)
 	return err
 }
@@ -749,11 +1038,19 @@ func (d *ddcBucketContract) ClusterRemoveNode(ctx context.Context, keyPair signa
 }
 
 func (d *ddcBucketContract) ClusterResetNode(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId, nodeKey NodeKey, vNodes [][]Token) error {
+	if err := ValidateVNodes(vNodes); err != nil {
+		return err
+	}
+
 	_, err := d.callToExec(ctx, keyPair, d.clusterResetNodeMethodId, clusterId, nodeKey, vNodes)
 	return err
 }
 
 func (d *ddcBucketContract) ClusterReplaceNode(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId, vNodes [][]Token, newNodeKey NodeKey) error {
+	if err := ValidateVNodes(vNodes); err != nil {
+		return err
+	}
+
 	_, err := d.callToExec(ctx, keyPair, d.clusterReplaceNodeMethodId, clusterId, vNodes, newNodeKey)
 	return err
 }
@@ -778,24 +1075,70 @@ func (d *ddcBucketContract) ClusterRemove(ctx context.Context, keyPair signature
 	return err
 }
 
-func (d *ddcBucketContract) ClusterSetNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId, nodeKey NodeKey, statusInCluster string) error {
-	_, err := d.callToExec(ctx, keyPair, d.clusterSetNodeStatusMethodId, clusterId, nodeKey, statusInCluster)
+func (d *ddcBucketContract) ClusterSetNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId, nodeKey NodeKey, statusInCluster NodeStatusInCluster) error {
+	if err := statusInCluster.Validate(); err != nil {
+		return err
+	}
+
+	_, err := d.callToExec(ctx, keyPair, d.clusterSetNodeStatusMethodId, clusterId, nodeKey, types.U8(statusInCluster))
+	return err
+}
+
+func (d *ddcBucketContract) ClusterSetCdnNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId, nodeKey CdnNodeKey, statusInCluster NodeStatusInCluster) error {
+	if err := statusInCluster.Validate(); err != nil {
+		return err
+	}
+
+	_, err := d.callToExec(ctx, keyPair, d.clusterSetCdnNodeStatusMethodId, clusterId, nodeKey, types.U8(statusInCluster))
 	return err
 }
 
-func (d *ddcBucketContract) ClusterSetCdnNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId, nodeKey CdnNodeKey, statusInCluster string) error {
-	_, err := d.callToExec(ctx, keyPair, d.clusterSetCdnNodeStatusMethodId, clusterId, nodeKey, statusInCluster)
+func (d *ddcBucketContract) ClusterDistributeRevenues(ctx context.Context, keyPair signature.KeyringPair, clusterId ClusterId) error {
+	_, err := d.callToExec(ctx, keyPair, d.clusterDistributeRevenuesMethodId, clusterId)
 	return err
 }
 
+// ClusterDistributeRevenuesEstimate estimates on the client the amounts each storage node provider
+// would be paid by the revenues distribution. It dry-runs the distribution on behalf of the cluster
+// manager to check that it would succeed, but the dry-run doesn't return the amounts, so they are
+// computed from the cluster revenues with the equal split of the contract. The remainder stays in
+// the cluster. Fees the contract charges before the split are not deducted.
+func (d *ddcBucketContract) ClusterDistributeRevenuesEstimate(clusterId ClusterId) (*RevenueDistribution, error) {
+	cluster, err := d.ClusterGet(clusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	manager := pkg.EncodeAddress(cluster.Cluster.ManagerId, pkg.SubstrateNetwork)
+	if err := d.callToReadFrom(manager, d.getTimeout, &struct{}{}, d.clusterDistributeRevenuesMethodId, clusterId); err != nil {
+		return nil, err
+	}
+
+	providers := make([]ProviderId, len(cluster.Cluster.NodesKeys))
+	for i, nodeKey := range cluster.Cluster.NodesKeys {
+		node, err := d.NodeGet(nodeKey)
+		if err != nil {
+			return nil, err
+		}
+		providers[i] = node.Node.ProviderId
+	}
+
+	return NewRevenueDistribution(clusterId, cluster.Cluster.Revenues, cluster.Cluster.NodesKeys, providers), nil
+}
+
 func (d *ddcBucketContract) ClusterList(offset types.U32, limit types.U32, filterManagerId types.OptionAccountID) (*ClusterListInfo, error) {
 	res := ClusterListInfo{}
 	err := d.callToReadNoResult(&res, d.clusterListMethodId, offset, limit, filterManagerId)
 	return &res, err
 }
 
-func (d *ddcBucketContract) NodeCreate(ctx context.Context, keyPair signature.KeyringPair, nodeKey NodeKey, params Params, capacity Resource, rent Rent) (blockHash types.Hash, err error) {
-	blockHash, err = d.callToExec(ctx, keyPair, d.nodeCreateMethodId, nodeKey, params, capacity, rent)
+func (d *ddcBucketContract) NodeCreate(ctx context.Context, keyPair signature.KeyringPair, nodeKey NodeKey, params Params, capacity StorageGb, rent Rent) (blockHash types.Hash, err error) {
+	capacityResource, err := capacity.Resource()
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	blockHash, err = d.callToExec(ctx, keyPair, d.nodeCreateMethodId, nodeKey, params, capacityResource, rent)
 	return blockHash, err
 }
 
@@ -872,10 +1215,12 @@ func (d *ddcBucketContract) AdminTransferCdnNodeOwnership(ctx context.Context, k
 	return err
 }
 
-func (d *ddcBucketContract) AccountDeposit(ctx context.Context, keyPair signature.KeyringPair) error {
+func (d *ddcBucketContract) AccountDeposit(ctx context.Context, keyPair signature.KeyringPair, value Balance) (blockHash types.Hash, err error) {
+	if value.Int == nil || !value.IsUint64() {
+		return types.Hash{}, errors.New("deposit value must fit uint64")
+	}
 
-	_, err := d.callToExec(ctx, keyPair, d.accountDepositMethodId)
-	return err
+	return d.callToExecWithValue(ctx, keyPair, value.Uint64(), d.accountDepositMethodId)
 }
 
 func (d *ddcBucketContract) AccountBond(ctx context.Context, keyPair signature.KeyringPair, bondAmount Balance) error {
@@ -906,13 +1251,18 @@ func (d *ddcBucketContract) AccountWithdrawUnbonded(ctx context.Context, keyPair
 
 func (d *ddcBucketContract) GetAccounts() ([]types.AccountID, error) {
 	var accounts []AccountId
-	err := d.callToRead(&accounts, d.getAccountsMethodId)
+	err := d.callToReadTimeout(d.listTimeout, &accounts, d.getAccountsMethodId)
 
 	return accounts, err
 }
 
 func (d *ddcBucketContract) BucketCreate(ctx context.Context, keyPair signature.KeyringPair, bucketParams BucketParams, clusterId ClusterId, ownerId types.OptionAccountID) (blockHash types.Hash, err error) {
-	blockHash, err = d.callToExec(ctx, keyPair, d.bucketCreateMethodId, bucketParams, clusterId, ownerId)
+	params, err := bucketParams.Params()
+	if err != nil {
+		return types.Hash{}, err
+	}
+
+	blockHash, err = d.callToExec(ctx, keyPair, d.bucketCreateMethodId, params, clusterId, ownerId)
 	return blockHash, err
 }
 
@@ -921,8 +1271,13 @@ func (d *ddcBucketContract) BucketChangeOwner(ctx context.Context, keyPair signa
 	return err
 }
 
-func (d *ddcBucketContract) BucketAllocIntoCluster(ctx context.Context, keyPair signature.KeyringPair, bucketId types.U32, resource Resource) error {
-	_, err := d.callToExec(ctx, keyPair, d.bucketAllocIntoClusterMethodId, resource, bucketId)
+func (d *ddcBucketContract) BucketAllocIntoCluster(ctx context.Context, keyPair signature.KeyringPair, bucketId types.U32, resource StorageGb) error {
+	resourceValue, err := resource.Resource()
+	if err != nil {
+		return err
+	}
+
+	_, err = d.callToExec(ctx, keyPair, d.bucketAllocIntoClusterMethodId, bucketId, resourceValue)
 	return err
 }
 
@@ -932,7 +1287,12 @@ func (d *ddcBucketContract) BucketSettlePayment(ctx context.Context, keyPair sig
 }
 
 func (d *ddcBucketContract) BucketChangeParams(ctx context.Context, keyPair signature.KeyringPair, bucketId types.U32, bucketParams BucketParams) error {
-	_, err := d.callToExec(ctx, keyPair, d.bucketChangeParamsMethodId, bucketParams, bucketId)
+	params, err := bucketParams.Params()
+	if err != nil {
+		return err
+	}
+
+	_, err = d.callToExec(ctx, keyPair, d.bucketChangeParamsMethodId, bucketId, params)
 	return err
 }
 
@@ -944,32 +1304,76 @@ func (d *ddcBucketContract) BucketList(offset types.U32, limit types.U32, filter
 
 func (d *ddcBucketContract) BucketListForAccount(ownerId AccountId) ([]Bucket, error) {
 	res := []Bucket{}
-	err := d.callToRead(&res, d.bucketListForAccountMethodId, ownerId)
+	err := d.callToReadTimeout(d.listTimeout, &res, d.bucketListForAccountMethodId, ownerId)
 	return res, err
 }
 
 func (d *ddcBucketContract) BucketSetAvailability(ctx context.Context, keyPair signature.KeyringPair, bucketId types.U32, publicAvailability bool) error {
-	_, err := d.callToExec(ctx, keyPair, d.bucketSetAvailabilityMethodId, publicAvailability, bucketId)
+	_, err := d.callToExec(ctx, keyPair, d.bucketSetAvailabilityMethodId, bucketId, publicAvailability)
 	return err
 }
 
-func (d *ddcBucketContract) BucketSetResourceCap(ctx context.Context, keyPair signature.KeyringPair, bucketId types.U32, newResourceCap Resource) error {
-	_, err := d.callToExec(ctx, keyPair, d.bucketSetResourceCapMethodId, newResourceCap, bucketId)
+func (d *ddcBucketContract) BucketSetResourceCap(ctx context.Context, keyPair signature.KeyringPair, bucketId types.U32, newResourceCap StorageGb) error {
+	resourceCap, err := newResourceCap.Resource()
+	if err != nil {
+		return err
+	}
+
+	_, err = d.callToExec(ctx, keyPair, d.bucketSetResourceCapMethodId, bucketId, resourceCap)
 	return err
 }
 
 func (d *ddcBucketContract) GetBucketWriters(ctx context.Context, keyPair signature.KeyringPair, bucketId types.U32) ([]AccountId, error) {
-	res := []AccountId{}
-	_, err := d.callToExec(ctx, keyPair, d.getBucketWritersMethodId, &res, bucketId)
+	var res []AccountId
+	err := d.callToReadTimeout(d.listTimeout, &res, d.getBucketWritersMethodId, bucketId)
 	return res, err
 }
 
 func (d *ddcBucketContract) GetBucketReaders(ctx context.Context, keyPair signature.KeyringPair, bucketId types.U32) ([]AccountId, error) {
-	res := []AccountId{}
-	_, err := d.callToExec(ctx, keyPair, d.getBucketReadersMethodId, &res, bucketId)
+	var res []AccountId
+	err := d.callToReadTimeout(d.listTimeout, &res, d.getBucketReadersMethodId, bucketId)
 	return res, err
 }
 
+// BucketWritersPage returns a page of the bucket writers. The contract returns the whole list, so
+// the page is cut on the client side. Use the cached contract to not read the list for every page.
+func (d *ddcBucketContract) BucketWritersPage(bucketId BucketId, offset types.U32, limit types.U32) (*AccountListInfo, error) {
+	writers, err := d.GetBucketWriters(context.Background(), signature.KeyringPair{}, bucketId)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAccountListPage(writers, offset, limit), nil
+}
+
+// BucketReadersPage returns a page of the bucket readers the same way as BucketWritersPage.
+func (d *ddcBucketContract) BucketReadersPage(bucketId BucketId, offset types.U32, limit types.U32) (*AccountListInfo, error) {
+	readers, err := d.GetBucketReaders(context.Background(), signature.KeyringPair{}, bucketId)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAccountListPage(readers, offset, limit), nil
+}
+
+func (d *ddcBucketContract) IsWriter(bucketId BucketId, account AccountId) (bool, error) {
+	writers, err := d.GetBucketWriters(context.Background(), signature.KeyringPair{}, bucketId)
+	if err != nil {
+		return false, err
+	}
+
+	return ContainsAccount(writers, account), nil
+}
+
+func (d *ddcBucketContract) IsReader(bucketId BucketId, account AccountId) (bool, error) {
+	readers, err := d.GetBucketReaders(context.Background(), signature.KeyringPair{}, bucketId)
+	if err != nil {
+		return false, err
+	}
+
+	return ContainsAccount(readers, account), nil
+}
+
 func (d *ddcBucketContract) BucketSetWriterPerm(ctx context.Context, keyPair signature.KeyringPair, bucketId types.U32, writer AccountId) error {
 	_, err := d.callToExec(ctx, keyPair, d.bucketSetWriterPermMethodId, bucketId, writer)
 	return err
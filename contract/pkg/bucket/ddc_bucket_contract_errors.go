@@ -1,6 +1,6 @@
 package bucket
 
-import "errors"
+import "github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors"
 
 const (
 	nodeDoesNotExist = iota
@@ -48,50 +48,51 @@ const (
 )
 
 // ToDo update error regarding latest contract
+// Errors are classified with ddcerrors codes, e.g. errors.Is(ErrBucketDoesNotExist, ddcerrors.ErrNotFound).
 var (
-	ErrBucketDoesNotExist                  = errors.New("bucket doesn't exist")
-	ErrTransferFailed                      = errors.New("transfer failed")
-	ErrUndefined                           = errors.New("undefined error")
-	ErrNodeDoesNotExist                    = errors.New("node does not exist")
-	ErrCdnNodeDoesNotExist                 = errors.New("cdn node does not exist")
-	ErrNodeAlreadyExists                   = errors.New("node already exists")
-	ErrCdnNodeAlreadyExists                = errors.New("cdn node already exists")
-	ErrAccountDoesNotExist                 = errors.New("account does not exist")
-	ErrParamsDoesNotExist                  = errors.New("params does not exist")
-	ErrParamsSizeExceedsLimit              = errors.New("params size exceeds limit")
-	ErrOnlyOwner                           = errors.New("only owner")
-	ErrOnlyNodeProvider                    = errors.New("only node provider")
-	ErrOnlyCdnNodeProvider                 = errors.New("only cdn node provider")
-	ErrOnlyClusterManager                  = errors.New("only cluster manager")
-	ErrOnlyTrustedClusterManager           = errors.New("only trusted cluster manager")
-	ErrOnlyValidator                       = errors.New("only validator")
-	ErrOnlySuperAdmin                      = errors.New("only super admin")
-	ErrOnlyClusterManagerOrNodeProvider    = errors.New("only cluster manager or node provider")
-	ErrOnlyClusterManagerOrCdnNodeProvider = errors.New("only cluster manager or cdn node provider")
-	ErrUnauthorized                        = errors.New("unauthorized")
-	ErrClusterDoesNotExist                 = errors.New("cluster does not exist")
-	ErrClusterIsNotEmpty                   = errors.New("cluster is not empty")
-	ErrTopologyIsNotCreated                = errors.New("topology is not created")
-	ErrTopologyAlreadyExists               = errors.New("topology already exists")
-	ErrNodesSizeExceedsLimit               = errors.New("nodes size exceeds limit")
-	ErrCdnNodesSizeExceedsLimit            = errors.New("cdn nodes size exceeds limit")
-	ErrVNodesSizeExceedsLimit              = errors.New("vnodes size exceeds limit")
-	ErrAccountsSizeExceedsLimit            = errors.New("accounts size exceeds limit")
-	ErrNodeIsNotAddedToCluster             = errors.New("node is not added to cluster")
-	ErrNodeIsAddedToCluster                = errors.New("node is added to cluster")
-	ErrCdnNodeIsNotAddedToCluster          = errors.New("cdn node is not added to cluster")
-	ErrCdnNodeIsAddedToCluster             = errors.New("cdn node is added to cluster")
-	ErrVNodeDoesNotExistsInCluster         = errors.New("vnode does not exists in cluster")
-	ErrVNodeIsNotAssignedToNode            = errors.New("vnode is not assigned to node")
-	ErrVNodeIsAlreadyAssignedToNode        = errors.New("vnode is already assigned to node")
-	ErrAtLeastOneVNodeHasToBeAssigned      = errors.New("at least one vnode has to be assigned")
-	ErrNodeProviderIsNotSuperAdmin         = errors.New("node provider is not super admin")
-	ErrCdnNodeOwnerIsNotSuperAdmin         = errors.New("cdn node owner is not super admin")
-	ErrBondingPeriodNotFinished            = errors.New("bonding period is not finished")
-	ErrInsufficientBalance                 = errors.New("insufficient balance")
-	ErrInsufficientNodeResources           = errors.New("insufficient node resources")
-	ErrInsufficientClusterResources        = errors.New("insufficient cluster resources")
-	ErrEraSettingFailed                    = errors.New("era setting failed")
+	ErrBucketDoesNotExist                  = ddcerrors.New(ddcerrors.CodeNotFound, "bucket doesn't exist")
+	ErrTransferFailed                      = ddcerrors.New(ddcerrors.CodeExecution, "transfer failed")
+	ErrUndefined                           = ddcerrors.New(ddcerrors.CodeExecution, "undefined error")
+	ErrNodeDoesNotExist                    = ddcerrors.New(ddcerrors.CodeNotFound, "node does not exist")
+	ErrCdnNodeDoesNotExist                 = ddcerrors.New(ddcerrors.CodeNotFound, "cdn node does not exist")
+	ErrNodeAlreadyExists                   = ddcerrors.New(ddcerrors.CodeAlreadyExists, "node already exists")
+	ErrCdnNodeAlreadyExists                = ddcerrors.New(ddcerrors.CodeAlreadyExists, "cdn node already exists")
+	ErrAccountDoesNotExist                 = ddcerrors.New(ddcerrors.CodeNotFound, "account does not exist")
+	ErrParamsDoesNotExist                  = ddcerrors.New(ddcerrors.CodeNotFound, "params does not exist")
+	ErrParamsSizeExceedsLimit              = ddcerrors.New(ddcerrors.CodeInvalidArgument, "params size exceeds limit")
+	ErrOnlyOwner                           = ddcerrors.New(ddcerrors.CodeUnauthorized, "only owner")
+	ErrOnlyNodeProvider                    = ddcerrors.New(ddcerrors.CodeUnauthorized, "only node provider")
+	ErrOnlyCdnNodeProvider                 = ddcerrors.New(ddcerrors.CodeUnauthorized, "only cdn node provider")
+	ErrOnlyClusterManager                  = ddcerrors.New(ddcerrors.CodeUnauthorized, "only cluster manager")
+	ErrOnlyTrustedClusterManager           = ddcerrors.New(ddcerrors.CodeUnauthorized, "only trusted cluster manager")
+	ErrOnlyValidator                       = ddcerrors.New(ddcerrors.CodeUnauthorized, "only validator")
+	ErrOnlySuperAdmin                      = ddcerrors.New(ddcerrors.CodeUnauthorized, "only super admin")
+	ErrOnlyClusterManagerOrNodeProvider    = ddcerrors.New(ddcerrors.CodeUnauthorized, "only cluster manager or node provider")
+	ErrOnlyClusterManagerOrCdnNodeProvider = ddcerrors.New(ddcerrors.CodeUnauthorized, "only cluster manager or cdn node provider")
+	ErrUnauthorized                        = ddcerrors.New(ddcerrors.CodeUnauthorized, "unauthorized")
+	ErrClusterDoesNotExist                 = ddcerrors.New(ddcerrors.CodeNotFound, "cluster does not exist")
+	ErrClusterIsNotEmpty                   = ddcerrors.New(ddcerrors.CodeInvalidArgument, "cluster is not empty")
+	ErrTopologyIsNotCreated                = ddcerrors.New(ddcerrors.CodeNotFound, "topology is not created")
+	ErrTopologyAlreadyExists               = ddcerrors.New(ddcerrors.CodeAlreadyExists, "topology already exists")
+	ErrNodesSizeExceedsLimit               = ddcerrors.New(ddcerrors.CodeInvalidArgument, "nodes size exceeds limit")
+	ErrCdnNodesSizeExceedsLimit            = ddcerrors.New(ddcerrors.CodeInvalidArgument, "cdn nodes size exceeds limit")
+	ErrVNodesSizeExceedsLimit              = ddcerrors.New(ddcerrors.CodeInvalidArgument, "vnodes size exceeds limit")
+	ErrAccountsSizeExceedsLimit            = ddcerrors.New(ddcerrors.CodeInvalidArgument, "accounts size exceeds limit")
+	ErrNodeIsNotAddedToCluster             = ddcerrors.New(ddcerrors.CodeInvalidArgument, "node is not added to cluster")
+	ErrNodeIsAddedToCluster                = ddcerrors.New(ddcerrors.CodeInvalidArgument, "node is added to cluster")
+	ErrCdnNodeIsNotAddedToCluster          = ddcerrors.New(ddcerrors.CodeInvalidArgument, "cdn node is not added to cluster")
+	ErrCdnNodeIsAddedToCluster             = ddcerrors.New(ddcerrors.CodeInvalidArgument, "cdn node is added to cluster")
+	ErrVNodeDoesNotExistsInCluster         = ddcerrors.New(ddcerrors.CodeNotFound, "vnode does not exists in cluster")
+	ErrVNodeIsNotAssignedToNode            = ddcerrors.New(ddcerrors.CodeInvalidArgument, "vnode is not assigned to node")
+	ErrVNodeIsAlreadyAssignedToNode        = ddcerrors.New(ddcerrors.CodeInvalidArgument, "vnode is already assigned to node")
+	ErrAtLeastOneVNodeHasToBeAssigned      = ddcerrors.New(ddcerrors.CodeInvalidArgument, "at least one vnode has to be assigned")
+	ErrNodeProviderIsNotSuperAdmin         = ddcerrors.New(ddcerrors.CodeUnauthorized, "node provider is not super admin")
+	ErrCdnNodeOwnerIsNotSuperAdmin         = ddcerrors.New(ddcerrors.CodeUnauthorized, "cdn node owner is not super admin")
+	ErrBondingPeriodNotFinished            = ddcerrors.New(ddcerrors.CodeInvalidArgument, "bonding period is not finished")
+	ErrInsufficientBalance                 = ddcerrors.New(ddcerrors.CodeInsufficientFunds, "insufficient balance")
+	ErrInsufficientNodeResources           = ddcerrors.New(ddcerrors.CodeInvalidArgument, "insufficient node resources")
+	ErrInsufficientClusterResources        = ddcerrors.New(ddcerrors.CodeInvalidArgument, "insufficient cluster resources")
+	ErrEraSettingFailed                    = ddcerrors.New(ddcerrors.CodeExecution, "era setting failed")
 )
 
 func parseDdcBucketContractError(error uint8) error {
@@ -0,0 +1,239 @@
+package bucket
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func testEventContract(t *testing.T) (*ddcBucketContract, types.Hash) {
+	eventKey, err := types.NewHashFromHexString(DepositEventId)
+	assert.NoError(t, err)
+
+	return &ddcBucketContract{
+		eventDispatcher: map[types.Hash]pkg.ContractEventDispatchEntry{
+			eventKey: {ArgumentType: reflect.TypeOf(DepositEvent{})},
+		},
+		eventHandlers: make(map[types.Hash]func(interface{})),
+	}, eventKey
+}
+
+func TestGetEventDispatcherSnapshot(t *testing.T) {
+	//given
+	contract, eventKey := testEventContract(t)
+	snapshot := contract.GetEventDispatcher()
+
+	//when
+	delete(snapshot, eventKey)
+
+	//then
+	assert.Contains(t, contract.GetEventDispatcher(), eventKey)
+	assert.Equal(t, reflect.TypeOf(DepositEvent{}), contract.GetEventDispatcher()[eventKey].ArgumentType)
+}
+
+func TestRegisterHandler(t *testing.T) {
+	//given
+	contract, eventKey := testEventContract(t)
+	snapshot := contract.GetEventDispatcher()
+	var handled []interface{}
+
+	//when
+	err := contract.RegisterHandler(DepositEventId, func(args interface{}) { handled = append(handled, args) })
+	snapshot[eventKey].Handler("deposit")
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"deposit"}, handled)
+	assert.Error(t, contract.RegisterHandler(DepositEventId, func(interface{}) {}))
+	assert.Error(t, contract.RegisterHandler(BucketCreatedEventId, func(interface{}) {}))
+}
+
+func TestUnregisterHandler(t *testing.T) {
+	//given
+	contract, eventKey := testEventContract(t)
+	snapshot := contract.GetEventDispatcher()
+	handled := 0
+	_ = contract.RegisterHandler(DepositEventId, func(interface{}) { handled++ })
+
+	//when
+	err := contract.UnregisterHandler(DepositEventId)
+	snapshot[eventKey].Handler("deposit")
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, 0, handled)
+	assert.NoError(t, contract.RegisterHandler(DepositEventId, func(interface{}) {}))
+}
+
+type recordingChainClient struct {
+	pkg.BlockchainClient
+	calls     []pkg.ContractCall
+	reads     []pkg.ReadCall
+	deadlines []time.Time
+}
+
+// CallToReadEncodedContext records the read and returns the contract error 0x23, ErrBucketDoesNotExist.
+func (c *recordingChainClient) CallToReadEncodedContext(ctx context.Context, readCall pkg.ReadCall) (string, error) {
+	c.reads = append(c.reads, readCall)
+	deadline, _ := ctx.Deadline()
+	c.deadlines = append(c.deadlines, deadline)
+	return "0x0123", nil
+}
+
+func (c *recordingChainClient) CallToExec(ctx context.Context, contractCall pkg.ContractCall) (types.Hash, error) {
+	c.calls = append(c.calls, contractCall)
+	return types.Hash{}, nil
+}
+
+func TestBucketWriteArgs(t *testing.T) {
+	chainClient := &recordingChainClient{}
+	contract := &ddcBucketContract{
+		chainClient:                    chainClient,
+		clock:                          pkg.SystemClock,
+		contractAddressSS58:            "5GmomkEekQQ3BipMvjDCG5bXKvzwhUDdXEcQqXRWmdkNCYkL",
+		bucketAllocIntoClusterMethodId: []byte{1},
+		bucketChangeParamsMethodId:     []byte{2},
+		bucketSetAvailabilityMethodId:  []byte{3},
+		bucketSetResourceCapMethodId:   []byte{4},
+	}
+	ctx, keyPair := context.Background(), signature.KeyringPair{}
+
+	tests := []struct {
+		name     string
+		call     func() error
+		wantArgs []interface{}
+	}{
+		{
+			name:     "alloc into cluster",
+			call:     func() error { return contract.BucketAllocIntoCluster(ctx, keyPair, 7, 5) },
+			wantArgs: []interface{}{types.U32(7), Resource(5)},
+		},
+		{
+			name:     "change params",
+			call:     func() error { return contract.BucketChangeParams(ctx, keyPair, 7, BucketParams{Replication: 3}) },
+			wantArgs: []interface{}{types.U32(7), `{"replication":3}`},
+		},
+		{
+			name:     "set availability",
+			call:     func() error { return contract.BucketSetAvailability(ctx, keyPair, 7, true) },
+			wantArgs: []interface{}{types.U32(7), true},
+		},
+		{
+			name:     "set resource cap",
+			call:     func() error { return contract.BucketSetResourceCap(ctx, keyPair, 7, 9) },
+			wantArgs: []interface{}{types.U32(7), Resource(9)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			chainClient.calls = nil
+
+			//when
+			err := tt.call()
+
+			//then
+			assert.NoError(t, err)
+			assert.Len(t, chainClient.calls, 1)
+			assert.Equal(t, tt.wantArgs, chainClient.calls[0].Args)
+		})
+	}
+}
+
+func TestGetAtArgs(t *testing.T) {
+	chainClient := &recordingChainClient{}
+	contract := &ddcBucketContract{
+		chainClient:        chainClient,
+		clock:              pkg.SystemClock,
+		getTimeout:         DefaultGetTimeout,
+		bucketGetMethodId:  []byte{1},
+		clusterGetMethodId: []byte{2},
+		nodeGetMethodId:    []byte{3},
+		cdnNodeGetMethodId: []byte{4},
+		accountGetMethodId: []byte{5},
+	}
+	ctx, blockHash := context.Background(), types.Hash{9}
+
+	tests := []struct {
+		name       string
+		call       func() error
+		wantMethod []byte
+		wantArgs   []interface{}
+	}{
+		{
+			name:       "bucket",
+			call:       func() error { _, err := contract.BucketGetAt(ctx, 7, blockHash); return err },
+			wantMethod: []byte{1},
+			wantArgs:   []interface{}{types.U32(7)},
+		},
+		{
+			name:       "cluster",
+			call:       func() error { _, err := contract.ClusterGetAt(ctx, 7, blockHash); return err },
+			wantMethod: []byte{2},
+			wantArgs:   []interface{}{types.U32(7)},
+		},
+		{
+			name:       "node",
+			call:       func() error { _, err := contract.NodeGetAt(ctx, NodeKey{1}, blockHash); return err },
+			wantMethod: []byte{3},
+			wantArgs:   []interface{}{NodeKey{1}},
+		},
+		{
+			name:       "cdn node",
+			call:       func() error { _, err := contract.CdnNodeGetAt(ctx, CdnNodeKey{1}, blockHash); return err },
+			wantMethod: []byte{4},
+			wantArgs:   []interface{}{CdnNodeKey{1}},
+		},
+		{
+			name:       "account",
+			call:       func() error { _, err := contract.AccountGetAt(ctx, AccountId{1}, blockHash); return err },
+			wantMethod: []byte{5},
+			wantArgs:   []interface{}{AccountId{1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			chainClient.reads = nil
+
+			//when
+			err := tt.call()
+
+			//then
+			assert.ErrorIs(t, err, ErrBucketDoesNotExist)
+			assert.Len(t, chainClient.reads, 1)
+			assert.Equal(t, blockHash, chainClient.reads[0].At)
+			assert.Equal(t, tt.wantMethod, chainClient.reads[0].Method)
+			assert.Equal(t, tt.wantArgs, chainClient.reads[0].Args)
+		})
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	//given
+	chainClient := &recordingChainClient{}
+	contract := &ddcBucketContract{
+		chainClient:       chainClient,
+		clock:             pkg.SystemClock,
+		getTimeout:        time.Hour,
+		bucketGetMethodId: []byte{1},
+	}
+	start := time.Now()
+
+	//when
+	_, _ = contract.BucketGetAt(context.Background(), 7, types.Hash{9})
+	_, _ = contract.BucketGetAt(WithCallTimeout(context.Background(), time.Minute), 7, types.Hash{9})
+
+	//then
+	assert.Len(t, chainClient.deadlines, 2)
+	assert.WithinDuration(t, start.Add(time.Hour), chainClient.deadlines[0], time.Minute)
+	assert.WithinDuration(t, start.Add(time.Minute), chainClient.deadlines[1], 30*time.Second)
+}
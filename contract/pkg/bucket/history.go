@@ -0,0 +1,84 @@
+package bucket
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+type (
+	// HistoricalBucketReader reads buckets from the contract state at past blocks. The bucket
+	// contract implements it.
+	HistoricalBucketReader interface {
+		BucketGetAt(ctx context.Context, bucketId BucketId, blockHash types.Hash) (*BucketInfo, error)
+	}
+
+	// HistoricalReader reads entities from the contract state at past blocks, e.g. for indexers
+	// and validators checking state as of a block. The bucket contract implements it.
+	HistoricalReader interface {
+		HistoricalBucketReader
+		ClusterGetAt(ctx context.Context, clusterId ClusterId, blockHash types.Hash) (*ClusterInfo, error)
+		NodeGetAt(ctx context.Context, nodeKey NodeKey, blockHash types.Hash) (*NodeInfo, error)
+		CdnNodeGetAt(ctx context.Context, nodeKey CdnNodeKey, blockHash types.Hash) (*CdnNodeInfo, error)
+		AccountGetAt(ctx context.Context, account AccountId, blockHash types.Hash) (*Account, error)
+	}
+
+	// BlockHashes resolves block numbers to hashes, e.g. the Chain RPC of the substrate API.
+	BlockHashes interface {
+		GetBlockHash(blockNumber uint64) (types.Hash, error)
+	}
+
+	// AccessHistory reconstructs the owner and the writers of buckets at past blocks, e.g. to
+	// resolve disputes and audit past writes. The contract doesn't emit events on ownership and
+	// writer changes, so the state is read at the block instead of replayed from indexed events.
+	AccessHistory struct {
+		buckets HistoricalBucketReader
+		blocks  BlockHashes
+	}
+)
+
+func CreateAccessHistory(buckets HistoricalBucketReader, blocks BlockHashes) *AccessHistory {
+	return &AccessHistory{buckets: buckets, blocks: blocks}
+}
+
+// OwnerAt returns the owner of the bucket at the block.
+func (h *AccessHistory) OwnerAt(ctx context.Context, bucketId BucketId, atBlock types.BlockNumber) (AccountId, error) {
+	info, err := h.bucketAt(ctx, bucketId, atBlock)
+	if err != nil {
+		return AccountId{}, err
+	}
+
+	return info.Bucket.OwnerId, nil
+}
+
+// WhoCouldWrite returns the accounts which could write to the bucket at the block, the owner first.
+func (h *AccessHistory) WhoCouldWrite(ctx context.Context, bucketId BucketId, atBlock types.BlockNumber) ([]AccountId, error) {
+	info, err := h.bucketAt(ctx, bucketId, atBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := []AccountId{info.Bucket.OwnerId}
+	for _, writerId := range info.WriterIds {
+		if writerId != info.Bucket.OwnerId {
+			accounts = append(accounts, writerId)
+		}
+	}
+
+	return accounts, nil
+}
+
+func (h *AccessHistory) bucketAt(ctx context.Context, bucketId BucketId, atBlock types.BlockNumber) (*BucketInfo, error) {
+	blockHash, err := h.blocks.GetBlockHash(uint64(atBlock))
+	if err != nil {
+		return nil, fmt.Errorf("block %d: %w", atBlock, err)
+	}
+
+	info, err := h.buckets.BucketGetAt(ctx, bucketId, blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("bucket %d at block %d: %w", bucketId, atBlock, err)
+	}
+
+	return info, nil
+}
@@ -0,0 +1,67 @@
+package bucket
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockedBlockHashes map[uint64]types.Hash
+
+func (m mockedBlockHashes) GetBlockHash(blockNumber uint64) (types.Hash, error) {
+	hash, ok := m[blockNumber]
+	if !ok {
+		return types.Hash{}, errors.New("unknown block")
+	}
+	return hash, nil
+}
+
+type mockedHistoricalBucketReader map[types.Hash]*BucketInfo
+
+func (m mockedHistoricalBucketReader) BucketGetAt(_ context.Context, _ BucketId, blockHash types.Hash) (*BucketInfo, error) {
+	info, ok := m[blockHash]
+	if !ok {
+		return nil, ErrBucketDoesNotExist
+	}
+	return info, nil
+}
+
+func TestAccessHistory(t *testing.T) {
+	//given
+	owner, newOwner, writer := types.AccountID{1}, types.AccountID{2}, types.AccountID{3}
+	history := CreateAccessHistory(
+		mockedHistoricalBucketReader{
+			{10}: {Bucket: Bucket{OwnerId: owner}, WriterIds: []AccountId{owner, writer}},
+			{20}: {Bucket: Bucket{OwnerId: newOwner}},
+		},
+		mockedBlockHashes{100: {10}, 200: {20}, 50: {5}},
+	)
+
+	//when
+	ownerAt100, err := history.OwnerAt(context.Background(), 1, 100)
+	assert.NoError(t, err)
+	ownerAt200, err := history.OwnerAt(context.Background(), 1, 200)
+	assert.NoError(t, err)
+	writersAt100, err := history.WhoCouldWrite(context.Background(), 1, 100)
+	assert.NoError(t, err)
+	writersAt200, err := history.WhoCouldWrite(context.Background(), 1, 200)
+	assert.NoError(t, err)
+
+	//then
+	assert.Equal(t, owner, ownerAt100)
+	assert.Equal(t, newOwner, ownerAt200)
+	assert.Equal(t, []AccountId{owner, writer}, writersAt100)
+	assert.Equal(t, []AccountId{newOwner}, writersAt200)
+}
+
+func TestAccessHistoryErrors(t *testing.T) {
+	history := CreateAccessHistory(mockedHistoricalBucketReader{}, mockedBlockHashes{50: {5}})
+
+	_, err := history.OwnerAt(context.Background(), 1, 50)
+	assert.ErrorIs(t, err, ErrBucketDoesNotExist)
+	_, err = history.WhoCouldWrite(context.Background(), 1, 60)
+	assert.Error(t, err)
+}
@@ -0,0 +1,169 @@
+package bucket
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+type (
+	// Iterator reads a list page by page as the entities are consumed. The next page is read only
+	// when Next runs out of the current one, e.g.
+	//
+	//	it := NewNodeIterator(contract, ListFilter[NodeInfo]{})
+	//	for it.Next() {
+	//		node := it.Value()
+	//	}
+	//	if err := it.Err(); err != nil {
+	//		...
+	//	}
+	//
+	// An iterator isn't safe for concurrent use.
+	Iterator[T any] struct {
+		filter ListFilter[T]
+		read   func(offset types.U32, limit types.U32) ([]T, types.U32, error)
+		offset types.U32
+		page   []T
+		index  int
+		total  types.U32
+		passed int
+		// started is set once the first page is read.
+		started bool
+		done    bool
+		value   T
+		err     error
+	}
+
+	ClusterIterator = Iterator[ClusterInfo]
+	NodeIterator    = Iterator[NodeInfo]
+	CdnNodeIterator = Iterator[CdnNodeInfo]
+	BucketIterator  = Iterator[BucketInfo]
+)
+
+// NewClusterIterator iterates clusters matching the filter.
+func NewClusterIterator(contract DdcBucketContract, filter ListFilter[ClusterInfo]) *ClusterIterator {
+	return newIterator(filter, clusterPages(contract, filter.AccountId))
+}
+
+// NewNodeIterator iterates storage nodes matching the filter.
+func NewNodeIterator(contract DdcBucketContract, filter ListFilter[NodeInfo]) *NodeIterator {
+	return newIterator(filter, nodePages(contract, filter.AccountId))
+}
+
+// NewCdnNodeIterator iterates CDN nodes matching the filter.
+func NewCdnNodeIterator(contract DdcBucketContract, filter ListFilter[CdnNodeInfo]) *CdnNodeIterator {
+	return newIterator(filter, cdnNodePages(contract, filter.AccountId))
+}
+
+// NewBucketIterator iterates buckets matching the filter.
+func NewBucketIterator(contract DdcBucketContract, filter ListFilter[BucketInfo]) *BucketIterator {
+	return newIterator(filter, bucketPages(contract, filter.AccountId))
+}
+
+func newIterator[T any](filter ListFilter[T], read func(offset types.U32, limit types.U32) ([]T, types.U32, error)) *Iterator[T] {
+	if filter.PageSize == 0 {
+		filter.PageSize = DefaultStreamPageSize
+	}
+
+	return &Iterator[T]{filter: filter, read: read}
+}
+
+// Next advances to the next entity matching the filter, reading the next page if needed. It returns
+// false at the end of the list, when the filter stops the iteration or when a read fails, see Err.
+func (it *Iterator[T]) Next() bool {
+	if it.done {
+		return false
+	}
+	if it.filter.Limit > 0 && it.passed >= it.filter.Limit {
+		return it.stop()
+	}
+
+	for {
+		for it.index < len(it.page) {
+			entity := it.page[it.index]
+			it.index++
+			if it.filter.StopWhen != nil && it.filter.StopWhen(entity) {
+				return it.stop()
+			}
+			if it.filter.Where != nil && !it.filter.Where(entity) {
+				continue
+			}
+			it.value = entity
+			it.passed++
+			return true
+		}
+
+		// Pages are read while the offset of the next page is less than the total, so a short
+		// page doesn't end the list.
+		if it.started && it.offset >= it.total {
+			return it.stop()
+		}
+		page, total, err := it.read(it.offset, it.filter.PageSize)
+		if err != nil {
+			it.err = err
+			return it.stop()
+		}
+		it.page, it.index, it.total, it.started = page, 0, total, true
+		it.offset += it.filter.PageSize
+	}
+}
+
+// Value returns the entity Next advanced to.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error the iteration stopped with, nil if it reached the end or was stopped by the filter.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Total returns the number of entities in the list as of the latest page read, matching the
+// account filter but not Where, 0 before the first page is read.
+func (it *Iterator[T]) Total() types.U32 {
+	return it.total
+}
+
+func (it *Iterator[T]) stop() bool {
+	var zero T
+	it.done, it.value = true, zero
+	return false
+}
+
+func clusterPages(contract DdcBucketContract, managerId types.OptionAccountID) func(offset types.U32, limit types.U32) ([]ClusterInfo, types.U32, error) {
+	return func(offset types.U32, limit types.U32) ([]ClusterInfo, types.U32, error) {
+		page, err := contract.ClusterList(offset, limit, managerId)
+		if err != nil {
+			return nil, 0, err
+		}
+		return page.Clusters, page.Total, nil
+	}
+}
+
+func nodePages(contract DdcBucketContract, providerId types.OptionAccountID) func(offset types.U32, limit types.U32) ([]NodeInfo, types.U32, error) {
+	return func(offset types.U32, limit types.U32) ([]NodeInfo, types.U32, error) {
+		page, err := contract.NodeList(offset, limit, providerId)
+		if err != nil {
+			return nil, 0, err
+		}
+		return page.Nodes, page.Total, nil
+	}
+}
+
+func cdnNodePages(contract DdcBucketContract, providerId types.OptionAccountID) func(offset types.U32, limit types.U32) ([]CdnNodeInfo, types.U32, error) {
+	return func(offset types.U32, limit types.U32) ([]CdnNodeInfo, types.U32, error) {
+		page, err := contract.CdnNodeList(offset, limit, providerId)
+		if err != nil {
+			return nil, 0, err
+		}
+		return page.Nodes, page.Total, nil
+	}
+}
+
+func bucketPages(contract DdcBucketContract, ownerId types.OptionAccountID) func(offset types.U32, limit types.U32) ([]BucketInfo, types.U32, error) {
+	return func(offset types.U32, limit types.U32) ([]BucketInfo, types.U32, error) {
+		page, err := contract.BucketList(offset, limit, ownerId)
+		if err != nil {
+			return nil, 0, err
+		}
+		return page.Buckets, page.Total, nil
+	}
+}
@@ -0,0 +1,85 @@
+package bucket
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingPageContract struct {
+	pagedContract
+	failFrom types.U32
+}
+
+func (c *failingPageContract) NodeList(offset types.U32, limit types.U32, filterProviderId types.OptionAccountID) (*NodeListInfo, error) {
+	if offset >= c.failFrom {
+		c.calls = append(c.calls, offset)
+		return nil, errors.New("connection closed")
+	}
+	return c.pagedContract.NodeList(offset, limit, filterProviderId)
+}
+
+func TestNodeIterator(t *testing.T) {
+	//given
+	contract := &pagedContract{nodes: testNodes(10)}
+	it := NewNodeIterator(contract, ListFilter[NodeInfo]{PageSize: 4})
+
+	//when
+	var rents []int64
+	for it.Next() {
+		rents = append(rents, it.Value().Node.RentPerMonth.Int.Int64())
+	}
+
+	//then
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, rents)
+	assert.Equal(t, []types.U32{0, 4, 8}, contract.calls)
+	assert.Equal(t, types.U32(10), it.Total())
+	assert.False(t, it.Next())
+	assert.Equal(t, []types.U32{0, 4, 8}, contract.calls)
+}
+
+func TestNodeIteratorReadsPagesLazily(t *testing.T) {
+	//given
+	contract := &pagedContract{nodes: testNodes(10)}
+	it := NewNodeIterator(contract, ListFilter[NodeInfo]{PageSize: 4})
+
+	//when
+	for i := 0; i < 5; i++ {
+		assert.True(t, it.Next())
+	}
+
+	//then
+	assert.Equal(t, []types.U32{0, 4}, contract.calls)
+}
+
+func TestNodeIteratorSurfacesErrors(t *testing.T) {
+	//given
+	contract := &failingPageContract{pagedContract: pagedContract{nodes: testNodes(10)}, failFrom: 4}
+	it := NewNodeIterator(contract, ListFilter[NodeInfo]{PageSize: 4})
+
+	//when
+	var count int
+	for it.Next() {
+		count++
+	}
+
+	//then
+	assert.Equal(t, 4, count)
+	assert.EqualError(t, it.Err(), "connection closed")
+	assert.False(t, it.Next())
+	assert.Equal(t, []types.U32{0, 4}, contract.calls)
+}
+
+func TestNodeIteratorEmptyList(t *testing.T) {
+	//given
+	contract := &pagedContract{}
+	it := NewNodeIterator(contract, ListFilter[NodeInfo]{})
+
+	//then
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []types.U32{0}, contract.calls)
+}
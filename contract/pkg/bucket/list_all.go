@@ -0,0 +1,53 @@
+package bucket
+
+import (
+	"context"
+)
+
+// ClusterListAll sends all clusters matching the filter to the returned channel, see listAll.
+func ClusterListAll(ctx context.Context, contract DdcBucketContract, filter ListFilter[ClusterInfo]) (<-chan ClusterInfo, <-chan error) {
+	return listAll(ctx, NewClusterIterator(contract, filter))
+}
+
+// NodeListAll sends all storage nodes matching the filter to the returned channel, see listAll.
+func NodeListAll(ctx context.Context, contract DdcBucketContract, filter ListFilter[NodeInfo]) (<-chan NodeInfo, <-chan error) {
+	return listAll(ctx, NewNodeIterator(contract, filter))
+}
+
+// CdnNodeListAll sends all CDN nodes matching the filter to the returned channel, see listAll.
+func CdnNodeListAll(ctx context.Context, contract DdcBucketContract, filter ListFilter[CdnNodeInfo]) (<-chan CdnNodeInfo, <-chan error) {
+	return listAll(ctx, NewCdnNodeIterator(contract, filter))
+}
+
+// BucketListAll sends all buckets matching the filter to the returned channel, see listAll.
+func BucketListAll(ctx context.Context, contract DdcBucketContract, filter ListFilter[BucketInfo]) (<-chan BucketInfo, <-chan error) {
+	return listAll(ctx, NewBucketIterator(contract, filter))
+}
+
+// listAll sends the entities of the iterator to the entities channel from a goroutine. The channel
+// is unbuffered, so the next page is read only once the consumer received the current one. Both
+// channels are closed when the list ends, the read fails or the context is done; the error channel
+// receives the error first, if any. Consumers that stop early should cancel the context.
+func listAll[T any](ctx context.Context, it *Iterator[T]) (<-chan T, <-chan error) {
+	entities := make(chan T)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(entities)
+
+		for it.Next() {
+			select {
+			case entities <- it.Value():
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			errs <- err
+		}
+	}()
+
+	return entities, errs
+}
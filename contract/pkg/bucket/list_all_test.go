@@ -0,0 +1,58 @@
+package bucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeListAll(t *testing.T) {
+	//given
+	contract := &pagedContract{nodes: testNodes(10)}
+
+	//when
+	nodes, errs := NodeListAll(context.Background(), contract, ListFilter[NodeInfo]{PageSize: 4})
+	var count int
+	for range nodes {
+		count++
+	}
+
+	//then
+	assert.NoError(t, <-errs)
+	assert.Equal(t, 10, count)
+	assert.Equal(t, []types.U32{0, 4, 8}, contract.calls)
+}
+
+func TestNodeListAllError(t *testing.T) {
+	//given
+	contract := &failingPageContract{pagedContract: pagedContract{nodes: testNodes(10)}, failFrom: 4}
+
+	//when
+	nodes, errs := NodeListAll(context.Background(), contract, ListFilter[NodeInfo]{PageSize: 4})
+	var count int
+	for range nodes {
+		count++
+	}
+
+	//then
+	assert.EqualError(t, <-errs, "connection closed")
+	assert.Equal(t, 4, count)
+}
+
+func TestNodeListAllCanceled(t *testing.T) {
+	//given
+	contract := &pagedContract{nodes: testNodes(10)}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	//when
+	nodes, errs := NodeListAll(ctx, contract, ListFilter[NodeInfo]{PageSize: 4})
+	<-nodes
+	cancel()
+	err := <-errs
+
+	//then
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []types.U32{0}, contract.calls)
+}
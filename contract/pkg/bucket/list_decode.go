@@ -0,0 +1,92 @@
+package bucket
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// ListIterator lists entities decoding them one by one instead of into a slice, so the peak memory
+// of responses with tens of thousands of entities is the encoded response and one entity. Methods
+// pass entities to yield until it returns false and return the total number of entities, which is
+// known only when all entities of the response were passed, 0 otherwise.
+type ListIterator interface {
+	ClusterListEach(offset types.U32, limit types.U32, filterManagerId types.OptionAccountID, yield func(cluster ClusterInfo) bool) (types.U32, error)
+	NodeListEach(offset types.U32, limit types.U32, filterProviderId types.OptionAccountID, yield func(node NodeInfo) bool) (types.U32, error)
+	CdnNodeListEach(offset types.U32, limit types.U32, filterProviderId types.OptionAccountID, yield func(node CdnNodeInfo) bool) (types.U32, error)
+}
+
+func (d *ddcBucketContract) ClusterListEach(offset types.U32, limit types.U32, filterManagerId types.OptionAccountID, yield func(cluster ClusterInfo) bool) (types.U32, error) {
+	data, err := d.readEncoded(d.listTimeout, d.clusterListMethodId, offset, limit, filterManagerId)
+	if err != nil {
+		return 0, err
+	}
+	d.lastAccessTime = d.clock.Now()
+
+	return decodeListEach(data, yield)
+}
+
+func (d *ddcBucketContract) NodeListEach(offset types.U32, limit types.U32, filterProviderId types.OptionAccountID, yield func(node NodeInfo) bool) (types.U32, error) {
+	data, err := d.readEncoded(d.listTimeout, d.nodeListMethodId, offset, limit, filterProviderId)
+	if err != nil {
+		return 0, err
+	}
+	d.lastAccessTime = d.clock.Now()
+
+	return decodeListEach(data, yield)
+}
+
+func (d *ddcBucketContract) CdnNodeListEach(offset types.U32, limit types.U32, filterProviderId types.OptionAccountID, yield func(node CdnNodeInfo) bool) (types.U32, error) {
+	data, err := d.readEncoded(d.listTimeout, d.cdnNodeListMethodId, offset, limit, filterProviderId)
+	if err != nil {
+		return 0, err
+	}
+	d.lastAccessTime = d.clock.Now()
+
+	return decodeListEach(data, yield)
+}
+
+// decodeListEach decodes the hex encoded (Vec<T>, u32) list response item by item.
+func decodeListEach[T any](data string, yield func(item T) bool) (types.U32, error) {
+	encoded, err := codec.HexDecodeString(data)
+	if err != nil {
+		return 0, err
+	}
+	decoder := scale.NewDecoder(bytes.NewReader(encoded))
+
+	n, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return 0, err
+	}
+	for i := uint64(0); i < n.Uint64(); i++ {
+		var item T
+		if err := decodeItem(decoder, &item); err != nil {
+			return 0, err
+		}
+		if !yield(item) {
+			return 0, nil
+		}
+	}
+
+	var total types.U32
+	if err := decodeItem(decoder, &total); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// decodeItem decodes the next item recovering a panic of the decoder, which panics on some
+// truncated inputs instead of returning an error, e.g. in the middle of a big integer.
+func decodeItem(decoder *scale.Decoder, target interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("decode %T: %v", target, r)
+		}
+	}()
+
+	return decoder.Decode(target)
+}
@@ -0,0 +1,56 @@
+package bucket
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeListEach(t *testing.T) {
+	nodes := testNodes(5)
+	data, err := codec.EncodeToHex(NodeListInfo{Nodes: nodes, Total: 42})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name string
+		// stopAfter is the number of nodes yield stops after, all nodes are passed if 0.
+		stopAfter int
+		wantNodes []NodeInfo
+		wantTotal types.U32
+	}{
+		{name: "all nodes", wantNodes: nodes, wantTotal: 42},
+		{name: "stopped", stopAfter: 2, wantNodes: nodes[:2], wantTotal: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			var got []NodeInfo
+
+			//when
+			total, err := decodeListEach(data, func(node NodeInfo) bool {
+				got = append(got, node)
+				return tt.stopAfter == 0 || len(got) < tt.stopAfter
+			})
+
+			//then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNodes, got)
+			assert.Equal(t, tt.wantTotal, total)
+		})
+	}
+}
+
+func TestDecodeListEachTruncated(t *testing.T) {
+	//given
+	data, err := codec.EncodeToHex(NodeListInfo{Nodes: testNodes(2), Total: 2})
+	assert.NoError(t, err)
+
+	//when
+	_, err = decodeListEach(data[:len(data)-10], func(NodeInfo) bool { return true })
+
+	//then
+	assert.Error(t, err)
+}
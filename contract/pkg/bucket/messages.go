@@ -4,34 +4,38 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"strings"
 	"time"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors"
 )
 
 type (
-	Balance             = types.U128
-	Cash                = Balance
-	Resource            = types.U32
-	Token               = types.U64
-	ClusterId           = types.U32
-	AccountId           = types.AccountID
-	ProviderId          = AccountId
-	BucketId            = types.U32
-	Params              = string
-	BucketParams        = Params
-	NodeParams          = Params
-	CdnNodeParams       = Params
-	NodeStatusInCluster = uint8
-	NodeKey             = AccountId
-	CdnNodeKey          = AccountId
-	Rent                = Balance
+	Balance       = types.U128
+	Cash          = Balance
+	Resource      = types.U32
+	Token         = types.U64
+	ClusterId     = types.U32
+	AccountId     = types.AccountID
+	ProviderId    = AccountId
+	BucketId      = types.U32
+	Params        = string
+	NodeParams    = Params
+	CdnNodeParams = Params
+	NodeKey       = AccountId
+	CdnNodeKey    = AccountId
+	Rent          = Balance
 )
 
 type UsdPerCereInfo struct {
 	Balance Balance
 }
 
+// NodeStatusInCluster is the status of a storage or CDN node in its cluster, a variant of the
+// NodeStatusInCluster enum of the contract.
+type NodeStatusInCluster uint8
+
 const (
 	ADDING = iota
 	ACTIVE
@@ -39,7 +43,16 @@ const (
 	OFFLINE
 )
 
-const UNKNOWN_NODE_STATUS_IN_CLUSTER byte = 0xFF
+const (
+	NodeStatusAdding   NodeStatusInCluster = ADDING
+	NodeStatusActive   NodeStatusInCluster = ACTIVE
+	NodeStatusDeleting NodeStatusInCluster = DELETING
+	NodeStatusOffline  NodeStatusInCluster = OFFLINE
+)
+
+const UNKNOWN_NODE_STATUS_IN_CLUSTER NodeStatusInCluster = 0xFF
+
+var ErrInvalidNodeStatusInCluster = ddcerrors.New(ddcerrors.CodeInvalidArgument, "invalid node status in cluster")
 
 var NodeStatusesInClusterMap = map[string]byte{
 	"ADDING":   ADDING,
@@ -48,79 +61,164 @@ var NodeStatusesInClusterMap = map[string]byte{
 	"OFFLINE":  OFFLINE,
 }
 
+// Cluster is a group of storage and CDN nodes serving buckets.
 type Cluster struct {
-	ManagerId        AccountId
-	Params           Params
-	NodesKeys        []NodeKey
+	// ManagerId is the account managing the cluster.
+	ManagerId AccountId
+	// Params are JSON encoded cluster parameters, see ClusterParams.
+	Params Params
+	// NodesKeys are keys of storage nodes added to the cluster.
+	NodesKeys []NodeKey
+	// ResourcePerVNode is the resource each virtual node of the cluster provides.
 	ResourcePerVNode Resource
-	ResourceUsed     Resource
-	Revenues         Cash
-	TotalRent        Balance
-	CdnNodesKeys     []CdnNodeKey
-	CdnRevenues      Cash
-	CdnUsdPerGb      Balance
+	// ResourceUsed is the resource reserved by buckets allocated into the cluster.
+	ResourceUsed Resource
+	// Revenues are collected from buckets and not yet distributed to storage node providers.
+	Revenues Cash
+	// TotalRent is the sum of rents of the cluster storage nodes.
+	TotalRent Balance
+	// CdnNodesKeys are keys of CDN nodes added to the cluster.
+	CdnNodesKeys []CdnNodeKey
+	// CdnRevenues are collected for CDN usage and not yet distributed to CDN node providers.
+	CdnRevenues Cash
+	// CdnUsdPerGb is the price of the CDN traffic.
+	CdnUsdPerGb Balance
+}
+
+// RevenueDistribution is the client-side estimate of the cluster revenues distribution.
+type RevenueDistribution struct {
+	ClusterId ClusterId
+	// Revenues are the cluster revenues to distribute.
+	Revenues Cash
+	// Payouts are the amounts paid to the providers of the cluster nodes.
+	Payouts []ProviderPayout
+	// Remainder is the part of the revenues which can't be split equally and stays in the cluster.
+	Remainder Cash
 }
 
+type ProviderPayout struct {
+	NodeKey    NodeKey
+	ProviderId ProviderId
+	Amount     Balance
+}
+
+// NewRevenueDistribution splits the revenues equally between the nodes the same way the contract
+// does. providers are the providers of the nodes in the same order.
+func NewRevenueDistribution(clusterId ClusterId, revenues Cash, nodesKeys []NodeKey, providers []ProviderId) *RevenueDistribution {
+	distribution := &RevenueDistribution{
+		ClusterId: clusterId,
+		Revenues:  revenues,
+		Payouts:   make([]ProviderPayout, len(nodesKeys)),
+		Remainder: revenues,
+	}
+	if len(nodesKeys) == 0 {
+		return distribution
+	}
+
+	total := new(big.Int)
+	if revenues.Int != nil {
+		total.Set(revenues.Int)
+	}
+	shares := big.NewInt(int64(len(nodesKeys)))
+	perShare, remainder := new(big.Int).QuoRem(total, shares, new(big.Int))
+	for i, nodeKey := range nodesKeys {
+		distribution.Payouts[i] = ProviderPayout{
+			NodeKey:    nodeKey,
+			ProviderId: providers[i],
+			Amount:     types.NewU128(*perShare),
+		}
+	}
+	distribution.Remainder = types.NewU128(*remainder)
+
+	return distribution
+}
+
+// NodeVNodesInfo is a storage node with the tokens of its virtual nodes in the cluster ring.
 type NodeVNodesInfo struct {
 	NodeKey NodeKey
 	VNodes  []Token
 }
 
+// ClusterInfo is a cluster with its id and virtual nodes, the result of ClusterGet.
 type ClusterInfo struct {
 	ClusterId   ClusterId
 	Cluster     Cluster
 	NodesVNodes []NodeVNodesInfo
 }
 
+// ClusterListInfo is a page of clusters and the total number of clusters matching the filter.
 type ClusterListInfo struct {
 	Clusters []ClusterInfo
 	Total    types.U32
 }
 
+// Node is a storage node.
 type Node struct {
-	ProviderId      ProviderId
-	RentPerMonth    Balance
-	FreeResources   Resource
-	Params          NodeParams
-	ClusterId       types.OptionU32
+	// ProviderId is the account of the node provider receiving the rent.
+	ProviderId ProviderId
+	// RentPerMonth is the rent the node provider charges for the node.
+	RentPerMonth Balance
+	// FreeResources is the node capacity not yet reserved by virtual nodes.
+	FreeResources Resource
+	// Params are JSON encoded node parameters, e.g. the node URL.
+	Params NodeParams
+	// ClusterId is the cluster the node is added to, if any.
+	ClusterId types.OptionU32
+	// StatusInCluster is the node status in its cluster, see GetStatusInCluster.
 	StatusInCluster types.OptionU8
 }
 
+// NodeInfo is a storage node with its key and virtual nodes, the result of NodeGet.
 type NodeInfo struct {
 	Key    NodeKey
 	Node   Node
 	VNodes []Token
 }
 
+// NodeListInfo is a page of storage nodes and the total number of nodes matching the filter.
 type NodeListInfo struct {
 	Nodes []NodeInfo
 	Total types.U32
 }
 
+// CdnNode is a CDN node.
 type CdnNode struct {
-	ProviderId           ProviderId
+	// ProviderId is the account of the node provider receiving payments.
+	ProviderId ProviderId
+	// UndistributedPayment is the payment for the node traffic not yet distributed to the provider.
 	UndistributedPayment Balance
-	Params               CdnNodeParams
-	ClusterId            types.OptionU32
-	StatusInCluster      types.OptionU8
+	// Params are JSON encoded node parameters, see CDNNodeParams.
+	Params CdnNodeParams
+	// ClusterId is the cluster the node is added to, if any.
+	ClusterId types.OptionU32
+	// StatusInCluster is the node status in its cluster, see GetStatusInCluster.
+	StatusInCluster types.OptionU8
 }
 
+// CdnNodeInfo is a CDN node with its key, the result of CdnNodeGet.
 type CdnNodeInfo struct {
 	Key  CdnNodeKey
 	Node CdnNode
 }
 
+// CdnNodeListInfo is a page of CDN nodes and the total number of nodes matching the filter.
 type CdnNodeListInfo struct {
 	Nodes []CdnNodeInfo
 	Total types.U32
 }
 
+// Bucket is a customer storage space in a cluster.
 type Bucket struct {
-	OwnerId            AccountId
-	ClusterId          ClusterId
-	ResourceReserved   Resource
+	// OwnerId is the account owning the bucket and paying for it.
+	OwnerId AccountId
+	// ClusterId is the cluster the bucket is created in.
+	ClusterId ClusterId
+	// ResourceReserved is the cluster resource allocated to the bucket.
+	ResourceReserved Resource
+	// PublicAvailability allows anyone to read the bucket.
 	PublicAvailability bool
-	GasConsumptionCap  Resource
+	// GasConsumptionCap limits the resource the bucket may consume.
+	GasConsumptionCap Resource
 }
 
 type Flow struct {
@@ -133,27 +231,72 @@ type Schedule struct {
 	Offset Balance
 }
 
+// BucketInfo is a bucket with its id, parameters and permissions, the result of BucketGet.
 type BucketInfo struct {
-	BucketId           BucketId
-	Bucket             Bucket
-	Params             BucketParams
-	WriterIds          []AccountId
-	ReaderIds          []AccountId
+	BucketId BucketId
+	Bucket   Bucket
+	// Params are JSON encoded bucket parameters, see ParseBucketParams.
+	Params Params
+	// WriterIds are accounts allowed to write to the bucket in addition to the owner.
+	WriterIds []AccountId
+	// ReaderIds are accounts allowed to read the bucket in addition to the owner and writers.
+	ReaderIds []AccountId
+	// RentCoveredUntilMs is the time in Unix milliseconds until which the owner deposit covers the
+	// bucket rent.
 	RentCoveredUntilMs types.U64
 }
 
+// BucketListInfo is a page of buckets and the total number of buckets matching the filter.
 type BucketListInfo struct {
 	Buckets []BucketInfo
 	Total   types.U32
 }
 
+// AccountListInfo is a page of accounts, e.g. bucket writers, and the total number of accounts.
+type AccountListInfo struct {
+	Accounts []AccountId
+	Total    types.U32
+}
+
+// NewAccountListPage cuts the page of accounts starting at offset and limited by limit.
+func NewAccountListPage(accounts []AccountId, offset types.U32, limit types.U32) *AccountListInfo {
+	total := types.U32(len(accounts))
+	if offset > total {
+		offset = total
+	}
+	end := total
+	if limit < total-offset {
+		end = offset + limit
+	}
+
+	return &AccountListInfo{Accounts: accounts[offset:end], Total: total}
+}
+
+// ContainsAccount reports whether the account is in the list.
+func ContainsAccount(accounts []AccountId, account AccountId) bool {
+	for _, a := range accounts {
+		if a == account {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Account is a customer account in the contract.
 type Account struct {
-	Deposit           Cash
-	Bonded            Cash
-	Negative          Cash
-	UnboundedAmount   Cash
+	// Deposit is the amount deposited to the account.
+	Deposit Cash
+	// Bonded is the amount bonded to pay for buckets.
+	Bonded Cash
+	// Negative is the debt when bonded amount doesn't cover the payments.
+	Negative Cash
+	// UnboundedAmount is the amount being unbonded.
+	UnboundedAmount Cash
+	// UnbondedTimestamp is the time in Unix milliseconds when the unbonding was requested.
 	UnbondedTimestamp types.U64
-	PayableSchedule   Schedule
+	// PayableSchedule is the schedule of the account payments for its buckets.
+	PayableSchedule Schedule
 }
 
 type BucketCreatedEvent struct {
@@ -179,7 +322,7 @@ type BucketAvailabilityUpdatedEvent struct {
 
 type BucketParamsSetEvent struct {
 	BucketId     BucketId
-	BucketParams BucketParams
+	BucketParams Params
 }
 
 type ClusterCreatedEvent struct {
@@ -342,7 +485,13 @@ func (c *ClusterInfo) ReplicationFactor() uint {
 }
 
 func (b *BucketInfo) RentExpired() bool {
-	return b.RentCoveredUntilMs < types.U64(time.Now().UnixMilli())
+	return b.RentExpiredAt(time.Now())
+}
+
+// RentExpiredAt reports whether the bucket rent is not covered at the given time. Use it with
+// the time of an injected pkg.Clock.
+func (b *BucketInfo) RentExpiredAt(now time.Time) bool {
+	return b.RentCoveredUntilMs < types.U64(now.UnixMilli())
 }
 
 func (b *BucketInfo) HasWriteAccess(publicKey []byte) bool {
@@ -401,7 +550,7 @@ func (n *Node) GetStatusInCluster() (NodeStatusInCluster, error) {
 	if !hasValue {
 		return UNKNOWN_NODE_STATUS_IN_CLUSTER, fmt.Errorf("unknown storage node status in cluster")
 	} else {
-		return uint8(val), nil
+		return NodeStatusInCluster(val), nil
 	}
 }
 
@@ -410,7 +559,7 @@ func (n *CdnNode) GetStatusInCluster() (NodeStatusInCluster, error) {
 	if !hasValue {
 		return UNKNOWN_NODE_STATUS_IN_CLUSTER, fmt.Errorf("unknown cdn node status in cluster")
 	} else {
-		return uint8(val), nil
+		return NodeStatusInCluster(val), nil
 	}
 }
 
@@ -421,3 +570,31 @@ func (n *NodeInfo) GetStatusInCluster() (NodeStatusInCluster, error) {
 func (n *CdnNodeInfo) GetStatusInCluster() (NodeStatusInCluster, error) {
 	return n.Node.GetStatusInCluster()
 }
+
+// ParseNodeStatusInCluster parses the name of the status, e.g. ACTIVE, case insensitively.
+func ParseNodeStatusInCluster(name string) (NodeStatusInCluster, error) {
+	if status, ok := NodeStatusesInClusterMap[strings.ToUpper(name)]; ok {
+		return NodeStatusInCluster(status), nil
+	}
+
+	return UNKNOWN_NODE_STATUS_IN_CLUSTER, fmt.Errorf("%w: %q", ErrInvalidNodeStatusInCluster, name)
+}
+
+func (s NodeStatusInCluster) String() string {
+	for name, status := range NodeStatusesInClusterMap {
+		if NodeStatusInCluster(status) == s {
+			return name
+		}
+	}
+
+	return "UNKNOWN"
+}
+
+// Validate returns ErrInvalidNodeStatusInCluster if the contract has no such status.
+func (s NodeStatusInCluster) Validate() error {
+	if s > NodeStatusOffline {
+		return fmt.Errorf("%w: %d", ErrInvalidNodeStatusInCluster, s)
+	}
+
+	return nil
+}
@@ -2,12 +2,15 @@ package bucket
 
 import (
 	"encoding/hex"
+	"math/big"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -27,6 +30,75 @@ func TestBucketWriteAccess(t *testing.T) {
 	assert.True(t, hasWriteAccess)
 }
 
+func TestBucketRentExpiredAt(t *testing.T) {
+	//given
+	coveredUntil := time.UnixMilli(1_700_000_000_000)
+	bucketInfo := &BucketInfo{RentCoveredUntilMs: types.U64(coveredUntil.UnixMilli())}
+
+	//then
+	assert.False(t, bucketInfo.RentExpiredAt(coveredUntil.Add(-time.Second)))
+	assert.False(t, bucketInfo.RentExpiredAt(coveredUntil))
+	assert.True(t, bucketInfo.RentExpiredAt(coveredUntil.Add(time.Millisecond)))
+}
+
+func TestNewAccountListPage(t *testing.T) {
+	accounts := []AccountId{{1}, {2}, {3}}
+
+	tests := []struct {
+		name   string
+		offset types.U32
+		limit  types.U32
+		want   []AccountId
+	}{
+		{name: "first page", offset: 0, limit: 2, want: accounts[:2]},
+		{name: "last page", offset: 2, limit: 2, want: accounts[2:]},
+		{name: "after end", offset: 5, limit: 2, want: []AccountId{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//when
+			page := NewAccountListPage(accounts, tt.offset, tt.limit)
+
+			//then
+			assert.Equal(t, tt.want, page.Accounts)
+			assert.Equal(t, types.U32(3), page.Total)
+		})
+	}
+}
+
+func TestNewRevenueDistribution(t *testing.T) {
+	//given
+	balance := func(v int64) Balance { return types.NewU128(*big.NewInt(v)) }
+	nodesKeys := []NodeKey{{1}, {2}, {3}}
+	providers := []ProviderId{{11}, {12}, {11}}
+
+	//when
+	distribution := NewRevenueDistribution(7, balance(100), nodesKeys, providers)
+
+	//then
+	assert.Equal(t, ClusterId(7), distribution.ClusterId)
+	assert.Len(t, distribution.Payouts, 3)
+	for i, payout := range distribution.Payouts {
+		assert.Equal(t, nodesKeys[i], payout.NodeKey)
+		assert.Equal(t, providers[i], payout.ProviderId)
+		assert.Equal(t, balance(33), payout.Amount)
+	}
+	assert.Equal(t, balance(1), distribution.Remainder)
+}
+
+func TestNewRevenueDistributionWithoutNodes(t *testing.T) {
+	//given
+	revenues := types.NewU128(*big.NewInt(100))
+
+	//when
+	distribution := NewRevenueDistribution(7, revenues, nil, nil)
+
+	//then
+	assert.Empty(t, distribution.Payouts)
+	assert.Equal(t, revenues, distribution.Remainder)
+}
+
 func TestClusterStatus_ReplicationFactor(t *testing.T) {
 	type fields struct {
 		ClusterId   ClusterId
@@ -80,3 +152,150 @@ func TestClusterStatus_ReplicationFactor(t *testing.T) {
 		})
 	}
 }
+
+func TestBucketEncodingLayout(t *testing.T) {
+	//given
+	ownerId := types.AccountID{1}
+	bucket := Bucket{
+		OwnerId:            ownerId,
+		ClusterId:          2,
+		ResourceReserved:   3,
+		PublicAvailability: true,
+		GasConsumptionCap:  4,
+	}
+
+	//when
+	encoded, err := codec.Encode(bucket)
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, append(ownerId[:], 2, 0, 0, 0, 3, 0, 0, 0, 1, 4, 0, 0, 0), encoded)
+}
+
+func TestMessagesCodecRoundTrip(t *testing.T) {
+	balance := func(v int64) Balance { return types.NewU128(*big.NewInt(v)) }
+
+	tests := []struct {
+		name    string
+		value   interface{}
+		decoded interface{}
+	}{
+		{
+			name: "BucketInfo",
+			value: &BucketInfo{
+				BucketId: 1,
+				Bucket: Bucket{
+					OwnerId:            types.AccountID{1},
+					ClusterId:          2,
+					ResourceReserved:   10,
+					PublicAvailability: true,
+					GasConsumptionCap:  20,
+				},
+				Params:             `{"replication":3}`,
+				WriterIds:          []AccountId{{2}},
+				ReaderIds:          []AccountId{{3}, {4}},
+				RentCoveredUntilMs: 1700000000000,
+			},
+			decoded: &BucketInfo{},
+		},
+		{
+			name: "ClusterInfo",
+			value: &ClusterInfo{
+				ClusterId: 1,
+				Cluster: Cluster{
+					ManagerId:        types.AccountID{1},
+					Params:           `{"replicationFactor":3}`,
+					NodesKeys:        []NodeKey{{2}, {3}},
+					ResourcePerVNode: 10,
+					ResourceUsed:     5,
+					Revenues:         balance(100),
+					TotalRent:        balance(200),
+					CdnNodesKeys:     []CdnNodeKey{{4}},
+					CdnRevenues:      balance(300),
+					CdnUsdPerGb:      balance(400),
+				},
+				NodesVNodes: []NodeVNodesInfo{{NodeKey: NodeKey{2}, VNodes: []Token{1, 2}}, {NodeKey: NodeKey{3}, VNodes: []Token{3}}},
+			},
+			decoded: &ClusterInfo{},
+		},
+		{
+			name: "NodeInfo",
+			value: &NodeInfo{
+				Key: NodeKey{1},
+				Node: Node{
+					ProviderId:      types.AccountID{2},
+					RentPerMonth:    balance(10),
+					FreeResources:   100,
+					Params:          `{"url":"https://node-1.cere.network"}`,
+					ClusterId:       types.NewOptionU32(1),
+					StatusInCluster: types.NewOptionU8(ACTIVE),
+				},
+				VNodes: []Token{1, 2, 3},
+			},
+			decoded: &NodeInfo{},
+		},
+		{
+			name: "CdnNodeInfo",
+			value: &CdnNodeInfo{
+				Key: CdnNodeKey{1},
+				Node: CdnNode{
+					ProviderId:           types.AccountID{2},
+					UndistributedPayment: balance(10),
+					Params:               `{"url":"https://cdn-1.cere.network"}`,
+					ClusterId:            types.NewOptionU32Empty(),
+					StatusInCluster:      types.NewOptionU8Empty(),
+				},
+			},
+			decoded: &CdnNodeInfo{},
+		},
+		{
+			name:    "ClusterNodeStatusSetEvent",
+			value:   &ClusterNodeStatusSetEvent{ClusterId: 2, NodeKey: AccountId{1}, NodeStatusInCluster: NodeStatusOffline},
+			decoded: &ClusterNodeStatusSetEvent{},
+		},
+		{
+			name: "Account",
+			value: &Account{
+				Deposit:           balance(1000),
+				Bonded:            balance(500),
+				Negative:          balance(1),
+				UnboundedAmount:   balance(100),
+				UnbondedTimestamp: 1700000000000,
+				PayableSchedule:   Schedule{Rate: balance(1), Offset: balance(2)},
+			},
+			decoded: &Account{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//when
+			encoded, err := codec.Encode(tt.value)
+			assert.NoError(t, err)
+			err = codec.Decode(encoded, tt.decoded)
+
+			//then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.value, tt.decoded)
+		})
+	}
+}
+
+func TestParseNodeStatusInCluster(t *testing.T) {
+	//when
+	status, err := ParseNodeStatusInCluster("active")
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, NodeStatusActive, status)
+	assert.Equal(t, "ACTIVE", status.String())
+
+	_, err = ParseNodeStatusInCluster("BLOCKED")
+	assert.ErrorIs(t, err, ErrInvalidNodeStatusInCluster)
+}
+
+func TestNodeStatusInClusterValidate(t *testing.T) {
+	assert.NoError(t, NodeStatusDeleting.Validate())
+	assert.ErrorIs(t, UNKNOWN_NODE_STATUS_IN_CLUSTER.Validate(), ErrInvalidNodeStatusInCluster)
+	assert.Equal(t, "UNKNOWN", UNKNOWN_NODE_STATUS_IN_CLUSTER.String())
+}
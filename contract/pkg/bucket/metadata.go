@@ -0,0 +1,252 @@
+package bucket
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var ErrMessageNotFound = errors.New("contract metadata has no message")
+
+// Selectors are selectors of the contract messages the client calls, resolved from contract
+// metadata. Load them with LoadSelectors and pass to CreateDdcBucketContract with WithSelectors.
+// The SDK doesn't embed contract metadata, so a contract created without WithSelectors calls the
+// selectors generated into selectors_gen.go.
+type Selectors struct {
+	methodIds map[string][]byte
+	// langError tells that messages return their result wrapped in Result<_, ink::LangError>, as
+	// contracts built with ink! 4 and newer do.
+	langError bool
+}
+
+// inkSpec is the part of ink! contract metadata with messages.
+type inkSpec struct {
+	Messages  []inkMessage    `json:"messages"`
+	LangError json.RawMessage `json:"lang_error"`
+}
+
+type inkMessage struct {
+	Label string `json:"label"`
+	// Name is the path of the message in metadata of ink! 3.0, the label in later versions.
+	Name     []string `json:"name"`
+	Selector string   `json:"selector"`
+}
+
+// LoadSelectors resolves selectors of all messages the client calls by their labels in ink!
+// metadata of the contract, e.g. bucket_get for BucketGet, so an upgraded contract is called with
+// selectors of its own build instead of the hardcoded ones. Embed the metadata.json of the deployed
+// contract with go:embed and load it at start. It fails if a message is missing.
+func LoadSelectors(metadata []byte) (*Selectors, error) {
+	spec, err := parseInkSpec(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	byLabel := make(map[string]string, len(spec.Messages))
+	for _, message := range spec.Messages {
+		byLabel[message.label()] = message.Selector
+	}
+
+	selectors := &Selectors{methodIds: make(map[string][]byte, len(methodSelectors)), langError: len(spec.LangError) > 0}
+	var missing []string
+	for name := range methodSelectors {
+		label := messageLabel(name)
+		selector, ok := byLabel[label]
+		if !ok {
+			missing = append(missing, label)
+			continue
+		}
+
+		id, err := hex.DecodeString(strings.TrimPrefix(selector, "0x"))
+		if err != nil || len(id) != 4 {
+			return nil, fmt.Errorf("message %s: selector %q is not 4 hex encoded bytes", label, selector)
+		}
+		selectors.methodIds[name] = id
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("%w: %s", ErrMessageNotFound, strings.Join(missing, ", "))
+	}
+
+	return selectors, nil
+}
+
+// WithSelectors replaces the hardcoded selectors with the ones loaded from contract metadata.
+func WithSelectors(selectors *Selectors) Option {
+	return func(d *ddcBucketContract) {
+		if err := d.UpdateSelectors(selectors); err != nil {
+			log.WithError(err).Fatal("Can't update selectors")
+		}
+	}
+}
+
+// UpdateSelectors replaces selectors of the contract messages, e.g. after a contract upgrade. Calls
+// in flight use either the previous or the new selectors. It fails without changing any selector
+// if one of the selectors is of a method the contract doesn't call.
+func (d *ddcBucketContract) UpdateSelectors(selectors *Selectors) error {
+	d.selectorsMutex.Lock()
+	defer d.selectorsMutex.Unlock()
+
+	methodIds := d.methodIds()
+	for name, id := range selectors.methodIds {
+		methodId, ok := methodIds[name]
+		if !ok {
+			return fmt.Errorf("no method id for selector %s", name)
+		}
+		if len(*methodId) != len(id) {
+			return fmt.Errorf("selector %s is %d bytes instead of %d", name, len(id), len(*methodId))
+		}
+	}
+
+	for name, id := range selectors.methodIds {
+		copy(*methodIds[name], id)
+	}
+	d.langError = selectors.langError
+
+	return nil
+}
+
+// hasLangError reports whether results are wrapped in Result<_, ink::LangError>.
+func (d *ddcBucketContract) hasLangError() bool {
+	d.selectorsMutex.RLock()
+	defer d.selectorsMutex.RUnlock()
+
+	return d.langError
+}
+
+// selector returns a copy of the method id safe to use while selectors are updated.
+func (d *ddcBucketContract) selector(methodId []byte) []byte {
+	d.selectorsMutex.RLock()
+	defer d.selectorsMutex.RUnlock()
+
+	return append([]byte(nil), methodId...)
+}
+
+// parseInkSpec finds the spec at the top level of metadata of ink! 4 and newer, or nested in the
+// object keyed by the metadata version in older versions, e.g. V3.
+func parseInkSpec(metadata []byte) (*inkSpec, error) {
+	var versioned map[string]json.RawMessage
+	if err := json.Unmarshal(metadata, &versioned); err != nil {
+		return nil, fmt.Errorf("parse contract metadata: %w", err)
+	}
+
+	raw, ok := versioned["spec"]
+	if !ok {
+		for _, version := range []string{"V3", "V2", "V1"} {
+			if nested, found := versioned[version]; found {
+				var m map[string]json.RawMessage
+				if err := json.Unmarshal(nested, &m); err != nil {
+					return nil, fmt.Errorf("parse contract metadata %s: %w", version, err)
+				}
+				raw, ok = m["spec"]
+				break
+			}
+		}
+	}
+	if !ok {
+		return nil, errors.New("parse contract metadata: no spec")
+	}
+
+	spec := &inkSpec{}
+	if err := json.Unmarshal(raw, spec); err != nil {
+		return nil, fmt.Errorf("parse contract metadata spec: %w", err)
+	}
+
+	return spec, nil
+}
+
+// label returns the message name without the trait prefix of trait messages.
+func (m inkMessage) label() string {
+	label := m.Label
+	if label == "" && len(m.Name) > 0 {
+		label = m.Name[len(m.Name)-1]
+	}
+	if i := strings.LastIndex(label, "::"); i >= 0 {
+		label = label[i+2:]
+	}
+
+	return label
+}
+
+// messageLabel converts the name of a selector constant to the message label, e.g. bucketGetMethod
+// to bucket_get.
+func messageLabel(name string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSuffix(name, "Method") {
+		if unicode.IsUpper(r) {
+			b.WriteByte('_')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// methodIds returns the method id fields by the names of their selector constants.
+func (d *ddcBucketContract) methodIds() map[string]*[]byte {
+	return map[string]*[]byte{
+		"nodeCreateMethod":                     &d.nodeCreateMethodId,
+		"nodeRemoveMethod":                     &d.nodeRemoveMethodId,
+		"nodeSetParamsMethod":                  &d.nodeSetParamsMethodId,
+		"nodeGetMethod":                        &d.nodeGetMethodId,
+		"nodeListMethod":                       &d.nodeListMethodId,
+		"cdnNodeCreateMethod":                  &d.cdnNodeCreateMethodId,
+		"cdnNodeRemoveMethod":                  &d.cdnNodeRemoveMethodId,
+		"cdnNodeSetParamsMethod":               &d.cdnNodeSetParamsMethodId,
+		"cdnNodeGetMethod":                     &d.cdnNodeGetMethodId,
+		"cdnNodeListMethod":                    &d.cdnNodeListMethodId,
+		"clusterCreateMethod":                  &d.clusterCreateMethodId,
+		"clusterAddNodeMethod":                 &d.clusterAddNodeMethodId,
+		"clusterRemoveNodeMethod":              &d.clusterRemoveNodeMethodId,
+		"clusterResetNodeMethod":               &d.clusterResetNodeMethodId,
+		"clusterReplaceNodeMethod":             &d.clusterReplaceNodeMethodId,
+		"clusterAddCdnNodeMethod":              &d.clusterAddCdnNodeMethodId,
+		"clusterRemoveCdnNodeMethod":           &d.clusterRemoveCdnNodeMethodId,
+		"clusterSetParamsMethod":               &d.clusterSetParamsMethodId,
+		"clusterRemoveMethod":                  &d.clusterRemoveMethodId,
+		"clusterSetNodeStatusMethod":           &d.clusterSetNodeStatusMethodId,
+		"clusterSetCdnNodeStatusMethod":        &d.clusterSetCdnNodeStatusMethodId,
+		"clusterDistributeRevenuesMethod":      &d.clusterDistributeRevenuesMethodId,
+		"clusterGetMethod":                     &d.clusterGetMethodId,
+		"clusterListMethod":                    &d.clusterListMethodId,
+		"hasPermissionMethod":                  &d.hasPermissionMethodId,
+		"grantTrustedManagerPermissionMethod":  &d.grantTrustedManagerPermissionMethodId,
+		"revokeTrustedManagerPermissionMethod": &d.revokeTrustedManagerPermissionMethodId,
+		"adminGrantPermissionMethod":           &d.adminGrantPermissionMethodId,
+		"adminRevokePermissionMethod":          &d.adminRevokePermissionMethodId,
+		"adminTransferNodeOwnershipMethod":     &d.adminTransferNodeOwnershipMethodId,
+		"adminTransferCdnNodeOwnershipMethod":  &d.adminTransferCdnNodeOwnershipMethodId,
+		"accountGetMethod":                     &d.accountGetMethodId,
+		"accountDepositMethod":                 &d.accountDepositMethodId,
+		"accountBondMethod":                    &d.accountBondMethodId,
+		"accountUnbondMethod":                  &d.accountUnbondMethodId,
+		"accountGetUsdPerCereMethod":           &d.accountGetUsdPerCereMethodId,
+		"accountSetUsdPerCereMethod":           &d.accountSetUsdPerCereMethodId,
+		"accountWithdrawUnbondedMethod":        &d.accountWithdrawUnbondedMethodId,
+		"getAccountsMethod":                    &d.getAccountsMethodId,
+		"bucketGetMethod":                      &d.bucketGetMethodId,
+		"bucketCreateMethod":                   &d.bucketCreateMethodId,
+		"bucketChangeOwnerMethod":              &d.bucketChangeOwnerMethodId,
+		"bucketAllocIntoClusterMethod":         &d.bucketAllocIntoClusterMethodId,
+		"bucketSettlePaymentMethod":            &d.bucketSettlePaymentMethodId,
+		"bucketChangeParamsMethod":             &d.bucketChangeParamsMethodId,
+		"bucketListMethod":                     &d.bucketListMethodId,
+		"bucketListForAccountMethod":           &d.bucketListForAccountMethodId,
+		"bucketSetAvailabilityMethod":          &d.bucketSetAvailabilityMethodId,
+		"bucketSetResourceCapMethod":           &d.bucketSetResourceCapMethodId,
+		"getBucketWritersMethod":               &d.getBucketWritersMethodId,
+		"getBucketReadersMethod":               &d.getBucketReadersMethodId,
+		"bucketSetWriterPermMethod":            &d.bucketSetWriterPermMethodId,
+		"bucketRevokeWriterPermMethod":         &d.bucketRevokeWriterPermMethodId,
+		"bucketSetReaderPermMethod":            &d.bucketSetReaderPermMethodId,
+		"bucketRevokeReaderPermMethod":         &d.bucketRevokeReaderPermMethodId,
+	}
+}
@@ -0,0 +1,106 @@
+package bucket
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// inkMetadataJson returns metadata with messages of all selectors, the selector of bucket_get
+// replaced with 0x01020304.
+func inkMetadataJson(t *testing.T, version string, skip string) []byte {
+	var messages []map[string]string
+	for name, selector := range methodSelectors {
+		label := messageLabel(name)
+		if label == skip {
+			continue
+		}
+		if label == "bucket_get" {
+			selector = "01020304"
+		}
+		messages = append(messages, map[string]string{"label": label, "selector": "0x" + selector})
+	}
+
+	var metadata interface{} = map[string]interface{}{"spec": map[string]interface{}{"messages": messages}}
+	if version != "" {
+		metadata = map[string]interface{}{version: metadata}
+	}
+	data, err := json.Marshal(metadata)
+	assert.NoError(t, err)
+
+	return data
+}
+
+func TestLoadSelectors(t *testing.T) {
+	for _, version := range []string{"", "V3"} {
+		t.Run("version "+version, func(t *testing.T) {
+			//when
+			selectors, err := LoadSelectors(inkMetadataJson(t, version, ""))
+
+			//then
+			assert.NoError(t, err)
+			d := CreateDdcBucketContract(nil, "", WithSelectors(selectors)).(*ddcBucketContract)
+			assert.Equal(t, []byte{1, 2, 3, 4}, d.bucketGetMethodId)
+			assert.Equal(t, []byte{0x84, 0x7f, 0x39, 0x97}, d.nodeGetMethodId)
+			assert.False(t, d.hasLangError())
+		})
+	}
+}
+
+func TestLoadSelectorsLangError(t *testing.T) {
+	//given
+	var metadata map[string]map[string]interface{}
+	assert.NoError(t, json.Unmarshal(inkMetadataJson(t, "", ""), &metadata))
+	metadata["spec"]["lang_error"] = map[string]interface{}{"type": 3}
+	data, err := json.Marshal(metadata)
+	assert.NoError(t, err)
+
+	//when
+	selectors, err := LoadSelectors(data)
+
+	//then
+	assert.NoError(t, err)
+	d := CreateDdcBucketContract(nil, "", WithSelectors(selectors)).(*ddcBucketContract)
+	assert.True(t, d.hasLangError())
+}
+
+func TestLoadSelectorsMissingMessage(t *testing.T) {
+	_, err := LoadSelectors(inkMetadataJson(t, "", "cluster_get"))
+
+	assert.ErrorIs(t, err, ErrMessageNotFound)
+	assert.EqualError(t, err, "contract metadata has no message: cluster_get")
+}
+
+func TestUpdateSelectorsUnknownMethod(t *testing.T) {
+	//given
+	d := CreateDdcBucketContract(nil, "").(*ddcBucketContract)
+	bucketGetMethodId := append([]byte(nil), d.bucketGetMethodId...)
+	selectors := &Selectors{methodIds: map[string][]byte{
+		"bucketGetMethod":     {1, 2, 3, 4},
+		"bucketUnknownMethod": {5, 6, 7, 8},
+	}}
+
+	//when
+	err := d.UpdateSelectors(selectors)
+
+	//then
+	assert.EqualError(t, err, "no method id for selector bucketUnknownMethod")
+	assert.Equal(t, bucketGetMethodId, d.bucketGetMethodId)
+}
+
+func TestMethodIdsCoverSelectors(t *testing.T) {
+	methodIds := (&ddcBucketContract{}).methodIds()
+
+	assert.Len(t, methodIds, len(methodSelectors))
+	for name := range methodSelectors {
+		assert.Contains(t, methodIds, name)
+	}
+}
+
+func TestMessageLabel(t *testing.T) {
+	assert.Equal(t, "bucket_get", messageLabel("bucketGetMethod"))
+	assert.Equal(t, "account_get_usd_per_cere", messageLabel("accountGetUsdPerCereMethod"))
+	assert.Equal(t, "bucket_set_writer_perm", inkMessage{Label: "Bucket::bucket_set_writer_perm"}.label())
+	assert.Equal(t, "bucket_get", inkMessage{Name: []string{"bucket_get"}}.label())
+}
@@ -0,0 +1,53 @@
+package bucket
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	bytesInGb = 1 << 30
+)
+
+var (
+	ErrInvalidResource = errors.New("invalid resource")
+)
+
+// StorageGb is a storage capacity in gigabytes. It's the only resource the bucket contract
+// accounts: node capacity, bucket reservation and resource cap are all Resource values in GB.
+type StorageGb uint64
+
+// StorageGbFromBytes returns the storage capacity in GB needed to store the given bytes.
+func StorageGbFromBytes(bytes uint64) StorageGb {
+	gb := bytes / bytesInGb
+	if bytes%bytesInGb != 0 {
+		gb++
+	}
+
+	return StorageGb(gb)
+}
+
+// StorageGbFromResource converts the contract Resource value to the storage capacity.
+func StorageGbFromResource(resource Resource) StorageGb {
+	return StorageGb(resource)
+}
+
+func (s StorageGb) Bytes() uint64 {
+	return uint64(s) * bytesInGb
+}
+
+// Resource converts the storage capacity to the contract Resource value. The capacity must be
+// positive and fit the contract Resource type.
+func (s StorageGb) Resource() (Resource, error) {
+	if s == 0 {
+		return 0, fmt.Errorf("%w: zero storage", ErrInvalidResource)
+	}
+	if s > math.MaxUint32 {
+		return 0, fmt.Errorf("%w: storage %d GB exceeds %d GB", ErrInvalidResource, uint64(s), uint64(math.MaxUint32))
+	}
+
+	return types.U32(s), nil
+}
@@ -0,0 +1,51 @@
+package bucket
+
+import (
+	"math"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageGbFromBytes(t *testing.T) {
+	tests := []struct {
+		name  string
+		bytes uint64
+		want  StorageGb
+	}{
+		{name: "zero", bytes: 0, want: 0},
+		{name: "one byte", bytes: 1, want: 1},
+		{name: "exact", bytes: 2 << 30, want: 2},
+		{name: "round up", bytes: 2<<30 + 1, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StorageGbFromBytes(tt.bytes))
+		})
+	}
+}
+
+func TestStorageGbResource(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage StorageGb
+		want    Resource
+		wantErr error
+	}{
+		{name: "valid", storage: 100, want: types.U32(100)},
+		{name: "max", storage: math.MaxUint32, want: types.U32(math.MaxUint32)},
+		{name: "zero", storage: 0, wantErr: ErrInvalidResource},
+		{name: "overflow", storage: math.MaxUint32 + 1, wantErr: ErrInvalidResource},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, err := tt.storage.Resource()
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, resource)
+		})
+	}
+}
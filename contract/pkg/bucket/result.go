@@ -6,6 +6,7 @@ import (
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
 )
 
 const (
@@ -16,9 +17,17 @@ const (
 type Result struct {
 	data interface{}
 	err  error
+	// langError tells that the result is wrapped in Result<_, ink::LangError>.
+	langError bool
 }
 
 func (result *Result) decodeDdcBucketContract(encodedData string) error {
+	if result.langError {
+		var err error
+		if encodedData, err = unwrapLangError(encodedData); err != nil {
+			return err
+		}
+	}
 
 	if strings.HasPrefix(encodedData, okPrefix) {
 		encodedData = strings.TrimPrefix(encodedData, okPrefix)
@@ -41,3 +50,21 @@ func (result *Result) decodeDdcBucketContract(encodedData string) error {
 
 	return errors.New("can't decode storage contract result")
 }
+
+// unwrapLangError returns the hex encoded message result of Result<_, ink::LangError>, or the
+// decoded language error, e.g. pkg.ErrCouldNotReadInput.
+func unwrapLangError(encodedData string) (string, error) {
+	if strings.HasPrefix(encodedData, okPrefix) {
+		return "0x" + strings.TrimPrefix(encodedData, okPrefix), nil
+	}
+
+	if strings.HasPrefix(encodedData, errPrefix) {
+		var variant types.U8
+		if err := codec.DecodeFromHex(strings.TrimPrefix(encodedData, errPrefix), &variant); err != nil {
+			return "", err
+		}
+		return "", pkg.DecodeLangError(uint8(variant))
+	}
+
+	return "", errors.New("can't decode contract language result")
+}
@@ -0,0 +1,42 @@
+package bucket
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeDdcBucketContract(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		langError bool
+		want      types.U32
+		wantErr   error
+	}{
+		{name: "ok", data: "0x0007000000", want: 7},
+		{name: "contract error", data: "0x0123", wantErr: ErrBucketDoesNotExist},
+		{name: "ok in lang result", data: "0x000007000000", langError: true, want: 7},
+		{name: "contract error in lang result", data: "0x000110", langError: true, wantErr: ErrUnauthorized},
+		{name: "lang error", data: "0x0101", langError: true, wantErr: pkg.ErrCouldNotReadInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			var value types.U32
+			res := Result{data: &value, langError: tt.langError}
+
+			//when
+			err := res.decodeDdcBucketContract(tt.data)
+			if err == nil {
+				err = res.err
+			}
+
+			//then
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package bucket
+
+import (
+	"time"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors"
+)
+
+type (
+	// rotatingContract is the contract at the new address which falls back to the contract at the
+	// previous address for entities not found at the new one until the grace period ends.
+	rotatingContract struct {
+		DdcBucketContract
+		previous   DdcBucketContract
+		graceUntil time.Time
+		clock      pkg.Clock
+	}
+
+	RotationOption func(r *rotatingContract)
+)
+
+// WithRotationClock sets the clock the grace period is checked with.
+func WithRotationClock(clock pkg.Clock) RotationOption {
+	return func(r *rotatingContract) {
+		r.clock = clock
+	}
+}
+
+// CreateRotatingContract switches consumers to a redeployed bucket contract without downtime.
+// Transactions, lists and events target the current contract. Bucket, cluster, node, CDN node and
+// account reads are served by the current contract and fall back to the previous one if the entity
+// isn't found, until the grace period starting now ends.
+func CreateRotatingContract(current DdcBucketContract, previous DdcBucketContract, gracePeriod time.Duration, opts ...RotationOption) DdcBucketContract {
+	r := &rotatingContract{DdcBucketContract: current, previous: previous, clock: pkg.SystemClock}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.graceUntil = r.clock.Now().Add(gracePeriod)
+
+	return r
+}
+
+func (r *rotatingContract) BucketGet(bucketId BucketId) (*BucketInfo, error) {
+	return readWithFallback(r, func(contract DdcBucketContract) (*BucketInfo, error) {
+		return contract.BucketGet(bucketId)
+	})
+}
+
+func (r *rotatingContract) ClusterGet(clusterId ClusterId) (*ClusterInfo, error) {
+	return readWithFallback(r, func(contract DdcBucketContract) (*ClusterInfo, error) {
+		return contract.ClusterGet(clusterId)
+	})
+}
+
+func (r *rotatingContract) NodeGet(nodeKey NodeKey) (*NodeInfo, error) {
+	return readWithFallback(r, func(contract DdcBucketContract) (*NodeInfo, error) {
+		return contract.NodeGet(nodeKey)
+	})
+}
+
+func (r *rotatingContract) CdnNodeGet(nodeKey CdnNodeKey) (*CdnNodeInfo, error) {
+	return readWithFallback(r, func(contract DdcBucketContract) (*CdnNodeInfo, error) {
+		return contract.CdnNodeGet(nodeKey)
+	})
+}
+
+func (r *rotatingContract) AccountGet(account AccountId) (*Account, error) {
+	return readWithFallback(r, func(contract DdcBucketContract) (*Account, error) {
+		return contract.AccountGet(account)
+	})
+}
+
+func (r *rotatingContract) inGracePeriod() bool {
+	return r.clock.Now().Before(r.graceUntil)
+}
+
+func readWithFallback[T any](r *rotatingContract, read func(contract DdcBucketContract) (T, error)) (T, error) {
+	result, err := read(r.DdcBucketContract)
+	if err == nil || !ddcerrors.Is(err, ddcerrors.CodeNotFound) || !r.inGracePeriod() {
+		return result, err
+	}
+
+	previousResult, previousErr := read(r.previous)
+	if previousErr != nil {
+		// The entity is reported missing at the current address.
+		return result, err
+	}
+
+	return previousResult, nil
+}
@@ -0,0 +1,59 @@
+package bucket
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+type addressedContract struct {
+	DdcBucketContract
+	address string
+	buckets map[BucketId]*BucketInfo
+	err     error
+}
+
+func (c *addressedContract) BucketGet(bucketId BucketId) (*BucketInfo, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if bucket, ok := c.buckets[bucketId]; ok {
+		return bucket, nil
+	}
+	return nil, ErrBucketDoesNotExist
+}
+
+func (c *addressedContract) GetContractAddress() string {
+	return c.address
+}
+
+func TestRotatingContract(t *testing.T) {
+	//given
+	now := time.Unix(1_700_000_000, 0)
+	clock := pkg.ClockFunc(func() time.Time { return now })
+	current := &addressedContract{address: "new", buckets: map[BucketId]*BucketInfo{1: {BucketId: 1}}}
+	previous := &addressedContract{address: "old", buckets: map[BucketId]*BucketInfo{2: {BucketId: 2}}}
+	contract := CreateRotatingContract(current, previous, time.Hour, WithRotationClock(clock))
+
+	//when
+	fromCurrent, currentErr := contract.BucketGet(1)
+	fromPrevious, previousErr := contract.BucketGet(2)
+	_, missingErr := contract.BucketGet(3)
+	now = now.Add(time.Hour)
+	_, expiredErr := contract.BucketGet(2)
+	current.err = errors.New("connection refused")
+	_, rpcErr := contract.BucketGet(2)
+
+	//then
+	assert.Equal(t, "new", contract.GetContractAddress())
+	assert.NoError(t, currentErr)
+	assert.Equal(t, BucketId(1), fromCurrent.BucketId)
+	assert.NoError(t, previousErr)
+	assert.Equal(t, BucketId(2), fromPrevious.BucketId)
+	assert.ErrorIs(t, missingErr, ErrBucketDoesNotExist)
+	assert.ErrorIs(t, expiredErr, ErrBucketDoesNotExist)
+	assert.EqualError(t, rpcErr, "connection refused")
+}
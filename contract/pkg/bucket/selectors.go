@@ -0,0 +1,41 @@
+package bucket
+
+//go:generate go run ../../cmd/selectorgen -source ddc_bucket_contract.go -output selectors_gen.go
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+)
+
+func init() {
+	if err := validateSelectors(methodSelectors); err != nil {
+		panic(err)
+	}
+}
+
+// validateSelectors checks every selector is 4 hex encoded bytes and no selector is used by two methods.
+func validateSelectors(selectors map[string]string) error {
+	names := make([]string, 0, len(selectors))
+	for name := range selectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	seen := make(map[string]string, len(selectors))
+	for _, name := range names {
+		selector := selectors[name]
+		if selector == "" {
+			return fmt.Errorf("method selector %s is empty", name)
+		}
+		if decoded, err := hex.DecodeString(selector); err != nil || len(decoded) != 4 {
+			return fmt.Errorf("method selector %s %q is not 4 hex encoded bytes", name, selector)
+		}
+		if other, ok := seen[selector]; ok {
+			return fmt.Errorf("method selector %s %q duplicates %s", name, selector, other)
+		}
+		seen[selector] = name
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+// Code generated by selectorgen from ddc_bucket_contract.go. DO NOT EDIT.
+
+package bucket
+
+var methodSelectors = map[string]string{
+	"accountBondMethod":                    accountBondMethod,
+	"accountDepositMethod":                 accountDepositMethod,
+	"accountGetMethod":                     accountGetMethod,
+	"accountGetUsdPerCereMethod":           accountGetUsdPerCereMethod,
+	"accountSetUsdPerCereMethod":           accountSetUsdPerCereMethod,
+	"accountUnbondMethod":                  accountUnbondMethod,
+	"accountWithdrawUnbondedMethod":        accountWithdrawUnbondedMethod,
+	"adminGrantPermissionMethod":           adminGrantPermissionMethod,
+	"adminRevokePermissionMethod":          adminRevokePermissionMethod,
+	"adminTransferCdnNodeOwnershipMethod":  adminTransferCdnNodeOwnershipMethod,
+	"adminTransferNodeOwnershipMethod":     adminTransferNodeOwnershipMethod,
+	"bucketAllocIntoClusterMethod":         bucketAllocIntoClusterMethod,
+	"bucketChangeOwnerMethod":              bucketChangeOwnerMethod,
+	"bucketChangeParamsMethod":             bucketChangeParamsMethod,
+	"bucketCreateMethod":                   bucketCreateMethod,
+	"bucketGetMethod":                      bucketGetMethod,
+	"bucketListForAccountMethod":           bucketListForAccountMethod,
+	"bucketListMethod":                     bucketListMethod,
+	"bucketRevokeReaderPermMethod":         bucketRevokeReaderPermMethod,
+	"bucketRevokeWriterPermMethod":         bucketRevokeWriterPermMethod,
+	"bucketSetAvailabilityMethod":          bucketSetAvailabilityMethod,
+	"bucketSetReaderPermMethod":            bucketSetReaderPermMethod,
+	"bucketSetResourceCapMethod":           bucketSetResourceCapMethod,
+	"bucketSetWriterPermMethod":            bucketSetWriterPermMethod,
+	"bucketSettlePaymentMethod":            bucketSettlePaymentMethod,
+	"cdnNodeCreateMethod":                  cdnNodeCreateMethod,
+	"cdnNodeGetMethod":                     cdnNodeGetMethod,
+	"cdnNodeListMethod":                    cdnNodeListMethod,
+	"cdnNodeRemoveMethod":                  cdnNodeRemoveMethod,
+	"cdnNodeSetParamsMethod":               cdnNodeSetParamsMethod,
+	"clusterAddCdnNodeMethod":              clusterAddCdnNodeMethod,
+	"clusterAddNodeMethod":                 clusterAddNodeMethod,
+	"clusterCreateMethod":                  clusterCreateMethod,
+	"clusterDistributeRevenuesMethod":      clusterDistributeRevenuesMethod,
+	"clusterGetMethod":                     clusterGetMethod,
+	"clusterListMethod":                    clusterListMethod,
+	"clusterRemoveCdnNodeMethod":           clusterRemoveCdnNodeMethod,
+	"clusterRemoveMethod":                  clusterRemoveMethod,
+	"clusterRemoveNodeMethod":              clusterRemoveNodeMethod,
+	"clusterReplaceNodeMethod":             clusterReplaceNodeMethod,
+	"clusterResetNodeMethod":               clusterResetNodeMethod,
+	"clusterSetCdnNodeStatusMethod":        clusterSetCdnNodeStatusMethod,
+	"clusterSetNodeStatusMethod":           clusterSetNodeStatusMethod,
+	"clusterSetParamsMethod":               clusterSetParamsMethod,
+	"getAccountsMethod":                    getAccountsMethod,
+	"getBucketReadersMethod":               getBucketReadersMethod,
+	"getBucketWritersMethod":               getBucketWritersMethod,
+	"grantTrustedManagerPermissionMethod":  grantTrustedManagerPermissionMethod,
+	"hasPermissionMethod":                  hasPermissionMethod,
+	"nodeCreateMethod":                     nodeCreateMethod,
+	"nodeGetMethod":                        nodeGetMethod,
+	"nodeListMethod":                       nodeListMethod,
+	"nodeRemoveMethod":                     nodeRemoveMethod,
+	"nodeSetParamsMethod":                  nodeSetParamsMethod,
+	"revokeTrustedManagerPermissionMethod": revokeTrustedManagerPermissionMethod,
+}
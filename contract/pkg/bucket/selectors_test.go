@@ -0,0 +1,57 @@
+package bucket
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSelectors(t *testing.T) {
+	tests := []struct {
+		name      string
+		selectors map[string]string
+		wantErr   bool
+	}{
+		{name: "Valid", selectors: map[string]string{"aMethod": "e8aa4ade", "bMethod": "b77ac1bb"}},
+		{name: "Empty", selectors: map[string]string{"aMethod": ""}, wantErr: true},
+		{name: "Not hex", selectors: map[string]string{"aMethod": "zzzzzzzz"}, wantErr: true},
+		{name: "Wrong length", selectors: map[string]string{"aMethod": "e8aa4a"}, wantErr: true},
+		{name: "Duplicate", selectors: map[string]string{"aMethod": "e8aa4ade", "bMethod": "e8aa4ade"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSelectors(tt.selectors)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestMethodSelectorsGenerated(t *testing.T) {
+	//given
+	file, err := parser.ParseFile(token.NewFileSet(), "ddc_bucket_contract.go", nil, 0)
+	assert.NoError(t, err)
+
+	//when
+	var declared []string
+	ast.Inspect(file, func(node ast.Node) bool {
+		if spec, ok := node.(*ast.ValueSpec); ok {
+			for _, name := range spec.Names {
+				if strings.HasSuffix(name.Name, "Method") {
+					declared = append(declared, name.Name)
+				}
+			}
+		}
+		return true
+	})
+
+	//then
+	assert.Len(t, methodSelectors, len(declared), "run go generate to update selectors_gen.go")
+	for _, name := range declared {
+		assert.Contains(t, methodSelectors, name, "run go generate to update selectors_gen.go")
+	}
+}
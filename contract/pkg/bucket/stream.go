@@ -0,0 +1,76 @@
+package bucket
+
+import (
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// DefaultStreamPageSize is the number of entities read by one contract call while streaming.
+const DefaultStreamPageSize types.U32 = 100
+
+// ListFilter filters streamed entity lists. The account filter is pushed down to the contract
+// call, other filters are applied to decoded pages, so reading stops as soon as possible.
+type ListFilter[T any] struct {
+	// AccountId filters clusters by manager, nodes and CDN nodes by provider and buckets by owner
+	// in the contract, all entities if empty.
+	AccountId types.OptionAccountID
+	// PageSize is the limit of one contract call, DefaultStreamPageSize if 0.
+	PageSize types.U32
+	// Where skips entities it returns false for, all entities pass if nil.
+	Where func(entity T) bool
+	// StopWhen stops streaming before the first entity it returns true for.
+	StopWhen func(entity T) bool
+	// Limit stops streaming after the number of entities passed Where, no limit if 0.
+	Limit int
+}
+
+// StreamClusters passes clusters matching the filter to yield page by page until yield returns false.
+func StreamClusters(contract DdcBucketContract, filter ListFilter[ClusterInfo], yield func(cluster ClusterInfo) bool) error {
+	return stream(filter, clusterPages(contract, filter.AccountId), yield)
+}
+
+// StreamNodes passes nodes matching the filter to yield page by page until yield returns false.
+func StreamNodes(contract DdcBucketContract, filter ListFilter[NodeInfo], yield func(node NodeInfo) bool) error {
+	return stream(filter, nodePages(contract, filter.AccountId), yield)
+}
+
+// StreamCdnNodes passes CDN nodes matching the filter to yield page by page until yield returns false.
+func StreamCdnNodes(contract DdcBucketContract, filter ListFilter[CdnNodeInfo], yield func(node CdnNodeInfo) bool) error {
+	return stream(filter, cdnNodePages(contract, filter.AccountId), yield)
+}
+
+// StreamBuckets passes buckets matching the filter to yield page by page until yield returns false.
+func StreamBuckets(contract DdcBucketContract, filter ListFilter[BucketInfo], yield func(bucket BucketInfo) bool) error {
+	return stream(filter, bucketPages(contract, filter.AccountId), yield)
+}
+
+// CollectClusters returns clusters matching the filter, use Limit or StopWhen to bound the result.
+func CollectClusters(contract DdcBucketContract, filter ListFilter[ClusterInfo]) ([]ClusterInfo, error) {
+	var clusters []ClusterInfo
+	err := StreamClusters(contract, filter, func(cluster ClusterInfo) bool {
+		clusters = append(clusters, cluster)
+		return true
+	})
+	return clusters, err
+}
+
+// CollectNodes returns nodes matching the filter, use Limit or StopWhen to bound the result.
+func CollectNodes(contract DdcBucketContract, filter ListFilter[NodeInfo]) ([]NodeInfo, error) {
+	var nodes []NodeInfo
+	err := StreamNodes(contract, filter, func(node NodeInfo) bool {
+		nodes = append(nodes, node)
+		return true
+	})
+	return nodes, err
+}
+
+// stream passes the entities of the iterator to yield until it returns false.
+func stream[T any](filter ListFilter[T], read func(offset types.U32, limit types.U32) ([]T, types.U32, error), yield func(entity T) bool) error {
+	it := newIterator(filter, read)
+	for it.Next() {
+		if !yield(it.Value()) {
+			return nil
+		}
+	}
+
+	return it.Err()
+}
@@ -0,0 +1,88 @@
+package bucket
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type pagedContract struct {
+	DdcBucketContract
+	nodes []NodeInfo
+	calls []types.U32
+}
+
+func (c *pagedContract) NodeList(offset types.U32, limit types.U32, _ types.OptionAccountID) (*NodeListInfo, error) {
+	c.calls = append(c.calls, offset)
+	end := offset + limit
+	if end > types.U32(len(c.nodes)) {
+		end = types.U32(len(c.nodes))
+	}
+	return &NodeListInfo{Nodes: c.nodes[offset:end], Total: types.U32(len(c.nodes))}, nil
+}
+
+func testNodes(count int) []NodeInfo {
+	nodes := make([]NodeInfo, count)
+	for i := range nodes {
+		nodes[i].Node.ClusterId = types.NewOptionU32(types.U32(i % 2))
+		nodes[i].Node.RentPerMonth = types.NewU128(*big.NewInt(int64(i)))
+	}
+	return nodes
+}
+
+func TestStreamNodes(t *testing.T) {
+	cluster := func(clusterId types.U32) func(NodeInfo) bool {
+		return func(node NodeInfo) bool {
+			_, id := node.Node.ClusterId.Unwrap()
+			return id == clusterId
+		}
+	}
+
+	tests := []struct {
+		name      string
+		filter    ListFilter[NodeInfo]
+		wantCount int
+		wantCalls []types.U32
+	}{
+		{name: "all", filter: ListFilter[NodeInfo]{PageSize: 4}, wantCount: 10, wantCalls: []types.U32{0, 4, 8}},
+		{name: "where", filter: ListFilter[NodeInfo]{PageSize: 4, Where: cluster(1)}, wantCount: 5, wantCalls: []types.U32{0, 4, 8}},
+		{name: "limit", filter: ListFilter[NodeInfo]{PageSize: 4, Where: cluster(0), Limit: 2}, wantCount: 2, wantCalls: []types.U32{0}},
+		{name: "stop when", filter: ListFilter[NodeInfo]{PageSize: 4, StopWhen: func(node NodeInfo) bool {
+			return node.Node.RentPerMonth.Int.Int64() == 5
+		}}, wantCount: 5, wantCalls: []types.U32{0, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			contract := &pagedContract{nodes: testNodes(10)}
+
+			//when
+			nodes, err := CollectNodes(contract, tt.filter)
+
+			//then
+			assert.NoError(t, err)
+			assert.Len(t, nodes, tt.wantCount)
+			assert.Equal(t, tt.wantCalls, contract.calls)
+		})
+	}
+}
+
+func TestStreamNodesStopsWhenYieldReturnsFalse(t *testing.T) {
+	//given
+	contract := &pagedContract{nodes: testNodes(10)}
+	var seen int
+
+	//when
+	err := StreamNodes(contract, ListFilter[NodeInfo]{PageSize: 4}, func(node NodeInfo) bool {
+		seen++
+		return seen < 6
+	})
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, 6, seen)
+	assert.Equal(t, []types.U32{0, 4}, contract.calls)
+}
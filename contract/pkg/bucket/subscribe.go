@@ -0,0 +1,71 @@
+package bucket
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Subscribe registers the handler of contract events of type T, e.g. ClusterCreatedEvent, so the
+// event id and type assertions aren't needed. Like with RegisterHandler, only one handler can be
+// registered for an event.
+func Subscribe[T any](contract DdcBucketContract, handler func(event T)) error {
+	eventId, err := eventIdOf[T]()
+	if err != nil {
+		return err
+	}
+
+	return contract.RegisterHandler(eventId, func(args interface{}) {
+		switch event := args.(type) {
+		case *T:
+			handler(*event)
+		case T:
+			handler(event)
+		}
+	})
+}
+
+// Unsubscribe removes the handler of contract events of type T.
+func Unsubscribe[T any](contract DdcBucketContract) error {
+	eventId, err := eventIdOf[T]()
+	if err != nil {
+		return err
+	}
+
+	return contract.UnregisterHandler(eventId)
+}
+
+func OnBucketCreated(contract DdcBucketContract, handler func(event BucketCreatedEvent)) error {
+	return Subscribe(contract, handler)
+}
+
+func OnBucketAllocated(contract DdcBucketContract, handler func(event BucketAllocatedEvent)) error {
+	return Subscribe(contract, handler)
+}
+
+func OnBucketSettlePayment(contract DdcBucketContract, handler func(event BucketSettlePaymentEvent)) error {
+	return Subscribe(contract, handler)
+}
+
+func OnBucketAvailabilityUpdated(contract DdcBucketContract, handler func(event BucketAvailabilityUpdatedEvent)) error {
+	return Subscribe(contract, handler)
+}
+
+func OnBucketParamsSet(contract DdcBucketContract, handler func(event BucketParamsSetEvent)) error {
+	return Subscribe(contract, handler)
+}
+
+func OnDeposit(contract DdcBucketContract, handler func(event DepositEvent)) error {
+	return Subscribe(contract, handler)
+}
+
+// eventIdOf returns the id of the contract event of type T.
+func eventIdOf[T any]() (string, error) {
+	eventType := reflect.TypeOf((*T)(nil)).Elem()
+	for eventId, argumentType := range eventDispatchTable {
+		if argumentType == eventType {
+			return eventId, nil
+		}
+	}
+
+	return "", fmt.Errorf("%s is not an event of the contract", eventType)
+}
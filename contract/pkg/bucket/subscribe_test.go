@@ -0,0 +1,45 @@
+package bucket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribe(t *testing.T) {
+	//given
+	contract, eventKey := testEventContract(t)
+	snapshot := contract.GetEventDispatcher()
+	var deposits []DepositEvent
+
+	//when
+	err := OnDeposit(contract, func(event DepositEvent) { deposits = append(deposits, event) })
+	snapshot[eventKey].Handler(&DepositEvent{AccountId: AccountId{1}})
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, []DepositEvent{{AccountId: AccountId{1}}}, deposits)
+	assert.Error(t, Subscribe(contract, func(event DepositEvent) {}))
+}
+
+func TestUnsubscribe(t *testing.T) {
+	//given
+	contract, eventKey := testEventContract(t)
+	snapshot := contract.GetEventDispatcher()
+	handled := 0
+	assert.NoError(t, Subscribe(contract, func(event DepositEvent) { handled++ }))
+
+	//when
+	err := Unsubscribe[DepositEvent](contract)
+	snapshot[eventKey].Handler(&DepositEvent{})
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, 0, handled)
+}
+
+func TestSubscribeNotEvent(t *testing.T) {
+	contract, _ := testEventContract(t)
+
+	assert.EqualError(t, Subscribe(contract, func(event BucketInfo) {}), "bucket.BucketInfo is not an event of the contract")
+}
@@ -0,0 +1,123 @@
+package bucket
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+)
+
+type (
+	// SelectorUpdater is a contract which selectors can be replaced at runtime. The bucket contract
+	// created with CreateDdcBucketContract implements it.
+	SelectorUpdater interface {
+		GetContractAddress() string
+		UpdateSelectors(selectors *Selectors) error
+	}
+
+	// MetadataSource returns ink! metadata of the contract code with the hash, e.g. from a registry
+	// of contract builds.
+	MetadataSource func(codeHash types.Hash) ([]byte, error)
+
+	// CodeUpdate is a code upgrade of a contract with set_code, e.g. a Contracts.ContractCodeUpdated
+	// event.
+	CodeUpdate struct {
+		BlockNumber types.BlockNumber
+		BlockHash   types.Hash
+		Contract    types.AccountID
+		OldCodeHash types.Hash
+		NewCodeHash types.Hash
+	}
+
+	// CodeUpdates notifies about code upgrades of contracts until the returned cancel function is
+	// called. Adapt Client.OnContractCodeUpdate of the blockchain module with CodeUpdatesFunc:
+	//
+	//	watcher.Watch(bucket.CodeUpdatesFunc(func(callback func(update bucket.CodeUpdate)) context.CancelFunc {
+	//		return client.OnContractCodeUpdate(func(update blockchain.ContractCodeUpdate) {
+	//			callback(bucket.CodeUpdate(update))
+	//		})
+	//	}))
+	CodeUpdates interface {
+		OnCodeUpdate(callback func(update CodeUpdate)) context.CancelFunc
+	}
+
+	CodeUpdatesFunc func(callback func(update CodeUpdate)) context.CancelFunc
+
+	// Upgrade is a code upgrade of the contract with set_code.
+	Upgrade struct {
+		BlockNumber types.BlockNumber
+		BlockHash   types.Hash
+		OldCodeHash types.Hash
+		NewCodeHash types.Hash
+		// Err is the error of the selectors refresh. The contract keeps the previous selectors if
+		// it's not nil.
+		Err error
+	}
+
+	// UpgradeWatcher refreshes contract selectors when the contract code is upgraded, so long-lived
+	// services don't need restarts. Event topics are derived from event names, which upgrades
+	// keep, so the event dispatcher isn't rebuilt.
+	UpgradeWatcher struct {
+		contract  SelectorUpdater
+		address   types.AccountID
+		metadata  MetadataSource
+		onUpgrade func(upgrade Upgrade)
+	}
+)
+
+func (f CodeUpdatesFunc) OnCodeUpdate(callback func(update CodeUpdate)) context.CancelFunc {
+	return f(callback)
+}
+
+// CreateUpgradeWatcher creates the watcher of upgrades of the contract. onUpgrade is called after
+// the selectors refresh of every upgrade, it's optional.
+func CreateUpgradeWatcher(contract SelectorUpdater, metadata MetadataSource, onUpgrade func(upgrade Upgrade)) (*UpgradeWatcher, error) {
+	address, err := pkg.DecodeAccountIDFromSS58(contract.GetContractAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpgradeWatcher{contract: contract, address: address, metadata: metadata, onUpgrade: onUpgrade}, nil
+}
+
+// Watch refreshes the selectors on upgrades of the contract reported by the updates until the
+// returned cancel function is called.
+func (w *UpgradeWatcher) Watch(updates CodeUpdates) context.CancelFunc {
+	return updates.OnCodeUpdate(w.HandleCodeUpdate)
+}
+
+// HandleCodeUpdate refreshes the selectors if the update is an upgrade of the contract.
+func (w *UpgradeWatcher) HandleCodeUpdate(update CodeUpdate) {
+	if update.Contract != w.address {
+		return
+	}
+
+	upgrade := Upgrade{
+		BlockNumber: update.BlockNumber,
+		BlockHash:   update.BlockHash,
+		OldCodeHash: update.OldCodeHash,
+		NewCodeHash: update.NewCodeHash,
+	}
+	upgrade.Err = w.refresh(upgrade.NewCodeHash)
+
+	if w.onUpgrade != nil {
+		w.onUpgrade(upgrade)
+	}
+}
+
+func (w *UpgradeWatcher) refresh(codeHash types.Hash) error {
+	metadata, err := w.metadata(codeHash)
+	if err != nil {
+		return fmt.Errorf("metadata of code %s: %w", codeHash.Hex(), err)
+	}
+	selectors, err := LoadSelectors(metadata)
+	if err != nil {
+		return fmt.Errorf("metadata of code %s: %w", codeHash.Hex(), err)
+	}
+	if err := w.contract.UpdateSelectors(selectors); err != nil {
+		return fmt.Errorf("metadata of code %s: %w", codeHash.Hex(), err)
+	}
+
+	return nil
+}
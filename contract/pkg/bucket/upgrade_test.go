@@ -0,0 +1,51 @@
+package bucket
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpgradeWatcher(t *testing.T) {
+	//given
+	address := "5GmomkEekQQ3BipMvjDCG5bXKvzwhUDdXEcQqXRWmdkNCYkL"
+	contractId, err := pkg.DecodeAccountIDFromSS58(address)
+	assert.NoError(t, err)
+	contract := CreateDdcBucketContract(nil, address).(*ddcBucketContract)
+	metadata := map[types.Hash][]byte{{2}: inkMetadataJson(t, "", "")}
+	var upgrades []Upgrade
+	watcher, err := CreateUpgradeWatcher(contract, func(codeHash types.Hash) ([]byte, error) {
+		if m, ok := metadata[codeHash]; ok {
+			return m, nil
+		}
+		return nil, errors.New("unknown code")
+	}, func(upgrade Upgrade) {
+		upgrades = append(upgrades, upgrade)
+	})
+	assert.NoError(t, err)
+
+	var notify func(update CodeUpdate)
+	cancelled := false
+	cancel := watcher.Watch(CodeUpdatesFunc(func(callback func(update CodeUpdate)) context.CancelFunc {
+		notify = callback
+		return func() { cancelled = true }
+	}))
+
+	//when
+	notify(CodeUpdate{BlockNumber: 10, Contract: types.AccountID{7}, OldCodeHash: types.Hash{1}, NewCodeHash: types.Hash{2}})
+	notify(CodeUpdate{BlockNumber: 10, Contract: contractId, OldCodeHash: types.Hash{2}, NewCodeHash: types.Hash{3}})
+	notify(CodeUpdate{BlockNumber: 10, Contract: contractId, OldCodeHash: types.Hash{1}, NewCodeHash: types.Hash{2}})
+	cancel()
+
+	//then
+	assert.True(t, cancelled)
+	assert.Len(t, upgrades, 2)
+	assert.Error(t, upgrades[0].Err)
+	assert.Equal(t, types.Hash{3}, upgrades[0].NewCodeHash)
+	assert.Equal(t, Upgrade{BlockNumber: 10, OldCodeHash: types.Hash{1}, NewCodeHash: types.Hash{2}}, upgrades[1])
+	assert.Equal(t, []byte{1, 2, 3, 4}, contract.selector(contract.bucketGetMethodId))
+}
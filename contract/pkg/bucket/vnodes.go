@@ -0,0 +1,232 @@
+package bucket
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+var (
+	ErrInvalidVNodes = errors.New("invalid vnodes layout")
+)
+
+// VNodesBuilder builds the vnodes layout passed to ClusterAddNode, ClusterResetNode and
+// ClusterReplaceNode. The layout is a list of token groups, each token is a position of a virtual
+// node in the cluster ring, e.g.
+//
+//	vNodes, err := NewVNodesBuilder().
+//		Spread(4, 0).
+//		ExcludeCluster(cluster).
+//		Build()
+//
+// Errors of the added groups are reported by Build.
+type VNodesBuilder struct {
+	groups [][]Token
+	taken  map[Token]struct{}
+	err    error
+}
+
+func NewVNodesBuilder() *VNodesBuilder {
+	return &VNodesBuilder{taken: make(map[Token]struct{})}
+}
+
+// Add adds a group of tokens.
+func (b *VNodesBuilder) Add(tokens ...Token) *VNodesBuilder {
+	b.groups = append(b.groups, append([]Token(nil), tokens...))
+	return b
+}
+
+// Spread adds a group of count tokens evenly spaced over the ring starting from the seed. Use
+// different seeds for nodes of the same cluster, so their tokens don't collide.
+func (b *VNodesBuilder) Spread(count int, seed Token) *VNodesBuilder {
+	if count <= 0 {
+		b.fail(fmt.Errorf("%w: spread of %d tokens", ErrInvalidVNodes, count))
+		return b
+	}
+
+	step := uint64(math.MaxUint64) / uint64(count)
+	tokens := make([]Token, count)
+	for i := range tokens {
+		tokens[i] = seed + Token(uint64(i)*step)
+	}
+
+	return b.Add(tokens...)
+}
+
+// Parse adds the groups of a layout encoded with FormatVNodes or VNodesHex, see ParseVNodes.
+func (b *VNodesBuilder) Parse(s string) *VNodesBuilder {
+	vNodes, err := ParseVNodes(s)
+	if err != nil {
+		b.fail(err)
+		return b
+	}
+
+	b.groups = append(b.groups, vNodes...)
+	return b
+}
+
+// ExcludeCluster makes Build fail if the layout contains tokens of nodes already in the cluster.
+func (b *VNodesBuilder) ExcludeCluster(cluster *ClusterInfo) *VNodesBuilder {
+	for _, node := range cluster.NodesVNodes {
+		for _, token := range node.VNodes {
+			b.taken[token] = struct{}{}
+		}
+	}
+
+	return b
+}
+
+// Build validates the layout, see ValidateVNodes, and checks it against the excluded tokens.
+func (b *VNodesBuilder) Build() ([][]Token, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err := ValidateVNodes(b.groups); err != nil {
+		return nil, err
+	}
+
+	for _, group := range b.groups {
+		for _, token := range group {
+			if _, ok := b.taken[token]; ok {
+				return nil, fmt.Errorf("%w: token %d is taken in the cluster", ErrVNodeIsAlreadyAssignedToNode, token)
+			}
+		}
+	}
+
+	return b.groups, nil
+}
+
+func (b *VNodesBuilder) fail(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// ValidateVNodes checks the layout has at least one token, no empty groups and no repeated tokens,
+// which the contract rejects.
+func ValidateVNodes(vNodes [][]Token) error {
+	seen := make(map[Token]struct{})
+	for i, group := range vNodes {
+		if len(group) == 0 {
+			return fmt.Errorf("%w: group %d is empty", ErrInvalidVNodes, i)
+		}
+		for _, token := range group {
+			if _, ok := seen[token]; ok {
+				return fmt.Errorf("%w: token %d is repeated", ErrVNodeIsAlreadyAssignedToNode, token)
+			}
+			seen[token] = struct{}{}
+		}
+	}
+	if len(seen) == 0 {
+		return ErrAtLeastOneVNodeHasToBeAssigned
+	}
+
+	return nil
+}
+
+// FormatVNodes formats the layout in the compact text form of ops tooling. Groups are separated by
+// ";" and tokens by ",". Three or more tokens with an equal positive step are written as
+// "<start>+<step>x<count>", e.g. "0+1000x3,5000;7000" is [[0 1000 2000 5000] [7000]].
+func FormatVNodes(vNodes [][]Token) string {
+	groups := make([]string, len(vNodes))
+	for i, group := range vNodes {
+		var items []string
+		for j := 0; j < len(group); {
+			count := 1
+			if j+1 < len(group) && group[j+1] > group[j] {
+				step := group[j+1] - group[j]
+				for j+count < len(group) && group[j+count] > group[j+count-1] && group[j+count]-group[j+count-1] == step {
+					count++
+				}
+				if count >= 3 {
+					items = append(items, fmt.Sprintf("%d+%dx%d", group[j], step, count))
+					j += count
+					continue
+				}
+			}
+			items = append(items, strconv.FormatUint(uint64(group[j]), 10))
+			j++
+		}
+		groups[i] = strings.Join(items, ",")
+	}
+
+	return strings.Join(groups, ";")
+}
+
+// ParseVNodes parses a layout in the compact text form of FormatVNodes or the hex SCALE encoding
+// of VNodesHex, e.g. copied from a polkadot.js extrinsic. Whitespace is ignored.
+func ParseVNodes(s string) ([][]Token, error) {
+	s = strings.Join(strings.Fields(s), "")
+	if strings.HasPrefix(s, "0x") {
+		var vNodes [][]Token
+		if err := codec.DecodeFromHex(s, &vNodes); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidVNodes, err)
+		}
+		return vNodes, nil
+	}
+	if s == "" {
+		return nil, nil
+	}
+
+	var vNodes [][]Token
+	for _, groupPart := range strings.Split(s, ";") {
+		var group []Token
+		for _, item := range strings.Split(groupPart, ",") {
+			tokens, err := parseVNodesItem(item)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q: %v", ErrInvalidVNodes, item, err)
+			}
+			group = append(group, tokens...)
+		}
+		vNodes = append(vNodes, group)
+	}
+
+	return vNodes, nil
+}
+
+// parseVNodesItem parses a token or a "<start>+<step>x<count>" run of tokens.
+func parseVNodesItem(item string) ([]Token, error) {
+	startPart, run, isRun := strings.Cut(item, "+")
+	start, err := strconv.ParseUint(startPart, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	if !isRun {
+		return []Token{Token(start)}, nil
+	}
+
+	stepPart, countPart, ok := strings.Cut(run, "x")
+	if !ok {
+		return nil, errors.New("run has no count")
+	}
+	step, err := strconv.ParseUint(stepPart, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.ParseUint(countPart, 10, 16)
+	if err != nil {
+		return nil, err
+	}
+	if step == 0 || count == 0 {
+		return nil, errors.New("run step and count must be positive")
+	}
+	if (count-1)*step/step != count-1 || start+(count-1)*step < start {
+		return nil, errors.New("run overflows tokens")
+	}
+
+	tokens := make([]Token, count)
+	for i := range tokens {
+		tokens[i] = Token(start + uint64(i)*step)
+	}
+
+	return tokens, nil
+}
+
+// VNodesHex returns the hex SCALE encoding of the layout as submitted to the contract.
+func VNodesHex(vNodes [][]Token) (string, error) {
+	return codec.EncodeToHex(vNodes)
+}
@@ -0,0 +1,122 @@
+package bucket
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVNodesBuilder(t *testing.T) {
+	//given
+	builder := NewVNodesBuilder().Add(1, 2).Spread(4, 10)
+
+	//when
+	vNodes, err := builder.Build()
+
+	//then
+	assert.NoError(t, err)
+	step := Token(math.MaxUint64 / 4)
+	assert.Equal(t, [][]Token{{1, 2}, {10, 10 + step, 10 + 2*step, 10 + 3*step}}, vNodes)
+}
+
+func TestVNodesBuilderExcludeCluster(t *testing.T) {
+	//given
+	cluster := &ClusterInfo{NodesVNodes: []NodeVNodesInfo{{NodeKey: NodeKey{1}, VNodes: []Token{100, 200}}}}
+
+	//when
+	_, err := NewVNodesBuilder().Add(50, 200).ExcludeCluster(cluster).Build()
+
+	//then
+	assert.ErrorIs(t, err, ErrVNodeIsAlreadyAssignedToNode)
+}
+
+func TestVNodesBuilderParse(t *testing.T) {
+	//when
+	vNodes, err := NewVNodesBuilder().Parse("0+1000x3").Add(5000).Build()
+	_, parseErr := NewVNodesBuilder().Parse("1,x").Add(5000).Build()
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, [][]Token{{0, 1000, 2000}, {5000}}, vNodes)
+	assert.ErrorIs(t, parseErr, ErrInvalidVNodes)
+}
+
+func TestValidateVNodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		vNodes  [][]Token
+		wantErr error
+	}{
+		{name: "valid layout", vNodes: [][]Token{{1, 2}, {3}}},
+		{name: "no groups", wantErr: ErrAtLeastOneVNodeHasToBeAssigned},
+		{name: "empty group", vNodes: [][]Token{{1}, {}}, wantErr: ErrInvalidVNodes},
+		{name: "repeated token in group", vNodes: [][]Token{{1, 1}}, wantErr: ErrVNodeIsAlreadyAssignedToNode},
+		{name: "repeated token across groups", vNodes: [][]Token{{1, 2}, {2}}, wantErr: ErrVNodeIsAlreadyAssignedToNode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateVNodes(tt.vNodes)
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatVNodes(t *testing.T) {
+	tests := []struct {
+		name   string
+		vNodes [][]Token
+		want   string
+	}{
+		{name: "runs and single tokens", vNodes: [][]Token{{0, 1000, 2000, 5000}, {7000}}, want: "0+1000x3,5000;7000"},
+		{name: "two tokens aren't a run", vNodes: [][]Token{{1, 2}}, want: "1,2"},
+		{name: "decreasing tokens", vNodes: [][]Token{{5, 3, 1}}, want: "5,3,1"},
+		{name: "max token", vNodes: [][]Token{{math.MaxUint64}}, want: "18446744073709551615"},
+		{name: "no groups", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatted := FormatVNodes(tt.vNodes)
+			parsed, err := ParseVNodes(formatted)
+
+			assert.Equal(t, tt.want, formatted)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.vNodes, parsed)
+		})
+	}
+}
+
+func TestParseVNodesErrors(t *testing.T) {
+	for _, s := range []string{"1,,2", "a", "1+2", "1+0x3", "1+2x0", "18446744073709551615+1x2", "0xzz"} {
+		t.Run(s, func(t *testing.T) {
+			_, err := ParseVNodes(s)
+
+			assert.ErrorIs(t, err, ErrInvalidVNodes)
+		})
+	}
+}
+
+// TestVNodesScaleEncoding checks the layout is encoded as Vec<Vec<u64>> expected by the contract:
+// compact lengths of the outer and inner vectors followed by little-endian tokens.
+func TestVNodesScaleEncoding(t *testing.T) {
+	//given
+	vNodes := [][]Token{{1, 1 << 63}, {3}}
+	want := "0x08" +
+		"08" + "0100000000000000" + "0000000000000080" +
+		"04" + "0300000000000000"
+
+	//when
+	encoded, err := VNodesHex(vNodes)
+	decoded, decodeErr := ParseVNodes(encoded)
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, want, encoded)
+	assert.NoError(t, decodeErr)
+	assert.Equal(t, vNodes, decoded)
+}
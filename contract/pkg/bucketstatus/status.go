@@ -0,0 +1,250 @@
+// Package bucketstatus combines the bucket status stored in the DDC bucket contract with live
+// stats of the storage nodes of the bucket cluster, so bucket dashboards need one call.
+package bucketstatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+const defaultStatsTimeout = 10 * time.Second
+
+type (
+	// Contract reads the bucket, its cluster and the cluster nodes, e.g. bucket.DdcBucketContract.
+	Contract interface {
+		BucketGet(bucketId bucket.BucketId) (*bucket.BucketInfo, error)
+		ClusterGet(clusterId bucket.ClusterId) (*bucket.ClusterInfo, error)
+		NodeGet(nodeKey bucket.NodeKey) (*bucket.NodeInfo, error)
+	}
+
+	// NodeStats are stats of the bucket reported by a storage node.
+	NodeStats struct {
+		StoredBytes uint64
+		Pieces      uint64
+		// LastWrite is the time of the latest piece of the bucket stored by the node, zero if none.
+		LastWrite time.Time
+	}
+
+	// StatsProvider reads bucket stats from the stats endpoint of a storage node.
+	StatsProvider interface {
+		BucketStats(ctx context.Context, node *bucket.NodeInfo, bucketId bucket.BucketId) (NodeStats, error)
+	}
+
+	// NodeStatus is a storage node of the bucket cluster with its stats of the bucket.
+	NodeStatus struct {
+		NodeKey bucket.NodeKey
+		Url     string
+		Status  bucket.NodeStatusInCluster
+		Stats   NodeStats
+		// Error is why the stats couldn't be read, empty if they were.
+		Error string
+	}
+
+	// BucketStatusEx is the bucket status from the contract with live stats of its nodes.
+	BucketStatusEx struct {
+		Bucket *bucket.BucketInfo
+		State  bucket.BucketState
+		Nodes  []NodeStatus
+		// StoredBytes is the sum of bytes stored by all nodes including replicas.
+		StoredBytes uint64
+		// LastWrite is the latest write reported by any node.
+		LastWrite time.Time
+		// ReplicationFactor is the number of replicas of each piece configured for the cluster,
+		// limited by the number of cluster nodes.
+		ReplicationFactor uint
+		// ReportingNodes is the number of nodes which stats were read.
+		ReportingNodes int
+		// ReplicationComplete is true if all cluster nodes reported stats, so no replica is on an
+		// unreachable node, and the cluster has enough nodes for the replication factor.
+		ReplicationComplete bool
+	}
+
+	// StatusReader is safe for concurrent use.
+	StatusReader struct {
+		contract     Contract
+		stats        StatsProvider
+		clock        pkg.Clock
+		statsTimeout time.Duration
+	}
+
+	Option func(r *StatusReader)
+)
+
+// WithStatsTimeout sets how long stats of one node are waited for, 10 seconds by default.
+func WithStatsTimeout(timeout time.Duration) Option {
+	return func(r *StatusReader) {
+		r.statsTimeout = timeout
+	}
+}
+
+// WithClock sets the clock the bucket state is computed with.
+func WithClock(clock pkg.Clock) Option {
+	return func(r *StatusReader) {
+		r.clock = clock
+	}
+}
+
+func CreateStatusReader(contract Contract, stats StatsProvider, opts ...Option) *StatusReader {
+	r := &StatusReader{
+		contract:     contract,
+		stats:        stats,
+		clock:        pkg.SystemClock,
+		statsTimeout: defaultStatsTimeout,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// GetBucketStatusEx reads the bucket and its cluster from the contract and the bucket stats of all
+// cluster nodes in parallel. Nodes which stats can't be read are reported with the error, only
+// contract errors fail the call.
+func (r *StatusReader) GetBucketStatusEx(ctx context.Context, bucketId bucket.BucketId) (*BucketStatusEx, error) {
+	bucketInfo, err := r.contract.BucketGet(bucketId)
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := r.contract.ClusterGet(bucketInfo.Bucket.ClusterId)
+	if err != nil {
+		return nil, fmt.Errorf("cluster %d: %w", bucketInfo.Bucket.ClusterId, err)
+	}
+
+	nodes := make([]*bucket.NodeInfo, len(cluster.NodesVNodes))
+	for i, nodeVNodes := range cluster.NodesVNodes {
+		if nodes[i], err = r.contract.NodeGet(nodeVNodes.NodeKey); err != nil {
+			return nil, fmt.Errorf("node %s: %w", pkg.EncodeAddress(nodeVNodes.NodeKey, pkg.CereNetwork), err)
+		}
+	}
+
+	status := &BucketStatusEx{
+		Bucket:            bucketInfo,
+		State:             bucket.ComputeState(bucketInfo, r.clock.Now()),
+		Nodes:             make([]NodeStatus, len(nodes)),
+		ReplicationFactor: cluster.ReplicationFactor(),
+	}
+	if status.ReplicationFactor == 0 {
+		status.ReplicationFactor = 1
+	}
+	if status.ReplicationFactor > uint(len(nodes)) {
+		status.ReplicationFactor = uint(len(nodes))
+	}
+
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		i, node := i, node
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status.Nodes[i] = r.nodeStatus(ctx, node, bucketId)
+		}()
+	}
+	wg.Wait()
+
+	for _, node := range status.Nodes {
+		if node.Error != "" {
+			continue
+		}
+		status.ReportingNodes++
+		status.StoredBytes += node.Stats.StoredBytes
+		if node.Stats.LastWrite.After(status.LastWrite) {
+			status.LastWrite = node.Stats.LastWrite
+		}
+	}
+	status.ReplicationComplete = len(nodes) > 0 && status.ReportingNodes == len(nodes) &&
+		uint(len(nodes)) >= cluster.ReplicationFactor()
+
+	return status, nil
+}
+
+func (r *StatusReader) nodeStatus(ctx context.Context, node *bucket.NodeInfo, bucketId bucket.BucketId) NodeStatus {
+	status := NodeStatus{NodeKey: node.Key, Url: nodeUrl(node), Status: bucket.UNKNOWN_NODE_STATUS_IN_CLUSTER}
+	if s, err := node.GetStatusInCluster(); err == nil {
+		status.Status = s
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.statsTimeout)
+	defer cancel()
+	stats, err := r.stats.BucketStats(ctx, node, bucketId)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Stats = stats
+
+	return status
+}
+
+// HttpStatsProvider reads bucket stats as JSON from node stats endpoints:
+//
+//	{"storedBytes": 1024, "pieces": 3, "lastWriteMs": 1700000000000}
+type HttpStatsProvider struct {
+	httpClient *http.Client
+	statsUrl   func(nodeUrl string, bucketId bucket.BucketId) string
+}
+
+// CreateHttpStatsProvider creates the provider requesting the URL built by statsUrl from the node
+// URL in the node params. The default HTTP client is used if httpClient is nil.
+func CreateHttpStatsProvider(httpClient *http.Client, statsUrl func(nodeUrl string, bucketId bucket.BucketId) string) *HttpStatsProvider {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &HttpStatsProvider{httpClient: httpClient, statsUrl: statsUrl}
+}
+
+func (h *HttpStatsProvider) BucketStats(ctx context.Context, node *bucket.NodeInfo, bucketId bucket.BucketId) (NodeStats, error) {
+	url := nodeUrl(node)
+	if url == "" {
+		return NodeStats{}, fmt.Errorf("node %s has no url", pkg.EncodeAddress(node.Key, pkg.CereNetwork))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.statsUrl(url, bucketId), nil)
+	if err != nil {
+		return NodeStats{}, err
+	}
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return NodeStats{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return NodeStats{}, fmt.Errorf("stats of bucket %d: http status %d", bucketId, resp.StatusCode)
+	}
+
+	var body struct {
+		StoredBytes uint64 `json:"storedBytes"`
+		Pieces      uint64 `json:"pieces"`
+		LastWriteMs int64  `json:"lastWriteMs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return NodeStats{}, fmt.Errorf("decode stats of bucket %d: %w", bucketId, err)
+	}
+
+	stats := NodeStats{StoredBytes: body.StoredBytes, Pieces: body.Pieces}
+	if body.LastWriteMs > 0 {
+		stats.LastWrite = time.UnixMilli(body.LastWriteMs)
+	}
+
+	return stats, nil
+}
+
+// nodeUrl returns the url from the JSON node params, empty if there is none.
+func nodeUrl(node *bucket.NodeInfo) string {
+	var params struct {
+		Url string `json:"url"`
+	}
+	if err := json.Unmarshal([]byte(node.Node.Params), &params); err != nil {
+		return ""
+	}
+
+	return params.Url
+}
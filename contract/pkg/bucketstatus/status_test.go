@@ -0,0 +1,125 @@
+package bucketstatus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockedContract struct {
+	nodes map[bucket.NodeKey]*bucket.NodeInfo
+}
+
+func (m *mockedContract) BucketGet(bucketId bucket.BucketId) (*bucket.BucketInfo, error) {
+	return &bucket.BucketInfo{BucketId: bucketId, Bucket: bucket.Bucket{ClusterId: 1, ResourceReserved: 1}}, nil
+}
+
+func (m *mockedContract) ClusterGet(clusterId bucket.ClusterId) (*bucket.ClusterInfo, error) {
+	cluster := &bucket.ClusterInfo{ClusterId: clusterId, Cluster: bucket.Cluster{Params: `{"replicationFactor":2}`}}
+	for key := range m.nodes {
+		cluster.NodesVNodes = append(cluster.NodesVNodes, bucket.NodeVNodesInfo{NodeKey: key})
+	}
+	return cluster, nil
+}
+
+func (m *mockedContract) NodeGet(nodeKey bucket.NodeKey) (*bucket.NodeInfo, error) {
+	return m.nodes[nodeKey], nil
+}
+
+type mockedStats map[string]NodeStats
+
+func (m mockedStats) BucketStats(_ context.Context, node *bucket.NodeInfo, _ bucket.BucketId) (NodeStats, error) {
+	stats, ok := m[nodeUrl(node)]
+	if !ok {
+		return NodeStats{}, errors.New("connection refused")
+	}
+	return stats, nil
+}
+
+func node(key byte, url string) *bucket.NodeInfo {
+	return &bucket.NodeInfo{
+		Key: bucket.NodeKey{key},
+		Node: bucket.Node{
+			Params:          `{"url":"` + url + `"}`,
+			StatusInCluster: types.NewOptionU8(types.NewU8(bucket.ACTIVE)),
+		},
+	}
+}
+
+func TestGetBucketStatusEx(t *testing.T) {
+	lastWrite := time.UnixMilli(1_700_000_000_000)
+	contract := &mockedContract{nodes: map[bucket.NodeKey]*bucket.NodeInfo{
+		{1}: node(1, "https://node-1"),
+		{2}: node(2, "https://node-2"),
+	}}
+
+	tests := []struct {
+		name         string
+		stats        mockedStats
+		wantBytes    uint64
+		wantComplete bool
+		wantErrors   int
+	}{
+		{
+			name: "all nodes report",
+			stats: mockedStats{
+				"https://node-1": {StoredBytes: 100, LastWrite: lastWrite},
+				"https://node-2": {StoredBytes: 100, LastWrite: lastWrite.Add(-time.Hour)},
+			},
+			wantBytes:    200,
+			wantComplete: true,
+		},
+		{
+			name:       "unreachable node",
+			stats:      mockedStats{"https://node-1": {StoredBytes: 100, LastWrite: lastWrite}},
+			wantBytes:  100,
+			wantErrors: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			reader := CreateStatusReader(contract, tt.stats)
+
+			//when
+			status, err := reader.GetBucketStatusEx(context.Background(), 7)
+
+			//then
+			assert.NoError(t, err)
+			assert.Len(t, status.Nodes, 2)
+			assert.Equal(t, tt.wantBytes, status.StoredBytes)
+			assert.Equal(t, tt.wantComplete, status.ReplicationComplete)
+			assert.Equal(t, 2-tt.wantErrors, status.ReportingNodes)
+			assert.Equal(t, uint(2), status.ReplicationFactor)
+			assert.Equal(t, lastWrite, status.LastWrite)
+		})
+	}
+}
+
+func TestHttpStatsProvider(t *testing.T) {
+	//given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/buckets/7/stats", r.URL.Path)
+		_, _ = w.Write([]byte(`{"storedBytes":1024,"pieces":3,"lastWriteMs":1700000000000}`))
+	}))
+	defer server.Close()
+	provider := CreateHttpStatsProvider(server.Client(), func(nodeUrl string, bucketId bucket.BucketId) string {
+		return fmt.Sprintf("%s/buckets/%d/stats", nodeUrl, bucketId)
+	})
+
+	//when
+	stats, err := provider.BucketStats(context.Background(), node(1, server.URL), 7)
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, NodeStats{StoredBytes: 1024, Pieces: 3, LastWrite: time.UnixMilli(1_700_000_000_000)}, stats)
+}
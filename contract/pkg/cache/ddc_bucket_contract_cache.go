@@ -25,9 +25,11 @@ type (
 		HookContractEvents() error
 		Clear()
 		ClearNodes()
+		ClearClusters()
 		ClearBuckets()
 		ClearAccounts()
 		ClearNodeById(id bucket.NodeKey)
+		ClearClusterById(id bucket.ClusterId)
 		ClearBucketById(id bucket.BucketId)
 		ClearAccountById(id bucket.AccountId)
 		bucket.DdcBucketContract
@@ -39,17 +41,28 @@ type (
 		bucketSingleFlight  singleflight.Group
 		nodeCache           *cache.Cache
 		nodeSingleFlight    singleflight.Group
+		cdnNodeCache        *cache.Cache
+		cdnNodeSingleFlight singleflight.Group
+		clusterCache        *cache.Cache
+		clusterSingleFlight singleflight.Group
 		accountCache        *cache.Cache
 		accountSingleFlight singleflight.Group
+		// permissionCache keeps bucket writers and readers lists.
+		permissionCache        *cache.Cache
+		permissionSingleFlight singleflight.Group
 	}
 
 	BucketCacheParameters struct {
 		BucketCacheExpiration time.Duration
 		BucketCacheCleanUp    time.Duration
 
+		// NodeCacheExpiration and NodeCacheCleanUp apply to storage and CDN nodes.
 		NodeCacheExpiration time.Duration
 		NodeCacheCleanUp    time.Duration
 
+		ClusterCacheExpiration time.Duration
+		ClusterCacheCleanUp    time.Duration
+
 		AccountCacheExpiration time.Duration
 		AccountCacheCleanUp    time.Duration
 	}
@@ -60,14 +73,23 @@ func CreateDdcBucketContractCache(ddcBucketContract bucket.DdcBucketContract, pa
 		cacheDurationOrDefault(parameters.BucketCacheExpiration, defaultExpiration), cacheDurationOrDefault(parameters.BucketCacheCleanUp, cleanupInterval))
 	nodeCache := cache.New(
 		cacheDurationOrDefault(parameters.NodeCacheExpiration, defaultExpiration), cacheDurationOrDefault(parameters.NodeCacheCleanUp, cleanupInterval))
+	cdnNodeCache := cache.New(
+		cacheDurationOrDefault(parameters.NodeCacheExpiration, defaultExpiration), cacheDurationOrDefault(parameters.NodeCacheCleanUp, cleanupInterval))
+	clusterCache := cache.New(
+		cacheDurationOrDefault(parameters.ClusterCacheExpiration, defaultExpiration), cacheDurationOrDefault(parameters.ClusterCacheCleanUp, cleanupInterval))
 	accountCache := cache.New(
 		cacheDurationOrDefault(parameters.AccountCacheExpiration, defaultExpiration), cacheDurationOrDefault(parameters.AccountCacheCleanUp, cleanupInterval))
+	permissionCache := cache.New(
+		cacheDurationOrDefault(parameters.BucketCacheExpiration, defaultExpiration), cacheDurationOrDefault(parameters.BucketCacheCleanUp, cleanupInterval))
 
 	return &ddcBucketContractCached{
 		ddcBucketContract: ddcBucketContract,
 		bucketCache:       bucketCache,
 		nodeCache:         nodeCache,
+		cdnNodeCache:      cdnNodeCache,
+		clusterCache:      clusterCache,
 		accountCache:      accountCache,
+		permissionCache:   permissionCache,
 	}
 }
 
@@ -109,50 +131,76 @@ func (d *ddcBucketContractCached) HookContractEvents() error {
 	}); err != nil {
 		return errors.Wrap(err, "Unable to hook event "+bucket.BucketParamsSetEventId)
 	}
+	if err := d.ddcBucketContract.AddContractEventHandler(bucket.ClusterCreatedEventId, func(raw interface{}) {
+		args := raw.(*bucket.ClusterCreatedEvent)
+		d.ClearClusterById(args.ClusterId)
+	}); err != nil {
+		return errors.Wrap(err, "Unable to hook event "+bucket.ClusterCreatedEventId)
+	}
+	if err := d.ddcBucketContract.AddContractEventHandler(bucket.ClusterParamsSetEventId, func(raw interface{}) {
+		args := raw.(*bucket.ClusterParamsSetEvent)
+		d.ClearClusterById(args.ClusterId)
+	}); err != nil {
+		return errors.Wrap(err, "Unable to hook event "+bucket.ClusterParamsSetEventId)
+	}
+	if err := d.ddcBucketContract.AddContractEventHandler(bucket.ClusterRemovedEventId, func(raw interface{}) {
+		args := raw.(*bucket.ClusterRemovedEvent)
+		d.ClearClusterById(args.ClusterId)
+	}); err != nil {
+		return errors.Wrap(err, "Unable to hook event "+bucket.ClusterRemovedEventId)
+	}
 	if err := d.ddcBucketContract.AddContractEventHandler(bucket.ClusterNodeAddedEventId, func(raw interface{}) {
 		args := raw.(*bucket.ClusterNodeAddedEvent)
+		d.ClearClusterById(args.ClusterId)
 		d.ClearNodeByKey(args.NodeKey)
 	}); err != nil {
 		return errors.Wrap(err, "Unable to hook event "+bucket.ClusterNodeAddedEventId)
 	}
 	if err := d.ddcBucketContract.AddContractEventHandler(bucket.ClusterNodeRemovedEventId, func(raw interface{}) {
 		args := raw.(*bucket.ClusterNodeRemovedEvent)
+		d.ClearClusterById(args.ClusterId)
 		d.ClearNodeByKey(args.NodeKey)
 	}); err != nil {
 		return errors.Wrap(err, "Unable to hook event "+bucket.ClusterNodeRemovedEventId)
 	}
 	if err := d.ddcBucketContract.AddContractEventHandler(bucket.ClusterCdnNodeAddedEventId, func(raw interface{}) {
 		args := raw.(*bucket.ClusterCdnNodeAddedEvent)
+		d.ClearClusterById(args.ClusterId)
 		d.ClearNodeByKey(args.CdnNodeKey)
 	}); err != nil {
 		return errors.Wrap(err, "Unable to hook event "+bucket.ClusterCdnNodeAddedEventId)
 	}
 	if err := d.ddcBucketContract.AddContractEventHandler(bucket.ClusterCdnNodeRemovedEventId, func(raw interface{}) {
 		args := raw.(*bucket.ClusterCdnNodeRemovedEvent)
+		d.ClearClusterById(args.ClusterId)
 		d.ClearNodeByKey(args.CdnNodeKey)
 	}); err != nil {
 		return errors.Wrap(err, "Unable to hook event "+bucket.ClusterCdnNodeRemovedEventId)
 	}
 	if err := d.ddcBucketContract.AddContractEventHandler(bucket.ClusterNodeStatusSetEventId, func(raw interface{}) {
 		args := raw.(*bucket.ClusterNodeStatusSetEvent)
+		d.ClearClusterById(args.ClusterId)
 		d.ClearNodeByKey(args.NodeKey)
 	}); err != nil {
 		return errors.Wrap(err, "Unable to hook event "+bucket.ClusterNodeStatusSetEventId)
 	}
 	if err := d.ddcBucketContract.AddContractEventHandler(bucket.ClusterCdnNodeStatusSetEventId, func(raw interface{}) {
 		args := raw.(*bucket.ClusterCdnNodeStatusSetEvent)
+		d.ClearClusterById(args.ClusterId)
 		d.ClearNodeByKey(args.CdnNodeKey)
 	}); err != nil {
 		return errors.Wrap(err, "Unable to hook event "+bucket.ClusterCdnNodeStatusSetEventId)
 	}
 	if err := d.ddcBucketContract.AddContractEventHandler(bucket.ClusterNodeReplacedEventId, func(raw interface{}) {
 		args := raw.(*bucket.ClusterNodeReplacedEvent)
+		d.ClearClusterById(args.ClusterId)
 		d.ClearNodeByKey(args.NodeKey)
 	}); err != nil {
 		return errors.Wrap(err, "Unable to hook event "+bucket.ClusterNodeReplacedEventId)
 	}
 	if err := d.ddcBucketContract.AddContractEventHandler(bucket.ClusterNodeResetEventId, func(raw interface{}) {
 		args := raw.(*bucket.ClusterNodeResetEvent)
+		d.ClearClusterById(args.ClusterId)
 		d.ClearNodeByKey(args.NodeKey)
 	}); err != nil {
 		return errors.Wrap(err, "Unable to hook event "+bucket.ClusterNodeResetEventId)
@@ -221,18 +269,21 @@ func (d *ddcBucketContractCached) HookContractEvents() error {
 	}
 	if err := d.ddcBucketContract.AddContractEventHandler(bucket.ClusterReserveResourceEventId, func(raw interface{}) {
 		args := raw.(*bucket.ClusterReserveResourceEvent)
+		d.ClearClusterById(args.ClusterId)
 		d.ClearNodeById(args.NodeKey)
 	}); err != nil {
 		return errors.Wrap(err, "Unable to hook event "+bucket.ClusterReserveResourceEventId)
 	}
 	if err := d.ddcBucketContract.AddContractEventHandler(bucket.ClusterDistributeRevenuesEventId, func(raw interface{}) {
 		args := raw.(*bucket.ClusterDistributeRevenuesEvent)
+		d.ClearClusterById(args.ClusterId)
 		d.ClearAccountById(args.AccountId)
 	}); err != nil {
 		return errors.Wrap(err, "Unable to hook event "+bucket.ClusterDistributeRevenuesEventId)
 	}
 	if err := d.ddcBucketContract.AddContractEventHandler(bucket.ClusterDistributeCdnRevenuesEventId, func(raw interface{}) {
 		args := raw.(*bucket.ClusterDistributeCdnRevenuesEvent)
+		d.ClearClusterById(args.ClusterId)
 		d.ClearAccountById(args.ProviderId)
 	}); err != nil {
 		return errors.Wrap(err, "Unable to hook event "+bucket.ClusterDistributeCdnRevenuesEventId)
@@ -242,7 +293,23 @@ func (d *ddcBucketContractCached) HookContractEvents() error {
 }
 
 func (d *ddcBucketContractCached) ClusterGet(clusterId bucket.ClusterId) (*bucket.ClusterInfo, error) {
-	return d.ddcBucketContract.ClusterGet(clusterId)
+	key := clusterKey(clusterId)
+	result, err := d.clusterSingleFlight.Do(key, func() (interface{}, error) {
+		if cached, ok := d.clusterCache.Get(key); ok {
+			return cached, nil
+		}
+
+		value, err := d.ddcBucketContract.ClusterGet(clusterId)
+		if err != nil {
+			return nil, err
+		}
+
+		d.clusterCache.SetDefault(key, value)
+		return value, nil
+	})
+
+	resp, _ := result.(*bucket.ClusterInfo)
+	return resp, err
 }
 
 func (d *ddcBucketContractCached) NodeGet(nodeKey bucket.NodeKey) (*bucket.NodeInfo, error) {
@@ -266,7 +333,23 @@ func (d *ddcBucketContractCached) NodeGet(nodeKey bucket.NodeKey) (*bucket.NodeI
 }
 
 func (d *ddcBucketContractCached) CdnNodeGet(nodeKey bucket.CdnNodeKey) (*bucket.CdnNodeInfo, error) {
-	return d.ddcBucketContract.CdnNodeGet(nodeKey)
+	key := nodeKey.ToHexString()
+	result, err := d.cdnNodeSingleFlight.Do(key, func() (interface{}, error) {
+		if cached, ok := d.cdnNodeCache.Get(key); ok {
+			return cached, nil
+		}
+
+		value, err := d.ddcBucketContract.CdnNodeGet(nodeKey)
+		if err != nil {
+			return nil, err
+		}
+
+		d.cdnNodeCache.SetDefault(key, value)
+		return value, nil
+	})
+
+	resp, _ := result.(*bucket.CdnNodeInfo)
+	return resp, err
 }
 
 func (d *ddcBucketContractCached) BucketGet(bucketId bucket.BucketId) (*bucket.BucketInfo, error) {
@@ -312,6 +395,7 @@ func (d *ddcBucketContractCached) AccountGet(account types.AccountID) (*bucket.A
 func (d *ddcBucketContractCached) Clear() {
 	d.ClearBuckets()
 	d.ClearNodes()
+	d.ClearClusters()
 	d.ClearAccounts()
 }
 
@@ -327,16 +411,30 @@ func (d *ddcBucketContractCached) AddContractEventHandler(event string, handler
 	return d.ddcBucketContract.AddContractEventHandler(event, handler)
 }
 
+func (d *ddcBucketContractCached) RegisterHandler(event string, handler func(interface{})) error {
+	return d.ddcBucketContract.RegisterHandler(event, handler)
+}
+
+func (d *ddcBucketContractCached) UnregisterHandler(event string) error {
+	return d.ddcBucketContract.UnregisterHandler(event)
+}
+
 func (d *ddcBucketContractCached) GetEventDispatcher() map[types.Hash]pkg.ContractEventDispatchEntry {
 	return d.ddcBucketContract.GetEventDispatcher()
 }
 
 func (d *ddcBucketContractCached) ClearNodes() {
 	d.nodeCache.Flush()
+	d.cdnNodeCache.Flush()
+}
+
+func (d *ddcBucketContractCached) ClearClusters() {
+	d.clusterCache.Flush()
 }
 
 func (d *ddcBucketContractCached) ClearBuckets() {
 	d.bucketCache.Flush()
+	d.permissionCache.Flush()
 }
 
 func (d *ddcBucketContractCached) ClearAccounts() {
@@ -344,15 +442,23 @@ func (d *ddcBucketContractCached) ClearAccounts() {
 }
 
 func (d *ddcBucketContractCached) ClearNodeById(key bucket.NodeKey) { //nolint:golint,unused
-	d.nodeCache.Delete(key.ToHexString())
+	d.ClearNodeByKey(key)
 }
 
+// ClearNodeByKey drops the storage or CDN node with the key.
 func (d *ddcBucketContractCached) ClearNodeByKey(nodeKey bucket.NodeKey) {
 	d.nodeCache.Delete(nodeKey.ToHexString())
+	d.cdnNodeCache.Delete(nodeKey.ToHexString())
+}
+
+func (d *ddcBucketContractCached) ClearClusterById(id bucket.ClusterId) {
+	d.clusterCache.Delete(clusterKey(id))
 }
 
 func (d *ddcBucketContractCached) ClearBucketById(id bucket.BucketId) {
 	d.bucketCache.Delete(toString(id))
+	d.permissionCache.Delete(writersKey(id))
+	d.permissionCache.Delete(readersKey(id))
 }
 
 func (d *ddcBucketContractCached) ClearAccountById(id bucket.AccountId) {
@@ -380,6 +486,10 @@ func toString(value bucket.BucketId) string {
 	return strconv.FormatUint(uint64(value), 10)
 }
 
+func clusterKey(clusterId bucket.ClusterId) string {
+	return strconv.FormatUint(uint64(clusterId), 10)
+}
+
 func validateCDNNodeParams(params bucket.CDNNodeParams) error {
 	if params.Url == "" {
 		return errors.New("Empty CDN node URL.")
@@ -425,6 +535,7 @@ func (d *ddcBucketContractCached) ClusterAddNode(ctx context.Context, keyPair si
 
 	d.ClearBuckets()
 	d.ClearNodes()
+	d.ClearClusterById(clusterId)
 
 	return nil
 }
@@ -437,6 +548,7 @@ func (d *ddcBucketContractCached) ClusterRemoveNode(ctx context.Context, keyPair
 
 	// If the node removal from the contract was successful, clear the cached node status.e
 	d.ClearNodeByKey(nodeKey)
+	d.ClearClusterById(clusterId)
 
 	return nil
 }
@@ -458,6 +570,7 @@ func (d *ddcBucketContractCached) ClusterResetNode(ctx context.Context, keyPair
 	}
 
 	d.ClearNodeByKey(nodeKey)
+	d.ClearClusterById(clusterId)
 
 	return nil
 }
@@ -483,6 +596,7 @@ func (d *ddcBucketContractCached) ClusterReplaceNode(ctx context.Context, keyPai
 
 	d.ClearBuckets()
 	d.ClearNodes()
+	d.ClearClusterById(clusterId)
 
 	return nil
 }
@@ -504,6 +618,7 @@ func (d *ddcBucketContractCached) ClusterAddCdnNode(ctx context.Context, keyPair
 
 	d.ClearBuckets()
 	d.ClearNodes()
+	d.ClearClusterById(clusterId)
 
 	return nil
 }
@@ -525,6 +640,7 @@ func (d *ddcBucketContractCached) ClusterRemoveCdnNode(ctx context.Context, keyP
 	}
 
 	d.ClearNodeByKey(cdnNodeKey)
+	d.ClearClusterById(clusterId)
 
 	return nil
 }
@@ -546,6 +662,7 @@ func (d *ddcBucketContractCached) ClusterSetParams(ctx context.Context, keyPair
 
 	d.ClearBuckets()
 	d.ClearNodes()
+	d.ClearClusterById(clusterId)
 
 	return nil
 }
@@ -563,13 +680,14 @@ func (d *ddcBucketContractCached) ClusterRemove(ctx context.Context, keyPair sig
 
 	d.ClearBuckets()
 	d.ClearNodes()
+	d.ClearClusterById(clusterId)
 
 	return nil
 }
 
-func (d *ddcBucketContractCached) ClusterSetNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, nodeKey bucket.NodeKey, statusInCluster string) error {
-	if statusInCluster == "" {
-		return errors.New("Empty status in cluster.")
+func (d *ddcBucketContractCached) ClusterSetNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, nodeKey bucket.NodeKey, statusInCluster bucket.NodeStatusInCluster) error {
+	if err := statusInCluster.Validate(); err != nil {
+		return err
 	}
 
 	clusterStatus, clusterError := d.ClusterGet(clusterId)
@@ -588,13 +706,14 @@ func (d *ddcBucketContractCached) ClusterSetNodeStatus(ctx context.Context, keyP
 
 	d.ClearBuckets()
 	d.ClearNodes()
+	d.ClearClusterById(clusterId)
 
 	return nil
 }
 
-func (d *ddcBucketContractCached) ClusterSetCdnNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, cdnNodeKey bucket.CdnNodeKey, statusInCluster string) error {
-	if statusInCluster == "" {
-		return errors.New("Empty status in cluster.")
+func (d *ddcBucketContractCached) ClusterSetCdnNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, cdnNodeKey bucket.CdnNodeKey, statusInCluster bucket.NodeStatusInCluster) error {
+	if err := statusInCluster.Validate(); err != nil {
+		return err
 	}
 
 	clusterStatus, err := d.ClusterGet(clusterId)
@@ -612,10 +731,25 @@ func (d *ddcBucketContractCached) ClusterSetCdnNodeStatus(ctx context.Context, k
 	}
 
 	d.ClearNodeByKey(cdnNodeKey)
+	d.ClearClusterById(clusterId)
 
 	return nil
 }
 
+func (d *ddcBucketContractCached) ClusterDistributeRevenues(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId) error {
+	if err := d.ddcBucketContract.ClusterDistributeRevenues(ctx, keyPair, clusterId); err != nil {
+		return err
+	}
+
+	d.ClearClusterById(clusterId)
+
+	return nil
+}
+
+func (d *ddcBucketContractCached) ClusterDistributeRevenuesEstimate(clusterId bucket.ClusterId) (*bucket.RevenueDistribution, error) {
+	return d.ddcBucketContract.ClusterDistributeRevenuesEstimate(clusterId)
+}
+
 func (d *ddcBucketContractCached) ClusterList(offset types.U32, limit types.U32, filterManagerId types.OptionAccountID) (*bucket.ClusterListInfo, error) {
 	if limit == 0 {
 		return nil, errors.New("Invalid limit. Limit must be greater than zero.")
@@ -633,7 +767,7 @@ func (d *ddcBucketContractCached) ClusterList(offset types.U32, limit types.U32,
 	return clusters, nil
 }
 
-func (d *ddcBucketContractCached) NodeCreate(ctx context.Context, keyPair signature.KeyringPair, nodeKey bucket.NodeKey, params bucket.Params, capacity bucket.Resource, rent bucket.Rent) (blockHash types.Hash, err error) {
+func (d *ddcBucketContractCached) NodeCreate(ctx context.Context, keyPair signature.KeyringPair, nodeKey bucket.NodeKey, params bucket.Params, capacity bucket.StorageGb, rent bucket.Rent) (blockHash types.Hash, err error) {
 	blockHash, err = d.ddcBucketContract.NodeCreate(ctx, keyPair, nodeKey, params, capacity, rent)
 
 	d.ClearNodes()
@@ -801,8 +935,8 @@ func (d *ddcBucketContractCached) AdminTransferCdnNodeOwnership(ctx context.Cont
 }
 
 // TODO implement caching for underlying methods
-func (d *ddcBucketContractCached) AccountDeposit(ctx context.Context, keyPair signature.KeyringPair) error {
-	return d.ddcBucketContract.AccountDeposit(ctx, keyPair)
+func (d *ddcBucketContractCached) AccountDeposit(ctx context.Context, keyPair signature.KeyringPair, value bucket.Balance) (blockHash types.Hash, err error) {
+	return d.ddcBucketContract.AccountDeposit(ctx, keyPair, value)
 }
 
 func (d *ddcBucketContractCached) AccountBond(ctx context.Context, keyPair signature.KeyringPair, bondAmount bucket.Balance) error {
@@ -838,7 +972,7 @@ func (d *ddcBucketContractCached) BucketChangeOwner(ctx context.Context, keyPair
 	return d.ddcBucketContract.BucketChangeOwner(ctx, keyPair, bucketId, ownerId)
 }
 
-func (d *ddcBucketContractCached) BucketAllocIntoCluster(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, resource bucket.Resource) error {
+func (d *ddcBucketContractCached) BucketAllocIntoCluster(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, resource bucket.StorageGb) error {
 	return d.ddcBucketContract.BucketAllocIntoCluster(ctx, keyPair, bucketId, resource)
 }
 
@@ -862,30 +996,103 @@ func (d *ddcBucketContractCached) BucketSetAvailability(ctx context.Context, key
 	return d.ddcBucketContract.BucketSetAvailability(ctx, keyPair, bucketId, publicAvailability)
 }
 
-func (d *ddcBucketContractCached) BucketSetResourceCap(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, newResourceCap bucket.Resource) error {
+func (d *ddcBucketContractCached) BucketSetResourceCap(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, newResourceCap bucket.StorageGb) error {
 	return d.ddcBucketContract.BucketSetResourceCap(ctx, keyPair, bucketId, newResourceCap)
 }
 
 func (d *ddcBucketContractCached) GetBucketWriters(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId) ([]bucket.AccountId, error) {
-	return d.ddcBucketContract.GetBucketWriters(ctx, keyPair, bucketId)
+	return d.bucketAccounts(writersKey(bucketId), func() ([]bucket.AccountId, error) {
+		return d.ddcBucketContract.GetBucketWriters(ctx, keyPair, bucketId)
+	})
 }
 
 func (d *ddcBucketContractCached) GetBucketReaders(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId) ([]bucket.AccountId, error) {
-	return d.ddcBucketContract.GetBucketReaders(ctx, keyPair, bucketId)
+	return d.bucketAccounts(readersKey(bucketId), func() ([]bucket.AccountId, error) {
+		return d.ddcBucketContract.GetBucketReaders(ctx, keyPair, bucketId)
+	})
+}
+
+func (d *ddcBucketContractCached) BucketWritersPage(bucketId bucket.BucketId, offset types.U32, limit types.U32) (*bucket.AccountListInfo, error) {
+	writers, err := d.GetBucketWriters(context.Background(), signature.KeyringPair{}, bucketId)
+	if err != nil {
+		return nil, err
+	}
+
+	return bucket.NewAccountListPage(writers, offset, limit), nil
+}
+
+func (d *ddcBucketContractCached) BucketReadersPage(bucketId bucket.BucketId, offset types.U32, limit types.U32) (*bucket.AccountListInfo, error) {
+	readers, err := d.GetBucketReaders(context.Background(), signature.KeyringPair{}, bucketId)
+	if err != nil {
+		return nil, err
+	}
+
+	return bucket.NewAccountListPage(readers, offset, limit), nil
+}
+
+func (d *ddcBucketContractCached) IsWriter(bucketId bucket.BucketId, account bucket.AccountId) (bool, error) {
+	writers, err := d.GetBucketWriters(context.Background(), signature.KeyringPair{}, bucketId)
+	if err != nil {
+		return false, err
+	}
+
+	return bucket.ContainsAccount(writers, account), nil
+}
+
+func (d *ddcBucketContractCached) IsReader(bucketId bucket.BucketId, account bucket.AccountId) (bool, error) {
+	readers, err := d.GetBucketReaders(context.Background(), signature.KeyringPair{}, bucketId)
+	if err != nil {
+		return false, err
+	}
+
+	return bucket.ContainsAccount(readers, account), nil
 }
 
 func (d *ddcBucketContractCached) BucketSetWriterPerm(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, writer bucket.AccountId) error {
+	defer d.permissionCache.Delete(writersKey(bucketId))
 	return d.ddcBucketContract.BucketSetWriterPerm(ctx, keyPair, bucketId, writer)
 }
 
 func (d *ddcBucketContractCached) BucketRevokeWriterPerm(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, writer bucket.AccountId) error {
+	defer d.permissionCache.Delete(writersKey(bucketId))
 	return d.ddcBucketContract.BucketRevokeWriterPerm(ctx, keyPair, bucketId, writer)
 }
 
 func (d *ddcBucketContractCached) BucketSetReaderPerm(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, reader bucket.AccountId) error {
+	defer d.permissionCache.Delete(readersKey(bucketId))
 	return d.ddcBucketContract.BucketSetReaderPerm(ctx, keyPair, bucketId, reader)
 }
 
 func (d *ddcBucketContractCached) BucketRevokeReaderPerm(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, reader bucket.AccountId) error {
+	defer d.permissionCache.Delete(readersKey(bucketId))
 	return d.ddcBucketContract.BucketRevokeReaderPerm(ctx, keyPair, bucketId, reader)
 }
+
+// bucketAccounts returns the cached bucket writers or readers list reading it with get on a miss.
+// The lists are dropped when the bucket changes or its permissions are changed through the cache.
+func (d *ddcBucketContractCached) bucketAccounts(key string, get func() ([]bucket.AccountId, error)) ([]bucket.AccountId, error) {
+	result, err := d.permissionSingleFlight.Do(key, func() (interface{}, error) {
+		if cached, ok := d.permissionCache.Get(key); ok {
+			return cached, nil
+		}
+
+		value, err := get()
+		if err != nil {
+			return nil, err
+		}
+
+		d.permissionCache.SetDefault(key, value)
+		return value, nil
+	})
+
+	resp, _ := result.([]bucket.AccountId)
+	return resp, err
+}
+
+func writersKey(bucketId bucket.BucketId) string {
+	return "writers/" + toString(bucketId)
+}
+
+func readersKey(bucketId bucket.BucketId) string {
+	return "readers/" + toString(bucketId)
+}
@@ -16,6 +16,7 @@ import (
 
 type mockedDdcBucketContract struct {
 	mock.Mock
+	handlers map[string]func(interface{})
 }
 
 func (m *mockedDdcBucketContract) GetContractAddress() string {
@@ -68,6 +69,17 @@ func (m *mockedDdcBucketContract) ClusterCreate(ctx context.Context, keyPair sig
 }
 
 func (d *mockedDdcBucketContract) AddContractEventHandler(event string, handler func(interface{})) error {
+	if d.handlers != nil {
+		d.handlers[event] = handler
+	}
+	return nil
+}
+
+func (d *mockedDdcBucketContract) RegisterHandler(event string, handler func(interface{})) error {
+	return nil
+}
+
+func (d *mockedDdcBucketContract) UnregisterHandler(event string) error {
 	return nil
 }
 
@@ -95,7 +107,7 @@ func (m *mockedDdcBucketContract) CdnNodeCreate(ctx context.Context, keyPair sig
 	return nil
 }
 
-func (m *mockedDdcBucketContract) NodeCreate(ctx context.Context, keyPair signature.KeyringPair, nodeKey bucket.NodeKey, params bucket.Params, capacity bucket.Resource, rent bucket.Rent) (blockHash types.Hash, err error) {
+func (m *mockedDdcBucketContract) NodeCreate(ctx context.Context, keyPair signature.KeyringPair, nodeKey bucket.NodeKey, params bucket.Params, capacity bucket.StorageGb, rent bucket.Rent) (blockHash types.Hash, err error) {
 	return types.Hash{}, nil
 }
 
@@ -154,12 +166,12 @@ func (m *mockedDdcBucketContract) ClusterResetNode(ctx context.Context, keyPair
 	return args.Error(1)
 }
 
-func (m *mockedDdcBucketContract) ClusterSetCdnNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, cdnNodeKey bucket.CdnNodeKey, statusInCluster string) error {
+func (m *mockedDdcBucketContract) ClusterSetCdnNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, cdnNodeKey bucket.CdnNodeKey, statusInCluster bucket.NodeStatusInCluster) error {
 	args := m.Called(clusterId, cdnNodeKey, statusInCluster)
 	return args.Error(1)
 }
 
-func (m *mockedDdcBucketContract) ClusterSetNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, nodeKey bucket.NodeKey, statusInCluster string) error {
+func (m *mockedDdcBucketContract) ClusterSetNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, nodeKey bucket.NodeKey, statusInCluster bucket.NodeStatusInCluster) error {
 	args := m.Called(clusterId, nodeKey, statusInCluster)
 	return args.Error(1)
 }
@@ -190,7 +202,7 @@ func (m *mockedDdcBucketContract) RevokeTrustedManagerPermission(ctx context.Con
 }
 
 // TODO: implement yhe underlying methods
-func (m *mockedDdcBucketContract) AccountDeposit(ctx context.Context, keyPair signature.KeyringPair) error {
+func (m *mockedDdcBucketContract) AccountDeposit(ctx context.Context, keyPair signature.KeyringPair, value bucket.Balance) (blockHash types.Hash, err error) {
 	panic("implement me")
 }
 
@@ -225,11 +237,11 @@ func (m *mockedDdcBucketContract) BucketChangeOwner(ctx context.Context, keyPair
 	panic("implement me")
 }
 
-func (m *mockedDdcBucketContract) BucketAllocIntoCluster(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, resource bucket.Resource) error {
+func (m *mockedDdcBucketContract) BucketAllocIntoCluster(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, resource bucket.StorageGb) error {
 	panic("implement me")
 }
 
-func (m *mockedDdcBucketContract) BucketSetResourceCap(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, newResourceCap bucket.Resource) error {
+func (m *mockedDdcBucketContract) BucketSetResourceCap(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, newResourceCap bucket.StorageGb) error {
 	panic("implement me")
 }
 
@@ -254,17 +266,43 @@ func (m *mockedDdcBucketContract) BucketSetAvailability(ctx context.Context, key
 }
 
 func (m *mockedDdcBucketContract) GetBucketWriters(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId) ([]types.AccountID, error) {
-	panic("implement me")
+	args := m.Called(bucketId)
+	return args.Get(0).([]types.AccountID), args.Error(1)
 }
 
 func (m *mockedDdcBucketContract) GetBucketReaders(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId) ([]types.AccountID, error) {
 	panic("implement me")
 }
 
-func (m *mockedDdcBucketContract) BucketSetWriterPerm(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, writer bucket.AccountId) error {
+func (m *mockedDdcBucketContract) ClusterDistributeRevenues(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId) error {
 	panic("implement me")
 }
 
+func (m *mockedDdcBucketContract) ClusterDistributeRevenuesEstimate(clusterId bucket.ClusterId) (*bucket.RevenueDistribution, error) {
+	panic("implement me")
+}
+
+func (m *mockedDdcBucketContract) BucketWritersPage(bucketId bucket.BucketId, offset types.U32, limit types.U32) (*bucket.AccountListInfo, error) {
+	panic("implement me")
+}
+
+func (m *mockedDdcBucketContract) BucketReadersPage(bucketId bucket.BucketId, offset types.U32, limit types.U32) (*bucket.AccountListInfo, error) {
+	panic("implement me")
+}
+
+func (m *mockedDdcBucketContract) IsWriter(bucketId bucket.BucketId, account bucket.AccountId) (bool, error) {
+	panic("implement me")
+}
+
+func (m *mockedDdcBucketContract) IsReader(bucketId bucket.BucketId, account bucket.AccountId) (bool, error) {
+	panic("implement me")
+}
+
+func (m *mockedDdcBucketContract) BucketSetWriterPerm(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, writer bucket.AccountId) error {
+	args := m.Called(bucketId, writer)
+	return args.Error(0)
+}
+
 func (m *mockedDdcBucketContract) BucketRevokeWriterPerm(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, writer bucket.AccountId) error {
 	panic("implement me")
 }
@@ -311,6 +349,130 @@ func TestBucketGetCached(t *testing.T) {
 	ddcBucketContract.AssertNumberOfCalls(t, "BucketGet", 1)
 }
 
+func TestClusterGetCached(t *testing.T) {
+	//given
+	ddcBucketContract := &mockedDdcBucketContract{}
+	testSubject := CreateDdcBucketContractCache(ddcBucketContract, BucketCacheParameters{})
+	result := &bucket.ClusterInfo{ClusterId: 1}
+	ddcBucketContract.On("ClusterGet", bucket.ClusterId(1)).Return(result, nil).Once()
+	_, _ = testSubject.ClusterGet(1)
+
+	//when
+	cluster, err := testSubject.ClusterGet(1)
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, result, cluster)
+	ddcBucketContract.AssertNumberOfCalls(t, "ClusterGet", 1)
+}
+
+func TestContractEventsInvalidateCache(t *testing.T) {
+	tests := []struct {
+		name   string
+		event  string
+		args   interface{}
+		method string
+		get    func(c DdcBucketContractCache)
+	}{
+		{
+			name:   "cluster params set",
+			event:  bucket.ClusterParamsSetEventId,
+			args:   &bucket.ClusterParamsSetEvent{ClusterId: 1},
+			method: "ClusterGet",
+			get:    func(c DdcBucketContractCache) { _, _ = c.ClusterGet(1) },
+		},
+		{
+			name:   "cluster node added",
+			event:  bucket.ClusterNodeAddedEventId,
+			args:   &bucket.ClusterNodeAddedEvent{ClusterId: 1, NodeKey: bucket.NodeKey{1}},
+			method: "ClusterGet",
+			get:    func(c DdcBucketContractCache) { _, _ = c.ClusterGet(1) },
+		},
+		{
+			name:   "node params set",
+			event:  bucket.NodeParamsSetEventId,
+			args:   &bucket.NodeParamsSetEvent{NodeKey: bucket.NodeKey{1}},
+			method: "NodeGet",
+			get:    func(c DdcBucketContractCache) { _, _ = c.NodeGet(bucket.NodeKey{1}) },
+		},
+		{
+			name:   "cdn node params set",
+			event:  bucket.CdnNodeParamsSetEventId,
+			args:   &bucket.CdnNodeParamsSetEvent{CdnNodeKey: bucket.CdnNodeKey{1}},
+			method: "CdnNodeGet",
+			get:    func(c DdcBucketContractCache) { _, _ = c.CdnNodeGet(bucket.CdnNodeKey{1}) },
+		},
+		{
+			name:   "bucket params set",
+			event:  bucket.BucketParamsSetEventId,
+			args:   &bucket.BucketParamsSetEvent{BucketId: 1},
+			method: "BucketGet",
+			get:    func(c DdcBucketContractCache) { _, _ = c.BucketGet(1) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			ddcBucketContract := &mockedDdcBucketContract{handlers: map[string]func(interface{}){}}
+			ddcBucketContract.On("ClusterGet", bucket.ClusterId(1)).Return(&bucket.ClusterInfo{}, nil)
+			ddcBucketContract.On("NodeGet", bucket.NodeKey{1}).Return(&bucket.NodeInfo{}, nil)
+			ddcBucketContract.On("CdnNodeGet", bucket.CdnNodeKey{1}).Return(&bucket.CdnNodeInfo{}, nil)
+			ddcBucketContract.On("BucketGet", bucket.BucketId(1)).Return(&bucket.BucketInfo{}, nil)
+			testSubject := CreateDdcBucketContractCache(ddcBucketContract, BucketCacheParameters{})
+			assert.NoError(t, testSubject.HookContractEvents())
+			tt.get(testSubject)
+			tt.get(testSubject)
+
+			//when
+			ddcBucketContract.handlers[tt.event](tt.args)
+			tt.get(testSubject)
+
+			//then
+			ddcBucketContract.AssertNumberOfCalls(t, tt.method, 2)
+		})
+	}
+}
+
+func TestIsWriterCached(t *testing.T) {
+	//given
+	ddcBucketContract := &mockedDdcBucketContract{}
+	testSubject := &ddcBucketContractCached{permissionCache: cache.New(defaultExpiration, cleanupInterval), ddcBucketContract: ddcBucketContract}
+	writer := types.AccountID{1}
+	ddcBucketContract.On("GetBucketWriters", types.NewU32(1)).Return([]types.AccountID{writer}, nil).Once()
+	_, _ = testSubject.IsWriter(types.NewU32(1), writer)
+
+	//when
+	isWriter, err := testSubject.IsWriter(types.NewU32(1), writer)
+	isNotWriter, _ := testSubject.IsWriter(types.NewU32(1), types.AccountID{2})
+
+	//then
+	assert.NoError(t, err)
+	assert.True(t, isWriter)
+	assert.False(t, isNotWriter)
+	ddcBucketContract.AssertNumberOfCalls(t, "GetBucketWriters", 1)
+}
+
+func TestBucketSetWriterPermRefreshesWriters(t *testing.T) {
+	//given
+	ddcBucketContract := &mockedDdcBucketContract{}
+	testSubject := &ddcBucketContractCached{permissionCache: cache.New(defaultExpiration, cleanupInterval), ddcBucketContract: ddcBucketContract}
+	writer := types.AccountID{1}
+	ddcBucketContract.On("GetBucketWriters", types.NewU32(1)).Return([]types.AccountID{}, nil).Once()
+	ddcBucketContract.On("BucketSetWriterPerm", types.NewU32(1), writer).Return(nil).Once()
+	ddcBucketContract.On("GetBucketWriters", types.NewU32(1)).Return([]types.AccountID{writer}, nil).Once()
+	_, _ = testSubject.IsWriter(types.NewU32(1), writer)
+
+	//when
+	err := testSubject.BucketSetWriterPerm(context.Background(), signature.KeyringPair{}, types.NewU32(1), writer)
+	page, _ := testSubject.BucketWritersPage(types.NewU32(1), 0, 10)
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, &bucket.AccountListInfo{Accounts: []types.AccountID{writer}, Total: 1}, page)
+	ddcBucketContract.AssertExpectations(t)
+}
+
 // func TestCDNNodeList(t *testing.T) {
 // 	//given
 //     ddcBucketContract := &mockedDdcBucketContract{}
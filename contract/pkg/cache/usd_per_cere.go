@@ -0,0 +1,165 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultRateRefreshInterval = time.Minute
+
+type (
+	// UsdPerCere is the cached exchange rate with its staleness metadata.
+	UsdPerCere struct {
+		Rate bucket.Balance
+		// FetchedAt is the time the rate was read from the contract.
+		FetchedAt time.Time
+		// Stale is set when the rate wasn't refreshed for longer than the max age, e.g. the RPC
+		// node is unavailable.
+		Stale bool
+		// LastError is the error of the latest refresh, nil if it succeeded.
+		LastError error
+	}
+
+	// RateChangeCallback is called with the previous and the new rate when the rate changes.
+	RateChangeCallback func(previous bucket.Balance, current bucket.Balance)
+
+	// UsdPerCereCache keeps AccountGetUsdPerCere of the contract refreshed periodically, so
+	// pricing code can read it on hot paths. It's safe for concurrent use.
+	UsdPerCereCache struct {
+		contract        bucket.DdcBucketContract
+		refreshInterval time.Duration
+		maxAge          time.Duration
+		now             func() time.Time
+
+		mutex     sync.RWMutex
+		rate      *UsdPerCere
+		callbacks []RateChangeCallback
+	}
+
+	UsdPerCereOption func(c *UsdPerCereCache)
+)
+
+var ErrRateNotFetched = errors.New("usd per cere rate is not fetched yet")
+
+// WithRefreshInterval sets how often the rate is read from the contract, a minute by default.
+func WithRefreshInterval(interval time.Duration) UsdPerCereOption {
+	return func(c *UsdPerCereCache) {
+		c.refreshInterval = interval
+	}
+}
+
+// WithMaxAge sets the age the rate is stale after, three refresh intervals by default.
+func WithMaxAge(maxAge time.Duration) UsdPerCereOption {
+	return func(c *UsdPerCereCache) {
+		c.maxAge = maxAge
+	}
+}
+
+func WithRateClock(now func() time.Time) UsdPerCereOption {
+	return func(c *UsdPerCereCache) {
+		c.now = now
+	}
+}
+
+func CreateUsdPerCereCache(contract bucket.DdcBucketContract, opts ...UsdPerCereOption) *UsdPerCereCache {
+	c := &UsdPerCereCache{
+		contract:        contract,
+		refreshInterval: defaultRateRefreshInterval,
+		now:             time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.maxAge == 0 {
+		c.maxAge = 3 * c.refreshInterval
+	}
+
+	return c
+}
+
+// Start reads the rate and refreshes it in the background until the context is done.
+func (c *UsdPerCereCache) Start(ctx context.Context) error {
+	if err := c.Refresh(); err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(c.refreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := c.Refresh(); err != nil {
+					log.WithError(err).Warn("Unable to refresh usd per cere rate")
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// OnRateChange registers the callback called when a refresh reads a different rate.
+func (c *UsdPerCereCache) OnRateChange(callback RateChangeCallback) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.callbacks = append(c.callbacks, callback)
+}
+
+// Refresh reads the rate from the contract. The cached rate is kept if the read fails.
+func (c *UsdPerCereCache) Refresh() error {
+	rate, err := c.contract.AccountGetUsdPerCere()
+
+	c.mutex.Lock()
+	if err != nil {
+		if c.rate != nil {
+			c.rate.LastError = err
+		}
+		c.mutex.Unlock()
+		return err
+	}
+
+	previous := c.rate
+	c.rate = &UsdPerCere{Rate: rate, FetchedAt: c.now()}
+	callbacks := c.callbacks
+	c.mutex.Unlock()
+
+	if previous != nil && !equalBalance(previous.Rate, rate) {
+		for _, callback := range callbacks {
+			callback(previous.Rate, rate)
+		}
+	}
+
+	return nil
+}
+
+// GetCachedUsdPerCere returns the cached rate without calling the contract.
+func (c *UsdPerCereCache) GetCachedUsdPerCere() (UsdPerCere, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if c.rate == nil {
+		return UsdPerCere{}, ErrRateNotFetched
+	}
+
+	rate := *c.rate
+	rate.Stale = c.now().Sub(rate.FetchedAt) > c.maxAge
+
+	return rate, nil
+}
+
+func equalBalance(a bucket.Balance, b bucket.Balance) bool {
+	if a.Int == nil || b.Int == nil {
+		return a.Int == b.Int
+	}
+
+	return a.Cmp(b.Int) == 0
+}
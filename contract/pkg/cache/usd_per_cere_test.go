@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+	"github.com/stretchr/testify/assert"
+)
+
+type rateContract struct {
+	bucket.DdcBucketContract
+	rate int64
+	err  error
+}
+
+func (r *rateContract) AccountGetUsdPerCere() (bucket.Balance, error) {
+	return types.NewU128(*big.NewInt(r.rate)), r.err
+}
+
+func TestUsdPerCereCache(t *testing.T) {
+	//given
+	now := time.UnixMilli(1_700_000_000_000)
+	contract := &rateContract{rate: 10}
+	rateCache := CreateUsdPerCereCache(contract, WithRefreshInterval(time.Minute), WithRateClock(func() time.Time { return now }))
+	var changes [][2]string
+	rateCache.OnRateChange(func(previous bucket.Balance, current bucket.Balance) {
+		changes = append(changes, [2]string{previous.String(), current.String()})
+	})
+	_, notFetchedErr := rateCache.GetCachedUsdPerCere()
+
+	//when
+	assert.NoError(t, rateCache.Refresh())
+	assert.NoError(t, rateCache.Refresh())
+	contract.rate = 12
+	assert.NoError(t, rateCache.Refresh())
+	fresh, err := rateCache.GetCachedUsdPerCere()
+	assert.NoError(t, err)
+	contract.err = errors.New("connection refused")
+	assert.Error(t, rateCache.Refresh())
+	now = now.Add(4 * time.Minute)
+	stale, err := rateCache.GetCachedUsdPerCere()
+	assert.NoError(t, err)
+
+	//then
+	assert.True(t, errors.Is(notFetchedErr, ErrRateNotFetched))
+	assert.Equal(t, [][2]string{{"10", "12"}}, changes)
+	assert.Equal(t, "12", fresh.Rate.String())
+	assert.False(t, fresh.Stale)
+	assert.Nil(t, fresh.LastError)
+	assert.Equal(t, "12", stale.Rate.String())
+	assert.True(t, stale.Stale)
+	assert.Equal(t, contract.err, stale.LastError)
+}
@@ -3,17 +3,23 @@ package pkg
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"os/signal"
 	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/chainevents"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
@@ -23,12 +29,17 @@ const (
 	MGAS = 1_000_000
 )
 
+const readYourWritesPollInterval = 200 * time.Millisecond
+
 type (
 	BlockchainClient interface {
 		CallToReadEncoded(contractAddressSS58 string, fromAddress string, method []byte, args ...interface{}) (string, error)
+		CallToReadEncodedContext(ctx context.Context, readCall ReadCall) (string, error)
 		CallToExec(ctx context.Context, contractCall ContractCall) (types.Hash, error)
 		Deploy(ctx context.Context, deployCall DeployCall) (types.AccountID, error)
 		SetEventDispatcher(contractAddressSS58 string, dispatcher map[types.Hash]ContractEventDispatchEntry) error
+		GetContractEvents(contractAddressSS58 string, blockHash types.Hash) ([]ContractEvent, error)
+		GetAccountInfo(accountId types.AccountID) (types.AccountInfo, error)
 	}
 
 	blockchainClient struct {
@@ -37,8 +48,26 @@ type (
 		eventDispatcher      map[types.Hash]ContractEventDispatchEntry
 		eventContextCancel   context.CancelFunc
 		connectMutex         sync.Mutex
+		addressOpts          []AddressOption
+		eventPreFilter       bool
+		readYourWrites       bool
+		middlewares          []Middleware
+		feeStrategy          FeeStrategy
+		nonceGapPolicy       *NonceGapPolicy
+		sessionMetrics       *SessionMetrics
+		sentExtrinsics       sentExtrinsics
+		hedger               *hedger
+		readRetry            *RetryPolicy
+		remoteSigner         RemoteSigner
+		scheduler            *scheduler
+		pool                 *endpointPool
+		// writeBlock is the number of the latest block a transaction of the client was included in
+		// and the RPC node wasn't seen at yet, 0 if there is none.
+		writeBlock uint64
 	}
 
+	ClientOption func(*blockchainClient)
+
 	ContractCall struct {
 		ContractAddress     types.AccountID
 		ContractAddressSS58 string
@@ -49,6 +78,15 @@ type (
 		Args                []interface{}
 	}
 
+	ReadCall struct {
+		ContractAddressSS58 string
+		From                string
+		Method              []byte
+		Args                []interface{}
+		// At is the hash of the block to read the contract state at, the best block if empty.
+		At types.Hash
+	}
+
 	DeployCall struct {
 		Code     []byte
 		Salt     []byte
@@ -66,6 +104,17 @@ type (
 
 	ContractEventHandler func(interface{})
 
+	// ContractEvent is an event emitted by a contract.
+	ContractEvent struct {
+		Topics []types.Hash
+		Data   []byte
+		// BlockHash and BlockNumber identify the block the event was emitted in.
+		BlockHash   types.Hash
+		BlockNumber types.BlockNumber
+		// Phase tells the index of the extrinsic which emitted the event.
+		Phase chainevents.Phase
+	}
+
 	Response struct {
 		DebugMessage string `json:"debugMessage"`
 		GasConsumed  int    `json:"gasConsumed"`
@@ -74,6 +123,7 @@ type (
 				Data  string `json:"data"`
 				Flags int    `json:"flags"`
 			} `json:"Ok"`
+			Err json.RawMessage `json:"Err"`
 		} `json:"result"`
 	}
 
@@ -86,24 +136,131 @@ type (
 	}
 )
 
-func CreateBlockchainClient(apiUrl string) BlockchainClient {
+// DeadlineExceededError is returned when the RPC endpoint didn't respond to a read call in time.
+// It doesn't mean the contract execution failed, the call may succeed when retried.
+type DeadlineExceededError struct {
+	Method string
+}
+
+func (e *DeadlineExceededError) Error() string {
+	return fmt.Sprintf("call %s: rpc deadline exceeded", e.Method)
+}
+
+func (e *DeadlineExceededError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+func (e *DeadlineExceededError) ErrorCode() ddcerrors.Code {
+	return ddcerrors.CodeTimeout
+}
+
+// ExecutionError is returned when the contract execution of a read call failed on chain, e.g. the
+// contract trapped or ran out of gas. It unwraps to the decoded reason, e.g. ErrContractTrapped.
+type ExecutionError struct {
+	Method       string
+	Err          string
+	DebugMessage string
+}
+
+func (e *ExecutionError) Error() string {
+	return fmt.Sprintf("call %s: contract execution failed: %s", e.Method, e.Err)
+}
+
+func (e *ExecutionError) Unwrap() error {
+	return DecodeDispatchError([]byte(e.Err))
+}
+
+func (e *ExecutionError) ErrorCode() ddcerrors.Code {
+	return ddcerrors.CodeExecution
+}
+
+// Attempt is one try of an RPC call or of a reconnect between tries.
+type Attempt struct {
+	// Operation is "call" or "reconnect".
+	Operation string
+	Endpoint  string
+	StartedAt time.Time
+	Duration  time.Duration
+	Err       error
+}
+
+// RetryError is returned when an RPC call failed after it was retried. It keeps the history of
+// all attempts and unwraps to the error of the last one.
+type RetryError struct {
+	Attempts []Attempt
+}
+
+func (e *RetryError) Error() string {
+	parts := make([]string, len(e.Attempts))
+	for i, attempt := range e.Attempts {
+		parts[i] = fmt.Sprintf("#%d %s %s after %s: %v", i+1, attempt.Operation, attempt.Endpoint, attempt.Duration, attempt.Err)
+	}
+
+	return fmt.Sprintf("failed after %d attempts: %s", len(e.Attempts), strings.Join(parts, "; "))
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Attempts[len(e.Attempts)-1].Err
+}
+
+func (e *RetryError) ErrorCode() ddcerrors.Code {
+	return ddcerrors.CodeRpc
+}
+
+// WithAddressOptions sets how address strings passed to the client are validated, e.g. to restrict
+// them to a network with WithNetwork or to accept hex encoded public keys with WithHexPublicKeys.
+func WithAddressOptions(opts ...AddressOption) ClientOption {
+	return func(b *blockchainClient) {
+		b.addressOpts = opts
+	}
+}
+
+// WithContractEventPreFilter skips decoding of blocks events which can't contain events of the
+// contract set with SetEventDispatcher. Events of all pallets are decoded in every block otherwise,
+// which is the most of the events processing time on a busy chain. A block is skipped when its
+// encoded events contain neither the contract account id nor any of the dispatcher event topics.
+func WithContractEventPreFilter() ClientOption {
+	return func(b *blockchainClient) {
+		b.eventPreFilter = true
+	}
+}
+
+// WithReadYourWrites makes reads following a transaction of the client see the transaction. Before
+// reading the best block state, the client waits until the RPC node imported the block the
+// transaction was included in. The wait is limited by the read call context.
+func WithReadYourWrites() ClientOption {
+	return func(b *blockchainClient) {
+		b.readYourWrites = true
+	}
+}
+
+func CreateBlockchainClient(apiUrl string, opts ...ClientOption) BlockchainClient {
 	substrateAPI, err := gsrpc.NewSubstrateAPI(apiUrl)
 	if err != nil {
 		log.WithError(err).WithField("apiUrl", apiUrl).Fatal("Can't connect to blockchainClient")
 	}
 
-	return &blockchainClient{
+	b := &blockchainClient{
 		SubstrateAPI: substrateAPI,
 	}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
 }
 
 func (b *blockchainClient) SetEventDispatcher(contractAddressSS58 string, dispatcher map[types.Hash]ContractEventDispatchEntry) error {
-	contract, err := DecodeAccountIDFromSS58(contractAddressSS58)
+	contract, err := DecodeAddress(contractAddressSS58, b.addressOpts...)
 	if err != nil {
 		return err
 	}
 	b.eventContractAccount = contract
-	b.eventDispatcher = dispatcher
+	// The listener reads the dispatcher concurrently, so later changes of the caller map mustn't reach it.
+	b.eventDispatcher = make(map[types.Hash]ContractEventDispatchEntry, len(dispatcher))
+	for topic, entry := range dispatcher {
+		b.eventDispatcher[topic] = entry
+	}
 	err = b.listenContractEvents()
 	if err != nil {
 		return err
@@ -168,6 +325,10 @@ func (b *blockchainClient) listenContractEvents() error {
 						continue
 					}
 
+					if b.eventPreFilter && !mayContainContractEvents(chng.StorageData, b.eventContractAccount, b.eventDispatcher) {
+						continue
+					}
+
 					events := chainevents.EventRecords{}
 					err = chainevents.EventRecordsRaw(chng.StorageData).DecodeEventRecords(meta, &events)
 					if err != nil {
@@ -218,21 +379,88 @@ func (b *blockchainClient) listenContractEvents() error {
 	return nil
 }
 
+// mayContainContractEvents reports whether SCALE encoded events may contain an event emitted by
+// the contract with one of the dispatcher topics. It has false positives but no false negatives as
+// ContractEmitted events encode the contract account id and the topics as is.
+func mayContainContractEvents(encodedEvents []byte, contract types.AccountID, dispatcher map[types.Hash]ContractEventDispatchEntry) bool {
+	if !bytes.Contains(encodedEvents, contract[:]) {
+		return false
+	}
+	if len(dispatcher) == 0 {
+		return true
+	}
+
+	for topic := range dispatcher {
+		if bytes.Contains(encodedEvents, topic[:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (b *blockchainClient) CallToReadEncoded(contractAddressSS58 string, fromAddress string, method []byte, args ...interface{}) (string, error) {
-	data, err := GetContractData(method, args...)
+	return b.CallToReadEncodedContext(context.Background(), ReadCall{
+		ContractAddressSS58: contractAddressSS58,
+		From:                fromAddress,
+		Method:              method,
+		Args:                args,
+	})
+}
+
+// CallToReadEncodedContext calls a contract message without submitting a transaction. The call is
+// canceled with the context. DeadlineExceededError is returned if the RPC endpoint didn't respond
+// before the context deadline, ExecutionError if the contract execution failed and
+// InvalidAddressError if the contract or the caller address is invalid.
+func (b *blockchainClient) CallToReadEncodedContext(ctx context.Context, readCall ReadCall) (string, error) {
+	contractAddress, err := NormalizeAddress(readCall.ContractAddressSS58, b.addressOpts...)
+	if err != nil {
+		return "", err
+	}
+	fromAddress, err := NormalizeAddress(readCall.From, b.addressOpts...)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := GetContractData(readCall.Method, readCall.Args...)
 	if err != nil {
 		return "", errors.Wrap(err, "getMessagesData")
 	}
 
-	res, err := b.callToRead(contractAddressSS58, fromAddress, data)
+	res, err := b.callToRead(ctx, contractAddress, fromAddress, data, readCall.At)
+	b.sessionMetrics.RecordRpcCall(sessionOf(ctx, fromAddress), err)
 	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return "", &DeadlineExceededError{Method: codec.HexEncodeToString(readCall.Method)}
+		}
 		return "", err
 	}
 
+	if len(res.Result.Err) > 0 {
+		return "", &ExecutionError{
+			Method:       codec.HexEncodeToString(readCall.Method),
+			Err:          string(res.Result.Err),
+			DebugMessage: res.DebugMessage,
+		}
+	}
+
 	return res.Result.Ok.Data, nil
 }
 
-func (b *blockchainClient) callToRead(contractAddressSS58 string, fromAddress string, data []byte) (Response, error) {
+func (b *blockchainClient) callToRead(ctx context.Context, contractAddressSS58 string, fromAddress string, data []byte, at types.Hash) (Response, error) {
+	if at == (types.Hash{}) {
+		if err := b.waitForWrites(ctx); err != nil {
+			return Response{}, err
+		}
+	}
+
+	if b.scheduler != nil {
+		release, err := b.scheduler.acquire(ctx, PriorityFromContext(ctx))
+		if err != nil {
+			return Response{}, err
+		}
+		defer release()
+	}
 
 	params := Request{
 		Origin:    fromAddress,
@@ -241,10 +469,42 @@ func (b *blockchainClient) callToRead(contractAddressSS58 string, fromAddress st
 		InputData: codec.HexEncodeToString(data),
 	}
 
-	res, err := withRetryOnClosedNetwork(b, func() (Response, error) {
-		res := Response{}
-		return res, b.Client.Call(&res, "contracts_call", params)
-	})
+	call := func(ctx context.Context, cl client.Client) (Response, error) {
+		if at != (types.Hash{}) {
+			return callContext[Response](ctx, cl, "contracts_call", params, at.Hex())
+		}
+		return callContext[Response](ctx, cl, "contracts_call", params)
+	}
+	endpoint, pinned, err := b.route(ctx)
+	if err != nil {
+		return Response{}, err
+	}
+	read := func(ctx context.Context) (Response, error) {
+		if endpoint == "" {
+			return withRetryOnClosedNetwork(b, func() (Response, error) { return call(ctx, b.Client) })
+		}
+		cl, err := b.pool.client(endpoint)
+		if err != nil {
+			return Response{}, err
+		}
+		return call(ctx, cl)
+	}
+
+	// Pinned reads must observe the state of their endpoint, so they aren't hedged to other ones.
+	try := func(ctx context.Context) (Response, error) {
+		if pinned {
+			return read(ctx)
+		}
+		return hedgedCall(ctx, b.hedger, read, call)
+	}
+	var retryable func(err error) bool
+	if b.readRetry != nil {
+		retryable = b.readRetry.retryableAt(at)
+	}
+	if endpoint == "" {
+		endpoint = b.Client.URL()
+	}
+	res, err := withReadRetry(ctx, b.readRetry, endpoint, retryable, try)
 	if err != nil {
 		return Response{}, errors.Wrap(err, "call")
 	}
@@ -252,6 +512,24 @@ func (b *blockchainClient) callToRead(contractAddressSS58 string, fromAddress st
 	return res, nil
 }
 
+// contextCaller is implemented by clients created with client.Connect. The client.Client interface
+// of go-substrate-rpc-client v4.0.8 required by this module doesn't declare CallContext yet.
+type contextCaller interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// callContext calls the RPC method canceling the call with the context.
+func callContext[T any](ctx context.Context, cl client.Client, method string, args ...interface{}) (T, error) {
+	var res T
+	caller, ok := cl.(contextCaller)
+	if !ok {
+		return res, fmt.Errorf("rpc client %T can't cancel calls", cl)
+	}
+	err := caller.CallContext(ctx, &res, method, args...)
+
+	return res, err
+}
+
 func (b *blockchainClient) CallToExec(ctx context.Context, contractCall ContractCall) (types.Hash, error) {
 	data, err := GetContractData(contractCall.Method, contractCall.Args...)
 	if err != nil {
@@ -263,18 +541,24 @@ func (b *blockchainClient) CallToExec(ctx context.Context, contractCall Contract
 	gasLimit := types.NewUCompactFromUInt(contractCall.GasLimit)
 	storageDepositLimit := types.NewOptionBoolEmpty()
 
-	extrinsic, err := withRetryOnClosedNetwork(b, func() (types.Extrinsic, error) {
-		return b.createExtrinsic("Contracts.call", contractCall.From, dest, value, gasLimit, storageDepositLimit, data)
+	hash, err := b.submit(ctx, &Transaction{
+		Call:         "Contracts.call",
+		Args:         []interface{}{dest, value, gasLimit, storageDepositLimit, data},
+		Signer:       contractCall.From,
+		ContractCall: &contractCall,
 	})
 	if err != nil {
 		return types.Hash{}, err
 	}
+	b.rememberWrite(hash)
 
-	hash, err := withRetryOnClosedNetwork(b, func() (types.Hash, error) {
-		return b.submitAndWaitExtrinsic(ctx, extrinsic)
-	})
-	if err != nil {
-		return types.Hash{}, err
+	if handle := txHandleFromContext(ctx); handle != nil {
+		_, err := withRetryOnClosedNetwork(b, func() (struct{}, error) {
+			return struct{}{}, b.resolveEvents(handle, contractCall.ContractAddressSS58)
+		})
+		if err != nil {
+			log.WithError(err).WithField("block", hash.Hex()).Warn("Can't get events of the transaction")
+		}
 	}
 
 	return hash, err
@@ -291,27 +575,23 @@ func (b *blockchainClient) Deploy(ctx context.Context, deployCall DeployCall) (t
 		return types.AccountID{}, err
 	}
 
-	extrinsic, err := withRetryOnClosedNetwork(b, func() (types.Extrinsic, error) {
-		return b.createExtrinsic(
-			"Contracts.instantiate_with_code",
-			deployCall.From,
-			types.NewUCompactFromUInt(uint64(deployCall.Value*CERE)),
-			types.NewUCompactFromUInt(uint64(deployCall.GasLimit*CERE)),
+	hash, err := b.submit(ctx, &Transaction{
+		Call: "Contracts.instantiate_with_code",
+		Args: []interface{}{
+			types.NewUCompactFromUInt(uint64(deployCall.Value * CERE)),
+			types.NewUCompactFromUInt(uint64(deployCall.GasLimit * CERE)),
 			types.NewOptionBoolEmpty(),
 			deployCall.Code,
 			data,
-			deployCall.Salt)
-	})
-	if err != nil {
-		return types.AccountID{}, err
-	}
-
-	hash, err := withRetryOnClosedNetwork(b, func() (types.Hash, error) {
-		return b.submitAndWaitExtrinsic(ctx, extrinsic)
+			deployCall.Salt,
+		},
+		Signer:     deployCall.From,
+		DeployCall: &deployCall,
 	})
 	if err != nil {
 		return types.AccountID{}, err
 	}
+	b.rememberWrite(hash)
 
 	return withRetryOnClosedNetwork(b, func() (types.AccountID, error) {
 		return b.grabContractInstantiated(hash, deployer)
@@ -355,51 +635,242 @@ func (b *blockchainClient) grabContractInstantiated(hash types.Hash, deployer *t
 	return types.AccountID{}, errors.New("Contract not instantiated at block " + hash.Hex())
 }
 
-func (b *blockchainClient) createExtrinsic(cmd string, authKey signature.KeyringPair, args ...interface{}) (types.Extrinsic, error) {
+// GetContractEvents returns events emitted by the contract in the block.
+func (b *blockchainClient) GetContractEvents(contractAddressSS58 string, blockHash types.Hash) ([]ContractEvent, error) {
+	contract, err := DecodeAddress(contractAddressSS58, b.addressOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := b.RPC.State.GetMetadata(blockHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "get metadata at block "+blockHash.Hex())
+	}
+
+	key, err := types.CreateStorageKey(meta, "System", "Events", nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "create storage key")
+	}
+
+	raw, err := b.RPC.State.GetStorageRaw(key, blockHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "get events at block "+blockHash.Hex())
+	}
+
+	header, err := b.RPC.Chain.GetHeader(blockHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "get header of block "+blockHash.Hex())
+	}
+
+	events := chainevents.EventRecords{}
+	if err := chainevents.EventRecordsRaw(*raw).DecodeEventRecords(meta, &events); err != nil {
+		return nil, errors.Wrap(err, "decode events")
+	}
+
+	var contractEvents []ContractEvent
+	for _, e := range events.Contracts_ContractEmitted {
+		if !contract.Equal(&e.Contract) {
+			continue
+		}
+		contractEvents = append(contractEvents, ContractEvent{
+			Topics:      e.Topics,
+			Data:        e.Data,
+			BlockHash:   blockHash,
+			BlockNumber: header.Number,
+			Phase:       e.Phase,
+		})
+	}
+
+	return contractEvents, nil
+}
+
+// GetAccountInfo returns the account nonce and balances.
+func (b *blockchainClient) GetAccountInfo(accountId types.AccountID) (types.AccountInfo, error) {
 	meta, err := b.RPC.State.GetMetadataLatest()
 	if err != nil {
-		return types.Extrinsic{}, errors.Wrap(err, "get metadata lastest error")
+		return types.AccountInfo{}, errors.Wrap(err, "get metadata lastest")
 	}
 
-	genesisHash, err := b.RPC.Chain.GetBlockHash(0)
+	key, err := types.CreateStorageKey(meta, "System", "Account", accountId[:], nil)
 	if err != nil {
-		return types.Extrinsic{}, errors.Wrap(err, "get block hash error")
+		return types.AccountInfo{}, errors.Wrap(err, "create storage key")
 	}
 
-	rv, err := b.RPC.State.GetRuntimeVersionLatest()
+	var accountInfo types.AccountInfo
+	if _, err := b.RPC.State.GetStorageLatest(key, &accountInfo); err != nil {
+		return types.AccountInfo{}, errors.Wrap(err, "get account info")
+	}
+
+	return accountInfo, nil
+}
+
+// WithHandler returns a copy of the entry with the handler, the entry itself doesn't change.
+func (e ContractEventDispatchEntry) WithHandler(handler ContractEventHandler) ContractEventDispatchEntry {
+	e.Handler = handler
+	return e
+}
+
+// Decode decodes the event into the argument type of the dispatch entry matching one of the event
+// topics. It returns false if no entry matches.
+func (e ContractEvent) Decode(dispatcher map[types.Hash]ContractEventDispatchEntry) (interface{}, bool, error) {
+	for _, topic := range e.Topics {
+		entry, found := dispatcher[topic]
+		if !found {
+			continue
+		}
+
+		args := reflect.New(entry.ArgumentType).Interface()
+		if len(e.Data) == 0 {
+			return nil, true, errors.New("empty event data")
+		}
+		if err := codec.Decode(e.Data[1:], args); err != nil {
+			return nil, true, err
+		}
+
+		return args, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// SubmitTransaction submits any pallet call through the client middlewares and returns the hash of
+// the block it was included in, e.g. Balances.transfer in test environments.
+func (b *blockchainClient) SubmitTransaction(ctx context.Context, tx *Transaction) (types.Hash, error) {
+	return b.submit(ctx, tx)
+}
+
+// submit submits the transaction through the client middlewares.
+func (b *blockchainClient) submit(ctx context.Context, tx *Transaction) (types.Hash, error) {
+	return ChainMiddleware(b.middlewares...)(b.submitTransaction)(ctx, tx)
+}
+
+// submitTransaction submits the transaction with the fee strategy of the client. If the tip is
+// escalated, the transaction is signed again with the nonce of the first attempt, so it replaces
+// the pending one, and the extrinsics submitted before are watched until one of them is included.
+func (b *blockchainClient) submitTransaction(ctx context.Context, tx *Transaction) (types.Hash, error) {
+	if tx.Tip == 0 {
+		tx.Tip = b.feeStrategy.Tip
+	}
+
+	var escalateBlocks uint32
+	if b.feeStrategy.escalates() {
+		escalateBlocks = b.feeStrategy.EscalateAfterBlocks
+	}
+
+	nonce, err := withRetryOnClosedNetwork(b, func() (uint64, error) {
+		return b.accountNonce(tx.Signer)
+	})
 	if err != nil {
-		return types.Extrinsic{}, errors.Wrap(err, "get runtime version lastest error")
+		return types.Hash{}, err
+	}
+
+	var pending []*pendingExtrinsic
+	defer func() {
+		for _, p := range pending {
+			if !p.followed {
+				p.sub.Unsubscribe()
+			}
+		}
+	}()
+
+	for {
+		extrinsic, err := withRetryOnClosedNetwork(b, func() (types.Extrinsic, error) {
+			return b.signExtrinsic(ctx, tx, nonce)
+		})
+		if err != nil {
+			return types.Hash{}, err
+		}
+
+		if b.feeStrategy.MaxFee > 0 {
+			fee, err := b.queryFee(ctx, extrinsic)
+			if err != nil {
+				return types.Hash{}, err
+			}
+			if err := b.feeStrategy.checkFee(tx, fee); err != nil {
+				return types.Hash{}, err
+			}
+		}
+
+		b.sentExtrinsics.remember(b.nonceGapPolicy, tx.Signer, extrinsic)
+		hash, err := withRetryOnClosedNetwork(b, func() (types.Hash, error) {
+			return b.submitAndWaitExtrinsic(ctx, extrinsic, &pending, escalateBlocks)
+		})
+		if !errors.Is(err, errNotIncluded) {
+			b.sentExtrinsics.forget(extrinsic)
+			return hash, err
+		}
+
+		tx.Tip += b.feeStrategy.TipIncrement
+		log.WithField("call", tx.Call).WithField("tip", tx.Tip).Info("Transaction wasn't included in time, resubmitting with higher tip")
+	}
+}
+
+// accountNonce returns the nonce of the signer account stored on chain, which doesn't count
+// transactions pending in the transaction pool.
+func (b *blockchainClient) accountNonce(authKey signature.KeyringPair) (uint64, error) {
+	meta, err := b.RPC.State.GetMetadataLatest()
+	if err != nil {
+		return 0, errors.Wrap(err, "get metadata lastest error")
 	}
 
 	key, err := types.CreateStorageKey(meta, "System", "Account", authKey.PublicKey, nil)
 	if err != nil {
-		return types.Extrinsic{}, errors.Wrap(err, "create storage key error")
+		return 0, errors.Wrap(err, "create storage key error")
 	}
 
 	var accountInfo types.AccountInfo
 	ok, err := b.RPC.State.GetStorageLatest(key, &accountInfo)
 	if err != nil {
-		return types.Extrinsic{}, errors.Wrapf(err, "create storage key error by %s", authKey.Address)
+		return 0, errors.Wrapf(err, "create storage key error by %s", authKey.Address)
 	} else if !ok {
-		return types.Extrinsic{}, errors.Errorf("no accountInfo found by %s", authKey.Address)
+		return 0, errors.Errorf("no accountInfo found by %s", authKey.Address)
+	}
+
+	return uint64(accountInfo.Nonce), nil
+}
+
+// signExtrinsic creates the extrinsic of the transaction signed with the nonce, by the remote signer
+// if the client has one and the key pair of the signer has no secret.
+func (b *blockchainClient) signExtrinsic(ctx context.Context, tx *Transaction, nonce uint64) (types.Extrinsic, error) {
+	authKey := tx.Signer
+
+	meta, err := b.RPC.State.GetMetadataLatest()
+	if err != nil {
+		return types.Extrinsic{}, errors.Wrap(err, "get metadata lastest error")
+	}
+
+	genesisHash, err := b.RPC.Chain.GetBlockHash(0)
+	if err != nil {
+		return types.Extrinsic{}, errors.Wrap(err, "get block hash error")
+	}
+
+	rv, err := b.RPC.State.GetRuntimeVersionLatest()
+	if err != nil {
+		return types.Extrinsic{}, errors.Wrap(err, "get runtime version lastest error")
 	}
 
 	o := types.SignatureOptions{
 		BlockHash:          genesisHash,
 		Era:                types.ExtrinsicEra{IsMortalEra: false},
 		GenesisHash:        genesisHash,
-		Nonce:              types.NewUCompactFromUInt(uint64(accountInfo.Nonce)),
+		Nonce:              types.NewUCompactFromUInt(nonce),
 		SpecVersion:        rv.SpecVersion,
-		Tip:                types.NewUCompactFromUInt(0),
+		Tip:                types.NewUCompactFromUInt(tx.Tip),
 		TransactionVersion: rv.TransactionVersion,
 	}
 
-	call, err := types.NewCall(meta, cmd, args...)
+	call, err := types.NewCall(meta, tx.Call, tx.Args...)
 	if err != nil {
 		return types.Extrinsic{}, errors.Wrap(err, "new call error")
 	}
 	ext := types.NewExtrinsic(call)
 
+	if b.signsRemotely(authKey) {
+		if err := b.signRemotely(ctx, &ext, tx, nonce, o); err != nil {
+			return types.Extrinsic{}, errors.Wrap(err, "sign extrinsic remotely error")
+		}
+		return ext, nil
+	}
 	if err := ext.Sign(authKey, o); err != nil {
 		return types.Extrinsic{}, errors.Wrap(err, "sign extrinsic error")
 	}
@@ -407,37 +878,204 @@ func (b *blockchainClient) createExtrinsic(cmd string, authKey signature.Keyring
 	return ext, nil
 }
 
-func (b *blockchainClient) submitAndWaitExtrinsic(ctx context.Context, extrinsic types.Extrinsic) (types.Hash, error) {
+// pendingExtrinsic is an extrinsic of the transaction waiting for inclusion. Extrinsics replaced by
+// a resubmission with a higher tip are still watched, as they can be included before the
+// replacement reaches the block author.
+type pendingExtrinsic struct {
+	extrinsic types.Extrinsic
+	sub       extrinsicStatusSubscription
+	followed  bool
+}
+
+// submitAndWaitExtrinsic submits the extrinsic and waits until it or one of the pending extrinsics
+// of the same transaction is included in a block. If waitBlocks is not 0, errNotIncluded is
+// returned when none was included in waitBlocks new blocks. With a nonce gap policy, the signer
+// account is checked for nonce gaps while the extrinsic waits.
+func (b *blockchainClient) submitAndWaitExtrinsic(ctx context.Context, extrinsic types.Extrinsic, pending *[]*pendingExtrinsic, waitBlocks uint32) (types.Hash, error) {
+	gapCheckBlocks := b.nonceGapPolicy.stuckAfterBlocks()
+	var heads <-chan types.Header
+	if waitBlocks > 0 || gapCheckBlocks > 0 {
+		headsSub, err := b.RPC.Chain.SubscribeNewHeads()
+		if err != nil {
+			return types.Hash{}, errors.Wrap(err, "subscribe new heads error")
+		}
+		defer headsSub.Unsubscribe()
+		heads = headsSub.Chan()
+	}
+
 	sub, err := b.RPC.Author.SubmitAndWatchExtrinsic(extrinsic)
 	if err != nil {
 		return types.Hash{}, errors.Wrap(err, "submit error")
 	}
-	defer sub.Unsubscribe()
+	*pending = append(*pending, &pendingExtrinsic{extrinsic: extrinsic, sub: sub})
+
+	return waitIncluded(ctx, *pending, heads, waitBlocks, func(blocks uint32) {
+		if gapCheckBlocks > 0 && blocks%gapCheckBlocks == 0 {
+			if err := b.recoverNonceGap(ctx, extrinsic); err != nil {
+				log.WithError(err).Warn("Nonce gap recovery failed")
+			}
+		}
+	})
+}
 
+// waitIncluded waits until one of the pending extrinsics is included in a block and returns the
+// block hash. Statuses other than inclusion are tracked for the last extrinsic only, since the
+// earlier ones are dropped from the transaction pool once it replaces them, and subscription errors
+// of the earlier ones stop watching them. onBlock is called with the number of new blocks seen.
+func waitIncluded(ctx context.Context, pending []*pendingExtrinsic, heads <-chan types.Header, waitBlocks uint32, onBlock func(blocks uint32)) (types.Hash, error) {
+	// A tracked transaction is followed after it's in a block until its status is final.
+	handle := txHandleFromContext(ctx)
+	last := pending[len(pending)-1]
+
+	// The select cases are done and heads, then the status and error channels of each extrinsic.
+	const doneCase, headsCase, pendingCases = 0, 1, 2
+	cases := []reflect.SelectCase{
+		doneCase:  {Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+		headsCase: {Dir: reflect.SelectRecv, Chan: reflect.ValueOf(heads)},
+	}
+	for _, p := range pending {
+		cases = append(cases,
+			reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(p.sub.Chan())},
+			reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(p.sub.Err())},
+		)
+	}
+
+	var blocks uint32
 	for {
-		select {
-		case status := <-sub.Chan():
-			if status.IsInBlock || status.IsFinalized {
-				return status.AsInBlock, nil
+		chosen, value, ok := reflect.Select(cases)
+		switch chosen {
+		case doneCase:
+			return types.Hash{}, ctx.Err()
+		case headsCase:
+			blocks++
+			onBlock(blocks)
+			if waitBlocks > 0 && blocks >= waitBlocks {
+				return types.Hash{}, errNotIncluded
+			}
+			continue
+		}
+
+		i := (chosen - pendingCases) / 2
+		p, statusCase := pending[i], pendingCases+2*i
+		if chosen != statusCase || !ok {
+			if p == last {
+				err, _ := value.Interface().(error)
+				if err == nil {
+					err = errors.New("subscription closed")
+				}
+				return types.Hash{}, errors.Wrap(err, "subscribe error")
 			}
-		case err := <-sub.Err():
-			return types.Hash{}, errors.Wrap(err, "subscribe error")
+			cases[statusCase].Chan, cases[statusCase+1].Chan = reflect.Value{}, reflect.Value{}
+			continue
+		}
+
+		status := value.Interface().(types.ExtrinsicStatus)
+		if p == last || status.IsInBlock || status.IsFinalized {
+			handle.update(p.extrinsic, status)
+		}
+		if status.IsFinalized {
+			return status.AsFinalized, nil
+		}
+		if status.IsInBlock {
+			if handle != nil {
+				p.followed = true
+				go handle.follow(p.extrinsic, p.sub)
+			}
+			return status.AsInBlock, nil
+		}
+	}
+}
+
+// rememberWrite remembers the number of the block the transaction was included in, so following
+// reads wait for it with WithReadYourWrites.
+func (b *blockchainClient) rememberWrite(blockHash types.Hash) {
+	if !b.readYourWrites {
+		return
+	}
+
+	header, err := withRetryOnClosedNetwork(b, func() (*types.Header, error) {
+		return b.RPC.Chain.GetHeader(blockHash)
+	})
+	if err != nil {
+		log.WithError(err).WithField("block", blockHash.Hex()).Warn("Can't get header of the transaction block, reads may not see the transaction")
+		return
+	}
+
+	number := uint64(header.Number)
+	for {
+		current := atomic.LoadUint64(&b.writeBlock)
+		if number <= current || atomic.CompareAndSwapUint64(&b.writeBlock, current, number) {
+			return
+		}
+	}
+}
+
+// waitForWrites waits until the best block of the RPC node is not older than the block of the
+// latest transaction of the client.
+func (b *blockchainClient) waitForWrites(ctx context.Context) error {
+	number := atomic.LoadUint64(&b.writeBlock)
+	if number == 0 {
+		return nil
+	}
+
+	for {
+		header, err := withRetryOnClosedNetwork(b, func() (*types.Header, error) {
+			return b.RPC.Chain.GetHeaderLatest()
+		})
+		if err != nil {
+			return errors.Wrap(err, "get best block header")
+		}
+		if uint64(header.Number) >= number {
+			atomic.CompareAndSwapUint64(&b.writeBlock, number, 0)
+			return nil
+		}
+
+		select {
 		case <-ctx.Done():
-			return types.Hash{}, ctx.Err()
+			return ctx.Err()
+		case <-time.After(readYourWritesPollInterval):
 		}
 	}
 }
 
+// withRetryOnClosedNetwork retries the call once after reconnect if the connection was closed.
+// If the retry fails too, the error is a RetryError with all attempts.
 func withRetryOnClosedNetwork[T any](b *blockchainClient, f func() (T, error)) (T, error) {
-	result, err := f()
-	if isClosedNetworkError(err) {
-		if b.reconnect() != nil {
-			return result, err
-		}
+	var attempts []Attempt
+	attempt := func(operation string, f func() error) error {
+		start := time.Now()
+		err := f()
+		attempts = append(attempts, Attempt{
+			Operation: operation,
+			Endpoint:  b.Client.URL(),
+			StartedAt: start,
+			Duration:  time.Since(start),
+			Err:       err,
+		})
+		return err
+	}
+	call := func() (result T, err error) {
+		err = attempt("call", func() error {
+			result, err = f()
+			return err
+		})
+		return result, err
+	}
+
+	result, err := call()
+	if !isClosedNetworkError(err) {
+		return result, err
+	}
+
+	if err := attempt("reconnect", b.reconnect); err != nil {
+		return result, &RetryError{Attempts: attempts}
+	}
 
-		result, err = f()
+	if result, err = call(); err != nil {
+		return result, &RetryError{Attempts: attempts}
 	}
-	return result, err
+
+	return result, nil
 }
 
 func (b *blockchainClient) reconnect() error {
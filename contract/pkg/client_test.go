@@ -0,0 +1,203 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type slowClient struct {
+	delay time.Duration
+}
+
+func (c *slowClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+// CallContext responds with the method name after the delay unless the context is done first.
+func (c *slowClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	select {
+	case <-time.After(c.delay):
+		*result.(*string) = method
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *slowClient) Subscribe(context.Context, string, string, string, string, interface{}, ...interface{}) (*gethrpc.ClientSubscription, error) {
+	return nil, errors.New("subscriptions aren't supported")
+}
+
+func (c *slowClient) URL() string {
+	return "ws://slow"
+}
+
+func (c *slowClient) Close() {}
+
+func TestCallContext(t *testing.T) {
+	tests := []struct {
+		name    string
+		delay   time.Duration
+		want    string
+		wantErr error
+	}{
+		{name: "in time", delay: 0, want: "contracts_call"},
+		{name: "deadline exceeded", delay: time.Second, wantErr: context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			res, err := callContext[string](ctx, &slowClient{delay: tt.delay}, "contracts_call")
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, res)
+		})
+	}
+}
+
+func TestDeadlineExceededError(t *testing.T) {
+	var err error = &DeadlineExceededError{Method: "0x3802cb77"}
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.EqualError(t, err, "call 0x3802cb77: rpc deadline exceeded")
+}
+
+func TestMayContainContractEvents(t *testing.T) {
+	contract := types.AccountID{1, 2, 3}
+	topic := types.Hash{7, 7, 7}
+	dispatcher := map[types.Hash]ContractEventDispatchEntry{topic: {}}
+
+	tests := []struct {
+		name   string
+		events []byte
+		want   bool
+	}{
+		{name: "contract and topic", events: concat([]byte{0x04}, contract[:], []byte{0x08}, topic[:]), want: true},
+		{name: "contract without topic", events: concat([]byte{0x04}, contract[:]), want: false},
+		{name: "topic without contract", events: concat([]byte{0x04}, topic[:]), want: false},
+		{name: "no events", events: []byte{0x00}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mayContainContractEvents(tt.events, contract, dispatcher))
+		})
+	}
+}
+
+func concat(parts ...[]byte) []byte {
+	var res []byte
+	for _, part := range parts {
+		res = append(res, part...)
+	}
+
+	return res
+}
+
+func TestRetryError(t *testing.T) {
+	//given
+	closedErr := errors.New("use of closed network connection")
+	var err error = &RetryError{Attempts: []Attempt{
+		{Operation: "call", Endpoint: "ws://node:9944", Duration: time.Second, Err: closedErr},
+		{Operation: "reconnect", Endpoint: "ws://node:9944", Duration: 2 * time.Second, Err: context.DeadlineExceeded},
+	}}
+
+	//when
+	wrapped := fmt.Errorf("read bucket: %w", err)
+
+	//then
+	var retryErr *RetryError
+	assert.True(t, errors.As(wrapped, &retryErr))
+	assert.Len(t, retryErr.Attempts, 2)
+	assert.True(t, errors.Is(wrapped, context.DeadlineExceeded))
+	assert.Equal(t, "failed after 2 attempts: #1 call ws://node:9944 after 1s: use of closed network connection; "+
+		"#2 reconnect ws://node:9944 after 2s: context deadline exceeded", err.Error())
+}
+
+func TestWaitIncluded(t *testing.T) {
+	errSubscription := errors.New("connection reset")
+	tests := []struct {
+		name        string
+		replaced    []types.ExtrinsicStatus
+		replacedErr error
+		last        []types.ExtrinsicStatus
+		lastErr     error
+		heads       int
+		want        types.Hash
+		wantErr     error
+	}{
+		{
+			name:     "replaced extrinsic included",
+			replaced: []types.ExtrinsicStatus{{IsInBlock: true, AsInBlock: types.Hash{1}}},
+			last:     []types.ExtrinsicStatus{{IsInvalid: true}},
+			want:     types.Hash{1},
+		},
+		{
+			name:     "replacement included",
+			replaced: []types.ExtrinsicStatus{{IsUsurped: true}},
+			last:     []types.ExtrinsicStatus{{IsReady: true}, {IsInBlock: true, AsInBlock: types.Hash{2}}},
+			want:     types.Hash{2},
+		},
+		{
+			name:        "replaced subscription error",
+			replacedErr: errSubscription,
+			last:        []types.ExtrinsicStatus{{IsFinalized: true, AsFinalized: types.Hash{2}}},
+			want:        types.Hash{2},
+		},
+		{name: "subscription error", lastErr: errSubscription, wantErr: errSubscription},
+		{name: "not included", heads: 2, wantErr: errNotIncluded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			newSub := func(statuses []types.ExtrinsicStatus, err error) *fakeStatusSubscription {
+				sub := &fakeStatusSubscription{
+					statuses:     make(chan types.ExtrinsicStatus, len(statuses)),
+					errs:         make(chan error, 1),
+					unsubscribed: make(chan struct{}),
+				}
+				for _, status := range statuses {
+					sub.statuses <- status
+				}
+				if err != nil {
+					sub.errs <- err
+				}
+				return sub
+			}
+			pending := []*pendingExtrinsic{
+				{sub: newSub(tt.replaced, tt.replacedErr)},
+				{sub: newSub(tt.last, tt.lastErr)},
+			}
+			heads := make(chan types.Header, tt.heads)
+			for i := 0; i < tt.heads; i++ {
+				heads <- types.Header{}
+			}
+			var blocks []uint32
+
+			//when
+			hash, err := waitIncluded(context.Background(), pending, heads, 2, func(n uint32) {
+				blocks = append(blocks, n)
+			})
+
+			//then
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, hash)
+			assert.Len(t, blocks, tt.heads)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package pkg
+
+import "time"
+
+// Clock tells the current time. Inject a fake clock to control time in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts an ordinary function to the Clock interface.
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock is the Clock which tells the system time.
+var SystemClock Clock = ClockFunc(time.Now)
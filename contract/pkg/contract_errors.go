@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors"
+)
+
+// Errors of the Contracts pallet and of ink! a failed call unwraps to, e.g.
+// errors.Is(err, ErrContractTrapped).
+var (
+	ErrContractTrapped              = ddcerrors.New(ddcerrors.CodeExecution, "contract trapped")
+	ErrContractReverted             = ddcerrors.New(ddcerrors.CodeExecution, "contract reverted")
+	ErrOutOfGas                     = ddcerrors.New(ddcerrors.CodeExecution, "out of gas")
+	ErrContractNotFound             = ddcerrors.New(ddcerrors.CodeNotFound, "contract not found")
+	ErrCodeNotFound                 = ddcerrors.New(ddcerrors.CodeNotFound, "contract code not found")
+	ErrStorageDepositLimitExhausted = ddcerrors.New(ddcerrors.CodeInsufficientFunds, "storage deposit limit exhausted")
+	ErrStorageDepositNotEnoughFunds = ddcerrors.New(ddcerrors.CodeInsufficientFunds, "not enough funds for storage deposit")
+	ErrDecodingFailed               = ddcerrors.New(ddcerrors.CodeDecoding, "contract input decoding failed")
+	ErrBadOrigin                    = ddcerrors.New(ddcerrors.CodeUnauthorized, "bad origin")
+	ErrDispatch                     = ddcerrors.New(ddcerrors.CodeExecution, "dispatch error")
+	ErrCouldNotReadInput            = ddcerrors.New(ddcerrors.CodeInvalidArgument, "contract could not read input")
+	ErrUndefinedLangError           = ddcerrors.New(ddcerrors.CodeExecution, "undefined ink! language error")
+)
+
+var dispatchErrorsByName = map[string]error{
+	"ContractTrapped":              ErrContractTrapped,
+	"ContractReverted":             ErrContractReverted,
+	"OutOfGas":                     ErrOutOfGas,
+	"ContractNotFound":             ErrContractNotFound,
+	"CodeNotFound":                 ErrCodeNotFound,
+	"StorageDepositLimitExhausted": ErrStorageDepositLimitExhausted,
+	"StorageDepositNotEnoughFunds": ErrStorageDepositNotEnoughFunds,
+	"DecodingFailed":               ErrDecodingFailed,
+	"BadOrigin":                    ErrBadOrigin,
+}
+
+// couldNotReadInput is the variant of ink::LangError returned when the contract can't decode the
+// selector or the arguments of the message.
+const couldNotReadInput = 1
+
+// DecodeDispatchError maps the error of a contract dry run, as the node returns it in JSON, to one
+// of the sentinel errors, ErrDispatch if the error is unknown. Module errors are matched by the name
+// in their message, e.g. {"module":{"index":18,"error":"0x0b000000","message":"ContractTrapped"}},
+// errors without data by the variant name, e.g. "BadOrigin".
+func DecodeDispatchError(raw []byte) error {
+	var variant string
+	if err := json.Unmarshal(raw, &variant); err == nil {
+		return dispatchErrorByName(variant)
+	}
+
+	var variants map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &variants); err != nil {
+		return ErrDispatch
+	}
+	for name, value := range variants {
+		if !strings.EqualFold(name, "module") {
+			return dispatchErrorByName(name)
+		}
+
+		var module struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(value, &module); err != nil {
+			return ErrDispatch
+		}
+		return dispatchErrorByName(module.Message)
+	}
+
+	return ErrDispatch
+}
+
+// DecodeLangError maps the variant of ink::LangError to the sentinel error.
+func DecodeLangError(variant uint8) error {
+	if variant == couldNotReadInput {
+		return ErrCouldNotReadInput
+	}
+
+	return ErrUndefinedLangError
+}
+
+func dispatchErrorByName(name string) error {
+	for variant, err := range dispatchErrorsByName {
+		if strings.EqualFold(variant, name) {
+			return err
+		}
+	}
+
+	return ErrDispatch
+}
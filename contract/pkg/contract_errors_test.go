@@ -0,0 +1,43 @@
+package pkg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeDispatchError(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want error
+	}{
+		{name: "module error", raw: `{"module":{"index":18,"error":"0x0b000000","message":"ContractTrapped"}}`, want: ErrContractTrapped},
+		{name: "module error of older nodes", raw: `{"Module":{"index":18,"error":11,"message":"OutOfGas"}}`, want: ErrOutOfGas},
+		{name: "variant without data", raw: `"BadOrigin"`, want: ErrBadOrigin},
+		{name: "variant object", raw: `{"badOrigin":null}`, want: ErrBadOrigin},
+		{name: "unknown module error", raw: `{"module":{"index":5,"error":"0x01000000","message":"InsufficientBalance"}}`, want: ErrDispatch},
+		{name: "not json", raw: `ContractTrapped`, want: ErrDispatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DecodeDispatchError([]byte(tt.raw)))
+		})
+	}
+}
+
+func TestExecutionErrorUnwrapsReason(t *testing.T) {
+	//given
+	var err error = &ExecutionError{Method: "0x01020304", Err: `{"module":{"index":18,"error":"0x1b000000","message":"ContractNotFound"}}`}
+
+	//then
+	assert.True(t, errors.Is(err, ErrContractNotFound))
+	assert.Equal(t, ddcerrors.CodeExecution, ddcerrors.CodeOf(err))
+}
+
+func TestDecodeLangError(t *testing.T) {
+	assert.Equal(t, ErrCouldNotReadInput, DecodeLangError(1))
+	assert.Equal(t, ErrUndefinedLangError, DecodeLangError(7))
+}
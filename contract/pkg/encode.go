@@ -0,0 +1,149 @@
+package pkg
+
+import (
+	"math/bits"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// encodedSize returns the SCALE encoded size of the contract call argument, false if the argument
+// isn't of a type with the fast encoding path. The path covers the types of large arguments, e.g.
+// vNodes of cluster calls, which the reflection based encoder encodes element by element.
+func encodedSize(arg interface{}) (int, bool) {
+	switch v := arg.(type) {
+	case types.Bool, types.U8, uint8:
+		return 1, true
+	case types.U16:
+		return 2, true
+	case types.U32:
+		return 4, true
+	case types.U64:
+		return 8, true
+	case types.AccountID, types.Hash:
+		return 32, true
+	case []byte:
+		return compactSize(uint64(len(v))) + len(v), true
+	case types.Bytes:
+		return compactSize(uint64(len(v))) + len(v), true
+	case types.Text:
+		return compactSize(uint64(len(v))) + len(v), true
+	case string:
+		return compactSize(uint64(len(v))) + len(v), true
+	case []types.U32:
+		return compactSize(uint64(len(v))) + 4*len(v), true
+	case []types.U64:
+		return compactSize(uint64(len(v))) + 8*len(v), true
+	case []types.AccountID:
+		return compactSize(uint64(len(v))) + 32*len(v), true
+	case [][]types.U64:
+		size := compactSize(uint64(len(v)))
+		for _, tokens := range v {
+			size += compactSize(uint64(len(tokens))) + 8*len(tokens)
+		}
+		return size, true
+	default:
+		return 0, false
+	}
+}
+
+// appendEncoded appends the SCALE encoding of the argument of a type encodedSize accepts.
+func appendEncoded(dst []byte, arg interface{}) []byte {
+	switch v := arg.(type) {
+	case types.Bool:
+		if v {
+			return append(dst, 1)
+		}
+		return append(dst, 0)
+	case types.U8:
+		return append(dst, byte(v))
+	case uint8:
+		return append(dst, v)
+	case types.U16:
+		return appendUint(dst, 2, uint64(v))
+	case types.U32:
+		return appendUint(dst, 4, uint64(v))
+	case types.U64:
+		return appendUint(dst, 8, uint64(v))
+	case types.AccountID:
+		return append(dst, v[:]...)
+	case types.Hash:
+		return append(dst, v[:]...)
+	case []byte:
+		return append(appendCompact(dst, uint64(len(v))), v...)
+	case types.Bytes:
+		return append(appendCompact(dst, uint64(len(v))), v...)
+	case types.Text:
+		return append(appendCompact(dst, uint64(len(v))), v...)
+	case string:
+		return append(appendCompact(dst, uint64(len(v))), v...)
+	case []types.U32:
+		dst = appendCompact(dst, uint64(len(v)))
+		for _, n := range v {
+			dst = appendUint(dst, 4, uint64(n))
+		}
+		return dst
+	case []types.U64:
+		return appendU64s(dst, v)
+	case []types.AccountID:
+		dst = appendCompact(dst, uint64(len(v)))
+		for _, accountId := range v {
+			dst = append(dst, accountId[:]...)
+		}
+		return dst
+	case [][]types.U64:
+		dst = appendCompact(dst, uint64(len(v)))
+		for _, tokens := range v {
+			dst = appendU64s(dst, tokens)
+		}
+		return dst
+	default:
+		panic("unsupported argument type")
+	}
+}
+
+func appendU64s(dst []byte, v []types.U64) []byte {
+	dst = appendCompact(dst, uint64(len(v)))
+	for _, n := range v {
+		dst = appendUint(dst, 8, uint64(n))
+	}
+
+	return dst
+}
+
+// compactSize returns the size of the SCALE compact encoding of the number.
+func compactSize(n uint64) int {
+	switch {
+	case n < 1<<6:
+		return 1
+	case n < 1<<14:
+		return 2
+	case n < 1<<30:
+		return 4
+	default:
+		return 1 + (bits.Len64(n)+7)/8
+	}
+}
+
+// appendCompact appends the SCALE compact encoding of the number.
+func appendCompact(dst []byte, n uint64) []byte {
+	switch {
+	case n < 1<<6:
+		return append(dst, byte(n<<2))
+	case n < 1<<14:
+		return appendUint(dst, 2, n<<2|0b01)
+	case n < 1<<30:
+		return appendUint(dst, 4, n<<2|0b10)
+	default:
+		size := (bits.Len64(n) + 7) / 8
+		return appendUint(append(dst, byte((size-4)<<2|0b11)), size, n)
+	}
+}
+
+// appendUint appends the size least significant bytes of the number in little endian order.
+func appendUint(dst []byte, size int, n uint64) []byte {
+	for i := 0; i < size; i++ {
+		dst = append(dst, byte(n>>(8*i)))
+	}
+
+	return dst
+}
@@ -0,0 +1,118 @@
+package pkg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func testVNodes(nodes int, tokens int) [][]types.U64 {
+	vNodes := make([][]types.U64, nodes)
+	for i := range vNodes {
+		vNodes[i] = make([]types.U64, tokens)
+		for j := range vNodes[i] {
+			vNodes[i][j] = types.U64(i*tokens + j)
+		}
+	}
+	return vNodes
+}
+
+// reflectEncode encodes the arguments with the reflection based encoder.
+func reflectEncode(args ...interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	encoder := scale.NewEncoder(buf)
+	for _, v := range args {
+		if err := encoder.Encode(v); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func TestAppendEncoded(t *testing.T) {
+	args := []interface{}{
+		types.NewBool(true),
+		types.U8(7),
+		uint8(3),
+		types.U16(0x1234),
+		types.U32(0x12345678),
+		types.U64(1 << 40),
+		types.AccountID{1, 2, 3},
+		types.Hash{4, 5, 6},
+		[]byte{1, 2, 3},
+		types.Bytes(bytes.Repeat([]byte{9}, 100)),
+		types.Text("params"),
+		`{"url":"https://node"}`,
+		[]types.U32{1, 2},
+		[]types.U64{3, 4},
+		[]types.AccountID{{1}, {2}},
+		testVNodes(3, 70),
+	}
+
+	for _, arg := range args {
+		//given
+		want, err := codec.Encode(arg)
+		assert.NoError(t, err)
+
+		//when
+		size, ok := encodedSize(arg)
+		got := appendEncoded(nil, arg)
+
+		//then
+		assert.True(t, ok)
+		assert.Equal(t, want, got, "%T", arg)
+		assert.Equal(t, len(want), size, "%T", arg)
+	}
+}
+
+func TestAppendCompact(t *testing.T) {
+	for _, n := range []uint64{0, 1, 63, 64, 16383, 16384, 1<<30 - 1, 1 << 30, 1<<32 - 1, 1 << 32, 1<<64 - 1} {
+		want, err := codec.Encode(types.NewUCompactFromUInt(n))
+		assert.NoError(t, err)
+
+		assert.Equal(t, want, appendCompact(nil, n), "%d", n)
+		assert.Equal(t, len(want), compactSize(n), "%d", n)
+	}
+}
+
+func TestGetContractDataFastPath(t *testing.T) {
+	//given
+	method := []byte{0xf7, 0x49, 0x6b, 0xdc}
+	args := []interface{}{types.U32(1), types.AccountID{1}, testVNodes(4, 64)}
+	encoded, err := reflectEncode(args...)
+	assert.NoError(t, err)
+
+	//when
+	data, err := GetContractData(method, args...)
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, append(method, encoded...), data)
+	assert.Equal(t, len(data), cap(data))
+}
+
+func BenchmarkGetContractDataVNodes(b *testing.B) {
+	method := []byte{0xf7, 0x49, 0x6b, 0xdc}
+	vNodes := testVNodes(64, 64)
+
+	b.Run("fast", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := GetContractData(method, types.U32(1), types.AccountID{}, vNodes); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("reflect", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := reflectEncode(types.U32(1), types.AccountID{}, vNodes); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
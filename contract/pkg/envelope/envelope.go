@@ -0,0 +1,282 @@
+// Package envelope shares encrypted bucket content with bucket readers. Content is encrypted with
+// a data key of the bucket and the data key is wrapped to the x25519 public key of each reader
+// with a sealed box. The wraps are stored in a key piece next to the content, so readers find and
+// unwrap the data key themselves. When a reader is revoked, the data key is rotated and wrapped
+// to the remaining readers only, so the revoked reader can't decrypt content stored afterwards.
+package envelope
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/crypto/nacl/secretbox"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+const (
+	keySize     = 32
+	nonceSize   = 24
+	versionSize = 4
+)
+
+var (
+	ErrNotReader       = errors.New("not a reader of the key piece")
+	ErrUnknownVersion  = errors.New("unknown data key version")
+	ErrInvalidEnvelope = errors.New("invalid envelope")
+)
+
+type (
+	// DataKey is the symmetric key bucket content is encrypted with.
+	DataKey [keySize]byte
+
+	// Reader is an account allowed to read the bucket with the x25519 public key data keys are
+	// wrapped to.
+	Reader struct {
+		AccountId bucket.AccountId
+		PublicKey [keySize]byte
+	}
+
+	// Wrap is the data key sealed to the public key of a reader.
+	Wrap struct {
+		// Reader is the hex encoded account id of the reader.
+		Reader string `json:"reader"`
+		Sealed []byte `json:"sealed"`
+	}
+
+	// KeyPiece holds wraps of one version of the bucket data key. It's stored as a piece in the
+	// bucket, e.g. under a well known tag, and replaced on every rotation.
+	KeyPiece struct {
+		BucketId bucket.BucketId `json:"bucketId"`
+		Version  uint32          `json:"version"`
+		Wraps    []Wrap          `json:"wraps"`
+	}
+
+	// Permissions grants and revokes bucket reader permissions, e.g. bucket.DdcBucketContract.
+	Permissions interface {
+		BucketSetReaderPerm(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, reader bucket.AccountId) error
+		BucketRevokeReaderPerm(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, reader bucket.AccountId) error
+	}
+
+	// Keyring is kept by the bucket owner. It holds data keys of all versions, so content stored
+	// before a rotation stays readable by the owner, and the readers of the current version.
+	// It's safe for concurrent use.
+	Keyring struct {
+		bucketId bucket.BucketId
+		random   io.Reader
+
+		mu      sync.Mutex
+		keys    map[uint32]DataKey
+		version uint32
+		readers map[bucket.AccountId]Reader
+	}
+)
+
+// CreateKeyring creates the keyring of the bucket with a new random data key of version 1.
+func CreateKeyring(bucketId bucket.BucketId) (*Keyring, error) {
+	k := &Keyring{
+		bucketId: bucketId,
+		random:   rand.Reader,
+		keys:     map[uint32]DataKey{},
+		readers:  map[bucket.AccountId]Reader{},
+	}
+	if err := k.rotate(); err != nil {
+		return nil, err
+	}
+
+	return k, nil
+}
+
+// Version returns the version of the current data key.
+func (k *Keyring) Version() uint32 {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.version
+}
+
+// AddReader wraps the current data key to the reader. The key piece has to be stored again.
+func (k *Keyring) AddReader(reader Reader) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.readers[reader.AccountId] = reader
+}
+
+// RemoveReader rotates the data key, so it's wrapped to the remaining readers only. It returns
+// false if the account wasn't a reader and the key wasn't rotated.
+func (k *Keyring) RemoveReader(accountId bucket.AccountId) (bool, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, ok := k.readers[accountId]; !ok {
+		return false, nil
+	}
+	delete(k.readers, accountId)
+
+	return true, k.rotate()
+}
+
+// KeyPiece wraps the current data key to all readers.
+func (k *Keyring) KeyPiece() (*KeyPiece, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	key := k.keys[k.version]
+	piece := &KeyPiece{BucketId: k.bucketId, Version: k.version, Wraps: make([]Wrap, 0, len(k.readers))}
+	for _, reader := range k.readers {
+		publicKey := reader.PublicKey
+		sealed, err := box.SealAnonymous(nil, key[:], &publicKey, k.random)
+		if err != nil {
+			return nil, fmt.Errorf("wrap data key to %s: %w", codec.HexEncodeToString(reader.AccountId[:]), err)
+		}
+		piece.Wraps = append(piece.Wraps, Wrap{Reader: codec.HexEncodeToString(reader.AccountId[:]), Sealed: sealed})
+	}
+
+	return piece, nil
+}
+
+// Encrypt encrypts the content with the current data key. The envelope starts with the data key
+// version, so readers know which key piece unwraps it.
+func (k *Keyring) Encrypt(content []byte) ([]byte, error) {
+	k.mu.Lock()
+	version, key := k.version, k.keys[k.version]
+	k.mu.Unlock()
+
+	return Seal(version, key, content, k.random)
+}
+
+// Decrypt decrypts the envelope with the data key of its version.
+func (k *Keyring) Decrypt(envelope []byte) ([]byte, error) {
+	version, err := EnvelopeVersion(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	k.mu.Lock()
+	key, ok := k.keys[version]
+	k.mu.Unlock()
+	if !ok {
+		return nil, ErrUnknownVersion
+	}
+
+	return Open(key, envelope)
+}
+
+// GrantReader sets the reader permission of the bucket and adds the reader to the keyring.
+func (k *Keyring) GrantReader(ctx context.Context, permissions Permissions, owner signature.KeyringPair, reader Reader) error {
+	if err := permissions.BucketSetReaderPerm(ctx, owner, k.bucketId, reader.AccountId); err != nil {
+		return err
+	}
+	k.AddReader(reader)
+
+	return nil
+}
+
+// RevokeReader revokes the reader permission of the bucket and rotates the data key.
+func (k *Keyring) RevokeReader(ctx context.Context, permissions Permissions, owner signature.KeyringPair, accountId bucket.AccountId) error {
+	if err := permissions.BucketRevokeReaderPerm(ctx, owner, k.bucketId, accountId); err != nil {
+		return err
+	}
+	_, err := k.RemoveReader(accountId)
+
+	return err
+}
+
+func (k *Keyring) rotate() error {
+	var key DataKey
+	if _, err := io.ReadFull(k.random, key[:]); err != nil {
+		return fmt.Errorf("generate data key: %w", err)
+	}
+	k.version++
+	k.keys[k.version] = key
+
+	return nil
+}
+
+// Unwrap opens the wrap of the reader in the key piece with the x25519 key pair of the reader.
+func (p *KeyPiece) Unwrap(accountId bucket.AccountId, publicKey, privateKey *[keySize]byte) (DataKey, error) {
+	reader := codec.HexEncodeToString(accountId[:])
+	for _, wrap := range p.Wraps {
+		if wrap.Reader != reader {
+			continue
+		}
+
+		opened, ok := box.OpenAnonymous(nil, wrap.Sealed, publicKey, privateKey)
+		if !ok || len(opened) != keySize {
+			return DataKey{}, fmt.Errorf("unwrap data key of %s: %w", reader, ErrInvalidEnvelope)
+		}
+		var key DataKey
+		copy(key[:], opened)
+
+		return key, nil
+	}
+
+	return DataKey{}, ErrNotReader
+}
+
+// Marshal encodes the key piece to be stored as piece data.
+func (p *KeyPiece) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// UnmarshalKeyPiece decodes piece data stored with KeyPiece.Marshal.
+func UnmarshalKeyPiece(data []byte) (*KeyPiece, error) {
+	piece := &KeyPiece{}
+	if err := json.Unmarshal(data, piece); err != nil {
+		return nil, err
+	}
+
+	return piece, nil
+}
+
+// Seal encrypts the content with the data key into an envelope of the version followed by the
+// secretbox nonce and the sealed content.
+func Seal(version uint32, key DataKey, content []byte, random io.Reader) ([]byte, error) {
+	var nonce [nonceSize]byte
+	if _, err := io.ReadFull(random, nonce[:]); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, versionSize, versionSize+nonceSize+len(content)+secretbox.Overhead)
+	binary.BigEndian.PutUint32(out, version)
+	out = append(out, nonce[:]...)
+	k := [keySize]byte(key)
+
+	return secretbox.Seal(out, content, &nonce, &k), nil
+}
+
+// Open decrypts the envelope created by Seal with the data key of its version.
+func Open(key DataKey, envelope []byte) ([]byte, error) {
+	if len(envelope) < versionSize+nonceSize+secretbox.Overhead {
+		return nil, ErrInvalidEnvelope
+	}
+
+	var nonce [nonceSize]byte
+	copy(nonce[:], envelope[versionSize:])
+	k := [keySize]byte(key)
+	content, ok := secretbox.Open(nil, envelope[versionSize+nonceSize:], &nonce, &k)
+	if !ok {
+		return nil, ErrInvalidEnvelope
+	}
+
+	return content, nil
+}
+
+// EnvelopeVersion returns the data key version of the envelope.
+func EnvelopeVersion(envelope []byte) (uint32, error) {
+	if len(envelope) < versionSize {
+		return 0, ErrInvalidEnvelope
+	}
+
+	return binary.BigEndian.Uint32(envelope), nil
+}
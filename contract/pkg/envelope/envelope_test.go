@@ -0,0 +1,111 @@
+package envelope
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/nacl/box"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+type readerKeys struct {
+	reader     Reader
+	privateKey *[keySize]byte
+}
+
+func newReader(t *testing.T, id byte) readerKeys {
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	return readerKeys{reader: Reader{AccountId: bucket.AccountId{id}, PublicKey: *publicKey}, privateKey: privateKey}
+}
+
+func (r readerKeys) decrypt(piece *KeyPiece, envelope []byte) ([]byte, error) {
+	publicKey := r.reader.PublicKey
+	key, err := piece.Unwrap(r.reader.AccountId, &publicKey, r.privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return Open(key, envelope)
+}
+
+type mockedPermissions struct {
+	readers map[bucket.AccountId]bool
+}
+
+func (m *mockedPermissions) BucketSetReaderPerm(_ context.Context, _ signature.KeyringPair, _ bucket.BucketId, reader bucket.AccountId) error {
+	m.readers[reader] = true
+	return nil
+}
+
+func (m *mockedPermissions) BucketRevokeReaderPerm(_ context.Context, _ signature.KeyringPair, _ bucket.BucketId, reader bucket.AccountId) error {
+	delete(m.readers, reader)
+	return nil
+}
+
+func TestKeyPieceSharing(t *testing.T) {
+	//given
+	keyring, err := CreateKeyring(1)
+	assert.NoError(t, err)
+	alice, bob := newReader(t, 1), newReader(t, 2)
+	keyring.AddReader(alice.reader)
+	keyring.AddReader(bob.reader)
+	envelope, err := keyring.Encrypt([]byte("content"))
+	assert.NoError(t, err)
+
+	//when
+	data, err := keyring.KeyPiece()
+	assert.NoError(t, err)
+	encoded, err := data.Marshal()
+	assert.NoError(t, err)
+	piece, err := UnmarshalKeyPiece(encoded)
+	assert.NoError(t, err)
+	aliceContent, aliceErr := alice.decrypt(piece, envelope)
+	bobContent, bobErr := bob.decrypt(piece, envelope)
+	_, strangerErr := newReader(t, 3).decrypt(piece, envelope)
+
+	//then
+	assert.NoError(t, aliceErr)
+	assert.NoError(t, bobErr)
+	assert.Equal(t, "content", string(aliceContent))
+	assert.Equal(t, "content", string(bobContent))
+	assert.ErrorIs(t, strangerErr, ErrNotReader)
+}
+
+func TestRevokeReaderRotatesDataKey(t *testing.T) {
+	//given
+	keyring, err := CreateKeyring(1)
+	assert.NoError(t, err)
+	alice, bob := newReader(t, 1), newReader(t, 2)
+	permissions := &mockedPermissions{readers: map[bucket.AccountId]bool{}}
+	ctx := context.Background()
+	assert.NoError(t, keyring.GrantReader(ctx, permissions, signature.KeyringPair{}, alice.reader))
+	assert.NoError(t, keyring.GrantReader(ctx, permissions, signature.KeyringPair{}, bob.reader))
+	before, err := keyring.Encrypt([]byte("before"))
+	assert.NoError(t, err)
+
+	//when
+	assert.NoError(t, keyring.RevokeReader(ctx, permissions, signature.KeyringPair{}, bob.reader.AccountId))
+	after, err := keyring.Encrypt([]byte("after"))
+	assert.NoError(t, err)
+	piece, err := keyring.KeyPiece()
+	assert.NoError(t, err)
+
+	//then
+	assert.Equal(t, map[bucket.AccountId]bool{alice.reader.AccountId: true}, permissions.readers)
+	assert.Equal(t, uint32(2), piece.Version)
+	version, err := EnvelopeVersion(after)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), version)
+	content, err := alice.decrypt(piece, after)
+	assert.NoError(t, err)
+	assert.Equal(t, "after", string(content))
+	_, err = bob.decrypt(piece, after)
+	assert.ErrorIs(t, err, ErrNotReader)
+	content, err = keyring.Decrypt(before)
+	assert.NoError(t, err)
+	assert.Equal(t, "before", string(content))
+}
@@ -0,0 +1,97 @@
+// Package explorer builds block explorer URLs of accounts, blocks, extrinsics and contract events,
+// e.g. to link transactions in logs or user interfaces.
+package explorer
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/network"
+)
+
+type (
+	// Explorer holds URL templates of a block explorer. Templates may contain placeholders:
+	//
+	//	{address}    SS58 address of the account
+	//	{block}      block number
+	//	{blockHash}  hex encoded block hash
+	//	{extrinsic}  index of the extrinsic in the block
+	//
+	// An empty template disables links of the kind.
+	Explorer struct {
+		AccountUrl   string
+		BlockUrl     string
+		ExtrinsicUrl string
+		// SS58Prefix is the address format of the network, pkg.CereNetwork if 0.
+		SS58Prefix uint16
+	}
+
+	// Explorers are explorers of networks.
+	Explorers map[network.Network]Explorer
+)
+
+// Subscan returns the templates of a Subscan explorer at the base URL, e.g. https://<network>.subscan.io.
+func Subscan(baseUrl string, ss58Prefix uint16) Explorer {
+	baseUrl = strings.TrimSuffix(baseUrl, "/")
+	return Explorer{
+		AccountUrl:   baseUrl + "/account/{address}",
+		BlockUrl:     baseUrl + "/block/{block}",
+		ExtrinsicUrl: baseUrl + "/extrinsic/{block}-{extrinsic}",
+		SS58Prefix:   ss58Prefix,
+	}
+}
+
+// PolkadotJs returns the templates of the Polkadot{.js} apps explorer connected to the RPC node,
+// which has no account and extrinsic pages, so extrinsics link to their blocks.
+func PolkadotJs(rpcUrl string) Explorer {
+	base := "https://polkadot.js.org/apps/?rpc=" + url.QueryEscape(rpcUrl) + "#/explorer/query/"
+	return Explorer{
+		BlockUrl:     base + "{blockHash}",
+		ExtrinsicUrl: base + "{blockHash}",
+	}
+}
+
+// Account returns the URL of the account, empty if the explorer has no account pages.
+func (e Explorer) Account(accountId types.AccountID) string {
+	prefix := e.SS58Prefix
+	if prefix == 0 {
+		prefix = pkg.CereNetwork
+	}
+	return expand(e.AccountUrl, "{address}", pkg.EncodeAddress(accountId, prefix))
+}
+
+// Block returns the URL of the block.
+func (e Explorer) Block(blockNumber types.BlockNumber, blockHash types.Hash) string {
+	return expand(e.BlockUrl, "{block}", formatNumber(blockNumber), "{blockHash}", blockHash.Hex())
+}
+
+// Extrinsic returns the URL of the extrinsic with the index in the block.
+func (e Explorer) Extrinsic(blockNumber types.BlockNumber, blockHash types.Hash, index uint32) string {
+	return expand(e.ExtrinsicUrl,
+		"{block}", formatNumber(blockNumber),
+		"{blockHash}", blockHash.Hex(),
+		"{extrinsic}", strconv.FormatUint(uint64(index), 10))
+}
+
+// ContractEvent returns the URL of the extrinsic which emitted the event or the URL of the block
+// if the event wasn't emitted by an extrinsic.
+func (e Explorer) ContractEvent(event pkg.ContractEvent) string {
+	if event.Phase.IsApplyExtrinsic {
+		return e.Extrinsic(event.BlockNumber, event.BlockHash, event.Phase.AsApplyExtrinsic)
+	}
+	return e.Block(event.BlockNumber, event.BlockHash)
+}
+
+func expand(template string, oldnew ...string) string {
+	if template == "" {
+		return ""
+	}
+	return strings.NewReplacer(oldnew...).Replace(template)
+}
+
+func formatNumber(blockNumber types.BlockNumber) string {
+	return strconv.FormatUint(uint64(blockNumber), 10)
+}
@@ -0,0 +1,43 @@
+package explorer
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/chainevents"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/network"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscan(t *testing.T) {
+	//given
+	explorers := Explorers{network.Mainnet: Subscan("https://cere.subscan.io/", pkg.CereNetwork)}
+	accountId, _ := pkg.DecodeAccountIDFromSS58("5GmomkEekQQ3BipMvjDCG5bXKvzwhUDdXEcQqXRWmdkNCYkL")
+	blockHash := types.Hash{1}
+	extrinsicEvent := pkg.ContractEvent{BlockHash: blockHash, BlockNumber: 100, Phase: chainevents.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 2}}
+	finalizationEvent := pkg.ContractEvent{BlockHash: blockHash, BlockNumber: 100, Phase: chainevents.Phase{IsFinalization: true}}
+
+	//when
+	explorer := explorers[network.Mainnet]
+
+	//then
+	assert.Equal(t, "https://cere.subscan.io/account/"+pkg.EncodeAddress(accountId, pkg.CereNetwork), explorer.Account(accountId))
+	assert.Equal(t, "https://cere.subscan.io/block/100", explorer.Block(100, blockHash))
+	assert.Equal(t, "https://cere.subscan.io/extrinsic/100-2", explorer.ContractEvent(extrinsicEvent))
+	assert.Equal(t, "https://cere.subscan.io/block/100", explorer.ContractEvent(finalizationEvent))
+}
+
+func TestPolkadotJs(t *testing.T) {
+	//given
+	explorer := PolkadotJs("wss://rpc.devnet.cere.network/ws")
+	blockHash := types.Hash{1}
+
+	//when
+	account := explorer.Account(types.AccountID{1})
+	extrinsic := explorer.Extrinsic(100, blockHash, 2)
+
+	//then
+	assert.Empty(t, account)
+	assert.Equal(t, "https://polkadot.js.org/apps/?rpc=wss%3A%2F%2Frpc.devnet.cere.network%2Fws#/explorer/query/"+blockHash.Hex(), extrinsic)
+}
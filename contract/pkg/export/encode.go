@@ -0,0 +1,79 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// writeCSV writes the header of csv tags of T and a record per row.
+func writeCSV[T any](w io.Writer, rows []T) error {
+	rowType := reflect.TypeOf((*T)(nil)).Elem()
+	header := make([]string, rowType.NumField())
+	for i := range header {
+		header[i] = rowType.Field(i).Tag.Get("csv")
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(header))
+	for _, row := range rows {
+		v := reflect.ValueOf(row)
+		for i := range record {
+			value, err := formatCSV(v.Field(i))
+			if err != nil {
+				return fmt.Errorf("column %s: %w", header[i], err)
+			}
+			record[i] = value
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatCSV(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	default:
+		return "", fmt.Errorf("unsupported type %s", v.Type())
+	}
+}
+
+// writeParquet writes the rows in a single row group with the schema of parquet tags of T.
+func writeParquet[T any](w io.Writer, rows []T) error {
+	pw, err := writer.NewParquetWriterFromWriter(w, new(T), 1)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if err := pw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return pw.WriteStop()
+}
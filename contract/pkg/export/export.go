@@ -0,0 +1,239 @@
+// Package export dumps DDC entities stored in the DDC bucket contract into CSV or Parquet files,
+// so DDC state can be loaded into analytics tooling directly.
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+type (
+	Format string
+	Entity string
+
+	// Contract is the part of the bucket contract API the exporter reads.
+	Contract interface {
+		ClusterList(offset types.U32, limit types.U32, filterManagerId types.OptionAccountID) (*bucket.ClusterListInfo, error)
+		NodeList(offset types.U32, limit types.U32, filterProviderId types.OptionAccountID) (*bucket.NodeListInfo, error)
+		CdnNodeList(offset types.U32, limit types.U32, filterProviderId types.OptionAccountID) (*bucket.CdnNodeListInfo, error)
+		BucketList(offset types.U32, limit types.U32, filterOwnerId types.OptionAccountID) (*bucket.BucketListInfo, error)
+		GetAccounts() ([]bucket.AccountId, error)
+		AccountGet(account bucket.AccountId) (*bucket.Account, error)
+		ClusterDistributeRevenuesEstimate(clusterId bucket.ClusterId) (*bucket.RevenueDistribution, error)
+	}
+
+	Exporter struct {
+		contract Contract
+		pageSize types.U32
+		network  uint16
+	}
+
+	Option func(e *Exporter)
+)
+
+const (
+	CSV     Format = "csv"
+	Parquet Format = "parquet"
+)
+
+const (
+	Clusters Entity = "clusters"
+	Nodes    Entity = "nodes"
+	CdnNodes Entity = "cdn_nodes"
+	Buckets  Entity = "buckets"
+	Accounts Entity = "accounts"
+	// Payouts are revenues distributions clusters would make now, see bucket.ClusterAdmin.ClusterDistributeRevenuesEstimate.
+	Payouts Entity = "payouts"
+)
+
+const defaultPageSize = 100
+
+// Entities are all entities the exporter supports.
+var Entities = []Entity{Clusters, Nodes, CdnNodes, Buckets, Accounts, Payouts}
+
+// WithPageSize sets the number of entities read from the contract by a list call.
+func WithPageSize(pageSize types.U32) Option {
+	return func(e *Exporter) {
+		e.pageSize = pageSize
+	}
+}
+
+// WithNetwork sets the SS58 network prefix of exported addresses.
+func WithNetwork(network uint16) Option {
+	return func(e *Exporter) {
+		e.network = network
+	}
+}
+
+func CreateExporter(contract Contract, opts ...Option) *Exporter {
+	e := &Exporter{
+		contract: contract,
+		pageSize: defaultPageSize,
+		network:  pkg.CereNetwork,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Export reads all entities of the kind from the contract and writes them to w in the format.
+func (e *Exporter) Export(w io.Writer, entity Entity, format Format) error {
+	switch entity {
+	case Clusters:
+		return writeRows(w, format, e.clusters)
+	case Nodes:
+		return writeRows(w, format, e.nodes)
+	case CdnNodes:
+		return writeRows(w, format, e.cdnNodes)
+	case Buckets:
+		return writeRows(w, format, e.buckets)
+	case Accounts:
+		return writeRows(w, format, e.accounts)
+	case Payouts:
+		return writeRows(w, format, e.payouts)
+	default:
+		return fmt.Errorf("unknown entity: %s", entity)
+	}
+}
+
+func writeRows[T any](w io.Writer, format Format, read func() ([]T, error)) error {
+	var write func(io.Writer, []T) error
+	switch format {
+	case CSV:
+		write = writeCSV[T]
+	case Parquet:
+		write = writeParquet[T]
+	default:
+		return fmt.Errorf("unknown format: %s", format)
+	}
+
+	rows, err := read()
+	if err != nil {
+		return err
+	}
+
+	return write(w, rows)
+}
+
+func (e *Exporter) clusters() ([]ClusterRow, error) {
+	var rows []ClusterRow
+	err := e.paginate(func(offset types.U32) (types.U32, error) {
+		page, err := e.contract.ClusterList(offset, e.pageSize, types.NewOptionAccountIDEmpty())
+		if err != nil {
+			return 0, err
+		}
+		for _, cluster := range page.Clusters {
+			rows = append(rows, e.clusterRow(cluster))
+		}
+		return page.Total, nil
+	})
+
+	return rows, err
+}
+
+func (e *Exporter) nodes() ([]NodeRow, error) {
+	var rows []NodeRow
+	err := e.paginate(func(offset types.U32) (types.U32, error) {
+		page, err := e.contract.NodeList(offset, e.pageSize, types.NewOptionAccountIDEmpty())
+		if err != nil {
+			return 0, err
+		}
+		for _, node := range page.Nodes {
+			rows = append(rows, e.nodeRow(node))
+		}
+		return page.Total, nil
+	})
+
+	return rows, err
+}
+
+func (e *Exporter) cdnNodes() ([]CdnNodeRow, error) {
+	var rows []CdnNodeRow
+	err := e.paginate(func(offset types.U32) (types.U32, error) {
+		page, err := e.contract.CdnNodeList(offset, e.pageSize, types.NewOptionAccountIDEmpty())
+		if err != nil {
+			return 0, err
+		}
+		for _, node := range page.Nodes {
+			rows = append(rows, e.cdnNodeRow(node))
+		}
+		return page.Total, nil
+	})
+
+	return rows, err
+}
+
+func (e *Exporter) buckets() ([]BucketRow, error) {
+	var rows []BucketRow
+	err := e.paginate(func(offset types.U32) (types.U32, error) {
+		page, err := e.contract.BucketList(offset, e.pageSize, types.NewOptionAccountIDEmpty())
+		if err != nil {
+			return 0, err
+		}
+		for _, b := range page.Buckets {
+			rows = append(rows, e.bucketRow(b))
+		}
+		return page.Total, nil
+	})
+
+	return rows, err
+}
+
+func (e *Exporter) accounts() ([]AccountRow, error) {
+	accountIds, err := e.contract.GetAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]AccountRow, 0, len(accountIds))
+	for _, accountId := range accountIds {
+		account, err := e.contract.AccountGet(accountId)
+		if err != nil {
+			return nil, fmt.Errorf("account %s: %w", e.address(accountId), err)
+		}
+		rows = append(rows, e.accountRow(accountId, account))
+	}
+
+	return rows, nil
+}
+
+func (e *Exporter) payouts() ([]PayoutRow, error) {
+	clusters, err := e.clusters()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []PayoutRow
+	for _, cluster := range clusters {
+		clusterId := bucket.ClusterId(cluster.ClusterId)
+		distribution, err := e.contract.ClusterDistributeRevenuesEstimate(clusterId)
+		if err != nil {
+			return nil, fmt.Errorf("cluster %d revenues distribution: %w", clusterId, err)
+		}
+		for _, payout := range distribution.Payouts {
+			rows = append(rows, e.payoutRow(clusterId, payout))
+		}
+	}
+
+	return rows, nil
+}
+
+// paginate calls list with offsets increasing by the page size until all entities are read.
+// list returns the total number of entities.
+func (e *Exporter) paginate(list func(offset types.U32) (types.U32, error)) error {
+	for offset := types.U32(0); ; offset += e.pageSize {
+		total, err := list(offset)
+		if err != nil {
+			return err
+		}
+		if offset+e.pageSize >= total {
+			return nil
+		}
+	}
+}
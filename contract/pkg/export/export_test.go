@@ -0,0 +1,117 @@
+package export
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockedContract struct {
+	Contract
+	clusters []bucket.ClusterInfo
+	nodes    []bucket.NodeInfo
+}
+
+func (m *mockedContract) ClusterList(offset types.U32, limit types.U32, _ types.OptionAccountID) (*bucket.ClusterListInfo, error) {
+	end := offset + limit
+	if int(end) > len(m.clusters) {
+		end = types.U32(len(m.clusters))
+	}
+
+	return &bucket.ClusterListInfo{Clusters: m.clusters[offset:end], Total: types.U32(len(m.clusters))}, nil
+}
+
+func (m *mockedContract) NodeList(_ types.U32, _ types.U32, _ types.OptionAccountID) (*bucket.NodeListInfo, error) {
+	return &bucket.NodeListInfo{Nodes: m.nodes, Total: types.U32(len(m.nodes))}, nil
+}
+
+func (m *mockedContract) ClusterDistributeRevenuesEstimate(clusterId bucket.ClusterId) (*bucket.RevenueDistribution, error) {
+	cluster := m.clusters[clusterId-1].Cluster
+	return bucket.NewRevenueDistribution(clusterId, cluster.Revenues, cluster.NodesKeys, cluster.NodesKeys), nil
+}
+
+func TestExportClustersCSV(t *testing.T) {
+	//given
+	manager := types.AccountID{1}
+	contract := &mockedContract{clusters: []bucket.ClusterInfo{
+		{ClusterId: 1, Cluster: bucket.Cluster{ManagerId: manager, Params: "{}", Revenues: types.NewU128(*big.NewInt(100))}},
+		{ClusterId: 2, Cluster: bucket.Cluster{ManagerId: manager, NodesKeys: []bucket.NodeKey{{2}, {3}}, ResourceUsed: 5}},
+	}}
+	exporter := CreateExporter(contract, WithPageSize(1), WithNetwork(pkg.SubstrateNetwork))
+	buf := &bytes.Buffer{}
+
+	//when
+	err := exporter.Export(buf, Clusters, CSV)
+
+	//then
+	managerAddress := pkg.EncodeAddress(manager, pkg.SubstrateNetwork)
+	nodesKeys := pkg.EncodeAddress(types.AccountID{2}, pkg.SubstrateNetwork) + " " + pkg.EncodeAddress(types.AccountID{3}, pkg.SubstrateNetwork)
+	assert.NoError(t, err)
+	assert.Equal(t, "schema_version,cluster_id,manager_id,params,nodes_keys,cdn_nodes_keys,resource_per_vnode,resource_used,revenues,total_rent,cdn_revenues,cdn_usd_per_gb\n"+
+		"1,1,"+managerAddress+",{},,,0,0,100,0,0,0\n"+
+		"1,2,"+managerAddress+",,"+nodesKeys+",,0,5,0,0,0,0\n", buf.String())
+}
+
+func TestExportNodesCSVOptionalColumns(t *testing.T) {
+	//given
+	contract := &mockedContract{nodes: []bucket.NodeInfo{
+		{Key: types.AccountID{1}, Node: bucket.Node{ClusterId: types.NewOptionU32(2), StatusInCluster: types.NewOptionU8(1)}, VNodes: []bucket.Token{1, 2}},
+		{Key: types.AccountID{2}, Node: bucket.Node{ClusterId: types.NewOptionU32Empty(), StatusInCluster: types.NewOptionU8Empty()}},
+	}}
+	buf := &bytes.Buffer{}
+
+	//when
+	err := CreateExporter(contract).Export(buf, Nodes, CSV)
+
+	//then
+	assert.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 3)
+	assert.True(t, bytes.HasSuffix(lines[1], []byte(",0,,2,1,2")))
+	assert.True(t, bytes.HasSuffix(lines[2], []byte(",0,,,,0")))
+}
+
+func TestExportPayoutsCSV(t *testing.T) {
+	//given
+	contract := &mockedContract{clusters: []bucket.ClusterInfo{
+		{ClusterId: 1, Cluster: bucket.Cluster{NodesKeys: []bucket.NodeKey{{1}, {2}}, Revenues: types.NewU128(*big.NewInt(100))}},
+	}}
+	buf := &bytes.Buffer{}
+
+	//when
+	err := CreateExporter(contract).Export(buf, Payouts, CSV)
+
+	//then
+	assert.NoError(t, err)
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "schema_version,cluster_id,node_key,provider_id,amount", string(lines[0]))
+	assert.True(t, bytes.HasSuffix(lines[1], []byte(",50")))
+	assert.True(t, bytes.HasSuffix(lines[2], []byte(",50")))
+}
+
+func TestExportParquet(t *testing.T) {
+	//given
+	contract := &mockedContract{clusters: []bucket.ClusterInfo{{ClusterId: 1, Cluster: bucket.Cluster{Revenues: types.NewU128(*big.NewInt(100))}}}}
+	buf := &bytes.Buffer{}
+
+	//when
+	err := CreateExporter(contract).Export(buf, Clusters, Parquet)
+
+	//then
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("PAR1")))
+	assert.True(t, bytes.HasSuffix(buf.Bytes(), []byte("PAR1")))
+}
+
+func TestExportUnknown(t *testing.T) {
+	exporter := CreateExporter(&mockedContract{})
+
+	assert.Error(t, exporter.Export(&bytes.Buffer{}, "unknown", CSV))
+	assert.Error(t, exporter.Export(&bytes.Buffer{}, Clusters, "xlsx"))
+}
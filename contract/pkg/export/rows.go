@@ -0,0 +1,216 @@
+package export
+
+import (
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+// SchemaVersion is written to every exported row. It's incremented on any change of the row
+// columns, so analysts can tell files of different layouts apart.
+const SchemaVersion = 1
+
+// Rows of the exported entities. Balances are decimal strings as they don't fit 64 bits,
+// account IDs are SS58 addresses. Optional values are pointers, empty in CSV.
+type (
+	ClusterRow struct {
+		SchemaVersion    int32  `csv:"schema_version" parquet:"name=schema_version, type=INT32"`
+		ClusterId        int64  `csv:"cluster_id" parquet:"name=cluster_id, type=INT64"`
+		ManagerId        string `csv:"manager_id" parquet:"name=manager_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+		Params           string `csv:"params" parquet:"name=params, type=BYTE_ARRAY, convertedtype=UTF8"`
+		NodesKeys        string `csv:"nodes_keys" parquet:"name=nodes_keys, type=BYTE_ARRAY, convertedtype=UTF8"`
+		CdnNodesKeys     string `csv:"cdn_nodes_keys" parquet:"name=cdn_nodes_keys, type=BYTE_ARRAY, convertedtype=UTF8"`
+		ResourcePerVNode int64  `csv:"resource_per_vnode" parquet:"name=resource_per_vnode, type=INT64"`
+		ResourceUsed     int64  `csv:"resource_used" parquet:"name=resource_used, type=INT64"`
+		Revenues         string `csv:"revenues" parquet:"name=revenues, type=BYTE_ARRAY, convertedtype=UTF8"`
+		TotalRent        string `csv:"total_rent" parquet:"name=total_rent, type=BYTE_ARRAY, convertedtype=UTF8"`
+		CdnRevenues      string `csv:"cdn_revenues" parquet:"name=cdn_revenues, type=BYTE_ARRAY, convertedtype=UTF8"`
+		CdnUsdPerGb      string `csv:"cdn_usd_per_gb" parquet:"name=cdn_usd_per_gb, type=BYTE_ARRAY, convertedtype=UTF8"`
+	}
+
+	NodeRow struct {
+		SchemaVersion   int32  `csv:"schema_version" parquet:"name=schema_version, type=INT32"`
+		NodeKey         string `csv:"node_key" parquet:"name=node_key, type=BYTE_ARRAY, convertedtype=UTF8"`
+		ProviderId      string `csv:"provider_id" parquet:"name=provider_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+		RentPerMonth    string `csv:"rent_per_month" parquet:"name=rent_per_month, type=BYTE_ARRAY, convertedtype=UTF8"`
+		FreeResources   int64  `csv:"free_resources" parquet:"name=free_resources, type=INT64"`
+		Params          string `csv:"params" parquet:"name=params, type=BYTE_ARRAY, convertedtype=UTF8"`
+		ClusterId       *int64 `csv:"cluster_id" parquet:"name=cluster_id, type=INT64, repetitiontype=OPTIONAL"`
+		StatusInCluster *int32 `csv:"status_in_cluster" parquet:"name=status_in_cluster, type=INT32, repetitiontype=OPTIONAL"`
+		VNodes          int32  `csv:"vnodes" parquet:"name=vnodes, type=INT32"`
+	}
+
+	CdnNodeRow struct {
+		SchemaVersion        int32  `csv:"schema_version" parquet:"name=schema_version, type=INT32"`
+		CdnNodeKey           string `csv:"cdn_node_key" parquet:"name=cdn_node_key, type=BYTE_ARRAY, convertedtype=UTF8"`
+		ProviderId           string `csv:"provider_id" parquet:"name=provider_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+		UndistributedPayment string `csv:"undistributed_payment" parquet:"name=undistributed_payment, type=BYTE_ARRAY, convertedtype=UTF8"`
+		Params               string `csv:"params" parquet:"name=params, type=BYTE_ARRAY, convertedtype=UTF8"`
+		ClusterId            *int64 `csv:"cluster_id" parquet:"name=cluster_id, type=INT64, repetitiontype=OPTIONAL"`
+		StatusInCluster      *int32 `csv:"status_in_cluster" parquet:"name=status_in_cluster, type=INT32, repetitiontype=OPTIONAL"`
+	}
+
+	BucketRow struct {
+		SchemaVersion      int32  `csv:"schema_version" parquet:"name=schema_version, type=INT32"`
+		BucketId           int64  `csv:"bucket_id" parquet:"name=bucket_id, type=INT64"`
+		OwnerId            string `csv:"owner_id" parquet:"name=owner_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+		ClusterId          int64  `csv:"cluster_id" parquet:"name=cluster_id, type=INT64"`
+		ResourceReserved   int64  `csv:"resource_reserved" parquet:"name=resource_reserved, type=INT64"`
+		PublicAvailability bool   `csv:"public_availability" parquet:"name=public_availability, type=BOOLEAN"`
+		GasConsumptionCap  int64  `csv:"gas_consumption_cap" parquet:"name=gas_consumption_cap, type=INT64"`
+		Params             string `csv:"params" parquet:"name=params, type=BYTE_ARRAY, convertedtype=UTF8"`
+		WriterIds          string `csv:"writer_ids" parquet:"name=writer_ids, type=BYTE_ARRAY, convertedtype=UTF8"`
+		ReaderIds          string `csv:"reader_ids" parquet:"name=reader_ids, type=BYTE_ARRAY, convertedtype=UTF8"`
+		RentCoveredUntilMs int64  `csv:"rent_covered_until_ms" parquet:"name=rent_covered_until_ms, type=INT64"`
+	}
+
+	AccountRow struct {
+		SchemaVersion     int32  `csv:"schema_version" parquet:"name=schema_version, type=INT32"`
+		AccountId         string `csv:"account_id" parquet:"name=account_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+		Deposit           string `csv:"deposit" parquet:"name=deposit, type=BYTE_ARRAY, convertedtype=UTF8"`
+		Bonded            string `csv:"bonded" parquet:"name=bonded, type=BYTE_ARRAY, convertedtype=UTF8"`
+		Negative          string `csv:"negative" parquet:"name=negative, type=BYTE_ARRAY, convertedtype=UTF8"`
+		UnboundedAmount   string `csv:"unbounded_amount" parquet:"name=unbounded_amount, type=BYTE_ARRAY, convertedtype=UTF8"`
+		UnbondedTimestamp int64  `csv:"unbonded_timestamp" parquet:"name=unbonded_timestamp, type=INT64"`
+		PayableRate       string `csv:"payable_rate" parquet:"name=payable_rate, type=BYTE_ARRAY, convertedtype=UTF8"`
+		PayableOffset     string `csv:"payable_offset" parquet:"name=payable_offset, type=BYTE_ARRAY, convertedtype=UTF8"`
+	}
+
+	// PayoutRow is a payout of undistributed cluster revenues the cluster would make to a provider now.
+	PayoutRow struct {
+		SchemaVersion int32  `csv:"schema_version" parquet:"name=schema_version, type=INT32"`
+		ClusterId     int64  `csv:"cluster_id" parquet:"name=cluster_id, type=INT64"`
+		NodeKey       string `csv:"node_key" parquet:"name=node_key, type=BYTE_ARRAY, convertedtype=UTF8"`
+		ProviderId    string `csv:"provider_id" parquet:"name=provider_id, type=BYTE_ARRAY, convertedtype=UTF8"`
+		Amount        string `csv:"amount" parquet:"name=amount, type=BYTE_ARRAY, convertedtype=UTF8"`
+	}
+)
+
+func (e *Exporter) clusterRow(info bucket.ClusterInfo) ClusterRow {
+	c := info.Cluster
+	return ClusterRow{
+		SchemaVersion:    SchemaVersion,
+		ClusterId:        int64(info.ClusterId),
+		ManagerId:        e.address(c.ManagerId),
+		Params:           c.Params,
+		NodesKeys:        e.addresses(c.NodesKeys),
+		CdnNodesKeys:     e.addresses(c.CdnNodesKeys),
+		ResourcePerVNode: int64(c.ResourcePerVNode),
+		ResourceUsed:     int64(c.ResourceUsed),
+		Revenues:         balance(c.Revenues),
+		TotalRent:        balance(c.TotalRent),
+		CdnRevenues:      balance(c.CdnRevenues),
+		CdnUsdPerGb:      balance(c.CdnUsdPerGb),
+	}
+}
+
+func (e *Exporter) nodeRow(info bucket.NodeInfo) NodeRow {
+	n := info.Node
+	return NodeRow{
+		SchemaVersion:   SchemaVersion,
+		NodeKey:         e.address(info.Key),
+		ProviderId:      e.address(n.ProviderId),
+		RentPerMonth:    balance(n.RentPerMonth),
+		FreeResources:   int64(n.FreeResources),
+		Params:          n.Params,
+		ClusterId:       optionU32(n.ClusterId),
+		StatusInCluster: optionU8(n.StatusInCluster),
+		VNodes:          int32(len(info.VNodes)),
+	}
+}
+
+func (e *Exporter) cdnNodeRow(info bucket.CdnNodeInfo) CdnNodeRow {
+	n := info.Node
+	return CdnNodeRow{
+		SchemaVersion:        SchemaVersion,
+		CdnNodeKey:           e.address(info.Key),
+		ProviderId:           e.address(n.ProviderId),
+		UndistributedPayment: balance(n.UndistributedPayment),
+		Params:               n.Params,
+		ClusterId:            optionU32(n.ClusterId),
+		StatusInCluster:      optionU8(n.StatusInCluster),
+	}
+}
+
+func (e *Exporter) bucketRow(info bucket.BucketInfo) BucketRow {
+	b := info.Bucket
+	return BucketRow{
+		SchemaVersion:      SchemaVersion,
+		BucketId:           int64(info.BucketId),
+		OwnerId:            e.address(b.OwnerId),
+		ClusterId:          int64(b.ClusterId),
+		ResourceReserved:   int64(b.ResourceReserved),
+		PublicAvailability: b.PublicAvailability,
+		GasConsumptionCap:  int64(b.GasConsumptionCap),
+		Params:             info.Params,
+		WriterIds:          e.addresses(info.WriterIds),
+		ReaderIds:          e.addresses(info.ReaderIds),
+		RentCoveredUntilMs: int64(info.RentCoveredUntilMs),
+	}
+}
+
+func (e *Exporter) accountRow(accountId bucket.AccountId, a *bucket.Account) AccountRow {
+	return AccountRow{
+		SchemaVersion:     SchemaVersion,
+		AccountId:         e.address(accountId),
+		Deposit:           balance(a.Deposit),
+		Bonded:            balance(a.Bonded),
+		Negative:          balance(a.Negative),
+		UnboundedAmount:   balance(a.UnboundedAmount),
+		UnbondedTimestamp: int64(a.UnbondedTimestamp),
+		PayableRate:       balance(a.PayableSchedule.Rate),
+		PayableOffset:     balance(a.PayableSchedule.Offset),
+	}
+}
+
+func (e *Exporter) payoutRow(clusterId bucket.ClusterId, payout bucket.ProviderPayout) PayoutRow {
+	return PayoutRow{
+		SchemaVersion: SchemaVersion,
+		ClusterId:     int64(clusterId),
+		NodeKey:       e.address(payout.NodeKey),
+		ProviderId:    e.address(payout.ProviderId),
+		Amount:        balance(payout.Amount),
+	}
+}
+
+func (e *Exporter) address(accountId types.AccountID) string {
+	return pkg.EncodeAddress(accountId, e.network)
+}
+
+// addresses joins the addresses with spaces to keep list columns flat.
+func (e *Exporter) addresses(accountIds []types.AccountID) string {
+	addresses := make([]string, len(accountIds))
+	for i, accountId := range accountIds {
+		addresses[i] = e.address(accountId)
+	}
+
+	return strings.Join(addresses, " ")
+}
+
+func balance(b bucket.Balance) string {
+	if b.Int == nil {
+		return "0"
+	}
+
+	return b.String()
+}
+
+func optionU32(o types.OptionU32) *int64 {
+	if ok, v := o.Unwrap(); ok {
+		value := int64(v)
+		return &value
+	}
+
+	return nil
+}
+
+func optionU8(o types.OptionU8) *int32 {
+	if ok, v := o.Unwrap(); ok {
+		value := int32(v)
+		return &value
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/pkg/errors"
+)
+
+type (
+	// FeeStrategy configures fees the client pays for transactions.
+	FeeStrategy struct {
+		// MaxFee is the maximum fee including the tip the client pays for a transaction, no cap if 0.
+		MaxFee uint64
+		// Tip is the tip of transactions which don't set a tip themselves.
+		Tip uint64
+		// TipIncrement is added to the tip each time the transaction is resubmitted.
+		TipIncrement uint64
+		// EscalateAfterBlocks is the number of blocks the transaction is waited for before it's
+		// resubmitted with the tip raised by TipIncrement. The tip isn't escalated if 0.
+		EscalateAfterBlocks uint32
+	}
+
+	// FeeCapExceededError is returned when the fee of the transaction including the tip would
+	// exceed FeeStrategy.MaxFee. The transaction isn't submitted then.
+	FeeCapExceededError struct {
+		Call   string
+		Fee    uint64
+		Tip    uint64
+		MaxFee uint64
+	}
+
+	feeInfo struct {
+		PartialFee json.RawMessage `json:"partialFee"`
+	}
+)
+
+// errNotIncluded is returned when the transaction wasn't included in the configured number of blocks.
+var errNotIncluded = errors.New("transaction not included")
+
+// WithFeeStrategy sets the fee cap and the tip strategy of transactions.
+func WithFeeStrategy(strategy FeeStrategy) ClientOption {
+	return func(b *blockchainClient) {
+		b.feeStrategy = strategy
+	}
+}
+
+func (e *FeeCapExceededError) Error() string {
+	return fmt.Sprintf("transaction %s fee %d with tip %d exceeds the cap %d", e.Call, e.Fee, e.Tip, e.MaxFee)
+}
+
+func (s FeeStrategy) escalates() bool {
+	return s.EscalateAfterBlocks > 0 && s.TipIncrement > 0
+}
+
+func (s FeeStrategy) checkFee(tx *Transaction, fee uint64) error {
+	if s.MaxFee == 0 {
+		return nil
+	}
+	if tx.Tip > s.MaxFee || fee > s.MaxFee-tx.Tip {
+		return &FeeCapExceededError{Call: tx.Call, Fee: fee, Tip: tx.Tip, MaxFee: s.MaxFee}
+	}
+
+	return nil
+}
+
+// queryFee returns the fee of the signed extrinsic without the tip.
+func (b *blockchainClient) queryFee(ctx context.Context, extrinsic types.Extrinsic) (uint64, error) {
+	encoded, err := codec.EncodeToHex(extrinsic)
+	if err != nil {
+		return 0, errors.Wrap(err, "encode extrinsic error")
+	}
+
+	info, err := withRetryOnClosedNetwork(b, func() (feeInfo, error) {
+		return callContext[feeInfo](ctx, b.Client, "payment_queryInfo", encoded)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "query fee error")
+	}
+
+	return parseFee(info.PartialFee)
+}
+
+// parseFee parses the fee encoded as a number or as a decimal or hex string, depending on the node version.
+func parseFee(raw json.RawMessage) (uint64, error) {
+	value := strings.Trim(string(raw), `"`)
+	fee, err := strconv.ParseUint(value, 0, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid fee %s", raw)
+	}
+
+	return fee, nil
+}
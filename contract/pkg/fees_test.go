@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckFee(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy FeeStrategy
+		fee      uint64
+		tip      uint64
+		wantErr  bool
+	}{
+		{name: "no cap", strategy: FeeStrategy{}, fee: math.MaxUint64, tip: 10},
+		{name: "below cap", strategy: FeeStrategy{MaxFee: 100}, fee: 80, tip: 10},
+		{name: "at cap", strategy: FeeStrategy{MaxFee: 100}, fee: 90, tip: 10},
+		{name: "fee with tip above cap", strategy: FeeStrategy{MaxFee: 100}, fee: 91, tip: 10, wantErr: true},
+		{name: "tip above cap", strategy: FeeStrategy{MaxFee: 100}, fee: 0, tip: 101, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//when
+			err := tt.strategy.checkFee(&Transaction{Call: "Contracts.call", Tip: tt.tip}, tt.fee)
+
+			//then
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			var capErr *FeeCapExceededError
+			assert.True(t, errors.As(err, &capErr))
+			assert.Equal(t, FeeCapExceededError{Call: "Contracts.call", Fee: tt.fee, Tip: tt.tip, MaxFee: tt.strategy.MaxFee}, *capErr)
+		})
+	}
+}
+
+func TestParseFee(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    uint64
+		wantErr bool
+	}{
+		{raw: `125000000`, want: 125000000},
+		{raw: `"125000000"`, want: 125000000},
+		{raw: `"0x7735940"`, want: 125000000},
+		{raw: `"-1"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			//when
+			got, err := parseFee(json.RawMessage(tt.raw))
+
+			//then
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package pkg
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultHedgeMinDelay = 200 * time.Millisecond
+	defaultHedgeMaxRate  = 0.05
+	// hedgeWindow is the number of the latest read latencies the hedge threshold is derived from.
+	hedgeWindow = 512
+	// hedgeMinSamples is the number of latencies required before the threshold is derived from them,
+	// HedgePolicy.MinDelay is used until then.
+	hedgeMinSamples = 50
+)
+
+type (
+	// HedgePolicy configures hedged contract reads. A read which hasn't returned within the P99
+	// latency of recent reads is sent again to one of Endpoints and the first response is used.
+	HedgePolicy struct {
+		// Endpoints are RPC URLs the duplicate reads are sent to, in turns.
+		Endpoints []string
+		// MinDelay is the lower bound of the hedge threshold, 200ms if 0.
+		MinDelay time.Duration
+		// MaxRate is the maximum fraction of reads which are hedged, 0.05 if 0.
+		MaxRate float64
+	}
+
+	hedger struct {
+		policy HedgePolicy
+		dial   func(url string) (client.Client, error)
+
+		// connectMutex serializes connecting to endpoints, so each is connected to once.
+		connectMutex sync.Mutex
+		mutex        sync.Mutex
+		latencies    []time.Duration
+		next         int
+		reads        uint64
+		hedges       uint64
+		turn         int
+		clients      []client.Client
+	}
+)
+
+// WithHedgedReads enables hedged contract reads, which cut the tail latency of large list reads on
+// public RPC endpoints at the cost of duplicate requests limited by HedgePolicy.MaxRate.
+func WithHedgedReads(policy HedgePolicy) ClientOption {
+	return func(b *blockchainClient) {
+		if len(policy.Endpoints) > 0 {
+			b.hedger = newHedger(policy)
+		}
+	}
+}
+
+func newHedger(policy HedgePolicy) *hedger {
+	if policy.MinDelay <= 0 {
+		policy.MinDelay = defaultHedgeMinDelay
+	}
+	if policy.MaxRate <= 0 {
+		policy.MaxRate = defaultHedgeMaxRate
+	}
+
+	return &hedger{
+		policy:  policy,
+		dial:    client.Connect,
+		clients: make([]client.Client, len(policy.Endpoints)),
+	}
+}
+
+// threshold returns how long a read is waited for before it's hedged.
+func (h *hedger) threshold() time.Duration {
+	h.mutex.Lock()
+	if len(h.latencies) < hedgeMinSamples {
+		h.mutex.Unlock()
+		return h.policy.MinDelay
+	}
+	latencies := append([]time.Duration(nil), h.latencies...)
+	h.mutex.Unlock()
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	p99 := latencies[len(latencies)*99/100]
+	if p99 < h.policy.MinDelay {
+		return h.policy.MinDelay
+	}
+
+	return p99
+}
+
+func (h *hedger) record(latency time.Duration) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if len(h.latencies) < hedgeWindow {
+		h.latencies = append(h.latencies, latency)
+		return
+	}
+	h.latencies[h.next] = latency
+	h.next = (h.next + 1) % hedgeWindow
+}
+
+func (h *hedger) countRead() {
+	h.mutex.Lock()
+	h.reads++
+	h.mutex.Unlock()
+}
+
+// acquire returns the endpoint index of the next hedge, false if hedging would exceed the max rate.
+func (h *hedger) acquire() (int, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if float64(h.hedges+1) > h.policy.MaxRate*float64(h.reads) {
+		return 0, false
+	}
+	h.hedges++
+	i := h.turn
+	h.turn = (h.turn + 1) % len(h.policy.Endpoints)
+
+	return i, true
+}
+
+// client returns the client of the endpoint connecting to it on first use.
+func (h *hedger) client(i int) (client.Client, error) {
+	h.connectMutex.Lock()
+	defer h.connectMutex.Unlock()
+
+	if h.clients[i] != nil {
+		return h.clients[i], nil
+	}
+	cl, err := h.dial(h.policy.Endpoints[i])
+	if err != nil {
+		return nil, err
+	}
+	h.clients[i] = cl
+
+	return cl, nil
+}
+
+// hedgedCall calls primary and, if it hasn't returned within the hedge threshold, hedge with the
+// client of the next hedge endpoint. The first successful result is returned and the other call
+// is canceled. An error is returned only if all started calls failed. Without a hedger only
+// primary is called.
+func hedgedCall[T any](ctx context.Context, h *hedger, primary func(ctx context.Context) (T, error), hedge func(ctx context.Context, cl client.Client) (T, error)) (T, error) {
+	if h == nil {
+		return primary(ctx)
+	}
+	h.countRead()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		res T
+		err error
+	}
+	resultC := make(chan result, 2)
+	start := time.Now()
+	go func() {
+		res, err := primary(ctx)
+		resultC <- result{res: res, err: err}
+	}()
+
+	timer := time.NewTimer(h.threshold())
+	defer timer.Stop()
+	timerC := timer.C
+	pending := 1
+
+	for {
+		select {
+		case r := <-resultC:
+			pending--
+			if r.err == nil {
+				h.record(time.Since(start))
+				return r.res, nil
+			}
+			if pending == 0 {
+				return r.res, r.err
+			}
+		case <-timerC:
+			timerC = nil
+			i, ok := h.acquire()
+			if !ok {
+				continue
+			}
+			pending++
+			go func() {
+				cl, err := h.client(i)
+				if err != nil {
+					log.WithError(err).WithField("endpoint", h.policy.Endpoints[i]).Warning("Can't connect to hedge endpoint")
+					var res T
+					resultC <- result{res: res, err: err}
+					return
+				}
+				res, err := hedge(ctx, cl)
+				resultC <- result{res: res, err: err}
+			}()
+		case <-ctx.Done():
+			var res T
+			return res, ctx.Err()
+		}
+	}
+}
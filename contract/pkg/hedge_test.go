@@ -0,0 +1,77 @@
+package pkg
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func testHedger(policy HedgePolicy) *hedger {
+	h := newHedger(policy)
+	h.dial = func(string) (client.Client, error) { return &slowClient{}, nil }
+	return h
+}
+
+func sleepingCall(ctx context.Context, delay time.Duration, result string) (string, error) {
+	select {
+	case <-time.After(delay):
+		return result, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func TestHedgedCall(t *testing.T) {
+	tests := []struct {
+		name         string
+		primaryDelay time.Duration
+		maxRate      float64
+		want         string
+		wantHedges   int32
+	}{
+		{name: "fast primary", primaryDelay: 0, maxRate: 1, want: "primary", wantHedges: 0},
+		{name: "slow primary", primaryDelay: time.Second, maxRate: 1, want: "hedge", wantHedges: 1},
+		{name: "hedge rate exceeded", primaryDelay: 100 * time.Millisecond, maxRate: 0.5, want: "primary", wantHedges: 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			h := testHedger(HedgePolicy{Endpoints: []string{"ws://hedge"}, MinDelay: 20 * time.Millisecond, MaxRate: tt.maxRate})
+			var hedges int32
+
+			//when
+			res, err := hedgedCall(context.Background(), h, func(ctx context.Context) (string, error) {
+				return sleepingCall(ctx, tt.primaryDelay, "primary")
+			}, func(ctx context.Context, cl client.Client) (string, error) {
+				atomic.AddInt32(&hedges, 1)
+				return sleepingCall(ctx, 0, "hedge")
+			})
+
+			//then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, res)
+			assert.Equal(t, tt.wantHedges, atomic.LoadInt32(&hedges))
+		})
+	}
+}
+
+func TestHedgeThreshold(t *testing.T) {
+	//given
+	h := testHedger(HedgePolicy{Endpoints: []string{"ws://hedge"}, MinDelay: 5 * time.Millisecond})
+	minDelay := h.threshold()
+
+	//when
+	for i := 1; i <= 100; i++ {
+		h.record(time.Duration(i) * time.Millisecond)
+	}
+
+	//then
+	assert.Equal(t, 5*time.Millisecond, minDelay)
+	assert.Equal(t, 100*time.Millisecond, h.threshold())
+}
@@ -0,0 +1,115 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	log "github.com/sirupsen/logrus"
+)
+
+const maxLoggedArgsLength = 256
+
+// Redactor returns a replacement of a sensitive contract call argument or false to log the
+// argument as is.
+type Redactor func(arg interface{}) (interface{}, bool)
+
+// RedactBytes hides byte slice arguments longer than maxLength, e.g. token payloads or signatures.
+func RedactBytes(maxLength int) Redactor {
+	return func(arg interface{}) (interface{}, bool) {
+		if b, ok := arg.([]byte); ok && len(b) > maxLength {
+			return fmt.Sprintf("<redacted %d bytes>", len(b)), true
+		}
+
+		return nil, false
+	}
+}
+
+type loggingClient struct {
+	BlockchainClient
+	logger    log.FieldLogger
+	redactors []Redactor
+}
+
+// NewLoggingClient wraps the client to log every contract read call, contract transaction and
+// deployment with its method, a summary of arguments, duration and outcome. Arguments are passed
+// through redactors before logging. Signers are logged by their address only, their seeds are never
+// logged.
+func NewLoggingClient(client BlockchainClient, logger log.FieldLogger, redactors ...Redactor) BlockchainClient {
+	return &loggingClient{
+		BlockchainClient: client,
+		logger:           logger,
+		redactors:        redactors,
+	}
+}
+
+func (l *loggingClient) CallToReadEncoded(contractAddressSS58 string, fromAddress string, method []byte, args ...interface{}) (string, error) {
+	start := time.Now()
+	res, err := l.BlockchainClient.CallToReadEncoded(contractAddressSS58, fromAddress, method, args...)
+	l.log("Contract read call", contractAddressSS58, fromAddress, method, args, start, err)
+
+	return res, err
+}
+
+func (l *loggingClient) CallToReadEncodedContext(ctx context.Context, readCall ReadCall) (string, error) {
+	start := time.Now()
+	res, err := l.BlockchainClient.CallToReadEncodedContext(ctx, readCall)
+	l.log("Contract read call", readCall.ContractAddressSS58, readCall.From, readCall.Method, readCall.Args, start, err)
+
+	return res, err
+}
+
+func (l *loggingClient) CallToExec(ctx context.Context, contractCall ContractCall) (types.Hash, error) {
+	start := time.Now()
+	hash, err := l.BlockchainClient.CallToExec(ctx, contractCall)
+	l.log("Contract transaction", contractCall.ContractAddressSS58, contractCall.From.Address, contractCall.Method, contractCall.Args, start, err)
+
+	return hash, err
+}
+
+func (l *loggingClient) Deploy(ctx context.Context, deployCall DeployCall) (types.AccountID, error) {
+	start := time.Now()
+	contract, err := l.BlockchainClient.Deploy(ctx, deployCall)
+	l.log("Contract deployment", "", deployCall.From.Address, deployCall.Method, deployCall.Args, start, err)
+
+	return contract, err
+}
+
+func (l *loggingClient) log(msg string, contract string, from string, method []byte, args []interface{}, start time.Time, err error) {
+	entry := l.logger.WithFields(log.Fields{
+		"contract": contract,
+		"from":     from,
+		"method":   codec.HexEncodeToString(method),
+		"args":     l.summary(args),
+		"duration": time.Since(start),
+	})
+
+	if err != nil {
+		entry.WithError(err).Warn(msg + " failed")
+		return
+	}
+	entry.Debug(msg)
+}
+
+func (l *loggingClient) summary(args []interface{}) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		for _, redact := range l.redactors {
+			if replacement, ok := redact(arg); ok {
+				arg = replacement
+				break
+			}
+		}
+		parts[i] = fmt.Sprintf("%v", arg)
+	}
+
+	summary := strings.Join(parts, ", ")
+	if len(summary) > maxLoggedArgsLength {
+		summary = summary[:maxLoggedArgsLength] + "..."
+	}
+
+	return summary
+}
@@ -0,0 +1,63 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	log "github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+type execClient struct {
+	BlockchainClient
+	err error
+}
+
+func (c *execClient) CallToExec(ctx context.Context, contractCall ContractCall) (types.Hash, error) {
+	return types.Hash{1}, c.err
+}
+
+func TestLoggingClientCallToExec(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		level log.Level
+	}{
+		{name: "success", level: log.DebugLevel},
+		{name: "failure", err: errors.New("extrinsic failed"), level: log.WarnLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			logger, hook := test.NewNullLogger()
+			logger.SetLevel(log.DebugLevel)
+			client := NewLoggingClient(&execClient{err: tt.err}, logger, RedactBytes(4))
+			call := ContractCall{
+				From:   signature.KeyringPair{URI: "//Alice", Address: "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+				Method: []byte{0x0a, 0x0b},
+				Args:   []interface{}{[]byte("secret token"), types.U32(7)},
+			}
+
+			//when
+			hash, err := client.CallToExec(context.Background(), call)
+
+			//then
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, types.Hash{1}, hash)
+			entry := hook.LastEntry()
+			assert.Equal(t, tt.level, entry.Level)
+			assert.Equal(t, "0x0a0b", entry.Data["method"])
+			assert.Equal(t, "<redacted 12 bytes>, 7", entry.Data["args"])
+			assert.Equal(t, call.From.Address, entry.Data["from"])
+			for _, value := range entry.Data {
+				assert.NotContains(t, fmt.Sprint(value), "//Alice")
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package migration
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockedBuckets struct {
+	contract map[bucket.BucketId]*bucket.BucketInfo
+	pallet   map[PalletBucketId]PalletBucket
+}
+
+func (m *mockedBuckets) BucketGet(bucketId bucket.BucketId) (*bucket.BucketInfo, error) {
+	if info, ok := m.contract[bucketId]; ok {
+		return info, nil
+	}
+	return nil, bucket.ErrBucketDoesNotExist
+}
+
+func (m *mockedBuckets) GetPalletBucket(bucketId PalletBucketId) (*PalletBucket, error) {
+	if palletBucket, ok := m.pallet[bucketId]; ok {
+		return &palletBucket, nil
+	}
+	return nil, ErrBucketNotFound
+}
+
+func TestReadTable(t *testing.T) {
+	//when
+	table, err := ReadTable(strings.NewReader("contract_id,pallet_id\n1,101\n2,102\n"))
+	_, conflictErr := ReadTable(strings.NewReader("1,101\n1,102\n"))
+	_, invalidErr := ReadTable(strings.NewReader("1,101\nx,102\n"))
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, 2, table.Len())
+	palletId, ok := table.PalletBucketId(2)
+	assert.True(t, ok)
+	assert.Equal(t, PalletBucketId(102), palletId)
+	contractId, ok := table.ContractBucketId(101)
+	assert.True(t, ok)
+	assert.Equal(t, bucket.BucketId(1), contractId)
+	assert.True(t, errors.Is(conflictErr, ErrMappingConflict))
+	assert.Error(t, invalidErr)
+}
+
+func TestReader(t *testing.T) {
+	//given
+	owner := types.AccountID{1}
+	buckets := &mockedBuckets{
+		contract: map[bucket.BucketId]*bucket.BucketInfo{
+			1: {BucketId: 1, Bucket: bucket.Bucket{OwnerId: owner}},
+			2: {BucketId: 2, Bucket: bucket.Bucket{OwnerId: owner}},
+			3: {BucketId: 3, Bucket: bucket.Bucket{OwnerId: owner}},
+		},
+		pallet: map[PalletBucketId]PalletBucket{
+			101: {BucketId: 101, OwnerId: owner, IsPublic: true},
+		},
+	}
+	table, err := CreateTable(Mapping{ContractBucketId: 1, PalletBucketId: 101}, Mapping{ContractBucketId: 2, PalletBucketId: 102})
+	assert.NoError(t, err)
+	reader := CreateReader(table, buckets, buckets, WithDualReads())
+
+	//when
+	migrated, err := reader.GetByContractId(1)
+	assert.NoError(t, err)
+	pending, err := reader.GetByContractId(2)
+	assert.NoError(t, err)
+	legacy, err := reader.GetByContractId(3)
+	assert.NoError(t, err)
+	byPalletId, err := reader.GetByPalletId(101)
+	assert.NoError(t, err)
+	_, notFoundErr := reader.GetByContractId(4)
+
+	//then
+	assert.NotNil(t, migrated.Pallet)
+	assert.NotNil(t, migrated.Contract)
+	assert.True(t, migrated.IsPublic)
+	assert.Equal(t, bucket.BucketId(1), *migrated.ContractBucketId)
+	assert.Nil(t, pending.Pallet)
+	assert.Nil(t, pending.PalletBucketId)
+	assert.Equal(t, bucket.BucketId(2), *pending.ContractBucketId)
+	assert.Nil(t, legacy.Pallet)
+	assert.Equal(t, owner, legacy.OwnerId)
+	assert.Equal(t, bucket.BucketId(1), *byPalletId.ContractBucketId)
+	assert.Equal(t, PalletBucketId(101), *byPalletId.PalletBucketId)
+	assert.True(t, errors.Is(notFoundErr, ErrBucketNotFound))
+}
@@ -0,0 +1,180 @@
+package migration
+
+import (
+	"errors"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+type (
+	// ContractBuckets reads buckets of the DDC bucket contract, e.g. bucket.DdcBucketContract.
+	ContractBuckets interface {
+		BucketGet(bucketId bucket.BucketId) (*bucket.BucketInfo, error)
+	}
+
+	// PalletBucketId is the ID of a bucket of the DDC customers pallet.
+	PalletBucketId = types.U64
+
+	// PalletBucket is the bucket of the DDC customers pallet.
+	PalletBucket struct {
+		BucketId  PalletBucketId
+		OwnerId   types.AccountID
+		ClusterId types.H160
+		IsPublic  bool
+		IsRemoved bool
+	}
+
+	// PalletBuckets reads buckets of the DDC customers pallet. GetPalletBucket returns
+	// ErrBucketNotFound if there is no bucket with the ID. Adapt DdcCustomersApi of the blockchain
+	// module with PalletBucketsFunc:
+	//
+	//	migration.PalletBucketsFunc(func(bucketId migration.PalletBucketId) (*migration.PalletBucket, error) {
+	//		option, err := client.DdcCustomers.GetBuckets(bucketId)
+	//		if err != nil {
+	//			return nil, err
+	//		}
+	//		ok, b := option.Unwrap()
+	//		if !ok {
+	//			return nil, migration.ErrBucketNotFound
+	//		}
+	//		return &migration.PalletBucket{
+	//			BucketId:  b.BucketId,
+	//			OwnerId:   b.OwnerId,
+	//			ClusterId: b.ClusterId,
+	//			IsPublic:  bool(b.IsPublic),
+	//			IsRemoved: bool(b.IsRemoved),
+	//		}, nil
+	//	})
+	PalletBuckets interface {
+		GetPalletBucket(bucketId PalletBucketId) (*PalletBucket, error)
+	}
+
+	PalletBucketsFunc func(bucketId PalletBucketId) (*PalletBucket, error)
+
+	// Bucket is the bucket read from the pallet, from the contract or from both.
+	Bucket struct {
+		// ContractBucketId is set if the bucket was created in the contract.
+		ContractBucketId *bucket.BucketId
+		// PalletBucketId is set if the bucket is in the pallet.
+		PalletBucketId *PalletBucketId
+		OwnerId        types.AccountID
+		IsPublic       bool
+		// Contract is the contract bucket, nil if it was read from the pallet only.
+		Contract *bucket.BucketInfo
+		// Pallet is the pallet bucket, nil if the bucket isn't migrated yet.
+		Pallet *PalletBucket
+	}
+
+	// Reader reads buckets by IDs of either generation.
+	Reader struct {
+		table     *Table
+		contract  ContractBuckets
+		pallet    PalletBuckets
+		dualReads bool
+	}
+
+	ReaderOption func(r *Reader)
+)
+
+var ErrBucketNotFound = errors.New("bucket not found")
+
+func (f PalletBucketsFunc) GetPalletBucket(bucketId PalletBucketId) (*PalletBucket, error) {
+	return f(bucketId)
+}
+
+// WithDualReads reads migrated buckets from the contract too, e.g. to compare both during the migration.
+func WithDualReads() ReaderOption {
+	return func(r *Reader) {
+		r.dualReads = true
+	}
+}
+
+func CreateReader(table *Table, contract ContractBuckets, pallet PalletBuckets, opts ...ReaderOption) *Reader {
+	r := &Reader{table: table, contract: contract, pallet: pallet}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// GetByContractId reads the bucket from the pallet if it was migrated and from the contract otherwise.
+func (r *Reader) GetByContractId(contractBucketId bucket.BucketId) (*Bucket, error) {
+	palletBucketId, ok := r.table.PalletBucketId(contractBucketId)
+	if !ok {
+		return r.readContract(contractBucketId)
+	}
+
+	result, err := r.readPallet(palletBucketId)
+	if errors.Is(err, ErrBucketNotFound) {
+		// The mapping is known before the bucket is created in the pallet.
+		return r.readContract(contractBucketId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result.ContractBucketId = &contractBucketId
+
+	if r.dualReads {
+		if result.Contract, err = r.contract.BucketGet(contractBucketId); err != nil && !errors.Is(err, bucket.ErrBucketDoesNotExist) {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// GetByPalletId reads the bucket from the pallet, with the contract bucket if it was migrated from
+// the contract and dual reads are enabled.
+func (r *Reader) GetByPalletId(palletBucketId PalletBucketId) (*Bucket, error) {
+	result, err := r.readPallet(palletBucketId)
+	if err != nil {
+		return nil, err
+	}
+
+	if contractBucketId, ok := r.table.ContractBucketId(palletBucketId); ok {
+		result.ContractBucketId = &contractBucketId
+		if r.dualReads {
+			if result.Contract, err = r.contract.BucketGet(contractBucketId); err != nil && !errors.Is(err, bucket.ErrBucketDoesNotExist) {
+				return nil, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (r *Reader) readContract(contractBucketId bucket.BucketId) (*Bucket, error) {
+	info, err := r.contract.BucketGet(contractBucketId)
+	if errors.Is(err, bucket.ErrBucketDoesNotExist) {
+		return nil, ErrBucketNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bucket{
+		ContractBucketId: &contractBucketId,
+		OwnerId:          info.Bucket.OwnerId,
+		IsPublic:         info.Bucket.PublicAvailability,
+		Contract:         info,
+	}, nil
+}
+
+func (r *Reader) readPallet(palletBucketId PalletBucketId) (*Bucket, error) {
+	palletBucket, err := r.pallet.GetPalletBucket(palletBucketId)
+	if err != nil {
+		return nil, err
+	}
+	if palletBucket.IsRemoved {
+		return nil, ErrBucketNotFound
+	}
+
+	return &Bucket{
+		PalletBucketId: &palletBucketId,
+		OwnerId:        palletBucket.OwnerId,
+		IsPublic:       palletBucket.IsPublic,
+		Pallet:         palletBucket,
+	}, nil
+}
@@ -0,0 +1,123 @@
+// Package migration helps applications move from buckets of the DDC bucket contract (uint32 IDs)
+// to buckets of the DDC customers pallet (uint64 IDs). A Table maps IDs between the two and a
+// Reader reads a bucket from the pallet once it's migrated and from the contract until then.
+package migration
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+type (
+	// Mapping maps the contract bucket to the pallet bucket it was migrated to.
+	Mapping struct {
+		ContractBucketId bucket.BucketId
+		PalletBucketId   PalletBucketId
+	}
+
+	// Table is the lookup table of migrated buckets. It's safe for concurrent use.
+	Table struct {
+		mutex      sync.RWMutex
+		toPallet   map[bucket.BucketId]PalletBucketId
+		toContract map[PalletBucketId]bucket.BucketId
+	}
+)
+
+var ErrMappingConflict = errors.New("bucket is already mapped to another bucket")
+
+func CreateTable(mappings ...Mapping) (*Table, error) {
+	t := &Table{
+		toPallet:   make(map[bucket.BucketId]PalletBucketId),
+		toContract: make(map[PalletBucketId]bucket.BucketId),
+	}
+	for _, mapping := range mappings {
+		if err := t.Add(mapping); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// ReadTable reads the table from CSV rows of the contract bucket ID and the pallet bucket ID. The
+// first row is skipped if it's not numeric, so the file may have a header.
+func ReadTable(r io.Reader) (*Table, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	t, _ := CreateTable()
+	for i, row := range rows {
+		if len(row) != 2 {
+			return nil, fmt.Errorf("row %d: expected 2 columns, got %d", i+1, len(row))
+		}
+		contractId, contractErr := strconv.ParseUint(row[0], 10, 32)
+		palletId, palletErr := strconv.ParseUint(row[1], 10, 64)
+		if i == 0 && contractErr != nil && palletErr != nil {
+			continue
+		}
+		if contractErr != nil {
+			return nil, fmt.Errorf("row %d: invalid contract bucket id: %w", i+1, contractErr)
+		}
+		if palletErr != nil {
+			return nil, fmt.Errorf("row %d: invalid pallet bucket id: %w", i+1, palletErr)
+		}
+
+		mapping := Mapping{ContractBucketId: bucket.BucketId(contractId), PalletBucketId: PalletBucketId(palletId)}
+		if err := t.Add(mapping); err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+1, err)
+		}
+	}
+
+	return t, nil
+}
+
+// Add adds the mapping. Adding the same mapping again is a no-op, mapping a bucket to another
+// bucket than it's mapped to fails with ErrMappingConflict.
+func (t *Table) Add(mapping Mapping) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	palletId, contractMapped := t.toPallet[mapping.ContractBucketId]
+	contractId, palletMapped := t.toContract[mapping.PalletBucketId]
+	if contractMapped && palletId != mapping.PalletBucketId || palletMapped && contractId != mapping.ContractBucketId {
+		return fmt.Errorf("%w: contract bucket %d, pallet bucket %d", ErrMappingConflict, mapping.ContractBucketId, mapping.PalletBucketId)
+	}
+
+	t.toPallet[mapping.ContractBucketId] = mapping.PalletBucketId
+	t.toContract[mapping.PalletBucketId] = mapping.ContractBucketId
+
+	return nil
+}
+
+// PalletBucketId returns ID of the pallet bucket the contract bucket was migrated to.
+func (t *Table) PalletBucketId(contractBucketId bucket.BucketId) (PalletBucketId, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	palletId, ok := t.toPallet[contractBucketId]
+	return palletId, ok
+}
+
+// ContractBucketId returns ID of the contract bucket the pallet bucket was migrated from.
+func (t *Table) ContractBucketId(palletBucketId PalletBucketId) (bucket.BucketId, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	contractId, ok := t.toContract[palletBucketId]
+	return contractId, ok
+}
+
+func (t *Table) Len() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return len(t.toPallet)
+}
@@ -223,6 +223,14 @@ func (d *ddcBucketContractMock) AddContractEventHandler(event string, handler fu
 	return nil
 }
 
+func (d *ddcBucketContractMock) RegisterHandler(event string, handler func(interface{})) error {
+	return nil
+}
+
+func (d *ddcBucketContractMock) UnregisterHandler(event string) error {
+	return nil
+}
+
 func CreateBucket(bucketId bucket.BucketId, clusterId uint32, bucketParams string, writerIds []types.AccountID) *bucket.BucketInfo {
 	return &bucket.BucketInfo{
 		BucketId: bucketId,
@@ -301,12 +309,22 @@ func (d *ddcBucketContractMock) ClusterRemove(ctx context.Context, keyPair signa
 	panic("implement me")
 }
 
-func (d *ddcBucketContractMock) ClusterSetNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, nodeKey bucket.NodeKey, statusInCluster string) error {
+func (d *ddcBucketContractMock) ClusterSetNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, nodeKey bucket.NodeKey, statusInCluster bucket.NodeStatusInCluster) error {
 	//TODO implement me
 	panic("implement me")
 }
 
-func (d *ddcBucketContractMock) ClusterSetCdnNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, cdnNodeKey bucket.CdnNodeKey, statusInCluster string) error {
+func (d *ddcBucketContractMock) ClusterSetCdnNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, cdnNodeKey bucket.CdnNodeKey, statusInCluster bucket.NodeStatusInCluster) error {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (d *ddcBucketContractMock) ClusterDistributeRevenues(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId) error {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (d *ddcBucketContractMock) ClusterDistributeRevenuesEstimate(clusterId bucket.ClusterId) (*bucket.RevenueDistribution, error) {
 	//TODO implement me
 	panic("implement me")
 }
@@ -316,7 +334,7 @@ func (d *ddcBucketContractMock) ClusterList(offset types.U32, limit types.U32, f
 	panic("implement me")
 }
 
-func (d *ddcBucketContractMock) NodeCreate(ctx context.Context, keyPair signature.KeyringPair, nodeKey bucket.NodeKey, params bucket.Params, capacity bucket.Resource, rent bucket.Rent) (blockHash types.Hash, err error) {
+func (d *ddcBucketContractMock) NodeCreate(ctx context.Context, keyPair signature.KeyringPair, nodeKey bucket.NodeKey, params bucket.Params, capacity bucket.StorageGb, rent bucket.Rent) (blockHash types.Hash, err error) {
 	//TODO implement me
 	panic("implement me")
 }
@@ -391,7 +409,7 @@ func (d *ddcBucketContractMock) AdminTransferCdnNodeOwnership(ctx context.Contex
 	panic("implement me")
 }
 
-func (d *ddcBucketContractMock) AccountDeposit(ctx context.Context, keyPair signature.KeyringPair) error {
+func (d *ddcBucketContractMock) AccountDeposit(ctx context.Context, keyPair signature.KeyringPair, value bucket.Balance) (blockHash types.Hash, err error) {
 	//TODO implement me
 	panic("implement me")
 }
@@ -436,7 +454,7 @@ func (d *ddcBucketContractMock) BucketChangeOwner(ctx context.Context, keyPair s
 	panic("implement me")
 }
 
-func (d *ddcBucketContractMock) BucketAllocIntoCluster(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, resource bucket.Resource) error {
+func (d *ddcBucketContractMock) BucketAllocIntoCluster(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, resource bucket.StorageGb) error {
 	//TODO implement me
 	panic("implement me")
 }
@@ -466,7 +484,7 @@ func (d *ddcBucketContractMock) BucketSetAvailability(ctx context.Context, keyPa
 	panic("implement me")
 }
 
-func (d *ddcBucketContractMock) BucketSetResourceCap(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, newResourceCap bucket.Resource) error {
+func (d *ddcBucketContractMock) BucketSetResourceCap(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, newResourceCap bucket.StorageGb) error {
 	//TODO implement me
 	panic("implement me")
 }
@@ -481,6 +499,42 @@ func (d *ddcBucketContractMock) GetBucketReaders(ctx context.Context, keyPair si
 	panic("implement me")
 }
 
+func (d *ddcBucketContractMock) BucketWritersPage(bucketId bucket.BucketId, offset types.U32, limit types.U32) (*bucket.AccountListInfo, error) {
+	bucketInfo, err := d.BucketGet(bucketId)
+	if err != nil {
+		return nil, err
+	}
+
+	return bucket.NewAccountListPage(bucketInfo.WriterIds, offset, limit), nil
+}
+
+func (d *ddcBucketContractMock) BucketReadersPage(bucketId bucket.BucketId, offset types.U32, limit types.U32) (*bucket.AccountListInfo, error) {
+	bucketInfo, err := d.BucketGet(bucketId)
+	if err != nil {
+		return nil, err
+	}
+
+	return bucket.NewAccountListPage(bucketInfo.ReaderIds, offset, limit), nil
+}
+
+func (d *ddcBucketContractMock) IsWriter(bucketId bucket.BucketId, account bucket.AccountId) (bool, error) {
+	bucketInfo, err := d.BucketGet(bucketId)
+	if err != nil {
+		return false, err
+	}
+
+	return bucket.ContainsAccount(bucketInfo.WriterIds, account), nil
+}
+
+func (d *ddcBucketContractMock) IsReader(bucketId bucket.BucketId, account bucket.AccountId) (bool, error) {
+	bucketInfo, err := d.BucketGet(bucketId)
+	if err != nil {
+		return false, err
+	}
+
+	return bucket.ContainsAccount(bucketInfo.ReaderIds, account), nil
+}
+
 func (d *ddcBucketContractMock) BucketSetWriterPerm(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, writer bucket.AccountId) error {
 	//TODO implement me
 	panic("implement me")
@@ -0,0 +1,9 @@
+// Package mocks provides generated mocks of the public contract interfaces, so downstream projects
+// don't maintain their own. Every method of a mock calls the function in the field named after the
+// method with the Func suffix and records the call arguments, e.g. BucketGetFunc and BucketGetCalls.
+//
+// Regenerate the mocks with go generate after an interface changes.
+package mocks
+
+//go:generate go run github.com/matryer/moq@v0.3.4 -out blockchain_client.go -pkg mocks .. BlockchainClient
+//go:generate go run github.com/matryer/moq@v0.3.4 -out ddc_bucket_contract.go -pkg mocks ../bucket DdcBucketContract
@@ -0,0 +1,244 @@
+// Package naming resolves human-readable bucket names to bucket IDs. A bucket is named by the
+// name in its params, see bucket.ReadBucketName. Names are indexed by scanning all buckets in the
+// contract, the index is cached and rebuilt when it gets older than the TTL.
+package naming
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+type (
+	// Contract is the part of the bucket contract API names are read from.
+	Contract interface {
+		BucketList(offset types.U32, limit types.U32, filterOwnerId types.OptionAccountID) (*bucket.BucketListInfo, error)
+	}
+
+	Resolver interface {
+		// Resolve returns ID of the bucket with the name.
+		Resolve(name string) (bucket.BucketId, error)
+		// ListNames returns sorted names of the buckets of the owner.
+		ListNames(owner bucket.AccountId) ([]string, error)
+		// Collisions returns names claimed by more than one bucket and IDs of these buckets.
+		Collisions() (map[string][]bucket.BucketId, error)
+		// Invalidate drops the index, so it's rebuilt on the next call, e.g. on BucketParamsSetEvent.
+		Invalidate()
+	}
+
+	// CollisionError is returned when the name is claimed by more than one bucket.
+	CollisionError struct {
+		Name      string
+		BucketIds []bucket.BucketId
+	}
+
+	resolver struct {
+		contract Contract
+		ttl      time.Duration
+		pageSize types.U32
+		clock    pkg.Clock
+
+		mutex   sync.Mutex
+		index   *index
+		builtAt time.Time
+	}
+
+	index struct {
+		buckets map[string][]bucket.BucketId
+		owners  map[bucket.AccountId][]string
+	}
+
+	Option func(r *resolver)
+)
+
+const (
+	defaultTTL      = 5 * time.Minute
+	defaultPageSize = 100
+)
+
+var (
+	ErrNameNotFound = errors.New("bucket name not found")
+	ErrInvalidName  = errors.New("invalid bucket name")
+
+	namePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`)
+)
+
+const maxNameLength = 253
+
+func (e *CollisionError) Error() string {
+	return fmt.Sprintf("bucket name %s is claimed by %d buckets: %v", e.Name, len(e.BucketIds), e.BucketIds)
+}
+
+// WithTTL sets how long the index is used before it's rebuilt.
+func WithTTL(ttl time.Duration) Option {
+	return func(r *resolver) {
+		r.ttl = ttl
+	}
+}
+
+// WithPageSize sets the number of buckets read by a list call while indexing.
+func WithPageSize(pageSize types.U32) Option {
+	return func(r *resolver) {
+		r.pageSize = pageSize
+	}
+}
+
+func WithClock(clock pkg.Clock) Option {
+	return func(r *resolver) {
+		r.clock = clock
+	}
+}
+
+func CreateResolver(contract Contract, opts ...Option) Resolver {
+	r := &resolver{
+		contract: contract,
+		ttl:      defaultTTL,
+		pageSize: defaultPageSize,
+		clock:    pkg.SystemClock,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// NormalizeName lowercases the name and checks it's made of DNS-like labels.
+func NormalizeName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) > maxNameLength || !namePattern.MatchString(name) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+
+	return name, nil
+}
+
+func (r *resolver) Resolve(name string) (bucket.BucketId, error) {
+	name, err := NormalizeName(name)
+	if err != nil {
+		return 0, err
+	}
+
+	idx, err := r.getIndex()
+	if err != nil {
+		return 0, err
+	}
+
+	bucketIds := idx.buckets[name]
+	switch len(bucketIds) {
+	case 0:
+		return 0, fmt.Errorf("%w: %s", ErrNameNotFound, name)
+	case 1:
+		return bucketIds[0], nil
+	default:
+		return 0, &CollisionError{Name: name, BucketIds: append([]bucket.BucketId(nil), bucketIds...)}
+	}
+}
+
+func (r *resolver) ListNames(owner bucket.AccountId) ([]string, error) {
+	idx, err := r.getIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]string(nil), idx.owners[owner]...), nil
+}
+
+func (r *resolver) Collisions() (map[string][]bucket.BucketId, error) {
+	idx, err := r.getIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	collisions := make(map[string][]bucket.BucketId)
+	for name, bucketIds := range idx.buckets {
+		if len(bucketIds) > 1 {
+			collisions[name] = append([]bucket.BucketId(nil), bucketIds...)
+		}
+	}
+
+	return collisions, nil
+}
+
+func (r *resolver) Invalidate() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.index = nil
+}
+
+func (r *resolver) getIndex() (*index, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.index != nil && r.clock.Now().Sub(r.builtAt) < r.ttl {
+		return r.index, nil
+	}
+
+	idx, err := r.buildIndex()
+	if err != nil {
+		return nil, err
+	}
+	r.index = idx
+	r.builtAt = r.clock.Now()
+
+	return idx, nil
+}
+
+// buildIndex scans all buckets. Buckets with invalid names are skipped.
+func (r *resolver) buildIndex() (*index, error) {
+	idx := &index{
+		buckets: make(map[string][]bucket.BucketId),
+		owners:  make(map[bucket.AccountId][]string),
+	}
+
+	for offset := types.U32(0); ; offset += r.pageSize {
+		page, err := r.contract.BucketList(offset, r.pageSize, types.NewOptionAccountIDEmpty())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, info := range page.Buckets {
+			name, ok := bucket.ReadBucketName(info.Params)
+			if !ok {
+				continue
+			}
+			if name, err = NormalizeName(name); err != nil {
+				continue
+			}
+			idx.buckets[name] = append(idx.buckets[name], info.BucketId)
+			idx.owners[info.Bucket.OwnerId] = append(idx.owners[info.Bucket.OwnerId], name)
+		}
+
+		if offset+r.pageSize >= page.Total {
+			break
+		}
+	}
+
+	for owner, names := range idx.owners {
+		sort.Strings(names)
+		idx.owners[owner] = compact(names)
+	}
+
+	return idx, nil
+}
+
+// compact removes consecutive duplicates of the sorted names.
+func compact(names []string) []string {
+	result := names[:0]
+	for i, name := range names {
+		if i == 0 || name != names[i-1] {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,122 @@
+package naming
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockedContract struct {
+	buckets []bucket.BucketInfo
+	calls   int
+}
+
+func (m *mockedContract) BucketList(offset types.U32, limit types.U32, _ types.OptionAccountID) (*bucket.BucketListInfo, error) {
+	m.calls++
+	end := offset + limit
+	if int(end) > len(m.buckets) {
+		end = types.U32(len(m.buckets))
+	}
+
+	return &bucket.BucketListInfo{Buckets: m.buckets[offset:end], Total: types.U32(len(m.buckets))}, nil
+}
+
+var (
+	alice = bucket.AccountId{1}
+	bob   = bucket.AccountId{2}
+)
+
+func testContract() *mockedContract {
+	return &mockedContract{buckets: []bucket.BucketInfo{
+		{BucketId: 1, Bucket: bucket.Bucket{OwnerId: alice}, Params: `{"name":"Photos"}`},
+		{BucketId: 2, Bucket: bucket.Bucket{OwnerId: alice}, Params: "name=videos"},
+		{BucketId: 3, Bucket: bucket.Bucket{OwnerId: bob}, Params: "name=shared"},
+		{BucketId: 4, Bucket: bucket.Bucket{OwnerId: alice}, Params: "name=shared"},
+		{BucketId: 5, Bucket: bucket.Bucket{OwnerId: bob}, Params: "name=not_valid"},
+		{BucketId: 6, Bucket: bucket.Bucket{OwnerId: bob}, Params: "{}"},
+	}}
+}
+
+func TestResolve(t *testing.T) {
+	resolver := CreateResolver(testContract(), WithPageSize(2))
+
+	tests := []struct {
+		name         string
+		bucketName   string
+		wantBucketId bucket.BucketId
+		wantErr      error
+	}{
+		{name: "JSON params name", bucketName: "photos", wantBucketId: 1},
+		{name: "Tag params name", bucketName: "videos", wantBucketId: 2},
+		{name: "Name is case insensitive", bucketName: "VIDEOS", wantBucketId: 2},
+		{name: "Unknown name", bucketName: "music", wantErr: ErrNameNotFound},
+		{name: "Invalid name", bucketName: "not_valid", wantErr: ErrInvalidName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucketId, err := resolver.Resolve(tt.bucketName)
+
+			assert.Equal(t, tt.wantBucketId, bucketId)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestResolveCollision(t *testing.T) {
+	//given
+	resolver := CreateResolver(testContract())
+
+	//when
+	_, err := resolver.Resolve("shared")
+	collisions, collisionsErr := resolver.Collisions()
+
+	//then
+	var collisionErr *CollisionError
+	assert.True(t, errors.As(err, &collisionErr))
+	assert.Equal(t, []bucket.BucketId{3, 4}, collisionErr.BucketIds)
+	assert.NoError(t, collisionsErr)
+	assert.Equal(t, map[string][]bucket.BucketId{"shared": {3, 4}}, collisions)
+}
+
+func TestListNames(t *testing.T) {
+	resolver := CreateResolver(testContract())
+
+	aliceNames, err := resolver.ListNames(alice)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"photos", "shared", "videos"}, aliceNames)
+
+	bobNames, err := resolver.ListNames(bob)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"shared"}, bobNames)
+}
+
+func TestIndexCache(t *testing.T) {
+	//given
+	contract := testContract()
+	now := time.Unix(0, 0)
+	resolver := CreateResolver(contract, WithTTL(time.Minute), WithClock(pkg.ClockFunc(func() time.Time { return now })))
+	_, _ = resolver.Resolve("photos")
+
+	//when
+	_, _ = resolver.Resolve("videos")
+	callsCached := contract.calls
+	now = now.Add(time.Minute)
+	_, _ = resolver.Resolve("videos")
+	callsExpired := contract.calls
+	resolver.Invalidate()
+	_, _ = resolver.Resolve("videos")
+
+	//then
+	assert.Equal(t, 1, callsCached)
+	assert.Equal(t, 2, callsExpired)
+	assert.Equal(t, 3, contract.calls)
+}
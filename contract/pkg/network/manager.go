@@ -0,0 +1,248 @@
+// Package network holds blockchain clients and DDC bucket contracts of several networks at once,
+// e.g. mainnet and testnet, for services which bridge or compare environments.
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+type (
+	Network string
+
+	// Config is a value type: change it with its With methods, which don't share option slices
+	// with the original config.
+	Config struct {
+		RpcUrl          string
+		ContractAddress string
+		// SS58Prefix is the address format of signers of the network, pkg.CereNetwork if not set.
+		SS58Prefix      uint16
+		ClientOptions   []pkg.ClientOption
+		ContractOptions []bucket.Option
+	}
+
+	Connection struct {
+		Network    Network
+		SS58Prefix uint16
+		Client     pkg.BlockchainClient
+		Contract   bucket.DdcBucketContract
+	}
+
+	HealthStatus struct {
+		Network   Network
+		Healthy   bool
+		Latency   time.Duration
+		Error     string
+		CheckedAt time.Time
+	}
+
+	// Health is the health of all networks, healthy only if every network is healthy.
+	Health struct {
+		Healthy  bool
+		Networks []HealthStatus
+	}
+
+	// Manager holds connections to networks and signers shared by them. It's safe for concurrent use.
+	Manager struct {
+		mutex       sync.RWMutex
+		connections map[Network]*Connection
+		signers     map[string]signature.KeyringPair
+	}
+)
+
+const (
+	Mainnet Network = "mainnet"
+	Testnet Network = "testnet"
+	Devnet  Network = "devnet"
+)
+
+var (
+	ErrUnknownNetwork = errors.New("unknown network")
+	ErrNetworkExists  = errors.New("network already added")
+	ErrUnknownSigner  = errors.New("unknown signer")
+)
+
+// WithClientOptions returns a copy of the config with the client options added.
+func (c Config) WithClientOptions(opts ...pkg.ClientOption) Config {
+	c.ClientOptions = append(append([]pkg.ClientOption(nil), c.ClientOptions...), opts...)
+	return c
+}
+
+// WithContractOptions returns a copy of the config with the contract options added.
+func (c Config) WithContractOptions(opts ...bucket.Option) Config {
+	c.ContractOptions = append(append([]bucket.Option(nil), c.ContractOptions...), opts...)
+	return c
+}
+
+func CreateNetworkManager() *Manager {
+	return &Manager{
+		connections: make(map[Network]*Connection),
+		signers:     make(map[string]signature.KeyringPair),
+	}
+}
+
+// Connect creates the client and the contract of the network.
+func (m *Manager) Connect(network Network, config Config) (*Connection, error) {
+	if config.SS58Prefix == 0 {
+		config.SS58Prefix = pkg.CereNetwork
+	}
+	client := pkg.CreateBlockchainClient(config.RpcUrl, config.ClientOptions...)
+	contract := bucket.CreateDdcBucketContract(client, config.ContractAddress, config.ContractOptions...)
+
+	connection := &Connection{Network: network, SS58Prefix: config.SS58Prefix, Client: client, Contract: contract}
+	if err := m.Add(connection); err != nil {
+		return nil, err
+	}
+
+	return connection, nil
+}
+
+// Add adds the connection created by the caller, e.g. with a cached contract.
+func (m *Manager) Add(connection *Connection) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.connections[connection.Network]; ok {
+		return fmt.Errorf("%w: %s", ErrNetworkExists, connection.Network)
+	}
+	m.connections[connection.Network] = connection
+
+	return nil
+}
+
+// Remove removes the connection of the network.
+func (m *Manager) Remove(network Network) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.connections, network)
+}
+
+func (m *Manager) Get(network Network) (*Connection, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	connection, ok := m.connections[network]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownNetwork, network)
+	}
+
+	return connection, nil
+}
+
+// Networks returns the added networks sorted by name.
+func (m *Manager) Networks() []Network {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	networks := make([]Network, 0, len(m.connections))
+	for network := range m.connections {
+		networks = append(networks, network)
+	}
+	sort.Slice(networks, func(i, j int) bool { return networks[i] < networks[j] })
+
+	return networks
+}
+
+// AddSigner shares the key pair with all networks under the name.
+func (m *Manager) AddSigner(name string, keyPair signature.KeyringPair) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.signers[name] = keyPair
+}
+
+// Signer returns a copy of the shared key pair with the address in the format of the network,
+// so callers can't change the key pair used by other networks.
+func (m *Manager) Signer(name string, network Network) (signature.KeyringPair, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	keyPair, ok := m.signers[name]
+	if !ok {
+		return signature.KeyringPair{}, fmt.Errorf("%w: %s", ErrUnknownSigner, name)
+	}
+	connection, ok := m.connections[network]
+	if !ok {
+		return signature.KeyringPair{}, fmt.Errorf("%w: %s", ErrUnknownNetwork, network)
+	}
+
+	accountId, err := pkg.DecodeAccountIDFromSS58(keyPair.Address)
+	if err != nil {
+		return signature.KeyringPair{}, err
+	}
+
+	return signature.KeyringPair{
+		URI:       keyPair.URI,
+		Address:   pkg.EncodeAddress(accountId, connection.SS58Prefix),
+		PublicKey: append([]byte(nil), keyPair.PublicKey...),
+	}, nil
+}
+
+// Health checks all networks concurrently by reading the contract account balance. A network is
+// unhealthy if the read failed or didn't complete before the context is done.
+func (m *Manager) Health(ctx context.Context) Health {
+	networks := m.Networks()
+	statuses := make([]HealthStatus, len(networks))
+
+	var wg sync.WaitGroup
+	for i, network := range networks {
+		connection, err := m.Get(network)
+		if err != nil {
+			statuses[i] = HealthStatus{Network: network, Error: err.Error(), CheckedAt: time.Now()}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, connection *Connection) {
+			defer wg.Done()
+			statuses[i] = checkHealth(ctx, connection)
+		}(i, connection)
+	}
+	wg.Wait()
+
+	health := Health{Healthy: true, Networks: statuses}
+	for _, status := range statuses {
+		health.Healthy = health.Healthy && status.Healthy
+	}
+
+	return health
+}
+
+func checkHealth(ctx context.Context, connection *Connection) HealthStatus {
+	start := time.Now()
+	status := HealthStatus{Network: connection.Network, CheckedAt: start}
+
+	errC := make(chan error, 1)
+	go func() {
+		contract, err := pkg.DecodeAccountIDFromSS58(connection.Contract.GetContractAddress())
+		if err == nil {
+			_, err = connection.Client.GetAccountInfo(contract)
+		}
+		errC <- err
+	}()
+
+	var err error
+	select {
+	case err = <-errC:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	status.Latency = time.Since(start)
+	if err != nil {
+		status.Error = err.Error()
+	} else {
+		status.Healthy = true
+	}
+
+	return status
+}
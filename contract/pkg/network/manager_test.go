@@ -0,0 +1,125 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+	"github.com/stretchr/testify/assert"
+)
+
+const contractAddress = "5GmomkEekQQ3BipMvjDCG5bXKvzwhUDdXEcQqXRWmdkNCYkL"
+
+type mockedClient struct {
+	pkg.BlockchainClient
+	err   error
+	delay time.Duration
+}
+
+func (m *mockedClient) GetAccountInfo(types.AccountID) (types.AccountInfo, error) {
+	time.Sleep(m.delay)
+	return types.AccountInfo{}, m.err
+}
+
+type mockedContract struct {
+	bucket.DdcBucketContract
+}
+
+func (m *mockedContract) GetContractAddress() string {
+	return contractAddress
+}
+
+func testConnection(network Network, client pkg.BlockchainClient) *Connection {
+	return &Connection{Network: network, SS58Prefix: pkg.CereNetwork, Client: client, Contract: &mockedContract{}}
+}
+
+func TestGet(t *testing.T) {
+	//given
+	manager := CreateNetworkManager()
+	mainnet := testConnection(Mainnet, &mockedClient{})
+	assert.NoError(t, manager.Add(mainnet))
+
+	//when
+	got, err := manager.Get(Mainnet)
+	_, unknownErr := manager.Get(Testnet)
+	addErr := manager.Add(testConnection(Mainnet, &mockedClient{}))
+
+	//then
+	assert.NoError(t, err)
+	assert.Same(t, mainnet, got)
+	assert.True(t, errors.Is(unknownErr, ErrUnknownNetwork))
+	assert.True(t, errors.Is(addErr, ErrNetworkExists))
+}
+
+func TestSigner(t *testing.T) {
+	//given
+	manager := CreateNetworkManager()
+	assert.NoError(t, manager.Add(testConnection(Mainnet, &mockedClient{})))
+	testnet := testConnection(Testnet, &mockedClient{})
+	testnet.SS58Prefix = pkg.SubstrateNetwork
+	assert.NoError(t, manager.Add(testnet))
+	keyPair := signature.TestKeyringPairAlice
+	manager.AddSigner("alice", keyPair)
+
+	//when
+	mainnetSigner, err := manager.Signer("alice", Mainnet)
+	assert.NoError(t, err)
+	testnetSigner, err := manager.Signer("alice", Testnet)
+	assert.NoError(t, err)
+	mainnetSigner.PublicKey[0]++
+	_, unknownErr := manager.Signer("bob", Mainnet)
+
+	//then
+	accountId, _ := pkg.DecodeAccountIDFromSS58(keyPair.Address)
+	assert.Equal(t, pkg.EncodeAddress(accountId, pkg.CereNetwork), mainnetSigner.Address)
+	assert.Equal(t, pkg.EncodeAddress(accountId, pkg.SubstrateNetwork), testnetSigner.Address)
+	assert.Equal(t, keyPair.PublicKey, testnetSigner.PublicKey)
+	assert.True(t, errors.Is(unknownErr, ErrUnknownSigner))
+}
+
+func TestHealth(t *testing.T) {
+	//given
+	manager := CreateNetworkManager()
+	assert.NoError(t, manager.Add(testConnection(Mainnet, &mockedClient{})))
+	assert.NoError(t, manager.Add(testConnection(Testnet, &mockedClient{err: errors.New("connection refused")})))
+	assert.NoError(t, manager.Add(testConnection(Devnet, &mockedClient{delay: time.Second})))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	//when
+	health := manager.Health(ctx)
+
+	//then
+	assert.False(t, health.Healthy)
+	assert.Len(t, health.Networks, 3)
+	byNetwork := make(map[Network]HealthStatus)
+	for _, status := range health.Networks {
+		byNetwork[status.Network] = status
+	}
+	assert.True(t, byNetwork[Mainnet].Healthy)
+	assert.Equal(t, "connection refused", byNetwork[Testnet].Error)
+	assert.False(t, byNetwork[Devnet].Healthy)
+	assert.Equal(t, context.DeadlineExceeded.Error(), byNetwork[Devnet].Error)
+}
+
+func TestConfigWithOptions(t *testing.T) {
+	//given
+	base := Config{RpcUrl: "ws://localhost:9944", ClientOptions: make([]pkg.ClientOption, 1, 4)}
+
+	//when
+	first := base.WithClientOptions(pkg.WithReadYourWrites())
+	second := base.WithClientOptions(pkg.WithContractEventPreFilter(), pkg.WithReadYourWrites())
+	contract := base.WithContractOptions(bucket.WithGetTimeout(time.Second))
+
+	//then
+	assert.Len(t, base.ClientOptions, 1)
+	assert.Len(t, first.ClientOptions, 2)
+	assert.Len(t, second.ClientOptions, 3)
+	assert.Len(t, contract.ContractOptions, 1)
+	assert.Empty(t, base.ContractOptions)
+}
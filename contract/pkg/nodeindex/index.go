@@ -0,0 +1,398 @@
+// Package nodeindex keeps storage and CDN nodes in memory indexed by provider, region and status,
+// so schedulers and dashboards query nodes without reading the contract on every query.
+package nodeindex
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors"
+	log "github.com/sirupsen/logrus"
+)
+
+const pageSize types.U32 = 100
+
+type (
+	Kind string
+
+	Source string
+
+	// EventSource registers contract event handlers, e.g. bucket.DdcBucketContract.
+	EventSource interface {
+		AddContractEventHandler(event string, handler func(interface{})) error
+	}
+
+	// Entry is an indexed node.
+	Entry struct {
+		Key        bucket.AccountId
+		Kind       Kind
+		Source     Source
+		ProviderId bucket.AccountId
+		// Region is the location from the node params, empty if unknown.
+		Region string
+		// Status is the node status in its cluster, bucket.UNKNOWN_NODE_STATUS_IN_CLUSTER if the
+		// node isn't in a cluster or its source has no status.
+		Status bucket.NodeStatusInCluster
+		// ClusterId is the decimal id of the contract cluster or the hex id of the pallet cluster,
+		// empty if the node isn't in a cluster.
+		ClusterId string
+		// FreeResources is the storage node capacity not yet reserved, 0 for other nodes.
+		FreeResources bucket.Resource
+		Params        bucket.Params
+	}
+
+	// PalletNode is a storage node of the DDC nodes pallet, e.g. read with DdcNodesApi of the
+	// blockchain module.
+	PalletNode struct {
+		PubKey     bucket.AccountId
+		ProviderId bucket.AccountId
+		// ClusterId is nil if the node isn't in a cluster.
+		ClusterId *types.H160
+	}
+
+	// Query selects nodes matching all set fields.
+	Query struct {
+		Kind             Kind
+		Source           Source
+		ProviderId       *bucket.AccountId
+		Region           string
+		Status           *bucket.NodeStatusInCluster
+		ClusterId        string
+		MinFreeResources bucket.Resource
+	}
+
+	// Index is safe for concurrent use.
+	Index struct {
+		nodes bucket.NodeReader
+
+		mu         sync.RWMutex
+		entries    map[bucket.AccountId]Entry
+		byProvider map[bucket.AccountId]map[bucket.AccountId]struct{}
+		byRegion   map[string]map[bucket.AccountId]struct{}
+		byStatus   map[bucket.NodeStatusInCluster]map[bucket.AccountId]struct{}
+	}
+)
+
+const (
+	KindStorage Kind = "storage"
+	KindCdn     Kind = "cdn"
+
+	SourceContract Source = "contract"
+	SourcePallet   Source = "pallet"
+)
+
+// CreateIndex creates an empty index refreshing contract nodes with the reader.
+func CreateIndex(nodes bucket.NodeReader) *Index {
+	return &Index{
+		nodes:      nodes,
+		entries:    make(map[bucket.AccountId]Entry),
+		byProvider: make(map[bucket.AccountId]map[bucket.AccountId]struct{}),
+		byRegion:   make(map[string]map[bucket.AccountId]struct{}),
+		byStatus:   make(map[bucket.NodeStatusInCluster]map[bucket.AccountId]struct{}),
+	}
+}
+
+// Load reads all storage and CDN nodes of the contract page by page.
+func (x *Index) Load() error {
+	for offset := types.U32(0); ; offset += pageSize {
+		page, err := x.nodes.NodeList(offset, pageSize, types.NewOptionAccountIDEmpty())
+		if err != nil {
+			return fmt.Errorf("list nodes: %w", err)
+		}
+		for i := range page.Nodes {
+			x.put(storageEntry(&page.Nodes[i]))
+		}
+		if len(page.Nodes) == 0 || offset+pageSize >= page.Total {
+			break
+		}
+	}
+
+	for offset := types.U32(0); ; offset += pageSize {
+		page, err := x.nodes.CdnNodeList(offset, pageSize, types.NewOptionAccountIDEmpty())
+		if err != nil {
+			return fmt.Errorf("list cdn nodes: %w", err)
+		}
+		for i := range page.Nodes {
+			x.put(cdnEntry(&page.Nodes[i]))
+		}
+		if len(page.Nodes) == 0 || offset+pageSize >= page.Total {
+			break
+		}
+	}
+
+	return nil
+}
+
+// HookContractEvents refreshes nodes changed by contract events and removes removed nodes.
+func (x *Index) HookContractEvents(events EventSource) error {
+	refreshNode := func(nodeKey bucket.NodeKey) {
+		x.refreshed(nodeKey, x.RefreshNode(nodeKey))
+	}
+	refreshCdnNode := func(nodeKey bucket.CdnNodeKey) {
+		x.refreshed(nodeKey, x.RefreshCdnNode(nodeKey))
+	}
+
+	hooks := map[string]func(interface{}){
+		bucket.NodeCreatedEventId:              func(e interface{}) { refreshNode(e.(*bucket.NodeCreatedEvent).NodeKey) },
+		bucket.NodeParamsSetEventId:            func(e interface{}) { refreshNode(e.(*bucket.NodeParamsSetEvent).NodeKey) },
+		bucket.NodeOwnershipTransferredEventId: func(e interface{}) { refreshNode(e.(*bucket.NodeOwnershipTransferredEvent).NodeKey) },
+		bucket.ClusterNodeAddedEventId:         func(e interface{}) { refreshNode(e.(*bucket.ClusterNodeAddedEvent).NodeKey) },
+		bucket.ClusterNodeRemovedEventId:       func(e interface{}) { refreshNode(e.(*bucket.ClusterNodeRemovedEvent).NodeKey) },
+		bucket.ClusterNodeResetEventId:         func(e interface{}) { refreshNode(e.(*bucket.ClusterNodeResetEvent).NodeKey) },
+		bucket.ClusterNodeReplacedEventId:      func(e interface{}) { refreshNode(e.(*bucket.ClusterNodeReplacedEvent).NodeKey) },
+		bucket.ClusterNodeStatusSetEventId:     func(e interface{}) { refreshNode(e.(*bucket.ClusterNodeStatusSetEvent).NodeKey) },
+		bucket.NodeRemovedEventId:              func(e interface{}) { x.Remove(e.(*bucket.NodeRemovedEvent).NodeKey) },
+
+		bucket.CdnNodeCreatedEventId:              func(e interface{}) { refreshCdnNode(e.(*bucket.CdnNodeCreatedEvent).CdnNodeKey) },
+		bucket.CdnNodeParamsSetEventId:            func(e interface{}) { refreshCdnNode(e.(*bucket.CdnNodeParamsSetEvent).CdnNodeKey) },
+		bucket.CdnNodeOwnershipTransferredEventId: func(e interface{}) { refreshCdnNode(e.(*bucket.CdnNodeOwnershipTransferredEvent).CdnNodeKey) },
+		bucket.ClusterCdnNodeAddedEventId:         func(e interface{}) { refreshCdnNode(e.(*bucket.ClusterCdnNodeAddedEvent).CdnNodeKey) },
+		bucket.ClusterCdnNodeRemovedEventId:       func(e interface{}) { refreshCdnNode(e.(*bucket.ClusterCdnNodeRemovedEvent).CdnNodeKey) },
+		bucket.ClusterCdnNodeStatusSetEventId:     func(e interface{}) { refreshCdnNode(e.(*bucket.ClusterCdnNodeStatusSetEvent).CdnNodeKey) },
+		bucket.CdnNodeRemovedEventId:              func(e interface{}) { x.Remove(e.(*bucket.CdnNodeRemovedEvent).CdnNodeKey) },
+	}
+	for event, handler := range hooks {
+		if err := events.AddContractEventHandler(event, handler); err != nil {
+			return fmt.Errorf("unable to hook event %s: %w", event, err)
+		}
+	}
+
+	return nil
+}
+
+// RefreshNode reads the storage node from the contract and indexes it again.
+func (x *Index) RefreshNode(nodeKey bucket.NodeKey) error {
+	node, err := x.nodes.NodeGet(nodeKey)
+	if err != nil {
+		return err
+	}
+	x.put(storageEntry(node))
+
+	return nil
+}
+
+// RefreshCdnNode reads the CDN node from the contract and indexes it again.
+func (x *Index) RefreshCdnNode(nodeKey bucket.CdnNodeKey) error {
+	node, err := x.nodes.CdnNodeGet(nodeKey)
+	if err != nil {
+		return err
+	}
+	x.put(cdnEntry(node))
+
+	return nil
+}
+
+// refreshed drops the node if it doesn't exist anymore and keeps the indexed one on other errors.
+func (x *Index) refreshed(key bucket.AccountId, err error) {
+	switch {
+	case err == nil:
+	case ddcerrors.Is(err, ddcerrors.CodeNotFound):
+		x.Remove(key)
+	default:
+		log.WithError(err).WithField("node", codec.HexEncodeToString(key[:])).Warn("Can't refresh indexed node")
+	}
+}
+
+// PutPalletNode indexes a storage node of the DDC nodes pallet, which has no region, status and
+// free resources.
+func (x *Index) PutPalletNode(node PalletNode) {
+	entry := Entry{
+		Key:        node.PubKey,
+		Kind:       KindStorage,
+		Source:     SourcePallet,
+		ProviderId: node.ProviderId,
+		Status:     bucket.UNKNOWN_NODE_STATUS_IN_CLUSTER,
+	}
+	if node.ClusterId != nil {
+		entry.ClusterId = codec.HexEncodeToString(node.ClusterId[:])
+	}
+	x.put(entry)
+}
+
+// Remove drops the node from the index.
+func (x *Index) Remove(key bucket.AccountId) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(key)
+}
+
+// Get returns the indexed node.
+func (x *Index) Get(key bucket.AccountId) (Entry, bool) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	entry, ok := x.entries[key]
+	return entry, ok
+}
+
+// Len returns the number of indexed nodes.
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	return len(x.entries)
+}
+
+// Find returns nodes matching the query ordered by the most free resources first and by key.
+func (x *Index) Find(q Query) []Entry {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	// Start with the smallest indexed candidate set.
+	var candidates map[bucket.AccountId]struct{}
+	narrow := func(set map[bucket.AccountId]struct{}) {
+		if candidates == nil || len(set) < len(candidates) {
+			candidates = set
+		}
+	}
+	if q.ProviderId != nil {
+		narrow(x.byProvider[*q.ProviderId])
+		if candidates == nil {
+			return nil
+		}
+	}
+	if q.Region != "" {
+		narrow(x.byRegion[q.Region])
+		if candidates == nil {
+			return nil
+		}
+	}
+	if q.Status != nil {
+		narrow(x.byStatus[*q.Status])
+		if candidates == nil {
+			return nil
+		}
+	}
+
+	var result []Entry
+	match := func(key bucket.AccountId) {
+		if entry := x.entries[key]; q.matches(entry) {
+			result = append(result, entry)
+		}
+	}
+	if candidates != nil {
+		for key := range candidates {
+			match(key)
+		}
+	} else {
+		for key := range x.entries {
+			match(key)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].FreeResources != result[j].FreeResources {
+			return result[i].FreeResources > result[j].FreeResources
+		}
+		return string(result[i].Key[:]) < string(result[j].Key[:])
+	})
+
+	return result
+}
+
+func (q Query) matches(e Entry) bool {
+	return (q.Kind == "" || e.Kind == q.Kind) &&
+		(q.Source == "" || e.Source == q.Source) &&
+		(q.ProviderId == nil || e.ProviderId == *q.ProviderId) &&
+		(q.Region == "" || e.Region == q.Region) &&
+		(q.Status == nil || e.Status == *q.Status) &&
+		(q.ClusterId == "" || e.ClusterId == q.ClusterId) &&
+		e.FreeResources >= q.MinFreeResources
+}
+
+func (x *Index) put(entry Entry) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.remove(entry.Key)
+	x.entries[entry.Key] = entry
+	add(x.byProvider, entry.ProviderId, entry.Key)
+	add(x.byRegion, entry.Region, entry.Key)
+	add(x.byStatus, entry.Status, entry.Key)
+}
+
+func (x *Index) remove(key bucket.AccountId) {
+	entry, ok := x.entries[key]
+	if !ok {
+		return
+	}
+	delete(x.entries, key)
+	drop(x.byProvider, entry.ProviderId, key)
+	drop(x.byRegion, entry.Region, key)
+	drop(x.byStatus, entry.Status, key)
+}
+
+func add[K comparable](index map[K]map[bucket.AccountId]struct{}, value K, key bucket.AccountId) {
+	set, ok := index[value]
+	if !ok {
+		set = make(map[bucket.AccountId]struct{})
+		index[value] = set
+	}
+	set[key] = struct{}{}
+}
+
+func drop[K comparable](index map[K]map[bucket.AccountId]struct{}, value K, key bucket.AccountId) {
+	delete(index[value], key)
+	if len(index[value]) == 0 {
+		delete(index, value)
+	}
+}
+
+func storageEntry(node *bucket.NodeInfo) Entry {
+	return Entry{
+		Key:           node.Key,
+		Kind:          KindStorage,
+		Source:        SourceContract,
+		ProviderId:    node.Node.ProviderId,
+		Region:        readRegion(node.Node.Params),
+		Status:        status(node.GetStatusInCluster()),
+		ClusterId:     clusterId(node.Node.ClusterId),
+		FreeResources: node.Node.FreeResources,
+		Params:        node.Node.Params,
+	}
+}
+
+func cdnEntry(node *bucket.CdnNodeInfo) Entry {
+	return Entry{
+		Key:        node.Key,
+		Kind:       KindCdn,
+		Source:     SourceContract,
+		ProviderId: node.Node.ProviderId,
+		Region:     readRegion(node.Node.Params),
+		Status:     status(node.GetStatusInCluster()),
+		ClusterId:  clusterId(node.Node.ClusterId),
+		Params:     node.Node.Params,
+	}
+}
+
+func status(status bucket.NodeStatusInCluster, err error) bucket.NodeStatusInCluster {
+	if err != nil {
+		return bucket.UNKNOWN_NODE_STATUS_IN_CLUSTER
+	}
+	return status
+}
+
+func clusterId(id types.OptionU32) string {
+	if ok, value := id.Unwrap(); ok {
+		return strconv.FormatUint(uint64(value), 10)
+	}
+	return ""
+}
+
+// readRegion returns the location of JSON node params, empty if the params have none.
+func readRegion(params bucket.Params) string {
+	var p struct {
+		Location string `json:"location"`
+	}
+	if err := json.Unmarshal([]byte(params), &p); err != nil {
+		return ""
+	}
+	return p.Location
+}
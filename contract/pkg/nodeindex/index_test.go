@@ -0,0 +1,123 @@
+package nodeindex
+
+import (
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockedNodes struct {
+	bucket.NodeReader
+	nodes    []bucket.NodeInfo
+	cdnNodes []bucket.CdnNodeInfo
+}
+
+func (m *mockedNodes) NodeList(offset types.U32, limit types.U32, _ types.OptionAccountID) (*bucket.NodeListInfo, error) {
+	return &bucket.NodeListInfo{Nodes: page(m.nodes, offset, limit), Total: types.U32(len(m.nodes))}, nil
+}
+
+func (m *mockedNodes) CdnNodeList(offset types.U32, limit types.U32, _ types.OptionAccountID) (*bucket.CdnNodeListInfo, error) {
+	return &bucket.CdnNodeListInfo{Nodes: page(m.cdnNodes, offset, limit), Total: types.U32(len(m.cdnNodes))}, nil
+}
+
+func (m *mockedNodes) NodeGet(nodeKey bucket.NodeKey) (*bucket.NodeInfo, error) {
+	for i := range m.nodes {
+		if m.nodes[i].Key == nodeKey {
+			return &m.nodes[i], nil
+		}
+	}
+	return nil, bucket.ErrNodeDoesNotExist
+}
+
+func page[T any](items []T, offset types.U32, limit types.U32) []T {
+	if int(offset) >= len(items) {
+		return nil
+	}
+	end := int(offset + limit)
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
+
+type mockedEvents map[string]func(interface{})
+
+func (m mockedEvents) AddContractEventHandler(event string, handler func(interface{})) error {
+	m[event] = handler
+	return nil
+}
+
+func storageNode(key byte, provider byte, location string, status uint8, free bucket.Resource) bucket.NodeInfo {
+	return bucket.NodeInfo{
+		Key: bucket.AccountId{key},
+		Node: bucket.Node{
+			ProviderId:      bucket.AccountId{provider},
+			FreeResources:   free,
+			Params:          `{"url":"https://node","location":"` + location + `"}`,
+			ClusterId:       types.NewOptionU32(1),
+			StatusInCluster: types.NewOptionU8(types.U8(status)),
+		},
+	}
+}
+
+func keys(entries []Entry) []byte {
+	var result []byte
+	for _, e := range entries {
+		result = append(result, e.Key[0])
+	}
+	return result
+}
+
+func TestFind(t *testing.T) {
+	//given
+	nodes := &mockedNodes{
+		nodes: []bucket.NodeInfo{
+			storageNode(1, 10, "eu", bucket.ACTIVE, 50),
+			storageNode(2, 10, "eu", bucket.ACTIVE, 200),
+			storageNode(3, 10, "us", bucket.ACTIVE, 300),
+			storageNode(4, 10, "eu", bucket.OFFLINE, 400),
+			storageNode(5, 20, "eu", bucket.ACTIVE, 500),
+		},
+		cdnNodes: []bucket.CdnNodeInfo{{Key: bucket.AccountId{6}, Node: bucket.CdnNode{ProviderId: bucket.AccountId{10}, Params: `{"location":"eu"}`}}},
+	}
+	index := CreateIndex(nodes)
+	provider := bucket.AccountId{10}
+	active := bucket.NodeStatusInCluster(bucket.ACTIVE)
+
+	//when
+	err := index.Load()
+	found := index.Find(Query{Kind: KindStorage, ProviderId: &provider, Region: "eu", Status: &active, MinFreeResources: 100})
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, 6, index.Len())
+	assert.Equal(t, []byte{2}, keys(found))
+	assert.Equal(t, []byte{4, 2, 1, 6}, keys(index.Find(Query{ProviderId: &provider, Region: "eu"})))
+	assert.Empty(t, index.Find(Query{Region: "asia"}))
+	cluster, _ := index.Get(bucket.AccountId{1})
+	assert.Equal(t, "1", cluster.ClusterId)
+}
+
+func TestHookContractEvents(t *testing.T) {
+	//given
+	nodes := &mockedNodes{nodes: []bucket.NodeInfo{storageNode(1, 10, "eu", bucket.ACTIVE, 50)}}
+	index := CreateIndex(nodes)
+	events := mockedEvents{}
+	assert.NoError(t, index.HookContractEvents(events))
+	assert.NoError(t, index.Load())
+
+	//when
+	nodes.nodes[0] = storageNode(1, 10, "us", bucket.OFFLINE, 50)
+	events[bucket.ClusterNodeStatusSetEventId](&bucket.ClusterNodeStatusSetEvent{NodeKey: bucket.AccountId{1}})
+	changed, _ := index.Get(bucket.AccountId{1})
+	nodes.nodes = nil
+	events[bucket.NodeParamsSetEventId](&bucket.NodeParamsSetEvent{NodeKey: bucket.AccountId{1}})
+
+	//then
+	assert.Equal(t, "us", changed.Region)
+	assert.Equal(t, bucket.NodeStatusInCluster(bucket.OFFLINE), changed.Status)
+	assert.Equal(t, 0, index.Len())
+	assert.Empty(t, index.Find(Query{Region: "eu"}))
+}
@@ -0,0 +1,218 @@
+package pkg
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultStuckAfterBlocks = 10
+	defaultMaxNonceGap      = 16
+)
+
+type (
+	// NonceGapAction is how a nonce gap of the signer account is filled.
+	NonceGapAction int
+
+	// NonceGapPolicy configures recovery of nonce gaps. A transaction is stuck on a nonce gap if an
+	// earlier transaction of the same account was dropped from the transaction pool, e.g. by
+	// another process sharing the account, so the pool keeps the transaction as a future one.
+	NonceGapPolicy struct {
+		Action NonceGapAction
+		// StuckAfterBlocks is the number of blocks a transaction waits for inclusion before the
+		// account is checked for nonce gaps, and between further checks. 10 if 0.
+		StuckAfterBlocks uint32
+		// MaxGap is the maximum number of missing nonces filled at once, 16 if 0. Larger gaps are
+		// logged and left to the operator.
+		MaxGap uint64
+	}
+
+	sentExtrinsic struct {
+		signer    signature.KeyringPair
+		extrinsic types.Extrinsic
+	}
+
+	// sentExtrinsics remembers extrinsics waiting for inclusion by account and nonce, so they can
+	// be broadcast again if the transaction pool dropped them.
+	sentExtrinsics struct {
+		mu       sync.Mutex
+		accounts map[types.AccountID]map[uint64]sentExtrinsic
+	}
+)
+
+const (
+	// NonceGapRemark fills missing nonces with System.remark transactions.
+	NonceGapRemark NonceGapAction = iota
+	// NonceGapRebroadcast submits again the dropped transactions of the client and fills nonces of
+	// transactions the client doesn't know with System.remark transactions.
+	NonceGapRebroadcast
+)
+
+// WithNonceGapRecovery enables detection and recovery of nonce gaps of accounts signing
+// transactions while the transactions wait for inclusion.
+func WithNonceGapRecovery(policy NonceGapPolicy) ClientOption {
+	return func(b *blockchainClient) {
+		b.nonceGapPolicy = &policy
+	}
+}
+
+func (p *NonceGapPolicy) stuckAfterBlocks() uint32 {
+	switch {
+	case p == nil:
+		return 0
+	case p.StuckAfterBlocks == 0:
+		return defaultStuckAfterBlocks
+	default:
+		return p.StuckAfterBlocks
+	}
+}
+
+func (p *NonceGapPolicy) maxGap() uint64 {
+	if p.MaxGap == 0 {
+		return defaultMaxNonceGap
+	}
+	return p.MaxGap
+}
+
+// recoverNonceGap fills nonces between the account nonce and the nonce of the waiting extrinsic
+// which have no transaction in the transaction pool.
+func (b *blockchainClient) recoverNonceGap(ctx context.Context, extrinsic types.Extrinsic) error {
+	account, nonce := extrinsicSender(extrinsic)
+	sent, ok := b.sentExtrinsics.get(account, nonce)
+	if !ok {
+		return nil
+	}
+
+	accountNonce, err := withRetryOnClosedNetwork(b, func() (uint64, error) {
+		return b.accountNonce(sent.signer)
+	})
+	if err != nil {
+		return err
+	}
+	b.sentExtrinsics.forgetBefore(account, accountNonce)
+
+	pending, err := withRetryOnClosedNetwork(b, func() ([]types.Extrinsic, error) {
+		return b.RPC.Author.PendingExtrinsics()
+	})
+	if err != nil {
+		return errors.Wrap(err, "get pending extrinsics")
+	}
+	var pendingNonces []uint64
+	for _, ext := range pending {
+		if sender, n := extrinsicSender(ext); sender == account {
+			pendingNonces = append(pendingNonces, n)
+		}
+	}
+
+	gaps := nonceGaps(accountNonce, nonce, pendingNonces)
+	if uint64(len(gaps)) > b.nonceGapPolicy.maxGap() {
+		return errors.Errorf("nonce gap of %d transactions before nonce %d of %s is too large to fill", len(gaps), nonce, sent.signer.Address)
+	}
+
+	for _, gap := range gaps {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		fill, err := b.gapFiller(ctx, sent.signer, account, gap)
+		if err != nil {
+			return err
+		}
+		if _, err := b.RPC.Author.SubmitExtrinsic(fill); err != nil {
+			return errors.Wrapf(err, "fill nonce %d of %s", gap, sent.signer.Address)
+		}
+		log.WithField("account", sent.signer.Address).WithField("nonce", gap).Info("Filled nonce gap")
+	}
+
+	return nil
+}
+
+// gapFiller returns the remembered extrinsic with the nonce if the policy rebroadcasts them or a
+// System.remark transaction signed with the nonce.
+func (b *blockchainClient) gapFiller(ctx context.Context, signer signature.KeyringPair, account types.AccountID, nonce uint64) (types.Extrinsic, error) {
+	if b.nonceGapPolicy.Action == NonceGapRebroadcast {
+		if sent, ok := b.sentExtrinsics.get(account, nonce); ok {
+			return sent.extrinsic, nil
+		}
+	}
+
+	return b.signExtrinsic(ctx, &Transaction{Call: "System.remark", Args: []interface{}{[]byte{}}, Signer: signer}, nonce)
+}
+
+// nonceGaps returns nonces from the account nonce up to the nonce which have no pending transaction.
+func nonceGaps(accountNonce uint64, nonce uint64, pending []uint64) []uint64 {
+	inPool := make(map[uint64]bool, len(pending))
+	for _, n := range pending {
+		inPool[n] = true
+	}
+
+	var gaps []uint64
+	for n := accountNonce; n < nonce; n++ {
+		if !inPool[n] {
+			gaps = append(gaps, n)
+		}
+	}
+
+	return gaps
+}
+
+func extrinsicSender(extrinsic types.Extrinsic) (types.AccountID, uint64) {
+	if !extrinsic.IsSigned() || !extrinsic.Signature.Signer.IsID {
+		return types.AccountID{}, 0
+	}
+	nonce := big.Int(extrinsic.Signature.Nonce)
+
+	return extrinsic.Signature.Signer.AsID, nonce.Uint64()
+}
+
+func (s *sentExtrinsics) remember(policy *NonceGapPolicy, signer signature.KeyringPair, extrinsic types.Extrinsic) {
+	if policy == nil {
+		return
+	}
+	account, nonce := extrinsicSender(extrinsic)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.accounts == nil {
+		s.accounts = make(map[types.AccountID]map[uint64]sentExtrinsic)
+	}
+	if s.accounts[account] == nil {
+		s.accounts[account] = make(map[uint64]sentExtrinsic)
+	}
+	s.accounts[account][nonce] = sentExtrinsic{signer: signer, extrinsic: extrinsic}
+}
+
+func (s *sentExtrinsics) get(account types.AccountID, nonce uint64) (sentExtrinsic, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sent, ok := s.accounts[account][nonce]
+	return sent, ok
+}
+
+func (s *sentExtrinsics) forget(extrinsic types.Extrinsic) {
+	account, nonce := extrinsicSender(extrinsic)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.accounts[account], nonce)
+	if len(s.accounts[account]) == 0 {
+		delete(s.accounts, account)
+	}
+}
+
+// forgetBefore forgets extrinsics of the account with nonces already used on chain.
+func (s *sentExtrinsics) forgetBefore(account types.AccountID, accountNonce uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for nonce := range s.accounts[account] {
+		if nonce < accountNonce {
+			delete(s.accounts[account], nonce)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+const (
+	StepClusterCreate     Step = "cluster create"
+	StepClusterParams     Step = "cluster params"
+	StepManagerPermission Step = "cluster manager permission"
+	StepNodeStatus        Step = "node status"
+	StepCdnNodeCreate     Step = "cdn node create"
+	StepClusterAddCdnNode Step = "cluster add cdn node"
+	StepCdnNodeStatus     Step = "cdn node status"
+)
+
+var (
+	ErrNodeInOtherCluster = errors.New("node is added to another cluster")
+)
+
+// ClusterManifest declares a cluster with its storage and CDN nodes.
+type ClusterManifest struct {
+	// ClusterId is the cluster to bootstrap, a new cluster is created if nil. Set it to the id of
+	// the cluster created by the first run to make following runs reconcile the same cluster.
+	ClusterId        *bucket.ClusterId
+	Manager          signature.KeyringPair
+	Params           bucket.Params
+	ResourcePerVNode bucket.Resource
+	Nodes            []ManifestNode
+	CdnNodes         []ManifestCdnNode
+}
+
+// ManifestNode declares a storage node of the cluster.
+type ManifestNode struct {
+	// Provider creates the node and grants the cluster manager the permission to add it.
+	Provider signature.KeyringPair
+	NodeKey  bucket.NodeKey
+	Params   bucket.NodeParams
+	Capacity bucket.StorageGb
+	Rent     bucket.Rent
+	VNodes   [][]bucket.Token
+	// Status is the status of the node in the cluster, e.g. bucket.NodeStatusActive.
+	Status bucket.NodeStatusInCluster
+}
+
+// ManifestCdnNode declares a CDN node of the cluster.
+type ManifestCdnNode struct {
+	// Provider creates the node and grants the cluster manager the permission to add it.
+	Provider signature.KeyringPair
+	NodeKey  bucket.CdnNodeKey
+	Params   bucket.CDNNodeParams
+	// Status is the status of the node in the cluster, e.g. bucket.NodeStatusActive.
+	Status bucket.NodeStatusInCluster
+}
+
+type ClusterBootstrapResult struct {
+	ClusterId bucket.ClusterId
+	// Applied are the steps submitted to the chain, the steps already satisfied on chain are skipped.
+	Applied []Step
+	// Cluster is the cluster read after all steps completed.
+	Cluster *bucket.ClusterInfo
+}
+
+// BootstrapCluster creates the cluster, creates the nodes and adds them to the cluster with their
+// virtual nodes, sets the node statuses and grants the cluster manager the permissions it needs.
+// Steps already satisfied on chain are skipped, so it's safe to run again with the same manifest,
+// e.g. after a failure. Nodes are never removed or moved from another cluster. On failure it
+// returns OnboardingError.
+func BootstrapCluster(ctx context.Context, client pkg.BlockchainClient, contract bucket.DdcBucketContract, manifest ClusterManifest) (*ClusterBootstrapResult, error) {
+	b := &bootstrap{contract: contract, manifest: manifest, result: &ClusterBootstrapResult{}}
+
+	managerId, err := types.NewAccountID(manifest.Manager.PublicKey)
+	if err != nil {
+		return nil, b.fail(StepClusterCreate, err)
+	}
+	b.managerId = *managerId
+
+	if err := b.cluster(ctx, client); err != nil {
+		return nil, err
+	}
+	for _, node := range manifest.Nodes {
+		if err := b.node(ctx, node); err != nil {
+			return nil, err
+		}
+	}
+	for _, node := range manifest.CdnNodes {
+		if err := b.cdnNode(ctx, node); err != nil {
+			return nil, err
+		}
+	}
+
+	b.result.Cluster, err = contract.ClusterGet(b.result.ClusterId)
+	if err != nil {
+		return nil, b.fail(StepClusterCreate, err)
+	}
+
+	return b.result, nil
+}
+
+type bootstrap struct {
+	progress
+	contract  bucket.DdcBucketContract
+	manifest  ClusterManifest
+	managerId bucket.AccountId
+	result    *ClusterBootstrapResult
+}
+
+func (b *bootstrap) apply(step Step, rollback string) {
+	b.complete(step, rollback)
+	b.result.Applied = append(b.result.Applied, step)
+}
+
+func (b *bootstrap) cluster(ctx context.Context, client pkg.BlockchainClient) error {
+	if b.manifest.ClusterId == nil {
+		blockHash, err := b.contract.ClusterCreate(ctx, b.manifest.Manager, b.manifest.Params, b.manifest.ResourcePerVNode)
+		if err != nil {
+			return b.fail(StepClusterCreate, err)
+		}
+		created, err := findEvent(client, b.contract, blockHash, func(e *bucket.ClusterCreatedEvent) bool {
+			return e.AccountId == b.managerId
+		})
+		if err != nil {
+			return b.fail(StepClusterCreate, err)
+		}
+		b.result.ClusterId = created.ClusterId
+		b.apply(StepClusterCreate, fmt.Sprintf("remove cluster %d with ClusterRemove signed by the manager", created.ClusterId))
+		return nil
+	}
+
+	b.result.ClusterId = *b.manifest.ClusterId
+	cluster, err := b.contract.ClusterGet(b.result.ClusterId)
+	if err != nil {
+		return b.fail(StepClusterCreate, err)
+	}
+	if cluster.Cluster.Params != b.manifest.Params {
+		if err := b.contract.ClusterSetParams(ctx, b.manifest.Manager, b.result.ClusterId, b.manifest.Params); err != nil {
+			return b.fail(StepClusterParams, err)
+		}
+		b.apply(StepClusterParams, "restore the previous params with ClusterSetParams")
+	}
+
+	return nil
+}
+
+func (b *bootstrap) node(ctx context.Context, node ManifestNode) error {
+	info, err := b.contract.NodeGet(node.NodeKey)
+	if errors.Is(err, bucket.ErrNodeDoesNotExist) {
+		if _, err := b.contract.NodeCreate(ctx, node.Provider, node.NodeKey, node.Params, node.Capacity, node.Rent); err != nil {
+			return b.fail(StepNodeCreate, err)
+		}
+		b.apply(StepNodeCreate, "remove the node with NodeRemove signed by the provider")
+		info, err = b.contract.NodeGet(node.NodeKey)
+	}
+	if err != nil {
+		return b.fail(StepNodeCreate, err)
+	}
+
+	if ok, clusterId := info.Node.ClusterId.Unwrap(); !ok {
+		if err := b.contract.GrantTrustedManagerPermission(ctx, node.Provider, b.managerId); err != nil {
+			return b.fail(StepManagerPermission, err)
+		}
+		b.apply(StepManagerPermission, "revoke the cluster manager permission with RevokeTrustedManagerPermission signed by the provider")
+
+		if err := b.contract.ClusterAddNode(ctx, b.manifest.Manager, b.result.ClusterId, node.NodeKey, node.VNodes); err != nil {
+			return b.fail(StepClusterAddNode, err)
+		}
+		b.apply(StepClusterAddNode, "remove the node from the cluster with ClusterRemoveNode signed by the manager")
+		if info, err = b.contract.NodeGet(node.NodeKey); err != nil {
+			return b.fail(StepClusterAddNode, err)
+		}
+	} else if clusterId != b.result.ClusterId {
+		return b.fail(StepClusterAddNode, fmt.Errorf("%w: storage node %s in cluster %d", ErrNodeInOtherCluster, node.NodeKey.ToHexString(), clusterId))
+	}
+
+	if status, err := info.GetStatusInCluster(); err != nil || status != node.Status {
+		if err := b.contract.ClusterSetNodeStatus(ctx, b.manifest.Manager, b.result.ClusterId, node.NodeKey, node.Status); err != nil {
+			return b.fail(StepNodeStatus, err)
+		}
+		b.apply(StepNodeStatus, "")
+	}
+
+	return nil
+}
+
+func (b *bootstrap) cdnNode(ctx context.Context, node ManifestCdnNode) error {
+	info, err := b.contract.CdnNodeGet(node.NodeKey)
+	if errors.Is(err, bucket.ErrCdnNodeDoesNotExist) {
+		if err := b.contract.CdnNodeCreate(ctx, node.Provider, node.NodeKey, node.Params); err != nil {
+			return b.fail(StepCdnNodeCreate, err)
+		}
+		b.apply(StepCdnNodeCreate, "remove the cdn node with CdnNodeRemove signed by the provider")
+		info, err = b.contract.CdnNodeGet(node.NodeKey)
+	}
+	if err != nil {
+		return b.fail(StepCdnNodeCreate, err)
+	}
+
+	if ok, clusterId := info.Node.ClusterId.Unwrap(); !ok {
+		if err := b.contract.GrantTrustedManagerPermission(ctx, node.Provider, b.managerId); err != nil {
+			return b.fail(StepManagerPermission, err)
+		}
+		b.apply(StepManagerPermission, "revoke the cluster manager permission with RevokeTrustedManagerPermission signed by the provider")
+
+		if err := b.contract.ClusterAddCdnNode(ctx, b.manifest.Manager, b.result.ClusterId, node.NodeKey); err != nil {
+			return b.fail(StepClusterAddCdnNode, err)
+		}
+		b.apply(StepClusterAddCdnNode, "remove the cdn node from the cluster with ClusterRemoveCdnNode signed by the manager")
+		if info, err = b.contract.CdnNodeGet(node.NodeKey); err != nil {
+			return b.fail(StepClusterAddCdnNode, err)
+		}
+	} else if clusterId != b.result.ClusterId {
+		return b.fail(StepClusterAddCdnNode, fmt.Errorf("%w: cdn node %s in cluster %d", ErrNodeInOtherCluster, node.NodeKey.ToHexString(), clusterId))
+	}
+
+	if status, err := info.GetStatusInCluster(); err != nil || status != node.Status {
+		if err := b.contract.ClusterSetCdnNodeStatus(ctx, b.manifest.Manager, b.result.ClusterId, node.NodeKey, node.Status); err != nil {
+			return b.fail(StepCdnNodeStatus, err)
+		}
+		b.apply(StepCdnNodeStatus, "")
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package onboarding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+func (m *mockedDdcBucketContract) ClusterGet(clusterId bucket.ClusterId) (*bucket.ClusterInfo, error) {
+	args := m.Called(clusterId)
+	return args.Get(0).(*bucket.ClusterInfo), args.Error(1)
+}
+
+func (m *mockedDdcBucketContract) ClusterSetParams(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, params bucket.Params) error {
+	return m.Called(clusterId, params).Error(0)
+}
+
+func (m *mockedDdcBucketContract) ClusterSetNodeStatus(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, nodeKey bucket.NodeKey, statusInCluster bucket.NodeStatusInCluster) error {
+	return m.Called(clusterId, nodeKey, statusInCluster).Error(0)
+}
+
+func (m *mockedDdcBucketContract) CdnNodeGet(nodeKey bucket.CdnNodeKey) (*bucket.CdnNodeInfo, error) {
+	args := m.Called(nodeKey)
+	return args.Get(0).(*bucket.CdnNodeInfo), args.Error(1)
+}
+
+func (m *mockedDdcBucketContract) CdnNodeCreate(ctx context.Context, keyPair signature.KeyringPair, nodeKey bucket.CdnNodeKey, params bucket.CDNNodeParams) error {
+	return m.Called(nodeKey).Error(0)
+}
+
+func (m *mockedDdcBucketContract) ClusterAddCdnNode(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, nodeKey bucket.CdnNodeKey) error {
+	return m.Called(clusterId, nodeKey).Error(0)
+}
+
+func TestBootstrapCluster(t *testing.T) {
+	//given
+	clusterId := bucket.ClusterId(5)
+	managerId := bucket.AccountId{2}
+	joinedKey, newKey, cdnKey := bucket.NodeKey{1}, bucket.NodeKey{3}, bucket.CdnNodeKey{4}
+	joined := &bucket.NodeInfo{Key: joinedKey, Node: bucket.Node{ClusterId: types.NewOptionU32(clusterId), StatusInCluster: types.NewOptionU8(bucket.ACTIVE)}}
+	added := &bucket.NodeInfo{Key: newKey, Node: bucket.Node{ClusterId: types.NewOptionU32(clusterId), StatusInCluster: types.NewOptionU8(bucket.ADDING)}}
+	cdnNode := &bucket.CdnNodeInfo{Key: cdnKey, Node: bucket.CdnNode{ClusterId: types.NewOptionU32(clusterId), StatusInCluster: types.NewOptionU8(bucket.ACTIVE)}}
+	cluster := &bucket.ClusterInfo{ClusterId: clusterId, Cluster: bucket.Cluster{Params: `{"replicationFactor":3}`}}
+
+	contract := &mockedDdcBucketContract{}
+	contract.On("ClusterGet", clusterId).Return(cluster, nil)
+	contract.On("NodeGet", joinedKey).Return(joined, nil).Once()
+	contract.On("NodeGet", newKey).Return((*bucket.NodeInfo)(nil), bucket.ErrNodeDoesNotExist).Once()
+	contract.On("NodeCreate", newKey).Return(types.Hash{}, nil).Once()
+	contract.On("NodeGet", newKey).Return(&bucket.NodeInfo{Key: newKey}, nil).Once()
+	contract.On("GrantTrustedManagerPermission", managerId).Return(nil).Once()
+	contract.On("ClusterAddNode", clusterId, newKey).Return(nil).Once()
+	contract.On("NodeGet", newKey).Return(added, nil).Once()
+	contract.On("ClusterSetNodeStatus", clusterId, newKey, bucket.NodeStatusActive).Return(nil).Once()
+	contract.On("CdnNodeGet", cdnKey).Return(cdnNode, nil).Once()
+
+	manifest := ClusterManifest{
+		ClusterId: &clusterId,
+		Manager:   signature.KeyringPair{PublicKey: managerId[:]},
+		Params:    `{"replicationFactor":3}`,
+		Nodes: []ManifestNode{
+			{NodeKey: joinedKey, Status: bucket.NodeStatusActive},
+			{NodeKey: newKey, Status: bucket.NodeStatusActive},
+		},
+		CdnNodes: []ManifestCdnNode{{NodeKey: cdnKey, Status: bucket.NodeStatusActive}},
+	}
+
+	//when
+	result, err := BootstrapCluster(context.Background(), nil, contract, manifest)
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, clusterId, result.ClusterId)
+	assert.Equal(t, []Step{StepNodeCreate, StepManagerPermission, StepClusterAddNode, StepNodeStatus}, result.Applied)
+	assert.Equal(t, cluster, result.Cluster)
+	contract.AssertExpectations(t)
+}
+
+func TestBootstrapClusterNodeInOtherCluster(t *testing.T) {
+	//given
+	clusterId := bucket.ClusterId(5)
+	nodeKey := bucket.NodeKey{1}
+	node := &bucket.NodeInfo{Key: nodeKey, Node: bucket.Node{ClusterId: types.NewOptionU32(6)}}
+
+	contract := &mockedDdcBucketContract{}
+	contract.On("ClusterGet", clusterId).Return(&bucket.ClusterInfo{ClusterId: clusterId}, nil).Once()
+	contract.On("NodeGet", nodeKey).Return(node, nil).Once()
+
+	manifest := ClusterManifest{
+		ClusterId: &clusterId,
+		Manager:   signature.KeyringPair{PublicKey: make([]byte, 32)},
+		Nodes:     []ManifestNode{{NodeKey: nodeKey}},
+	}
+
+	//when
+	result, err := BootstrapCluster(context.Background(), nil, contract, manifest)
+
+	//then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrNodeInOtherCluster)
+	var onboardingErr *OnboardingError
+	assert.ErrorAs(t, err, &onboardingErr)
+	assert.Equal(t, StepClusterAddNode, onboardingErr.Step)
+	assert.Empty(t, onboardingErr.Completed)
+	contract.AssertExpectations(t)
+}
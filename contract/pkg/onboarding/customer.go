@@ -0,0 +1,128 @@
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+const (
+	StepBalanceCheck       Step = "balance check"
+	StepDeposit            Step = "deposit"
+	StepBucketCreate       Step = "bucket create"
+	StepResourceAllocation Step = "resource allocation"
+)
+
+var (
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrEventNotFound       = errors.New("event not found")
+)
+
+// CustomerSpec describes a customer deposit and the bucket to create.
+type CustomerSpec struct {
+	Customer     signature.KeyringPair
+	Amount       bucket.Balance
+	BucketParams bucket.BucketParams
+	ClusterId    bucket.ClusterId
+	// Resource is allocated to the bucket in the cluster. The step is skipped if zero.
+	Resource bucket.StorageGb
+}
+
+type CustomerOnboardingResult struct {
+	DepositBlock       types.Hash
+	Deposit            *bucket.DepositEvent
+	BucketCreatedBlock types.Hash
+	BucketCreated      *bucket.BucketCreatedEvent
+	// Bucket is the created bucket read after all steps completed.
+	Bucket *bucket.BucketInfo
+}
+
+// CustomerOnboarding checks the customer balance covers the amount, deposits it to the contract,
+// creates the bucket with the params in the cluster and allocates the bucket resource. The deposit
+// and the bucket creation are verified by the Deposit and BucketCreated events emitted in their
+// blocks. On failure it returns OnboardingError.
+func CustomerOnboarding(ctx context.Context, client pkg.BlockchainClient, contract bucket.DdcBucketContract, spec CustomerSpec) (*CustomerOnboardingResult, error) {
+	var p progress
+	result := &CustomerOnboardingResult{}
+
+	customerId, err := types.NewAccountID(spec.Customer.PublicKey)
+	if err != nil {
+		return nil, p.fail(StepBalanceCheck, err)
+	}
+
+	accountInfo, err := client.GetAccountInfo(*customerId)
+	if err != nil {
+		return nil, p.fail(StepBalanceCheck, err)
+	}
+	if accountInfo.Data.Free.Int == nil || spec.Amount.Int == nil || accountInfo.Data.Free.Cmp(spec.Amount.Int) < 0 {
+		return nil, p.fail(StepBalanceCheck, fmt.Errorf("%w: free balance %v, deposit %v", ErrInsufficientBalance, accountInfo.Data.Free, spec.Amount))
+	}
+	p.complete(StepBalanceCheck, "")
+
+	result.DepositBlock, err = contract.AccountDeposit(ctx, spec.Customer, spec.Amount)
+	if err != nil {
+		return nil, p.fail(StepDeposit, err)
+	}
+	result.Deposit, err = findEvent(client, contract, result.DepositBlock, func(e *bucket.DepositEvent) bool {
+		return e.AccountId == *customerId
+	})
+	if err != nil {
+		return nil, p.fail(StepDeposit, err)
+	}
+	p.complete(StepDeposit, "withdraw the deposit with AccountUnbond and AccountWithdrawUnbonded")
+
+	ownerId := types.NewOptionAccountID(*customerId)
+	result.BucketCreatedBlock, err = contract.BucketCreate(ctx, spec.Customer, spec.BucketParams, spec.ClusterId, ownerId)
+	if err != nil {
+		return nil, p.fail(StepBucketCreate, err)
+	}
+	result.BucketCreated, err = findEvent(client, contract, result.BucketCreatedBlock, func(e *bucket.BucketCreatedEvent) bool {
+		return e.AccountId == *customerId
+	})
+	if err != nil {
+		return nil, p.fail(StepBucketCreate, err)
+	}
+	p.complete(StepBucketCreate, fmt.Sprintf("buckets can't be removed, reuse bucket %d or transfer it with BucketChangeOwner", result.BucketCreated.BucketId))
+
+	if spec.Resource > 0 {
+		if err := contract.BucketAllocIntoCluster(ctx, spec.Customer, result.BucketCreated.BucketId, spec.Resource); err != nil {
+			return nil, p.fail(StepResourceAllocation, err)
+		}
+		p.complete(StepResourceAllocation, "")
+	}
+
+	result.Bucket, err = contract.BucketGet(result.BucketCreated.BucketId)
+	if err != nil {
+		return nil, p.fail(StepBucketCreate, err)
+	}
+
+	return result, nil
+}
+
+// findEvent returns the first contract event of type T in the block which matches.
+func findEvent[T any](client pkg.BlockchainClient, contract bucket.DdcBucketContract, blockHash types.Hash, match func(e *T) bool) (*T, error) {
+	events, err := client.GetContractEvents(contract.GetContractAddress(), blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	dispatcher := contract.GetEventDispatcher()
+	for _, event := range events {
+		decoded, ok, err := event.Decode(dispatcher)
+		if err != nil || !ok {
+			continue
+		}
+		if e, ok := decoded.(*T); ok && match(e) {
+			return e, nil
+		}
+	}
+
+	var zero T
+	return nil, fmt.Errorf("%w: %T in block %s", ErrEventNotFound, zero, blockHash.Hex())
+}
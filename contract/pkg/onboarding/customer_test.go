@@ -0,0 +1,107 @@
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+type mockedBlockchainClient struct {
+	pkg.BlockchainClient
+	mock.Mock
+}
+
+func (m *mockedBlockchainClient) GetContractEvents(contractAddressSS58 string, blockHash types.Hash) ([]pkg.ContractEvent, error) {
+	args := m.Called(blockHash)
+	return args.Get(0).([]pkg.ContractEvent), args.Error(1)
+}
+
+func (m *mockedBlockchainClient) GetAccountInfo(accountId types.AccountID) (types.AccountInfo, error) {
+	args := m.Called(accountId)
+	return args.Get(0).(types.AccountInfo), args.Error(1)
+}
+
+func contractEvent(t *testing.T, eventId string, event interface{}) pkg.ContractEvent {
+	topic, err := types.NewHashFromHexString(eventId)
+	assert.NoError(t, err)
+	data, err := codec.Encode(event)
+	assert.NoError(t, err)
+
+	return pkg.ContractEvent{Topics: []types.Hash{topic}, Data: append([]byte{0}, data...)}
+}
+
+func TestCustomerOnboarding(t *testing.T) {
+	//given
+	customer := signature.KeyringPair{PublicKey: make([]byte, 32)}
+	customer.PublicKey[0] = 1
+	customerId := types.AccountID{1}
+	amount := types.NewU128(*big.NewInt(1000))
+	depositBlock, bucketBlock := types.Hash{1}, types.Hash{2}
+	bucketInfo := &bucket.BucketInfo{BucketId: 7}
+
+	var accountInfo types.AccountInfo
+	accountInfo.Data.Free = types.NewU128(*big.NewInt(5000))
+
+	client := &mockedBlockchainClient{}
+	client.On("GetAccountInfo", customerId).Return(accountInfo, nil)
+	client.On("GetContractEvents", depositBlock).Return([]pkg.ContractEvent{
+		contractEvent(t, bucket.DepositEventId, bucket.DepositEvent{AccountId: customerId, Value: amount}),
+	}, nil)
+	client.On("GetContractEvents", bucketBlock).Return([]pkg.ContractEvent{
+		contractEvent(t, bucket.BucketCreatedEventId, bucket.BucketCreatedEvent{BucketId: 6, AccountId: bucket.AccountId{9}}),
+		contractEvent(t, bucket.BucketCreatedEventId, bucket.BucketCreatedEvent{BucketId: 7, AccountId: customerId}),
+	}, nil)
+
+	contract := &mockedDdcBucketContract{}
+	contract.On("AccountDeposit", amount).Return(depositBlock, nil)
+	contract.On("BucketCreate", bucket.BucketParams{Replication: 3}, bucket.ClusterId(2)).Return(bucketBlock, nil)
+	contract.On("BucketGet", bucket.BucketId(7)).Return(bucketInfo, nil)
+
+	spec := CustomerSpec{Customer: customer, Amount: amount, BucketParams: bucket.BucketParams{Replication: 3}, ClusterId: 2}
+
+	//when
+	result, err := CustomerOnboarding(context.Background(), client, contract, spec)
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, depositBlock, result.DepositBlock)
+	assert.Equal(t, customerId, result.Deposit.AccountId)
+	assert.Equal(t, bucketBlock, result.BucketCreatedBlock)
+	assert.Equal(t, bucket.BucketId(7), result.BucketCreated.BucketId)
+	assert.Equal(t, bucketInfo, result.Bucket)
+	contract.AssertExpectations(t)
+}
+
+func TestCustomerOnboardingInsufficientBalance(t *testing.T) {
+	//given
+	customer := signature.KeyringPair{PublicKey: make([]byte, 32)}
+
+	var accountInfo types.AccountInfo
+	accountInfo.Data.Free = types.NewU128(*big.NewInt(10))
+
+	client := &mockedBlockchainClient{}
+	client.On("GetAccountInfo", types.AccountID{}).Return(accountInfo, nil)
+
+	spec := CustomerSpec{Customer: customer, Amount: types.NewU128(*big.NewInt(1000))}
+
+	//when
+	result, err := CustomerOnboarding(context.Background(), client, &mockedDdcBucketContract{}, spec)
+
+	//then
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, ErrInsufficientBalance))
+	var onboardingErr *OnboardingError
+	assert.ErrorAs(t, err, &onboardingErr)
+	assert.Equal(t, StepBalanceCheck, onboardingErr.Step)
+	assert.Empty(t, onboardingErr.Completed)
+}
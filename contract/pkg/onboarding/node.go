@@ -0,0 +1,130 @@
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+const (
+	StepNodeCreate     Step = "node create"
+	StepBond           Step = "bond"
+	StepJoinRequest    Step = "cluster join request"
+	StepClusterAddNode Step = "cluster add node"
+	StepNodeActivation Step = "node activation"
+)
+
+var (
+	ErrNodeNotActive = errors.New("node is not active in the cluster")
+)
+
+// NodeSpec describes a storage node to register and join to a cluster.
+type NodeSpec struct {
+	// Provider is the node provider account which creates the node and receives the rent.
+	Provider signature.KeyringPair
+	NodeKey  bucket.NodeKey
+	Params   bucket.NodeParams
+	Capacity bucket.StorageGb
+	Rent     bucket.Rent
+
+	// Bond bonds the provider stake before joining the cluster. The bucket contract has no node
+	// staking, so the stake is bonded by the caller, e.g. with a staking pallet extrinsic. The
+	// step is skipped if nil.
+	Bond func(ctx context.Context) error
+
+	ClusterId bucket.ClusterId
+	// ClusterManagerId is the cluster manager which is granted the permission to add the node.
+	ClusterManagerId bucket.AccountId
+	// ClusterManager adds the node to the cluster if the operator manages the cluster too.
+	// Otherwise the join request is completed by the cluster manager separately and onboarding
+	// waits for it.
+	ClusterManager *signature.KeyringPair
+	VNodes         [][]bucket.Token
+
+	// PollInterval is the interval of the node status polling. DefaultPollInterval is used if zero.
+	PollInterval time.Duration
+}
+
+type NodeOnboardingResult struct {
+	// NodeCreatedBlock is the block which includes the node creation.
+	NodeCreatedBlock types.Hash
+	// Node is the node active in the cluster.
+	Node *bucket.NodeInfo
+}
+
+// NodeOnboarding creates the node, bonds the stake, requests joining the cluster and polls the
+// node until it's active in the cluster. Polling continues until the context is done, so use a
+// context with a deadline. On failure it returns OnboardingError.
+func NodeOnboarding(ctx context.Context, contract bucket.DdcBucketContract, spec NodeSpec) (*NodeOnboardingResult, error) {
+	var p progress
+	result := &NodeOnboardingResult{}
+
+	blockHash, err := contract.NodeCreate(ctx, spec.Provider, spec.NodeKey, spec.Params, spec.Capacity, spec.Rent)
+	if err != nil {
+		return nil, p.fail(StepNodeCreate, err)
+	}
+	result.NodeCreatedBlock = blockHash
+	p.complete(StepNodeCreate, "remove the node with NodeRemove signed by the provider")
+
+	if spec.Bond != nil {
+		if err := spec.Bond(ctx); err != nil {
+			return nil, p.fail(StepBond, err)
+		}
+		p.complete(StepBond, "unbond the provider stake")
+	}
+
+	if err := contract.GrantTrustedManagerPermission(ctx, spec.Provider, spec.ClusterManagerId); err != nil {
+		return nil, p.fail(StepJoinRequest, err)
+	}
+	p.complete(StepJoinRequest, "revoke the cluster manager permission with RevokeTrustedManagerPermission signed by the provider")
+
+	if spec.ClusterManager != nil {
+		if err := contract.ClusterAddNode(ctx, *spec.ClusterManager, spec.ClusterId, spec.NodeKey, spec.VNodes); err != nil {
+			return nil, p.fail(StepClusterAddNode, err)
+		}
+		p.complete(StepClusterAddNode, "remove the node from the cluster with ClusterRemoveNode signed by the cluster manager")
+	}
+
+	node, err := waitNodeActive(ctx, contract, spec)
+	if err != nil {
+		return nil, p.fail(StepNodeActivation, err)
+	}
+	result.Node = node
+
+	return result, nil
+}
+
+func waitNodeActive(ctx context.Context, contract bucket.DdcBucketContract, spec NodeSpec) (*bucket.NodeInfo, error) {
+	interval := spec.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		node, err := contract.NodeGet(spec.NodeKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok, clusterId := node.Node.ClusterId.Unwrap(); ok && clusterId == spec.ClusterId {
+			if status, err := node.GetStatusInCluster(); err == nil && status == bucket.ACTIVE {
+				return node, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %v", ErrNodeNotActive, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
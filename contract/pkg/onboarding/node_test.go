@@ -0,0 +1,124 @@
+package onboarding
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+type mockedDdcBucketContract struct {
+	bucket.DdcBucketContract
+	mock.Mock
+}
+
+func (m *mockedDdcBucketContract) NodeCreate(ctx context.Context, keyPair signature.KeyringPair, nodeKey bucket.NodeKey, params bucket.Params, capacity bucket.StorageGb, rent bucket.Rent) (types.Hash, error) {
+	args := m.Called(nodeKey)
+	return args.Get(0).(types.Hash), args.Error(1)
+}
+
+func (m *mockedDdcBucketContract) GrantTrustedManagerPermission(ctx context.Context, keyPair signature.KeyringPair, managerId bucket.AccountId) error {
+	return m.Called(managerId).Error(0)
+}
+
+func (m *mockedDdcBucketContract) ClusterAddNode(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, nodeKey bucket.NodeKey, vNodes [][]bucket.Token) error {
+	return m.Called(clusterId, nodeKey).Error(0)
+}
+
+func (m *mockedDdcBucketContract) AccountDeposit(ctx context.Context, keyPair signature.KeyringPair, value bucket.Balance) (types.Hash, error) {
+	args := m.Called(value)
+	return args.Get(0).(types.Hash), args.Error(1)
+}
+
+func (m *mockedDdcBucketContract) BucketCreate(ctx context.Context, keyPair signature.KeyringPair, bucketParams bucket.BucketParams, clusterId bucket.ClusterId, ownerId types.OptionAccountID) (types.Hash, error) {
+	args := m.Called(bucketParams, clusterId)
+	return args.Get(0).(types.Hash), args.Error(1)
+}
+
+func (m *mockedDdcBucketContract) BucketGet(bucketId bucket.BucketId) (*bucket.BucketInfo, error) {
+	args := m.Called(bucketId)
+	return args.Get(0).(*bucket.BucketInfo), args.Error(1)
+}
+
+func (m *mockedDdcBucketContract) GetContractAddress() string {
+	return "contract"
+}
+
+func (m *mockedDdcBucketContract) GetEventDispatcher() map[types.Hash]pkg.ContractEventDispatchEntry {
+	return bucket.CreateDdcBucketContract(nil, "contract").GetEventDispatcher()
+}
+
+func (m *mockedDdcBucketContract) NodeGet(nodeKey bucket.NodeKey) (*bucket.NodeInfo, error) {
+	args := m.Called(nodeKey)
+	return args.Get(0).(*bucket.NodeInfo), args.Error(1)
+}
+
+func TestNodeOnboarding(t *testing.T) {
+	//given
+	nodeKey := bucket.NodeKey{1}
+	managerId := bucket.AccountId{2}
+	blockHash := types.Hash{3}
+	adding := &bucket.NodeInfo{Key: nodeKey, Node: bucket.Node{ClusterId: types.NewOptionU32(5), StatusInCluster: types.NewOptionU8(bucket.ADDING)}}
+	active := &bucket.NodeInfo{Key: nodeKey, Node: bucket.Node{ClusterId: types.NewOptionU32(5), StatusInCluster: types.NewOptionU8(bucket.ACTIVE)}}
+
+	contract := &mockedDdcBucketContract{}
+	contract.On("NodeCreate", nodeKey).Return(blockHash, nil).Once()
+	contract.On("GrantTrustedManagerPermission", managerId).Return(nil).Once()
+	contract.On("ClusterAddNode", bucket.ClusterId(5), nodeKey).Return(nil).Once()
+	contract.On("NodeGet", nodeKey).Return(adding, nil).Once()
+	contract.On("NodeGet", nodeKey).Return(active, nil).Once()
+
+	bonded := false
+	spec := NodeSpec{
+		NodeKey:          nodeKey,
+		Bond:             func(context.Context) error { bonded = true; return nil },
+		ClusterId:        5,
+		ClusterManagerId: managerId,
+		ClusterManager:   &signature.KeyringPair{},
+		PollInterval:     time.Millisecond,
+	}
+
+	//when
+	result, err := NodeOnboarding(context.Background(), contract, spec)
+
+	//then
+	assert.NoError(t, err)
+	assert.True(t, bonded)
+	assert.Equal(t, blockHash, result.NodeCreatedBlock)
+	assert.Equal(t, active, result.Node)
+	contract.AssertExpectations(t)
+}
+
+func TestNodeOnboardingPartialFailure(t *testing.T) {
+	//given
+	nodeKey := bucket.NodeKey{1}
+	managerId := bucket.AccountId{2}
+	failure := errors.New("not enough balance")
+
+	contract := &mockedDdcBucketContract{}
+	contract.On("NodeCreate", nodeKey).Return(types.Hash{}, nil).Once()
+	contract.On("GrantTrustedManagerPermission", managerId).Return(failure).Once()
+
+	spec := NodeSpec{NodeKey: nodeKey, ClusterId: 5, ClusterManagerId: managerId}
+
+	//when
+	result, err := NodeOnboarding(context.Background(), contract, spec)
+
+	//then
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, failure)
+	var onboardingErr *OnboardingError
+	assert.ErrorAs(t, err, &onboardingErr)
+	assert.Equal(t, StepJoinRequest, onboardingErr.Step)
+	assert.Equal(t, []Step{StepNodeCreate}, onboardingErr.Completed)
+	assert.Len(t, onboardingErr.Rollback, 1)
+	contract.AssertExpectations(t)
+}
@@ -0,0 +1,66 @@
+// Package onboarding sequences the multi-step contract calls of common onboarding flows of node
+// operators and customers. When a step fails, the error reports which steps are already completed
+// on chain and how to revert them.
+package onboarding
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPollInterval = 6 * time.Second
+)
+
+type Step string
+
+// OnboardingError is returned when an onboarding step fails after previous steps are completed.
+type OnboardingError struct {
+	// Step is the failed step.
+	Step Step
+	// Completed are the steps completed before the failure.
+	Completed []Step
+	// Rollback describes how to revert the completed steps, in the order to revert them.
+	Rollback []string
+	Err      error
+}
+
+func (e *OnboardingError) Error() string {
+	if len(e.Completed) == 0 {
+		return fmt.Sprintf("%s failed: %v", e.Step, e.Err)
+	}
+
+	completed := make([]string, len(e.Completed))
+	for i, step := range e.Completed {
+		completed[i] = string(step)
+	}
+
+	return fmt.Sprintf("%s failed after %s completed: %v", e.Step, strings.Join(completed, ", "), e.Err)
+}
+
+func (e *OnboardingError) Unwrap() error {
+	return e.Err
+}
+
+// progress tracks completed steps and rollback guidance of an onboarding flow.
+type progress struct {
+	completed []Step
+	rollback  []string
+}
+
+func (p *progress) complete(step Step, rollback string) {
+	p.completed = append(p.completed, step)
+	if rollback != "" {
+		p.rollback = append([]string{rollback}, p.rollback...)
+	}
+}
+
+func (p *progress) fail(step Step, err error) error {
+	return &OnboardingError{
+		Step:      step,
+		Completed: append([]Step(nil), p.completed...),
+		Rollback:  append([]string(nil), p.rollback...),
+		Err:       err,
+	}
+}
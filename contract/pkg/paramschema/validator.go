@@ -0,0 +1,203 @@
+// Package paramschema validates JSON params of buckets, nodes and clusters against JSON schemas
+// of the DDC bucket contract version, so invalid params are rejected with field errors before they
+// are submitted and land on-chain.
+package paramschema
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+)
+
+// DefaultVersion is the version of the schemas of the DDC bucket contract in use.
+const DefaultVersion = "v1"
+
+type Kind string
+
+const (
+	KindBucket  Kind = "bucket"
+	KindNode    Kind = "node"
+	KindCdnNode Kind = "cdn_node"
+	KindCluster Kind = "cluster"
+)
+
+var kinds = []Kind{KindBucket, KindNode, KindCdnNode, KindCluster}
+
+//go:embed schemas
+var schemasFS embed.FS
+
+// ValidationError lists the fields of params which don't match the schema.
+type ValidationError struct {
+	Kind   Kind
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Error()
+	}
+
+	return fmt.Sprintf("invalid %s params: %s", e.Kind, strings.Join(messages, "; "))
+}
+
+type (
+	// Validator is safe for concurrent use.
+	Validator struct {
+		schemas map[Kind]*Schema
+	}
+
+	Option func(v *Validator)
+)
+
+// WithSchema replaces the schema of the params kind, e.g. with a stricter one of a cluster policy.
+func WithSchema(kind Kind, schema *Schema) Option {
+	return func(v *Validator) {
+		v.schemas[kind] = schema
+	}
+}
+
+// CreateValidator creates the validator with the schemas embedded for the contract version.
+func CreateValidator(version string, opts ...Option) (*Validator, error) {
+	v := &Validator{schemas: make(map[Kind]*Schema, len(kinds))}
+	for _, kind := range kinds {
+		data, err := schemasFS.ReadFile(fmt.Sprintf("schemas/%s/%s.json", version, kind))
+		if err != nil {
+			return nil, fmt.Errorf("no %s params schema of contract version %s", kind, version)
+		}
+		if v.schemas[kind], err = ParseSchema(data); err != nil {
+			return nil, fmt.Errorf("%s params schema of contract version %s: %w", kind, version, err)
+		}
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v, nil
+}
+
+// Validate returns ValidationError if the params don't match the schema of the kind. Empty params
+// are valid. Bucket params may be tags like "name=photos;replication=3" which are validated as an
+// object of string values.
+func (v *Validator) Validate(kind Kind, params bucket.Params) error {
+	schema, ok := v.schemas[kind]
+	if !ok {
+		return fmt.Errorf("unknown params kind %s", kind)
+	}
+
+	params = strings.TrimSpace(params)
+	if params == "" {
+		return nil
+	}
+
+	data := []byte(params)
+	if kind == KindBucket && !strings.HasPrefix(params, "{") {
+		data, _ = json.Marshal(bucketTags(params))
+	}
+	if errs := schema.Validate(data); len(errs) > 0 {
+		return &ValidationError{Kind: kind, Fields: errs}
+	}
+
+	return nil
+}
+
+// bucketTags splits tags the way bucket.ReadBucketName does.
+func bucketTags(params bucket.Params) map[string]string {
+	tags := make(map[string]string)
+	for _, tag := range strings.FieldsFunc(params, func(r rune) bool { return r == ';' || r == ',' || r == ' ' }) {
+		name, value, _ := strings.Cut(tag, "=")
+		tags[name] = value
+	}
+
+	return tags
+}
+
+// ValidatingContract validates params before they are submitted by the write methods of the
+// contract.
+type ValidatingContract struct {
+	bucket.DdcBucketContract
+	validator *Validator
+}
+
+func CreateValidatingContract(contract bucket.DdcBucketContract, validator *Validator) *ValidatingContract {
+	return &ValidatingContract{DdcBucketContract: contract, validator: validator}
+}
+
+func (c *ValidatingContract) BucketCreate(ctx context.Context, keyPair signature.KeyringPair, bucketParams bucket.BucketParams, clusterId bucket.ClusterId, ownerId types.OptionAccountID) (types.Hash, error) {
+	params, err := bucketParams.Params()
+	if err != nil {
+		return types.Hash{}, err
+	}
+	if err := c.validator.Validate(KindBucket, params); err != nil {
+		return types.Hash{}, err
+	}
+	return c.DdcBucketContract.BucketCreate(ctx, keyPair, bucketParams, clusterId, ownerId)
+}
+
+func (c *ValidatingContract) BucketChangeParams(ctx context.Context, keyPair signature.KeyringPair, bucketId bucket.BucketId, bucketParams bucket.BucketParams) error {
+	params, err := bucketParams.Params()
+	if err != nil {
+		return err
+	}
+	if err := c.validator.Validate(KindBucket, params); err != nil {
+		return err
+	}
+	return c.DdcBucketContract.BucketChangeParams(ctx, keyPair, bucketId, bucketParams)
+}
+
+func (c *ValidatingContract) ClusterCreate(ctx context.Context, keyPair signature.KeyringPair, params bucket.Params, resourcePerVNode bucket.Resource) (types.Hash, error) {
+	if err := c.validator.Validate(KindCluster, params); err != nil {
+		return types.Hash{}, err
+	}
+	return c.DdcBucketContract.ClusterCreate(ctx, keyPair, params, resourcePerVNode)
+}
+
+func (c *ValidatingContract) ClusterSetParams(ctx context.Context, keyPair signature.KeyringPair, clusterId bucket.ClusterId, params bucket.Params) error {
+	if err := c.validator.Validate(KindCluster, params); err != nil {
+		return err
+	}
+	return c.DdcBucketContract.ClusterSetParams(ctx, keyPair, clusterId, params)
+}
+
+func (c *ValidatingContract) NodeCreate(ctx context.Context, keyPair signature.KeyringPair, nodeKey bucket.NodeKey, params bucket.Params, capacity bucket.StorageGb, rent bucket.Rent) (types.Hash, error) {
+	if err := c.validator.Validate(KindNode, params); err != nil {
+		return types.Hash{}, err
+	}
+	return c.DdcBucketContract.NodeCreate(ctx, keyPair, nodeKey, params, capacity, rent)
+}
+
+func (c *ValidatingContract) NodeSetParams(ctx context.Context, keyPair signature.KeyringPair, nodeKey bucket.NodeKey, params bucket.Params) error {
+	if err := c.validator.Validate(KindNode, params); err != nil {
+		return err
+	}
+	return c.DdcBucketContract.NodeSetParams(ctx, keyPair, nodeKey, params)
+}
+
+func (c *ValidatingContract) CdnNodeCreate(ctx context.Context, keyPair signature.KeyringPair, nodeKey bucket.CdnNodeKey, params bucket.CDNNodeParams) error {
+	if err := c.validateCdnNodeParams(params); err != nil {
+		return err
+	}
+	return c.DdcBucketContract.CdnNodeCreate(ctx, keyPair, nodeKey, params)
+}
+
+func (c *ValidatingContract) CdnNodeSetParams(ctx context.Context, keyPair signature.KeyringPair, nodeKey bucket.CdnNodeKey, params bucket.CDNNodeParams) error {
+	if err := c.validateCdnNodeParams(params); err != nil {
+		return err
+	}
+	return c.DdcBucketContract.CdnNodeSetParams(ctx, keyPair, nodeKey, params)
+}
+
+func (c *ValidatingContract) validateCdnNodeParams(params bucket.CDNNodeParams) error {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return c.validator.Validate(KindCdnNode, string(encoded))
+}
@@ -0,0 +1,102 @@
+package paramschema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	validator, err := CreateValidator(DefaultVersion)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		kind       Kind
+		params     string
+		wantFields []FieldError
+	}{
+		{name: "empty bucket params", kind: KindBucket},
+		{name: "bucket params", kind: KindBucket, params: `{"name":"photos","replication":"3"}`},
+		{name: "bucket tags", kind: KindBucket, params: "name=photos;replication=3"},
+		{
+			name:   "invalid bucket tags",
+			kind:   KindBucket,
+			params: "name=my/photos;replication=x",
+			wantFields: []FieldError{
+				{Field: "name", Message: "must match ^[a-zA-Z0-9._-]+$"},
+				{Field: "replication", Message: "must be a number"},
+			},
+		},
+		{name: "node params", kind: KindNode, params: `{"url":"https://node-1.cere.network","location":"eu","size":2}`},
+		{
+			name:   "node params without url",
+			kind:   KindNode,
+			params: `{"location":"eu","size":0}`,
+			wantFields: []FieldError{
+				{Field: "url", Message: "is required"},
+				{Field: "size", Message: "must be at least 1"},
+			},
+		},
+		{
+			name:       "relative node url",
+			kind:       KindNode,
+			params:     `{"url":"node-1"}`,
+			wantFields: []FieldError{{Field: "url", Message: "must be an absolute URI"}},
+		},
+		{
+			name:       "cluster replication factor type",
+			kind:       KindCluster,
+			params:     `{"replicationFactor":true}`,
+			wantFields: []FieldError{{Field: "replicationFactor", Message: "must be integer or string"}},
+		},
+		{
+			name:       "not JSON",
+			kind:       KindCluster,
+			params:     `replicationFactor=3`,
+			wantFields: []FieldError{{Message: "invalid JSON: invalid character 'r' looking for beginning of value"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//when
+			err := validator.Validate(tt.kind, tt.params)
+
+			//then
+			if tt.wantFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			assert.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, tt.wantFields, validationErr.Fields)
+		})
+	}
+}
+
+func TestWithSchema(t *testing.T) {
+	//given
+	policy, err := ParseSchema([]byte(`{"type":"object","additionalProperties":false,"properties":{"replicationFactor":{"enum":[3]}}}`))
+	assert.NoError(t, err)
+	validator, err := CreateValidator(DefaultVersion, WithSchema(KindCluster, policy))
+	assert.NoError(t, err)
+
+	//when
+	err = validator.Validate(KindCluster, `{"replicationFactor":2,"owner":"me"}`)
+
+	//then
+	assert.Equal(t, &ValidationError{Kind: KindCluster, Fields: []FieldError{
+		{Field: "owner", Message: "is not allowed"},
+		{Field: "replicationFactor", Message: "must be one of [3]"},
+	}}, err)
+}
+
+func TestUnknownVersion(t *testing.T) {
+	//when
+	_, err := CreateValidator("v0")
+
+	//then
+	assert.Error(t, err)
+}
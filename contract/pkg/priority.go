@@ -0,0 +1,146 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultMaxBatchWait = time.Second
+
+type (
+	Priority uint8
+
+	// PriorityPolicy configures prioritized contract reads. Reads beyond MaxConcurrent wait in
+	// queues and interactive reads are served before batch ones.
+	PriorityPolicy struct {
+		// MaxConcurrent limits contract reads in flight.
+		MaxConcurrent int
+		// MaxBatchWait protects batch reads from starvation: a batch read waiting longer is served
+		// before interactive reads. 1s if 0.
+		MaxBatchWait time.Duration
+	}
+
+	scheduler struct {
+		maxBatchWait time.Duration
+		now          func() time.Time
+
+		mu          sync.Mutex
+		free        int
+		interactive []*waiter
+		batch       []*waiter
+	}
+
+	waiter struct {
+		since   time.Time
+		ready   chan struct{}
+		granted bool
+	}
+
+	priorityKey struct{}
+)
+
+const (
+	// PriorityInteractive is the priority of reads serving user requests, the default.
+	PriorityInteractive Priority = iota
+	// PriorityBatch is the priority of background reads, e.g. backfills and audits.
+	PriorityBatch
+)
+
+// WithPriority returns the context making contract reads made with it of the priority.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// PriorityFromContext returns the priority set with WithPriority, PriorityInteractive if there is none.
+func PriorityFromContext(ctx context.Context) Priority {
+	priority, _ := ctx.Value(priorityKey{}).(Priority)
+	return priority
+}
+
+// WithPrioritizedReads limits contract reads in flight and serves interactive reads first, so batch
+// traffic in the same process doesn't add to the latency of user requests.
+func WithPrioritizedReads(policy PriorityPolicy) ClientOption {
+	return func(b *blockchainClient) {
+		if policy.MaxConcurrent > 0 {
+			b.scheduler = newScheduler(policy)
+		}
+	}
+}
+
+func newScheduler(policy PriorityPolicy) *scheduler {
+	if policy.MaxBatchWait <= 0 {
+		policy.MaxBatchWait = defaultMaxBatchWait
+	}
+
+	return &scheduler{maxBatchWait: policy.MaxBatchWait, now: time.Now, free: policy.MaxConcurrent}
+}
+
+// acquire waits for a free slot and returns the function releasing it. The error of the context is
+// returned if it's done before a slot is free.
+func (s *scheduler) acquire(ctx context.Context, priority Priority) (func(), error) {
+	s.mu.Lock()
+	if s.free > 0 && len(s.interactive) == 0 && len(s.batch) == 0 {
+		s.free--
+		s.mu.Unlock()
+		return s.release, nil
+	}
+
+	w := &waiter{since: s.now(), ready: make(chan struct{})}
+	if priority == PriorityBatch {
+		s.batch = append(s.batch, w)
+	} else {
+		s.interactive = append(s.interactive, w)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return s.release, nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		granted := w.granted
+		if !granted {
+			s.interactive = removeWaiter(s.interactive, w)
+			s.batch = removeWaiter(s.batch, w)
+		}
+		s.mu.Unlock()
+
+		if granted {
+			s.release()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// release passes the slot to the next waiting read or frees it.
+func (s *scheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var next *waiter
+	switch {
+	case len(s.batch) > 0 && s.now().Sub(s.batch[0].since) >= s.maxBatchWait:
+		next, s.batch = s.batch[0], s.batch[1:]
+	case len(s.interactive) > 0:
+		next, s.interactive = s.interactive[0], s.interactive[1:]
+	case len(s.batch) > 0:
+		next, s.batch = s.batch[0], s.batch[1:]
+	default:
+		s.free++
+		return
+	}
+
+	next.granted = true
+	close(next.ready)
+}
+
+func removeWaiter(waiters []*waiter, w *waiter) []*waiter {
+	for i, other := range waiters {
+		if other == w {
+			return append(waiters[:i], waiters[i+1:]...)
+		}
+	}
+
+	return waiters
+}
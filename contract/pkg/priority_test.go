@@ -0,0 +1,98 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// queued acquires a slot in the background, reports the name when it's granted and waits until
+// the read is queued.
+func queued(ctx context.Context, s *scheduler, priority Priority, name string, granted chan<- string) {
+	s.mu.Lock()
+	waiting := len(s.interactive) + len(s.batch) + 1
+	s.mu.Unlock()
+
+	go func() {
+		release, err := s.acquire(ctx, priority)
+		if err != nil {
+			granted <- "canceled " + name
+			return
+		}
+		granted <- name
+		release()
+	}()
+
+	for {
+		s.mu.Lock()
+		n := len(s.interactive) + len(s.batch)
+		s.mu.Unlock()
+		if n == waiting {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSchedulerServesInteractiveFirst(t *testing.T) {
+	//given
+	s := newScheduler(PriorityPolicy{MaxConcurrent: 1, MaxBatchWait: time.Hour})
+	release, err := s.acquire(context.Background(), PriorityInteractive)
+	assert.NoError(t, err)
+	granted := make(chan string, 2)
+	queued(context.Background(), s, PriorityBatch, "batch", granted)
+	queued(context.Background(), s, PriorityInteractive, "interactive", granted)
+
+	//when
+	release()
+
+	//then
+	assert.Equal(t, "interactive", <-granted)
+	assert.Equal(t, "batch", <-granted)
+}
+
+func TestSchedulerPreventsBatchStarvation(t *testing.T) {
+	//given
+	s := newScheduler(PriorityPolicy{MaxConcurrent: 1, MaxBatchWait: time.Minute})
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	release, err := s.acquire(context.Background(), PriorityInteractive)
+	assert.NoError(t, err)
+	granted := make(chan string, 2)
+	queued(context.Background(), s, PriorityBatch, "batch", granted)
+	queued(context.Background(), s, PriorityInteractive, "interactive", granted)
+
+	//when
+	now = now.Add(time.Minute)
+	release()
+
+	//then
+	assert.Equal(t, "batch", <-granted)
+	assert.Equal(t, "interactive", <-granted)
+}
+
+func TestSchedulerCanceledWait(t *testing.T) {
+	//given
+	s := newScheduler(PriorityPolicy{MaxConcurrent: 1})
+	release, err := s.acquire(context.Background(), PriorityInteractive)
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	granted := make(chan string, 1)
+	queued(ctx, s, PriorityBatch, "batch", granted)
+
+	//when
+	cancel()
+
+	//then
+	assert.Equal(t, "canceled batch", <-granted)
+	release()
+	assert.Equal(t, 1, s.free)
+	assert.Empty(t, s.batch)
+}
+
+func TestPriorityFromContext(t *testing.T) {
+	assert.Equal(t, PriorityInteractive, PriorityFromContext(context.Background()))
+	assert.Equal(t, PriorityBatch, PriorityFromContext(WithPriority(context.Background(), PriorityBatch)))
+}
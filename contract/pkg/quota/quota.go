@@ -0,0 +1,225 @@
+// Package quota decides whether a gateway accepts an upload to a bucket, so gateway services
+// enforce bucket limits consistently.
+package quota
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+	"github.com/patrickmn/go-cache"
+)
+
+const (
+	defaultBucketExpiration = 5 * time.Minute
+	defaultUsageExpiration  = 30 * time.Second
+)
+
+type (
+	// Buckets reads buckets of the DDC bucket contract, e.g. bucket.DdcBucketContract.
+	Buckets interface {
+		BucketGet(bucketId bucket.BucketId) (*bucket.BucketInfo, error)
+	}
+
+	// UsageProvider returns the number of bytes stored in the bucket, e.g. summed up from the
+	// storage node stats of the bucket cluster.
+	UsageProvider interface {
+		BucketUsage(ctx context.Context, bucketId bucket.BucketId) (uint64, error)
+	}
+
+	// EventSource registers contract event handlers, e.g. bucket.DdcBucketContract.
+	EventSource interface {
+		AddContractEventHandler(event string, handler func(interface{})) error
+	}
+
+	Reason string
+
+	// Decision is the result of a quota check. Limit and Used are in bytes.
+	Decision struct {
+		Allowed bool
+		// Reason explains why the upload is rejected, empty if it's allowed.
+		Reason Reason
+		State  bucket.BucketState
+		Limit  uint64
+		Used   uint64
+	}
+
+	// QuotaChecker combines the bucket resource cap, the current bucket usage and the bucket rent
+	// coverage. Buckets are cached until a contract event changes them, usages for a short time.
+	// It's safe for concurrent use.
+	QuotaChecker struct {
+		buckets          Buckets
+		usage            UsageProvider
+		clock            pkg.Clock
+		bucketExpiration time.Duration
+		usageExpiration  time.Duration
+		bucketCache      *cache.Cache
+		usageCache       *cache.Cache
+	}
+
+	Option func(q *QuotaChecker)
+)
+
+const (
+	// ReasonNoResource is a bucket without reserved resource.
+	ReasonNoResource Reason = "no_resource"
+	// ReasonRentNotCovered is a bucket which rent isn't covered by the owner deposit.
+	ReasonRentNotCovered Reason = "rent_not_covered"
+	// ReasonQuotaExceeded is an upload which would exceed the bucket resource cap.
+	ReasonQuotaExceeded Reason = "quota_exceeded"
+)
+
+// WithBucketExpiration sets how long buckets are cached if no event changed them, 5 minutes by default.
+func WithBucketExpiration(expiration time.Duration) Option {
+	return func(q *QuotaChecker) {
+		q.bucketExpiration = expiration
+	}
+}
+
+// WithUsageExpiration sets how long bucket usages are cached, 30 seconds by default.
+func WithUsageExpiration(expiration time.Duration) Option {
+	return func(q *QuotaChecker) {
+		q.usageExpiration = expiration
+	}
+}
+
+// WithClock sets the clock the rent coverage is checked with.
+func WithClock(clock pkg.Clock) Option {
+	return func(q *QuotaChecker) {
+		q.clock = clock
+	}
+}
+
+func CreateQuotaChecker(buckets Buckets, usage UsageProvider, opts ...Option) *QuotaChecker {
+	q := &QuotaChecker{
+		buckets:          buckets,
+		usage:            usage,
+		clock:            pkg.SystemClock,
+		bucketExpiration: defaultBucketExpiration,
+		usageExpiration:  defaultUsageExpiration,
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	q.bucketCache = cache.New(q.bucketExpiration, 2*q.bucketExpiration)
+	q.usageCache = cache.New(q.usageExpiration, 2*q.usageExpiration)
+
+	return q
+}
+
+// HookContractEvents invalidates cached buckets on contract events changing the bucket
+// reservation or the rent coverage.
+func (q *QuotaChecker) HookContractEvents(events EventSource) error {
+	hooks := map[string]func(interface{}){
+		bucket.BucketAllocatedEventId: func(raw interface{}) {
+			q.Invalidate(raw.(*bucket.BucketAllocatedEvent).BucketId)
+		},
+		bucket.BucketSettlePaymentEventId: func(raw interface{}) {
+			q.Invalidate(raw.(*bucket.BucketSettlePaymentEvent).BucketId)
+		},
+		bucket.BucketParamsSetEventId: func(raw interface{}) {
+			q.Invalidate(raw.(*bucket.BucketParamsSetEvent).BucketId)
+		},
+		// A deposit changes the rent coverage of all buckets of the account.
+		bucket.DepositEventId: func(interface{}) {
+			q.bucketCache.Flush()
+		},
+	}
+	for event, handler := range hooks {
+		if err := events.AddContractEventHandler(event, handler); err != nil {
+			return fmt.Errorf("unable to hook event %s: %w", event, err)
+		}
+	}
+
+	return nil
+}
+
+// Invalidate drops the cached bucket and its usage.
+func (q *QuotaChecker) Invalidate(bucketId bucket.BucketId) {
+	q.bucketCache.Delete(key(bucketId))
+	q.usageCache.Delete(key(bucketId))
+}
+
+// CanUpload decides whether an upload of size bytes is accepted by the bucket. The upload is
+// rejected if the bucket has no reserved resource, its rent isn't covered or the usage would
+// exceed the bucket resource cap, the smaller of the reserved resource and the consumption cap.
+func (q *QuotaChecker) CanUpload(ctx context.Context, bucketId bucket.BucketId, size uint64) (Decision, error) {
+	bucketInfo, err := q.bucket(bucketId)
+	if err != nil {
+		return Decision{}, err
+	}
+
+	decision := Decision{State: bucket.ComputeState(bucketInfo, q.clock.Now()), Limit: limit(bucketInfo.Bucket)}
+	if decision.State == bucket.BucketCreated {
+		decision.Reason = ReasonNoResource
+		return decision, nil
+	}
+	if decision.State != bucket.BucketActive {
+		decision.Reason = ReasonRentNotCovered
+		return decision, nil
+	}
+
+	decision.Used, err = q.used(ctx, bucketId)
+	if err != nil {
+		return Decision{}, err
+	}
+	if decision.Used > decision.Limit || size > decision.Limit-decision.Used {
+		decision.Reason = ReasonQuotaExceeded
+		return decision, nil
+	}
+	decision.Allowed = true
+
+	return decision, nil
+}
+
+// RecordUpload adds the uploaded bytes to the cached usage of the bucket, so subsequent checks
+// see the upload before the usage is read again.
+func (q *QuotaChecker) RecordUpload(bucketId bucket.BucketId, size uint64) {
+	// The usage isn't cached if it wasn't read yet or expired, the next check reads it then.
+	_, _ = q.usageCache.IncrementUint64(key(bucketId), size)
+}
+
+func (q *QuotaChecker) bucket(bucketId bucket.BucketId) (*bucket.BucketInfo, error) {
+	if cached, ok := q.bucketCache.Get(key(bucketId)); ok {
+		return cached.(*bucket.BucketInfo), nil
+	}
+
+	bucketInfo, err := q.buckets.BucketGet(bucketId)
+	if err != nil {
+		return nil, err
+	}
+	q.bucketCache.SetDefault(key(bucketId), bucketInfo)
+
+	return bucketInfo, nil
+}
+
+func (q *QuotaChecker) used(ctx context.Context, bucketId bucket.BucketId) (uint64, error) {
+	if cached, ok := q.usageCache.Get(key(bucketId)); ok {
+		return cached.(uint64), nil
+	}
+
+	used, err := q.usage.BucketUsage(ctx, bucketId)
+	if err != nil {
+		return 0, err
+	}
+	q.usageCache.SetDefault(key(bucketId), used)
+
+	return used, nil
+}
+
+// limit returns the bucket resource cap in bytes.
+func limit(b bucket.Bucket) uint64 {
+	resource := b.ResourceReserved
+	if b.GasConsumptionCap > 0 && b.GasConsumptionCap < resource {
+		resource = b.GasConsumptionCap
+	}
+
+	return bucket.StorageGbFromResource(resource).Bytes()
+}
+
+func key(bucketId bucket.BucketId) string {
+	return strconv.FormatUint(uint64(bucketId), 10)
+}
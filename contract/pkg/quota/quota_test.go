@@ -0,0 +1,104 @@
+package quota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg/bucket"
+	"github.com/stretchr/testify/assert"
+)
+
+const gb = 1 << 30
+
+type mockedBuckets struct {
+	buckets map[bucket.BucketId]*bucket.BucketInfo
+	reads   int
+}
+
+func (m *mockedBuckets) BucketGet(bucketId bucket.BucketId) (*bucket.BucketInfo, error) {
+	m.reads++
+	if b, ok := m.buckets[bucketId]; ok {
+		return b, nil
+	}
+	return nil, bucket.ErrBucketDoesNotExist
+}
+
+type mockedUsage map[bucket.BucketId]uint64
+
+func (m mockedUsage) BucketUsage(_ context.Context, bucketId bucket.BucketId) (uint64, error) {
+	return m[bucketId], nil
+}
+
+type mockedEvents map[string]func(interface{})
+
+func (m mockedEvents) AddContractEventHandler(event string, handler func(interface{})) error {
+	m[event] = handler
+	return nil
+}
+
+func TestCanUpload(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	covered := types.U64(now.Add(time.Hour).UnixMilli())
+	expired := types.U64(now.Add(-time.Hour).UnixMilli())
+	buckets := &mockedBuckets{buckets: map[bucket.BucketId]*bucket.BucketInfo{
+		1: {Bucket: bucket.Bucket{ResourceReserved: 10}, RentCoveredUntilMs: covered},
+		2: {Bucket: bucket.Bucket{ResourceReserved: 10, GasConsumptionCap: 2}, RentCoveredUntilMs: covered},
+		3: {Bucket: bucket.Bucket{}},
+		4: {Bucket: bucket.Bucket{ResourceReserved: 10}, RentCoveredUntilMs: expired},
+	}}
+	usage := mockedUsage{1: 9 * gb, 2: gb}
+	checker := CreateQuotaChecker(buckets, usage, WithClock(pkg.ClockFunc(func() time.Time { return now })))
+
+	tests := []struct {
+		name       string
+		bucketId   bucket.BucketId
+		size       uint64
+		wantReason Reason
+		wantLimit  uint64
+	}{
+		{name: "fits", bucketId: 1, size: gb, wantLimit: 10 * gb},
+		{name: "exceeds reservation", bucketId: 1, size: gb + 1, wantReason: ReasonQuotaExceeded, wantLimit: 10 * gb},
+		{name: "exceeds cap", bucketId: 2, size: gb + 1, wantReason: ReasonQuotaExceeded, wantLimit: 2 * gb},
+		{name: "no resource", bucketId: 3, size: 1, wantReason: ReasonNoResource},
+		{name: "rent expired", bucketId: 4, size: 1, wantReason: ReasonRentNotCovered, wantLimit: 10 * gb},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := checker.CanUpload(context.Background(), tt.bucketId, tt.size)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantReason == "", decision.Allowed)
+			assert.Equal(t, tt.wantReason, decision.Reason)
+			assert.Equal(t, tt.wantLimit, decision.Limit)
+		})
+	}
+}
+
+func TestQuotaCacheInvalidation(t *testing.T) {
+	//given
+	buckets := &mockedBuckets{buckets: map[bucket.BucketId]*bucket.BucketInfo{
+		1: {Bucket: bucket.Bucket{ResourceReserved: 1}, RentCoveredUntilMs: types.U64(time.Now().Add(time.Hour).UnixMilli())},
+	}}
+	checker := CreateQuotaChecker(buckets, mockedUsage{1: 0})
+	events := mockedEvents{}
+	assert.NoError(t, checker.HookContractEvents(events))
+	ctx := context.Background()
+
+	//when
+	_, _ = checker.CanUpload(ctx, 1, 1)
+	checker.RecordUpload(1, gb)
+	full, _ := checker.CanUpload(ctx, 1, 1)
+	events[bucket.BucketAllocatedEventId](&bucket.BucketAllocatedEvent{BucketId: 1})
+	invalidated, _ := checker.CanUpload(ctx, 1, 1)
+	_, missingErr := checker.CanUpload(ctx, 2, 1)
+
+	//then
+	assert.Equal(t, ReasonQuotaExceeded, full.Reason)
+	assert.True(t, invalidated.Allowed)
+	assert.Equal(t, 3, buckets.reads)
+	assert.ErrorIs(t, missingErr, bucket.ErrBucketDoesNotExist)
+}
@@ -0,0 +1,141 @@
+package pkg
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors"
+)
+
+const sr25519SignatureLength = 64
+
+type (
+	// SigningRequest is a transaction waiting for the signature of a remote signer.
+	SigningRequest struct {
+		// Id is random and unique per request, e.g. to poll the approval of the request.
+		Id string
+		// Signer is the SS58 address of the account signing the transaction.
+		Signer string
+		// Call is the name of the pallet call, e.g. Contracts.call.
+		Call string
+		// ContractCall is the call of a Contracts.call transaction, nil for other transactions.
+		ContractCall *ContractCall
+		Nonce        uint64
+		Tip          uint64
+		// Payload is the SCALE encoded signing payload of the extrinsic. As Substrate expects, the
+		// payload is signed as is if it's at most 256 bytes long, its blake2b-256 hash otherwise.
+		Payload []byte
+	}
+
+	// RemoteSigner signs transactions of accounts the client doesn't have secrets of, e.g. by asking
+	// a human to approve the transaction in a wallet. It returns the sr25519 signature of the payload.
+	RemoteSigner interface {
+		SignPayload(ctx context.Context, request SigningRequest) ([]byte, error)
+	}
+
+	// RemoteSignerFunc adapts a function to RemoteSigner, e.g. to show a QR code of the payload
+	// and read the signature scanned from the wallet.
+	RemoteSignerFunc func(ctx context.Context, request SigningRequest) ([]byte, error)
+)
+
+var (
+	// ErrSigningRejected is returned when the transaction was rejected by the remote signer.
+	ErrSigningRejected = ddcerrors.New(ddcerrors.CodeUnauthorized, "signing rejected")
+)
+
+func (f RemoteSignerFunc) SignPayload(ctx context.Context, request SigningRequest) ([]byte, error) {
+	return f(ctx, request)
+}
+
+// WithRemoteSigner signs transactions of signers without the secret URI, e.g. a key pair with only
+// the address and the public key of an admin account, with the remote signer. The transaction
+// waits for the signature until the context is done, so it should have a deadline long enough for
+// a human to approve it.
+func WithRemoteSigner(signer RemoteSigner) ClientOption {
+	return func(b *blockchainClient) {
+		b.remoteSigner = signer
+	}
+}
+
+func (b *blockchainClient) signsRemotely(signer signature.KeyringPair) bool {
+	return b.remoteSigner != nil && signer.URI == ""
+}
+
+// signRemotely signs the extrinsic with the signature of the remote signer, as Extrinsic.Sign
+// does with a local key pair.
+func (b *blockchainClient) signRemotely(ctx context.Context, ext *types.Extrinsic, tx *Transaction, nonce uint64, o types.SignatureOptions) error {
+	method, err := codec.Encode(ext.Method)
+	if err != nil {
+		return err
+	}
+
+	era := o.Era
+	if !era.IsMortalEra {
+		era = types.ExtrinsicEra{IsImmortalEra: true}
+	}
+	payload, err := codec.Encode(types.ExtrinsicPayloadV4{
+		ExtrinsicPayloadV3: types.ExtrinsicPayloadV3{
+			Method:      method,
+			Era:         era,
+			Nonce:       o.Nonce,
+			Tip:         o.Tip,
+			SpecVersion: o.SpecVersion,
+			GenesisHash: o.GenesisHash,
+			BlockHash:   o.BlockHash,
+		},
+		TransactionVersion: o.TransactionVersion,
+	})
+	if err != nil {
+		return err
+	}
+
+	signer, err := types.NewMultiAddressFromAccountID(tx.Signer.PublicKey)
+	if err != nil {
+		return err
+	}
+	id, err := newSigningRequestId()
+	if err != nil {
+		return err
+	}
+
+	sig, err := b.remoteSigner.SignPayload(ctx, SigningRequest{
+		Id:           id,
+		Signer:       tx.Signer.Address,
+		Call:         tx.Call,
+		ContractCall: tx.ContractCall,
+		Nonce:        nonce,
+		Tip:          tx.Tip,
+		Payload:      payload,
+	})
+	if err != nil {
+		return err
+	}
+	if len(sig) != sr25519SignatureLength {
+		return fmt.Errorf("remote signature of %s is %d bytes long, expected %d", tx.Signer.Address, len(sig), sr25519SignatureLength)
+	}
+
+	ext.Signature = types.ExtrinsicSignatureV4{
+		Signer:    signer,
+		Signature: types.MultiSignature{IsSr25519: true, AsSr25519: types.NewSignature(sig)},
+		Era:       era,
+		Nonce:     o.Nonce,
+		Tip:       o.Tip,
+	}
+	ext.Version |= types.ExtrinsicBitSigned
+
+	return nil
+}
+
+func newSigningRequestId() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,146 @@
+package pkg
+
+import (
+	"context"
+	"io"
+	"math"
+	"math/rand"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff     = 2 * time.Second
+	defaultRetryMultiplier     = 2
+	defaultRetryJitter         = 0.2
+)
+
+// RetryPolicy configures retries of contract reads failed with transient errors, e.g. a dropped
+// WebSocket connection or the best block state discarded by the node during the read. The waits
+// between tries grow exponentially.
+type RetryPolicy struct {
+	// MaxAttempts is the number of tries including the first one, 3 if 0.
+	MaxAttempts int
+	// InitialBackoff is the wait before the second try, 100ms if 0.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between tries, 2s if 0.
+	MaxBackoff time.Duration
+	// Multiplier grows the wait after every try, 2 if 0.
+	Multiplier float64
+	// Jitter is the maximum fraction of the wait randomly cut from it, so clients failed at once
+	// don't retry at once, 0.2 if 0.
+	Jitter float64
+	// Retryable reports whether the read failed with a transient error, IsRetryableReadError if nil.
+	Retryable func(err error) bool
+}
+
+// WithReadRetry retries contract reads failed with transient errors. Reads failed after retries
+// return RetryError with all attempts.
+func WithReadRetry(policy RetryPolicy) ClientOption {
+	return func(b *blockchainClient) {
+		if policy.MaxAttempts <= 0 {
+			policy.MaxAttempts = defaultRetryMaxAttempts
+		}
+		if policy.InitialBackoff <= 0 {
+			policy.InitialBackoff = defaultRetryInitialBackoff
+		}
+		if policy.MaxBackoff <= 0 {
+			policy.MaxBackoff = defaultRetryMaxBackoff
+		}
+		if policy.Multiplier <= 0 {
+			policy.Multiplier = defaultRetryMultiplier
+		}
+		if policy.Jitter <= 0 {
+			policy.Jitter = defaultRetryJitter
+		}
+		if policy.Retryable == nil {
+			policy.Retryable = IsRetryableReadError
+		}
+		b.readRetry = &policy
+	}
+}
+
+// IsRetryableReadError reports whether a read may succeed if it's tried again: the connection was
+// closed or reset, or the node discarded the state of the block while it was read.
+func IsRetryableReadError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if isClosedNetworkError(err) || isStateDiscardedError(err) || errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	return strings.Contains(err.Error(), "connection reset by peer")
+}
+
+func isStateDiscardedError(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "state already discarded")
+}
+
+// retryableAt returns the classifier of errors of reads at the block. The discarded state of a past
+// block doesn't come back, so reads at it aren't retried.
+func (p *RetryPolicy) retryableAt(at types.Hash) func(err error) bool {
+	if at == (types.Hash{}) {
+		return p.Retryable
+	}
+
+	return func(err error) bool {
+		return !isStateDiscardedError(err) && p.Retryable(err)
+	}
+}
+
+// backoff returns the wait after the attempt-th try.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	backoff := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	if backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+
+	return time.Duration(backoff * (1 - p.Jitter*rand.Float64()))
+}
+
+// withReadRetry tries the read until it succeeds, fails with an error which isn't retryable or runs
+// out of attempts. The read is tried once without a policy.
+func withReadRetry[T any](ctx context.Context, policy *RetryPolicy, endpoint string, retryable func(err error) bool, read func(ctx context.Context) (T, error)) (T, error) {
+	if policy == nil {
+		return read(ctx)
+	}
+
+	var attempts []Attempt
+	for {
+		start := time.Now()
+		result, err := read(ctx)
+		if err == nil {
+			return result, nil
+		}
+		attempts = append(attempts, Attempt{
+			Operation: "call",
+			Endpoint:  endpoint,
+			StartedAt: start,
+			Duration:  time.Since(start),
+			Err:       err,
+		})
+
+		if len(attempts) >= policy.MaxAttempts || !retryable(err) {
+			if len(attempts) == 1 {
+				return result, err
+			}
+			return result, &RetryError{Attempts: attempts}
+		}
+
+		timer := time.NewTimer(policy.backoff(len(attempts)))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return result, ctx.Err()
+		}
+	}
+}
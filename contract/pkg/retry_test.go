@@ -0,0 +1,95 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy(maxAttempts int) *RetryPolicy {
+	b := &blockchainClient{}
+	WithReadRetry(RetryPolicy{MaxAttempts: maxAttempts, InitialBackoff: time.Millisecond})(b)
+	return b.readRetry
+}
+
+func TestWithReadRetry(t *testing.T) {
+	discarded := errors.New("State already discarded for 0x01")
+	trapped := errors.New("contract trapped")
+	tests := []struct {
+		name         string
+		errs         []error
+		at           types.Hash
+		wantErr      error
+		wantAttempts int
+	}{
+		{name: "transient error", errs: []error{discarded, discarded}, wantAttempts: 3},
+		{name: "not retryable error", errs: []error{trapped}, wantErr: trapped, wantAttempts: 1},
+		{name: "discarded state of past block", errs: []error{discarded}, at: types.Hash{1}, wantErr: discarded, wantAttempts: 1},
+		{name: "out of attempts", errs: []error{discarded, discarded, discarded}, wantErr: discarded, wantAttempts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			policy := testRetryPolicy(3)
+			attempts := 0
+
+			//when
+			res, err := withReadRetry(context.Background(), policy, "ws://node", policy.retryableAt(tt.at), func(ctx context.Context) (string, error) {
+				attempts++
+				if attempts <= len(tt.errs) {
+					return "", tt.errs[attempts-1]
+				}
+				return "ok", nil
+			})
+
+			//then
+			assert.Equal(t, tt.wantAttempts, attempts)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, "ok", res)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+			var retryErr *RetryError
+			assert.Equal(t, tt.wantAttempts > 1, errors.As(err, &retryErr))
+		})
+	}
+}
+
+func TestWithReadRetryCanceled(t *testing.T) {
+	//given
+	b := &blockchainClient{}
+	WithReadRetry(RetryPolicy{InitialBackoff: time.Hour, MaxBackoff: time.Hour})(b)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	//when
+	_, err := withReadRetry(ctx, b.readRetry, "ws://node", b.readRetry.Retryable, func(ctx context.Context) (string, error) {
+		return "", errors.New("use of closed network connection")
+	})
+
+	//then
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2, Jitter: 0.2}
+
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second} {
+		backoff := policy.backoff(attempt)
+		assert.LessOrEqual(t, backoff, want)
+		assert.GreaterOrEqual(t, backoff, want*8/10)
+	}
+}
+
+func TestIsRetryableReadError(t *testing.T) {
+	assert.True(t, IsRetryableReadError(errors.New("read tcp: connection reset by peer")))
+	assert.True(t, IsRetryableReadError(errors.New("State already discarded for 0x01")))
+	assert.False(t, IsRetryableReadError(context.DeadlineExceeded))
+	assert.False(t, IsRetryableReadError(nil))
+}
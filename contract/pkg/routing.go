@@ -0,0 +1,144 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors"
+)
+
+var ErrUnknownEndpoint = ddcerrors.New(ddcerrors.CodeInvalidArgument, "endpoint is neither the client endpoint nor in the endpoint pool")
+
+type (
+	// endpointPool balances contract reads across the client endpoint and the pool endpoints in
+	// turns. The client endpoint is the empty URL.
+	endpointPool struct {
+		endpoints []string
+		dial      func(url string) (client.Client, error)
+
+		// connectMutex serializes connecting to endpoints, so each is connected to once.
+		connectMutex sync.Mutex
+		clients      map[string]client.Client
+		mutex        sync.Mutex
+		turn         int
+	}
+
+	// stickySession remembers the endpoint picked by the first read of the session.
+	stickySession struct {
+		mutex    sync.Mutex
+		endpoint string
+		picked   bool
+	}
+
+	endpointKey      struct{}
+	stickySessionKey struct{}
+)
+
+// WithEndpointPool balances contract reads across the client endpoint and the endpoints. Reads made
+// with a context set with WithEndpoint or WithStickySession go to one endpoint and aren't hedged.
+// Transactions are always submitted to the client endpoint.
+func WithEndpointPool(endpoints []string) ClientOption {
+	return func(b *blockchainClient) {
+		if len(endpoints) > 0 {
+			b.pool = newEndpointPool(endpoints)
+		}
+	}
+}
+
+// WithEndpoint returns the context sending contract reads made with it to the endpoint, the client
+// endpoint or one of WithEndpointPool.
+func WithEndpoint(ctx context.Context, url string) context.Context {
+	return context.WithValue(ctx, endpointKey{}, url)
+}
+
+// WithStickySession returns the context pinning contract reads made with it to one endpoint, picked
+// by the first read, e.g. for a sequence of reads which must observe consistent state. Other reads
+// continue to be balanced across the pool.
+func WithStickySession(ctx context.Context) context.Context {
+	return context.WithValue(ctx, stickySessionKey{}, &stickySession{})
+}
+
+func newEndpointPool(endpoints []string) *endpointPool {
+	return &endpointPool{
+		endpoints: endpoints,
+		dial:      client.Connect,
+		clients:   make(map[string]client.Client, len(endpoints)),
+	}
+}
+
+// next returns the endpoint of the next read, empty for the client endpoint.
+func (p *endpointPool) next() string {
+	if p == nil {
+		return ""
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	turn := p.turn
+	p.turn = (p.turn + 1) % (len(p.endpoints) + 1)
+	if turn == 0 {
+		return ""
+	}
+
+	return p.endpoints[turn-1]
+}
+
+func (p *endpointPool) contains(url string) bool {
+	if p == nil {
+		return false
+	}
+	for _, endpoint := range p.endpoints {
+		if endpoint == url {
+			return true
+		}
+	}
+
+	return false
+}
+
+// client returns the client of the endpoint connecting to it on first use.
+func (p *endpointPool) client(url string) (client.Client, error) {
+	p.connectMutex.Lock()
+	defer p.connectMutex.Unlock()
+
+	if cl, ok := p.clients[url]; ok {
+		return cl, nil
+	}
+	cl, err := p.dial(url)
+	if err != nil {
+		return nil, err
+	}
+	p.clients[url] = cl
+
+	return cl, nil
+}
+
+// route returns the endpoint of the read made with the context, empty for the client endpoint, and
+// whether the read is pinned to it.
+func (b *blockchainClient) route(ctx context.Context) (string, bool, error) {
+	if url, ok := ctx.Value(endpointKey{}).(string); ok {
+		switch {
+		case url == b.Client.URL():
+			return "", true, nil
+		case b.pool.contains(url):
+			return url, true, nil
+		default:
+			return "", false, ErrUnknownEndpoint
+		}
+	}
+
+	if session, ok := ctx.Value(stickySessionKey{}).(*stickySession); ok {
+		session.mutex.Lock()
+		defer session.mutex.Unlock()
+
+		if !session.picked {
+			session.endpoint = b.pool.next()
+			session.picked = true
+		}
+		return session.endpoint, true, nil
+	}
+
+	return b.pool.next(), false, nil
+}
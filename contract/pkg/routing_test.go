@@ -0,0 +1,93 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+
+	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	"github.com/stretchr/testify/assert"
+)
+
+type urlClient struct {
+	client.Client
+	url string
+}
+
+func (c *urlClient) URL() string {
+	return c.url
+}
+
+func testRoutingClient() *blockchainClient {
+	b := &blockchainClient{SubstrateAPI: &gsrpc.SubstrateAPI{Client: &urlClient{url: "ws://primary"}}}
+	WithEndpointPool([]string{"ws://a", "ws://b"})(b)
+	return b
+}
+
+func routes(t *testing.T, b *blockchainClient, ctx context.Context, n int) []string {
+	var endpoints []string
+	for i := 0; i < n; i++ {
+		endpoint, _, err := b.route(ctx)
+		assert.NoError(t, err)
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints
+}
+
+func TestRouteBalancesReads(t *testing.T) {
+	b := testRoutingClient()
+
+	assert.Equal(t, []string{"", "ws://a", "ws://b", ""}, routes(t, b, context.Background(), 4))
+}
+
+func TestRouteEndpoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		want       string
+		wantErr    error
+		wantPinned bool
+	}{
+		{name: "client endpoint", url: "ws://primary", want: "", wantPinned: true},
+		{name: "pool endpoint", url: "ws://b", want: "ws://b", wantPinned: true},
+		{name: "unknown endpoint", url: "ws://other", wantErr: ErrUnknownEndpoint},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			b := testRoutingClient()
+
+			//when
+			endpoint, pinned, err := b.route(WithEndpoint(context.Background(), tt.url))
+
+			//then
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, endpoint)
+			assert.Equal(t, tt.wantPinned, pinned)
+		})
+	}
+}
+
+func TestRouteStickySession(t *testing.T) {
+	//given
+	b := testRoutingClient()
+	b.pool.next()
+	ctx := WithStickySession(context.Background())
+
+	//when
+	pinned := routes(t, b, ctx, 3)
+	other := routes(t, b, context.Background(), 2)
+
+	//then
+	assert.Equal(t, []string{"ws://a", "ws://a", "ws://a"}, pinned)
+	assert.Equal(t, []string{"ws://b", ""}, other)
+}
+
+func TestRouteWithoutPool(t *testing.T) {
+	b := &blockchainClient{SubstrateAPI: &gsrpc.SubstrateAPI{Client: &urlClient{url: "ws://primary"}}}
+
+	assert.Equal(t, []string{"", ""}, routes(t, b, WithStickySession(context.Background()), 2))
+	_, _, err := b.route(WithEndpoint(context.Background(), "ws://a"))
+	assert.ErrorIs(t, err, ErrUnknownEndpoint)
+}
@@ -0,0 +1,103 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultGcpEndpoint = "https://secretmanager.googleapis.com"
+
+type (
+	// TokenSource returns an OAuth2 access token, e.g. of the GCP metadata server or of
+	// golang.org/x/oauth2/google.
+	TokenSource func(ctx context.Context) (string, error)
+
+	gcpProvider struct {
+		project     string
+		tokenSource TokenSource
+		endpoint    string
+		httpClient  *http.Client
+	}
+
+	GcpOption func(p *gcpProvider)
+
+	gcpResponse struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+)
+
+// WithGcpEndpoint overrides the Secret Manager endpoint, e.g. for a private service connection.
+func WithGcpEndpoint(endpoint string) GcpOption {
+	return func(p *gcpProvider) {
+		p.endpoint = strings.TrimSuffix(endpoint, "/")
+	}
+}
+
+func WithGcpHttpClient(httpClient *http.Client) GcpOption {
+	return func(p *gcpProvider) {
+		p.httpClient = httpClient
+	}
+}
+
+// CreateGcpProvider creates the provider reading the latest versions of GCP Secret Manager secrets
+// of the project. A secret name may have a version suffix, e.g. "signer/versions/3".
+func CreateGcpProvider(project string, tokenSource TokenSource, opts ...GcpOption) Provider {
+	p := &gcpProvider{
+		project:     project,
+		tokenSource: tokenSource,
+		endpoint:    defaultGcpEndpoint,
+		httpClient:  http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+func (p *gcpProvider) GetSecret(ctx context.Context, name string) ([]byte, error) {
+	version := "latest"
+	if i := strings.Index(name, "/versions/"); i >= 0 {
+		name, version = name[:i], name[i+len("/versions/"):]
+	}
+
+	token, err := p.tokenSource(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get access token: %w", err)
+	}
+
+	secretUrl := fmt.Sprintf("%s/v1/projects/%s/secrets/%s/versions/%s:access",
+		p.endpoint, url.PathEscape(p.project), url.PathEscape(name), url.PathEscape(version))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, secretUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrSecretNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("secret manager responded with status %d", resp.StatusCode)
+	}
+
+	var body gcpResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode secret manager response: %w", err)
+	}
+
+	return base64.StdEncoding.DecodeString(body.Payload.Data)
+}
@@ -0,0 +1,62 @@
+// Package secrets loads signer material from secrets managers, so seeds of production signers
+// don't have to be put into environment variables or files. Providers for HashiCorp Vault and GCP
+// Secret Manager are included, other managers, e.g. AWS Secrets Manager, can be plugged in with
+// ProviderFunc.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+)
+
+type (
+	// Provider reads secrets by name.
+	Provider interface {
+		GetSecret(ctx context.Context, name string) ([]byte, error)
+	}
+
+	// ProviderFunc adapts a function to Provider, e.g. a call of the AWS SDK.
+	ProviderFunc func(ctx context.Context, name string) ([]byte, error)
+)
+
+var (
+	ErrSecretNotFound = errors.New("secret not found")
+	ErrEmptySecret    = errors.New("secret is empty")
+)
+
+func (f ProviderFunc) GetSecret(ctx context.Context, name string) ([]byte, error) {
+	return f(ctx, name)
+}
+
+// LoadKeyringPair reads the seed or mnemonic phrase of a sr25519 signer from the provider and
+// derives the key pair with the address for the network, e.g. pkg.CereNetwork.
+func LoadKeyringPair(ctx context.Context, provider Provider, name string, network uint16) (signature.KeyringPair, error) {
+	secret, err := provider.GetSecret(ctx, name)
+	if err != nil {
+		return signature.KeyringPair{}, fmt.Errorf("load secret %s: %w", name, err)
+	}
+
+	seed := strings.TrimSpace(string(secret))
+	if seed == "" {
+		return signature.KeyringPair{}, fmt.Errorf("load secret %s: %w", name, ErrEmptySecret)
+	}
+
+	// The address is re-encoded below, so the derivation prefix doesn't matter.
+	keyPair, err := signature.KeyringPairFromSecret(seed, 42)
+	if err != nil {
+		return signature.KeyringPair{}, fmt.Errorf("derive key pair from secret %s: %w", name, err)
+	}
+
+	accountId, err := pkg.DecodeAccountIDFromSS58(keyPair.Address)
+	if err != nil {
+		return signature.KeyringPair{}, err
+	}
+	keyPair.Address = pkg.EncodeAddress(accountId, network)
+
+	return keyPair, nil
+}
@@ -0,0 +1,86 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVaultProvider(t *testing.T) {
+	//given
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" || r.URL.Path != "/v1/kv/data/ddc/signer" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"data":{"seed":"//Alice"}}}`))
+	}))
+	defer server.Close()
+	provider := CreateVaultProvider(server.URL, "token", WithVaultMount("kv"))
+
+	//when
+	secret, err := provider.GetSecret(context.Background(), "ddc/signer")
+	_, notFoundErr := provider.GetSecret(context.Background(), "ddc/other")
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, "//Alice", string(secret))
+	assert.True(t, errors.Is(notFoundErr, ErrSecretNotFound))
+}
+
+func TestGcpProvider(t *testing.T) {
+	//given
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"payload":{"data":"` + base64.StdEncoding.EncodeToString([]byte("//Alice")) + `"}}`))
+	}))
+	defer server.Close()
+	provider := CreateGcpProvider("ddc", func(context.Context) (string, error) { return "token", nil }, WithGcpEndpoint(server.URL))
+
+	//when
+	latest, err := provider.GetSecret(context.Background(), "signer")
+	assert.NoError(t, err)
+	versioned, err := provider.GetSecret(context.Background(), "signer/versions/3")
+	assert.NoError(t, err)
+
+	//then
+	assert.Equal(t, "//Alice", string(latest))
+	assert.Equal(t, "//Alice", string(versioned))
+	assert.Equal(t, []string{
+		"/v1/projects/ddc/secrets/signer/versions/latest:access",
+		"/v1/projects/ddc/secrets/signer/versions/3:access",
+	}, paths)
+}
+
+func TestLoadKeyringPair(t *testing.T) {
+	//given
+	provider := ProviderFunc(func(_ context.Context, name string) ([]byte, error) {
+		if name == "empty" {
+			return []byte(" \n"), nil
+		}
+		return []byte("//Alice\n"), nil
+	})
+
+	//when
+	keyPair, err := LoadKeyringPair(context.Background(), provider, "signer", pkg.CereNetwork)
+	_, emptyErr := LoadKeyringPair(context.Background(), provider, "empty", pkg.CereNetwork)
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, signature.TestKeyringPairAlice.PublicKey, keyPair.PublicKey)
+	accountId, _ := pkg.DecodeAccountIDFromSS58(signature.TestKeyringPairAlice.Address)
+	assert.Equal(t, pkg.EncodeAddress(accountId, pkg.CereNetwork), keyPair.Address)
+	assert.True(t, errors.Is(emptyErr, ErrEmptySecret))
+}
@@ -0,0 +1,104 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	defaultVaultMount = "secret"
+	defaultVaultField = "seed"
+)
+
+type (
+	vaultProvider struct {
+		address    string
+		token      string
+		mount      string
+		field      string
+		httpClient *http.Client
+	}
+
+	VaultOption func(p *vaultProvider)
+
+	vaultResponse struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+)
+
+// WithVaultMount sets the mount path of the KV v2 secrets engine, "secret" by default.
+func WithVaultMount(mount string) VaultOption {
+	return func(p *vaultProvider) {
+		p.mount = strings.Trim(mount, "/")
+	}
+}
+
+// WithVaultField sets the field of the secret holding the seed, "seed" by default.
+func WithVaultField(field string) VaultOption {
+	return func(p *vaultProvider) {
+		p.field = field
+	}
+}
+
+func WithVaultHttpClient(httpClient *http.Client) VaultOption {
+	return func(p *vaultProvider) {
+		p.httpClient = httpClient
+	}
+}
+
+// CreateVaultProvider creates the provider reading secrets of the HashiCorp Vault KV v2 secrets
+// engine. The secret name is the path of the secret in the engine.
+func CreateVaultProvider(address string, token string, opts ...VaultOption) Provider {
+	p := &vaultProvider{
+		address:    strings.TrimSuffix(address, "/"),
+		token:      token,
+		mount:      defaultVaultMount,
+		field:      defaultVaultField,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+func (p *vaultProvider) GetSecret(ctx context.Context, name string) ([]byte, error) {
+	secretUrl := fmt.Sprintf("%s/v1/%s/data/%s", p.address, p.mount, (&url.URL{Path: strings.Trim(name, "/")}).EscapedPath())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, secretUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrSecretNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault responded with status %d", resp.StatusCode)
+	}
+
+	var body vaultResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode vault response: %w", err)
+	}
+
+	value, ok := body.Data.Data[p.field]
+	if !ok {
+		return nil, fmt.Errorf("%w: field %s", ErrSecretNotFound, p.field)
+	}
+
+	return []byte(value), nil
+}
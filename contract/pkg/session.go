@@ -0,0 +1,191 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+type (
+	// SessionStats are counters of one session, e.g. of a tenant of a multi-tenant backend.
+	SessionStats struct {
+		RpcCalls           uint64
+		FailedRpcCalls     uint64
+		Transactions       uint64
+		FailedTransactions uint64
+		BytesUploaded      uint64
+		BytesDownloaded    uint64
+	}
+
+	// SessionMetrics counts RPC calls, transactions and transferred bytes per session, so platform
+	// teams can attribute costs to consumers of a shared client. The session is taken from the
+	// context set with WithSession or is the caller address of read calls and the signer address of
+	// transactions. It's safe for concurrent use.
+	SessionMetrics struct {
+		mu       sync.Mutex
+		sessions map[string]*SessionStats
+	}
+
+	sessionKey struct{}
+)
+
+// WithSession returns the context attributing calls made with it to the session.
+func WithSession(ctx context.Context, session string) context.Context {
+	return context.WithValue(ctx, sessionKey{}, session)
+}
+
+// SessionFromContext returns the session set with WithSession, empty if there is none.
+func SessionFromContext(ctx context.Context) string {
+	session, _ := ctx.Value(sessionKey{}).(string)
+	return session
+}
+
+// WithSessionMetrics counts contract read calls and transactions of the client in the metrics.
+func WithSessionMetrics(metrics *SessionMetrics) ClientOption {
+	return func(b *blockchainClient) {
+		b.sessionMetrics = metrics
+		b.middlewares = append(b.middlewares, AfterSubmit(func(ctx context.Context, tx *Transaction, _ types.Hash, err error) {
+			metrics.RecordTransaction(sessionOf(ctx, tx.Signer.Address), err)
+		}))
+	}
+}
+
+func CreateSessionMetrics() *SessionMetrics {
+	return &SessionMetrics{sessions: make(map[string]*SessionStats)}
+}
+
+// RecordRpcCall counts an RPC call of the session which failed if err is not nil.
+func (m *SessionMetrics) RecordRpcCall(session string, err error) {
+	m.update(session, func(s *SessionStats) {
+		s.RpcCalls++
+		if err != nil {
+			s.FailedRpcCalls++
+		}
+	})
+}
+
+// RecordTransaction counts a transaction of the session which failed if err is not nil.
+func (m *SessionMetrics) RecordTransaction(session string, err error) {
+	m.update(session, func(s *SessionStats) {
+		s.Transactions++
+		if err != nil {
+			s.FailedTransactions++
+		}
+	})
+}
+
+// RecordUpload counts bytes the session stored, e.g. pieces uploaded to storage nodes.
+func (m *SessionMetrics) RecordUpload(session string, bytes uint64) {
+	m.update(session, func(s *SessionStats) {
+		s.BytesUploaded += bytes
+	})
+}
+
+// RecordDownload counts bytes the session read, e.g. pieces downloaded from storage nodes.
+func (m *SessionMetrics) RecordDownload(session string, bytes uint64) {
+	m.update(session, func(s *SessionStats) {
+		s.BytesDownloaded += bytes
+	})
+}
+
+// Stats returns a copy of the counters of all sessions.
+func (m *SessionMetrics) Stats() map[string]SessionStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := make(map[string]SessionStats, len(m.sessions))
+	for session, s := range m.sessions {
+		stats[session] = *s
+	}
+
+	return stats
+}
+
+// Reset drops the counters of the session, e.g. of a removed tenant.
+func (m *SessionMetrics) Reset(session string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sessions, session)
+}
+
+// WritePrometheus writes the counters in the Prometheus text exposition format with the session
+// label and the constant labels, e.g. the service or the environment.
+func (m *SessionMetrics) WritePrometheus(w io.Writer, labels map[string]string) error {
+	stats := m.Stats()
+	sessions := make([]string, 0, len(stats))
+	for session := range stats {
+		sessions = append(sessions, session)
+	}
+	sort.Strings(sessions)
+
+	counters := []struct {
+		name  string
+		help  string
+		value func(s SessionStats) uint64
+	}{
+		{"ddc_session_rpc_calls_total", "Number of RPC calls of the session.", func(s SessionStats) uint64 { return s.RpcCalls }},
+		{"ddc_session_rpc_call_errors_total", "Number of failed RPC calls of the session.", func(s SessionStats) uint64 { return s.FailedRpcCalls }},
+		{"ddc_session_transactions_total", "Number of transactions of the session.", func(s SessionStats) uint64 { return s.Transactions }},
+		{"ddc_session_transaction_errors_total", "Number of failed transactions of the session.", func(s SessionStats) uint64 { return s.FailedTransactions }},
+		{"ddc_session_uploaded_bytes_total", "Number of bytes uploaded by the session.", func(s SessionStats) uint64 { return s.BytesUploaded }},
+		{"ddc_session_downloaded_bytes_total", "Number of bytes downloaded by the session.", func(s SessionStats) uint64 { return s.BytesDownloaded }},
+	}
+	for _, c := range counters {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name); err != nil {
+			return err
+		}
+		for _, session := range sessions {
+			if _, err := fmt.Fprintf(w, "%s%s %d\n", c.name, formatSessionLabels(session, labels), c.value(stats[session])); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *SessionMetrics) update(session string, f func(s *SessionStats)) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.sessions[session]
+	if !ok {
+		s = &SessionStats{}
+		m.sessions[session] = s
+	}
+	f(s)
+}
+
+// sessionOf returns the session of the context or the fallback if the context has none.
+func sessionOf(ctx context.Context, fallback string) string {
+	if session := SessionFromContext(ctx); session != "" {
+		return session
+	}
+	return fallback
+}
+
+func formatSessionLabels(session string, labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(labels)+1)
+	pairs = append(pairs, fmt.Sprintf("session=%q", session))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", name, labels[name]))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
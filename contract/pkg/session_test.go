@@ -0,0 +1,60 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionMetrics(t *testing.T) {
+	//given
+	metrics := CreateSessionMetrics()
+	b := &blockchainClient{}
+	WithSessionMetrics(metrics)(b)
+	submit := ChainMiddleware(b.middlewares...)(func(ctx context.Context, tx *Transaction) (types.Hash, error) {
+		if tx.Call == "Balances.transfer" {
+			return types.Hash{}, errors.New("insufficient balance")
+		}
+		return types.Hash{1}, nil
+	})
+	tenant := WithSession(context.Background(), "tenant-a")
+	signer := signature.KeyringPair{Address: "5signer"}
+
+	//when
+	_, _ = submit(tenant, &Transaction{Call: "Contracts.call", Signer: signer})
+	_, _ = submit(tenant, &Transaction{Call: "Balances.transfer", Signer: signer})
+	_, _ = submit(context.Background(), &Transaction{Call: "Contracts.call", Signer: signer})
+	metrics.RecordRpcCall(sessionOf(tenant, "5caller"), nil)
+	metrics.RecordRpcCall(sessionOf(context.Background(), "5caller"), errors.New("timeout"))
+	metrics.RecordUpload("tenant-a", 100)
+	metrics.RecordDownload("tenant-a", 40)
+
+	//then
+	assert.Equal(t, map[string]SessionStats{
+		"tenant-a": {RpcCalls: 1, Transactions: 2, FailedTransactions: 1, BytesUploaded: 100, BytesDownloaded: 40},
+		"5signer":  {Transactions: 1},
+		"5caller":  {RpcCalls: 1, FailedRpcCalls: 1},
+	}, metrics.Stats())
+}
+
+func TestSessionMetricsWritePrometheus(t *testing.T) {
+	//given
+	metrics := CreateSessionMetrics()
+	metrics.RecordUpload("tenant-b", 7)
+	metrics.RecordUpload("tenant-a", 5)
+	var out bytes.Buffer
+
+	//when
+	err := metrics.WritePrometheus(&out, map[string]string{"service": "gateway"})
+
+	//then
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "# TYPE ddc_session_uploaded_bytes_total counter\n"+
+		"ddc_session_uploaded_bytes_total{session=\"tenant-a\",service=\"gateway\"} 5\n"+
+		"ddc_session_uploaded_bytes_total{session=\"tenant-b\",service=\"gateway\"} 7\n")
+}
@@ -0,0 +1,100 @@
+// Package testkeys provides the standard Substrate development accounts derived from the well-known
+// dev seed, so tests don't derive them by hand. Never use them outside test networks.
+package testkeys
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+)
+
+type (
+	// Account is a development account with its derivation URI and public key.
+	Account struct {
+		Name      string
+		URI       string
+		PublicKey []byte
+	}
+
+	// Transactor submits transactions, e.g. the client created with pkg.CreateBlockchainClient.
+	Transactor interface {
+		SubmitTransaction(ctx context.Context, tx *pkg.Transaction) (types.Hash, error)
+	}
+)
+
+var (
+	// Sr25519 accounts sign transactions, the chain accepts them as is.
+	Alice   = mustAccount("Alice", "d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+	Bob     = mustAccount("Bob", "8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
+	Charlie = mustAccount("Charlie", "90b5ab205c6974c9ea841be688864633dc9ca8a357843eeacf2314649965fe22")
+
+	// Ed25519 accounts are for node and session keys only. Key pairs of the signature package are
+	// always signed as sr25519, so they can't sign transactions.
+	AliceEd25519   = mustAccount("Alice", "88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee")
+	BobEd25519     = mustAccount("Bob", "d17c2d7823ebf260fd138f2d7e27d114c0145d968b5ff5006125f2414fadae69")
+	CharlieEd25519 = mustAccount("Charlie", "439660b36c6c03afafca027b910b4fecf99801834c62a5e6006f27d978de234f")
+)
+
+// Sr25519 returns the sr25519 dev accounts in the order Alice, Bob, Charlie.
+func Sr25519() []Account {
+	return []Account{Alice, Bob, Charlie}
+}
+
+// Ed25519 returns the ed25519 dev accounts in the order Alice, Bob, Charlie.
+func Ed25519() []Account {
+	return []Account{AliceEd25519, BobEd25519, CharlieEd25519}
+}
+
+func (a Account) AccountId() types.AccountID {
+	accountId, _ := types.NewAccountID(a.PublicKey)
+	return *accountId
+}
+
+// Address returns the SS58 address of the account for the network prefix, e.g. pkg.CereNetwork.
+func (a Account) Address(network uint16) string {
+	return pkg.EncodeAddress(a.AccountId(), network)
+}
+
+// KeyringPair returns the key pair with the address for the network prefix.
+func (a Account) KeyringPair(network uint16) signature.KeyringPair {
+	return signature.KeyringPair{
+		URI:       a.URI,
+		Address:   a.Address(network),
+		PublicKey: append([]byte(nil), a.PublicKey...),
+	}
+}
+
+// Fund transfers the amount from the account to each of the recipients, e.g. to accounts created by
+// a test. It stops at the first failed transfer.
+func Fund(ctx context.Context, client Transactor, from Account, amount uint64, to ...types.AccountID) error {
+	for _, recipient := range to {
+		_, err := client.SubmitTransaction(ctx, &pkg.Transaction{
+			Call:   "Balances.transfer",
+			Args:   []interface{}{types.MultiAddress{IsID: true, AsID: recipient}, types.NewUCompactFromUInt(amount)},
+			Signer: from.KeyringPair(pkg.SubstrateNetwork),
+		})
+		if err != nil {
+			return fmt.Errorf("fund %s: %w", pkg.EncodeAddress(recipient, pkg.SubstrateNetwork), err)
+		}
+	}
+
+	return nil
+}
+
+// FundDevAccounts tops up Bob and Charlie with the amount from Alice.
+func FundDevAccounts(ctx context.Context, client Transactor, amount uint64) error {
+	return Fund(ctx, client, Alice, amount, Bob.AccountId(), Charlie.AccountId())
+}
+
+func mustAccount(name string, publicKeyHex string) Account {
+	publicKey, err := hex.DecodeString(publicKeyHex)
+	if err != nil {
+		panic(err)
+	}
+
+	return Account{Name: name, URI: "//" + name, PublicKey: publicKey}
+}
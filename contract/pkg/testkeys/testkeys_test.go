@@ -0,0 +1,61 @@
+package testkeys
+
+import (
+	"context"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/contract/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingTransactor struct {
+	txs []*pkg.Transaction
+}
+
+func (r *recordingTransactor) SubmitTransaction(_ context.Context, tx *pkg.Transaction) (types.Hash, error) {
+	r.txs = append(r.txs, tx)
+	return types.Hash{}, nil
+}
+
+func TestKeyringPair(t *testing.T) {
+	//when
+	alice := Alice.KeyringPair(pkg.SubstrateNetwork)
+
+	//then
+	assert.Equal(t, signature.TestKeyringPairAlice, alice)
+	assert.Equal(t, "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty", Bob.Address(pkg.SubstrateNetwork))
+	assert.Equal(t, "5FLSigC9HGRKVhB9FiEo4Y3koPsNmBmLJbpXg2mp1hXcS59Y", Charlie.Address(pkg.SubstrateNetwork))
+	assert.Equal(t, "//Alice", AliceEd25519.URI)
+}
+
+func TestKeyringPairDerivation(t *testing.T) {
+	for _, account := range Sr25519() {
+		t.Run(account.Name, func(t *testing.T) {
+			//when
+			derived, err := signature.KeyringPairFromSecret(account.URI, 42)
+
+			//then
+			assert.NoError(t, err)
+			assert.Equal(t, derived, account.KeyringPair(pkg.SubstrateNetwork))
+		})
+	}
+}
+
+func TestFundDevAccounts(t *testing.T) {
+	//given
+	client := &recordingTransactor{}
+
+	//when
+	err := FundDevAccounts(context.Background(), client, 1000)
+
+	//then
+	assert.NoError(t, err)
+	assert.Len(t, client.txs, 2)
+	for i, recipient := range []Account{Bob, Charlie} {
+		assert.Equal(t, "Balances.transfer", client.txs[i].Call)
+		assert.Equal(t, Alice.KeyringPair(pkg.SubstrateNetwork), client.txs[i].Signer)
+		assert.Equal(t, types.MultiAddress{IsID: true, AsID: recipient.AccountId()}, client.txs[i].Args[0])
+	}
+}
@@ -0,0 +1,238 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"sync"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/pkg/errors"
+)
+
+type (
+	TxStatus uint8
+
+	// TxHandle tracks the lifecycle of the transaction submitted with the context returned by
+	// WithTxHandle. Write calls return once the transaction is in a block, the handle keeps
+	// following it until it's finalized, dropped or invalid. It's safe for concurrent use.
+	TxHandle struct {
+		mu        sync.Mutex
+		status    TxStatus
+		blockHash types.Hash
+		extrinsic types.Extrinsic
+		events    []ContractEvent
+		callbacks []func(status TxStatus)
+		done      chan struct{}
+	}
+
+	extrinsicStatusSubscription interface {
+		Chan() <-chan types.ExtrinsicStatus
+		Err() <-chan error
+		Unsubscribe()
+	}
+
+	txHandleKey struct{}
+)
+
+const (
+	// TxBroadcast is the status of a transaction in the transaction pool, also after the block it
+	// was included in is retracted.
+	TxBroadcast TxStatus = iota + 1
+	TxInBlock
+	TxFinalized
+	// TxDropped is the status of a transaction dropped from the pool, e.g. replaced by another one
+	// with the same nonce.
+	TxDropped
+	TxInvalid
+)
+
+func (s TxStatus) String() string {
+	switch s {
+	case TxBroadcast:
+		return "broadcast"
+	case TxInBlock:
+		return "in block"
+	case TxFinalized:
+		return "finalized"
+	case TxDropped:
+		return "dropped"
+	case TxInvalid:
+		return "invalid"
+	default:
+		return "unknown"
+	}
+}
+
+// final reports whether the transaction doesn't change the status anymore.
+func (s TxStatus) final() bool {
+	return s == TxFinalized || s == TxDropped || s == TxInvalid
+}
+
+// WithTxHandle returns the context tracking the transaction submitted with it, e.g. by a write
+// call of the bucket contract, and the handle of the transaction.
+func WithTxHandle(ctx context.Context) (context.Context, *TxHandle) {
+	handle := &TxHandle{done: make(chan struct{})}
+	return context.WithValue(ctx, txHandleKey{}, handle), handle
+}
+
+func txHandleFromContext(ctx context.Context) *TxHandle {
+	handle, _ := ctx.Value(txHandleKey{}).(*TxHandle)
+	return handle
+}
+
+// OnStatus calls the callback on status transitions, first with the current status if the
+// transaction was already submitted.
+func (h *TxHandle) OnStatus(callback func(status TxStatus)) {
+	h.mu.Lock()
+	h.callbacks = append(h.callbacks, callback)
+	status := h.status
+	h.mu.Unlock()
+
+	if status != 0 {
+		callback(status)
+	}
+}
+
+// Status returns the latest status, 0 before the transaction is submitted.
+func (h *TxHandle) Status() TxStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.status
+}
+
+// BlockHash returns the hash of the block the transaction is included in, the final one once the
+// status is TxFinalized.
+func (h *TxHandle) BlockHash() types.Hash {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.blockHash
+}
+
+// Events returns the events of the called contract emitted by the transaction, known once the
+// write call returns.
+func (h *TxHandle) Events() []ContractEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.events
+}
+
+// Done is closed when the status is final.
+func (h *TxHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// update records the status of the extrinsic and calls the callbacks on transitions.
+func (h *TxHandle) update(extrinsic types.Extrinsic, extrinsicStatus types.ExtrinsicStatus) {
+	if h == nil {
+		return
+	}
+
+	var status TxStatus
+	var blockHash types.Hash
+	switch {
+	case extrinsicStatus.IsReady, extrinsicStatus.IsBroadcast, extrinsicStatus.IsRetracted:
+		status = TxBroadcast
+	case extrinsicStatus.IsInBlock:
+		status, blockHash = TxInBlock, extrinsicStatus.AsInBlock
+	case extrinsicStatus.IsFinalized:
+		status, blockHash = TxFinalized, extrinsicStatus.AsFinalized
+	case extrinsicStatus.IsDropped, extrinsicStatus.IsUsurped, extrinsicStatus.IsFinalityTimeout:
+		status = TxDropped
+	case extrinsicStatus.IsInvalid:
+		status = TxInvalid
+	default:
+		return
+	}
+
+	h.mu.Lock()
+	if h.status == status || h.status.final() {
+		h.mu.Unlock()
+		return
+	}
+	h.status = status
+	h.extrinsic = extrinsic
+	if blockHash != (types.Hash{}) {
+		h.blockHash = blockHash
+	}
+	callbacks := make([]func(status TxStatus), len(h.callbacks))
+	copy(callbacks, h.callbacks)
+	h.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback(status)
+	}
+	if status.final() {
+		close(h.done)
+	}
+}
+
+// follow updates the status until it's final and unsubscribes.
+func (h *TxHandle) follow(extrinsic types.Extrinsic, sub extrinsicStatusSubscription) {
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case status := <-sub.Chan():
+			h.update(extrinsic, status)
+			if h.Status().final() {
+				return
+			}
+		case <-sub.Err():
+			return
+		}
+	}
+}
+
+// resolveEvents sets the events of the contract emitted by the transaction.
+func (b *blockchainClient) resolveEvents(h *TxHandle, contractAddressSS58 string) error {
+	h.mu.Lock()
+	blockHash, extrinsic := h.blockHash, h.extrinsic
+	h.mu.Unlock()
+
+	index, err := b.extrinsicIndex(blockHash, extrinsic)
+	if err != nil {
+		return err
+	}
+	events, err := b.GetContractEvents(contractAddressSS58, blockHash)
+	if err != nil {
+		return err
+	}
+
+	var own []ContractEvent
+	for _, event := range events {
+		if event.Phase.IsApplyExtrinsic && event.Phase.AsApplyExtrinsic == index {
+			own = append(own, event)
+		}
+	}
+
+	h.mu.Lock()
+	h.events = own
+	h.mu.Unlock()
+
+	return nil
+}
+
+// extrinsicIndex returns the index of the extrinsic in the block.
+func (b *blockchainClient) extrinsicIndex(blockHash types.Hash, extrinsic types.Extrinsic) (uint32, error) {
+	encoded, err := codec.Encode(extrinsic)
+	if err != nil {
+		return 0, err
+	}
+	block, err := b.RPC.Chain.GetBlock(blockHash)
+	if err != nil {
+		return 0, errors.Wrap(err, "get block "+blockHash.Hex())
+	}
+
+	for i, other := range block.Block.Extrinsics {
+		otherEncoded, err := codec.Encode(other)
+		if err == nil && bytes.Equal(otherEncoded, encoded) {
+			return uint32(i), nil
+		}
+	}
+
+	return 0, errors.New("transaction not found in block " + blockHash.Hex())
+}
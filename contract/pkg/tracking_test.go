@@ -0,0 +1,75 @@
+package pkg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStatusSubscription struct {
+	statuses     chan types.ExtrinsicStatus
+	errs         chan error
+	unsubscribed chan struct{}
+}
+
+func (s *fakeStatusSubscription) Chan() <-chan types.ExtrinsicStatus { return s.statuses }
+func (s *fakeStatusSubscription) Err() <-chan error                  { return s.errs }
+func (s *fakeStatusSubscription) Unsubscribe()                       { close(s.unsubscribed) }
+
+func TestTxHandle(t *testing.T) {
+	//given
+	ctx, handle := WithTxHandle(context.Background())
+	var statuses []TxStatus
+	handle.OnStatus(func(status TxStatus) {
+		statuses = append(statuses, status)
+	})
+	sub := &fakeStatusSubscription{
+		statuses:     make(chan types.ExtrinsicStatus, 2),
+		errs:         make(chan error),
+		unsubscribed: make(chan struct{}),
+	}
+
+	//when
+	tracked := txHandleFromContext(ctx)
+	tracked.update(types.Extrinsic{}, types.ExtrinsicStatus{IsReady: true})
+	tracked.update(types.Extrinsic{}, types.ExtrinsicStatus{IsBroadcast: true})
+	tracked.update(types.Extrinsic{}, types.ExtrinsicStatus{IsInBlock: true, AsInBlock: types.Hash{1}})
+	sub.statuses <- types.ExtrinsicStatus{IsFinalized: true, AsFinalized: types.Hash{1}}
+	sub.statuses <- types.ExtrinsicStatus{IsDropped: true}
+	go tracked.follow(types.Extrinsic{}, sub)
+
+	//then
+	select {
+	case <-handle.Done():
+	case <-time.After(time.Second):
+		t.Fatal("handle isn't done")
+	}
+	<-sub.unsubscribed
+	assert.Equal(t, []TxStatus{TxBroadcast, TxInBlock, TxFinalized}, statuses)
+	assert.Equal(t, TxFinalized, handle.Status())
+	assert.Equal(t, types.Hash{1}, handle.BlockHash())
+}
+
+func TestTxHandleLateCallback(t *testing.T) {
+	//given
+	_, handle := WithTxHandle(context.Background())
+	handle.update(types.Extrinsic{}, types.ExtrinsicStatus{IsInvalid: true})
+
+	//when
+	var statuses []TxStatus
+	handle.OnStatus(func(status TxStatus) {
+		statuses = append(statuses, status)
+	})
+
+	//then
+	assert.Equal(t, []TxStatus{TxInvalid}, statuses)
+	assert.Equal(t, "invalid", handle.Status().String())
+}
+
+func TestTxHandleNotTracked(t *testing.T) {
+	assert.Nil(t, txHandleFromContext(context.Background()))
+	txHandleFromContext(context.Background()).update(types.Extrinsic{}, types.ExtrinsicStatus{IsReady: true})
+}
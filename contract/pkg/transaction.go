@@ -0,0 +1,76 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+type (
+	// Transaction is an extrinsic the client is about to sign and submit. Middlewares may change
+	// it before it's passed to the next submitter, e.g. to raise the tip.
+	Transaction struct {
+		// Call is the name of the pallet call, e.g. Contracts.call.
+		Call   string
+		Args   []interface{}
+		Signer signature.KeyringPair
+		// Tip is paid to the block author in addition to the fee.
+		Tip uint64
+		// ContractCall is the call of a Contracts.call transaction, nil for other transactions.
+		ContractCall *ContractCall
+		// DeployCall is the call of a Contracts.instantiate_with_code transaction, nil for other transactions.
+		DeployCall *DeployCall
+	}
+
+	// Submitter signs and submits the transaction and returns the hash of the block it was included in.
+	Submitter func(ctx context.Context, tx *Transaction) (types.Hash, error)
+
+	// Middleware wraps the next submitter to run code before and after transactions submission,
+	// e.g. to enforce organizational policies, collect metrics or persist submitted transactions.
+	Middleware func(next Submitter) Submitter
+)
+
+// WithTransactionMiddleware adds middlewares the client submits transactions through. The first
+// middleware is the outermost one: it's called first and sees the result of all others.
+func WithTransactionMiddleware(middlewares ...Middleware) ClientOption {
+	return func(b *blockchainClient) {
+		b.middlewares = append(b.middlewares, middlewares...)
+	}
+}
+
+// ChainMiddleware composes the middlewares into one, the first middleware is the outermost one.
+func ChainMiddleware(middlewares ...Middleware) Middleware {
+	return func(next Submitter) Submitter {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// BeforeSubmit creates a middleware calling the hook before the transaction is submitted, e.g. to
+// check a policy or to simulate the transaction. The transaction isn't submitted if the hook fails.
+func BeforeSubmit(hook func(ctx context.Context, tx *Transaction) error) Middleware {
+	return func(next Submitter) Submitter {
+		return func(ctx context.Context, tx *Transaction) (types.Hash, error) {
+			if err := hook(ctx, tx); err != nil {
+				return types.Hash{}, fmt.Errorf("transaction %s rejected: %w", tx.Call, err)
+			}
+			return next(ctx, tx)
+		}
+	}
+}
+
+// AfterSubmit creates a middleware calling the hook with the result of the transaction submission,
+// e.g. to collect metrics or to persist the transaction. The result is returned unchanged.
+func AfterSubmit(hook func(ctx context.Context, tx *Transaction, blockHash types.Hash, err error)) Middleware {
+	return func(next Submitter) Submitter {
+		return func(ctx context.Context, tx *Transaction) (types.Hash, error) {
+			blockHash, err := next(ctx, tx)
+			hook(ctx, tx, blockHash, err)
+			return blockHash, err
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func recordingMiddleware(name string, calls *[]string) Middleware {
+	return func(next Submitter) Submitter {
+		return func(ctx context.Context, tx *Transaction) (types.Hash, error) {
+			*calls = append(*calls, "before "+name)
+			hash, err := next(ctx, tx)
+			*calls = append(*calls, "after "+name)
+			return hash, err
+		}
+	}
+}
+
+func TestChainMiddleware(t *testing.T) {
+	//given
+	var calls []string
+	submitter := func(ctx context.Context, tx *Transaction) (types.Hash, error) {
+		calls = append(calls, "submit")
+		return types.Hash{1}, nil
+	}
+	chain := ChainMiddleware(recordingMiddleware("first", &calls), recordingMiddleware("second", &calls))
+
+	//when
+	hash, err := chain(submitter)(context.Background(), &Transaction{Call: "Contracts.call"})
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, types.Hash{1}, hash)
+	assert.Equal(t, []string{"before first", "before second", "submit", "after second", "after first"}, calls)
+}
+
+func TestSubmitHooks(t *testing.T) {
+	errPolicy := errors.New("signer is not allowed")
+	errSubmit := errors.New("submission failed")
+
+	tests := []struct {
+		name          string
+		policyErr     error
+		submitErr     error
+		wantSubmitted bool
+		wantErr       error
+		wantObserved  error
+	}{
+		{name: "submitted", wantSubmitted: true},
+		{name: "rejected by policy", policyErr: errPolicy, wantErr: errPolicy},
+		{name: "failed submission", submitErr: errSubmit, wantSubmitted: true, wantErr: errSubmit, wantObserved: errSubmit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			submitted := false
+			submitter := func(ctx context.Context, tx *Transaction) (types.Hash, error) {
+				submitted = true
+				return types.Hash{2}, tt.submitErr
+			}
+			var observedTx *Transaction
+			var observedErr error
+			chain := ChainMiddleware(
+				AfterSubmit(func(ctx context.Context, tx *Transaction, blockHash types.Hash, err error) {
+					observedTx, observedErr = tx, err
+				}),
+				BeforeSubmit(func(ctx context.Context, tx *Transaction) error {
+					tx.Tip = 10
+					return tt.policyErr
+				}),
+			)
+			tx := &Transaction{Call: "Contracts.call"}
+
+			//when
+			_, err := chain(submitter)(context.Background(), tx)
+
+			//then
+			assert.Equal(t, tt.wantSubmitted, submitted)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Same(t, tx, observedTx)
+			assert.Equal(t, uint64(10), observedTx.Tip)
+			if tt.policyErr != nil {
+				assert.ErrorIs(t, observedErr, tt.policyErr)
+			} else {
+				assert.Equal(t, tt.wantObserved, observedErr)
+			}
+		})
+	}
+}
@@ -2,64 +2,51 @@ package pkg
 
 import (
 	"bytes"
-	"errors"
-	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
-	"github.com/decred/base58"
-	"golang.org/x/crypto/blake2b"
 )
 
-const addressLength = 32 + 1 + 2
-
 var defaultSS58Prefix = []byte("SS58PRE")
 
-func DecodeAccountIDFromSS58(address string) (types.AccountID, error) {
-	a := base58.Decode(address)
-
-	if len(a) == 0 {
-		return types.AccountID{}, errors.New("no address bytes encode")
-	}
-
-	if len(a) == addressLength {
-		addr := a[:addressLength-2]
-
-		hash, err := blake2b.New512([]byte{})
-		if err != nil {
-			return types.AccountID{}, fmt.Errorf("[DecodeAccountID] invalid blake2b: %w", err)
-		}
-
-		buf := make([]byte, 0, len(defaultSS58Prefix)+len(addr)+1)
-		buf = append(buf, defaultSS58Prefix...)
-		buf = append(buf, addr...)
+// maxPooledBufferSize limits buffers returned to encodeBufferPool, so a single large call doesn't
+// keep its buffer alive.
+const maxPooledBufferSize = 64 << 10
 
-		_, err = hash.Write(buf)
-		if err != nil {
-			return types.AccountID{}, fmt.Errorf("[DecodeAccountID] invalid blake2b write: %w", err)
-		}
-
-		h := hash.Sum(nil)
+// encodeBufferPool reuses contract call encoding buffers, which are allocated on every read call.
+var encodeBufferPool = sync.Pool{
+	New: func() interface{} {
+		return bytes.NewBuffer(make([]byte, 0, 1024))
+	},
+}
 
-		if (a[addressLength-2] == h[0]) && (a[addressLength-1] == h[1]) {
-			id, err := types.NewAccountID(a[1:33])
-			if err != nil {
-				return types.AccountID{}, err
-			}
+// DecodeAccountIDFromSS58 decodes an SS58 address of any network. Use DecodeAddress to restrict the
+// network or accept hex encoded public keys.
+func DecodeAccountIDFromSS58(address string) (types.AccountID, error) {
+	return DecodeAddress(address)
+}
 
-			return *id, nil
+// GetContractData encodes the contract call of the method with the arguments. Calls which arguments
+// all have the fast encoding path, e.g. cluster calls with large vNodes, are encoded in one
+// allocation of the precomputed size.
+func GetContractData(method []byte, args ...interface{}) ([]byte, error) {
+	if size, ok := contractDataSize(method, args); ok {
+		data := append(make([]byte, 0, size), method...)
+		for _, v := range args {
+			data = appendEncoded(data, v)
 		}
-
-		return types.AccountID{},
-			fmt.Errorf("invalid checksum %x%x, expected %x%x", a[addressLength-2], a[addressLength-1], h[0], h[1])
+		return data, nil
 	}
 
-	return types.AccountID{}, errors.New("invalid length")
-}
-
-func GetContractData(method []byte, args ...interface{}) ([]byte, error) {
-	buf := bytes.NewBuffer(make([]byte, 0, 1024))
+	buf := encodeBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBufferSize {
+			encodeBufferPool.Put(buf)
+		}
+	}()
 	buf.Write(method)
 
 	encoder := scale.NewEncoder(buf)
@@ -70,7 +57,20 @@ func GetContractData(method []byte, args ...interface{}) ([]byte, error) {
 		}
 	}
 
-	return buf.Bytes(), nil
+	return append([]byte(nil), buf.Bytes()...), nil
+}
+
+func contractDataSize(method []byte, args []interface{}) (int, bool) {
+	size := len(method)
+	for _, v := range args {
+		n, ok := encodedSize(v)
+		if !ok {
+			return 0, false
+		}
+		size += n
+	}
+
+	return size, true
 }
 
 func isClosedNetworkError(err error) bool {
@@ -2,6 +2,7 @@ package pkg
 
 import (
 	"encoding/hex"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -17,3 +18,28 @@ func TestDecodeAccountIDFromSS58(t *testing.T) {
 	//then
 	assert.Equal(t, publicKey, accountID[:])
 }
+
+func TestGetContractData(t *testing.T) {
+	//given
+	method := []byte{0x38, 0x02, 0xcb, 0x77}
+
+	//when
+	first, err := GetContractData(method, types.U32(1))
+	assert.NoError(t, err)
+	second, err := GetContractData(method, types.U32(2))
+	assert.NoError(t, err)
+
+	//then
+	assert.Equal(t, []byte{0x38, 0x02, 0xcb, 0x77, 1, 0, 0, 0}, first)
+	assert.Equal(t, []byte{0x38, 0x02, 0xcb, 0x77, 2, 0, 0, 0}, second)
+}
+
+func BenchmarkGetContractData(b *testing.B) {
+	method := []byte{0x38, 0x02, 0xcb, 0x77}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := GetContractData(method, types.U32(i), types.U32(100), types.NewOptionAccountIDEmpty()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ require (
 	github.com/ChainSafe/go-schnorrkel v1.0.0
 	github.com/ethereum/go-ethereum v1.10.17
 	github.com/ipfs/go-cid v0.0.7
+	github.com/multiformats/go-multihash v0.0.13
 	github.com/sirupsen/logrus v1.8.1
 	github.com/stretchr/testify v1.7.0
 	github.com/vedhavyas/go-subkey v1.0.3
@@ -25,7 +26,6 @@ require (
 	github.com/multiformats/go-base32 v0.0.3 // indirect
 	github.com/multiformats/go-base36 v0.1.0 // indirect
 	github.com/multiformats/go-multibase v0.0.3 // indirect
-	github.com/multiformats/go-multihash v0.0.13 // indirect
 	github.com/multiformats/go-varint v0.0.5 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/spaolacci/murmur3 v1.1.0 // indirect
@@ -1,7 +1,12 @@
 package cid
 
 import (
+	"hash"
+	"sync"
+
 	"github.com/ipfs/go-cid"
+	"github.com/multiformats/go-multihash"
+	"golang.org/x/crypto/blake2b"
 )
 
 const (
@@ -9,6 +14,14 @@ const (
 	defaultCodec = cid.Raw
 )
 
+// blake2b256Pool reuses Blake2b-256 hashers across Build calls, the default multihash of pieces.
+var blake2b256Pool = sync.Pool{
+	New: func() interface{} {
+		h, _ := blake2b.New256(nil)
+		return h
+	},
+}
+
 type Builder struct {
 	cidBuilder cid.V1Builder
 }
@@ -21,10 +34,25 @@ func CreateBuilder(mhType uint64) *Builder {
 }
 
 func (b *Builder) Build(data []byte) (string, error) {
-	c, err := b.cidBuilder.Sum(data)
+	if b.cidBuilder.MhType != Blake2b256 {
+		c, err := b.cidBuilder.Sum(data)
+		if err != nil {
+			return "", err
+		}
+		return c.String(), nil
+	}
+
+	h := blake2b256Pool.Get().(hash.Hash)
+	h.Reset()
+	h.Write(data)
+	var digest [blake2b.Size256]byte
+	h.Sum(digest[:0])
+	blake2b256Pool.Put(h)
+
+	mh, err := multihash.Encode(digest[:], Blake2b256)
 	if err != nil {
 		return "", err
 	}
 
-	return c.String(), nil
+	return cid.NewCidV1(defaultCodec, mh).String(), nil
 }
@@ -21,3 +21,19 @@ func doTestGetPieceCid(t *testing.T, mhType uint64, data string, expectedCid str
 	assert.NoError(t, err)
 	assert.Equal(t, expectedCid, c)
 }
+
+func TestBuildSha256(t *testing.T) {
+	doTestGetPieceCid(t, 0x12, "Hello world!", "bafkreigaknpexyvxt76zgkitavbwx6ejgfheup5oybpm77f3pxzrvwpfdi")
+}
+
+func BenchmarkBuild(b *testing.B) {
+	builder := CreateBuilder(Blake2b256)
+	data := make([]byte, 1<<20)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := builder.Build(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
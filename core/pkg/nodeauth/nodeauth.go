@@ -0,0 +1,129 @@
+// Package nodeauth signs and verifies the challenge/response which DDC nodes use to authenticate
+// their operators: the node issues a random nonce and the operator proves possession of the node key
+// by signing it, so node-management tools can authenticate to their own nodes.
+package nodeauth
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/core/pkg/crypto"
+)
+
+const (
+	// NonceSize is the size of the challenge nonce in bytes.
+	NonceSize = 32
+	// DefaultMaxAge is how long a challenge can be answered after it was issued.
+	DefaultMaxAge = time.Minute
+
+	// messagePrefix separates signed challenges from other messages signed with the node key.
+	messagePrefix = "ddc-node-auth"
+)
+
+var (
+	ErrNonceMismatch   = errors.New("response to another challenge")
+	ErrWrongNode       = errors.New("challenge issued for another node")
+	ErrWrongKey        = errors.New("response signed with another key than the node key")
+	ErrExpired         = errors.New("challenge expired")
+	ErrInvalidResponse = errors.New("invalid response signature")
+)
+
+type (
+	Challenge struct {
+		// NodeKey is the hex encoded public key of the node the challenge is issued by.
+		NodeKey string `json:"nodeKey"`
+		Nonce   []byte `json:"nonce"`
+		// Timestamp is the issue time in milliseconds since epoch.
+		Timestamp int64 `json:"timestamp"`
+	}
+
+	Response struct {
+		Challenge Challenge `json:"challenge"`
+		Scheme    string    `json:"scheme"`
+		PublicKey string    `json:"publicKey"`
+		Signature []byte    `json:"signature"`
+	}
+)
+
+// NewChallenge issues a challenge with a random nonce for the node.
+func NewChallenge(nodeKey string) (Challenge, error) {
+	nonce := make([]byte, NonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return Challenge{}, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	return Challenge{NodeKey: normalizeKey(nodeKey), Nonce: nonce, Timestamp: time.Now().UnixMilli()}, nil
+}
+
+// Message is the signed form of the challenge: "ddc-node-auth:<node key>:<hex nonce>:<timestamp>".
+func (c Challenge) Message() []byte {
+	return []byte(strings.Join([]string{
+		messagePrefix,
+		normalizeKey(c.NodeKey),
+		hex.EncodeToString(c.Nonce),
+		strconv.FormatInt(c.Timestamp, 10),
+	}, ":"))
+}
+
+// Respond signs the challenge with the node key.
+func Respond(scheme crypto.Scheme, challenge Challenge) (*Response, error) {
+	if normalizeKey(challenge.NodeKey) != normalizeKey(scheme.PublicKeyHex()) {
+		return nil, ErrWrongNode
+	}
+
+	signature, err := scheme.Sign(challenge.Message())
+	if err != nil {
+		return nil, fmt.Errorf("sign challenge: %w", err)
+	}
+
+	return &Response{
+		Challenge: challenge,
+		Scheme:    scheme.Name(),
+		PublicKey: scheme.PublicKeyHex(),
+		Signature: signature,
+	}, nil
+}
+
+// Verify checks the response answers the issued challenge in time and is signed with the node key.
+func Verify(issued Challenge, response *Response, maxAge time.Duration) error {
+	answered := response.Challenge
+	if !bytes.Equal(issued.Nonce, answered.Nonce) || issued.Timestamp != answered.Timestamp {
+		return ErrNonceMismatch
+	}
+	if normalizeKey(issued.NodeKey) != normalizeKey(answered.NodeKey) {
+		return ErrWrongNode
+	}
+	if normalizeKey(response.PublicKey) != normalizeKey(issued.NodeKey) {
+		return ErrWrongKey
+	}
+	if maxAge <= 0 {
+		maxAge = DefaultMaxAge
+	}
+	if time.Since(time.UnixMilli(issued.Timestamp)) > maxAge {
+		return ErrExpired
+	}
+
+	publicKey, err := hex.DecodeString(normalizeKey(response.PublicKey))
+	if err != nil {
+		return fmt.Errorf("decode public key: %w", err)
+	}
+	ok, err := crypto.Verify(crypto.SchemeName(response.Scheme), publicKey, answered.Message(), response.Signature)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidResponse
+	}
+
+	return nil
+}
+
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.TrimPrefix(key, "0x"))
+}
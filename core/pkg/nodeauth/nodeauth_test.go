@@ -0,0 +1,114 @@
+package nodeauth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/core/pkg/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	nodeSeed  = "0x0029ffc486837f4d7159837fdcdffef0c4283e4ae77af25a4ea1d76ab38bbb5a"
+	otherSeed = "6e40d467e86ec447ae0088c81072feff8c860eebcff7dc44017b1b15746cce0d"
+)
+
+func TestVerify(t *testing.T) {
+	for _, schemeName := range []crypto.SchemeName{crypto.Sr25519, crypto.Ed25519} {
+		nodeScheme, err := crypto.CreateScheme(schemeName, nodeSeed)
+		assert.NoError(t, err)
+		otherScheme, err := crypto.CreateScheme(schemeName, otherSeed)
+		assert.NoError(t, err)
+
+		tests := []struct {
+			name    string
+			respond func(t *testing.T, issued Challenge) *Response
+			wantErr error
+		}{
+			{
+				name: "signed with node key",
+				respond: func(t *testing.T, issued Challenge) *Response {
+					response, err := Respond(nodeScheme, issued)
+					assert.NoError(t, err)
+					return response
+				},
+			},
+			{
+				name: "another nonce",
+				respond: func(t *testing.T, issued Challenge) *Response {
+					issued.Nonce = append([]byte{}, issued.Nonce...)
+					issued.Nonce[0]++
+					response, err := Respond(nodeScheme, issued)
+					assert.NoError(t, err)
+					return response
+				},
+				wantErr: ErrNonceMismatch,
+			},
+			{
+				name: "another key",
+				respond: func(t *testing.T, issued Challenge) *Response {
+					signature, err := otherScheme.Sign(issued.Message())
+					assert.NoError(t, err)
+					return &Response{Challenge: issued, Scheme: otherScheme.Name(), PublicKey: otherScheme.PublicKeyHex(), Signature: signature}
+				},
+				wantErr: ErrWrongKey,
+			},
+			{
+				name: "forged signature",
+				respond: func(t *testing.T, issued Challenge) *Response {
+					signature, err := otherScheme.Sign(issued.Message())
+					assert.NoError(t, err)
+					return &Response{Challenge: issued, Scheme: nodeScheme.Name(), PublicKey: nodeScheme.PublicKeyHex(), Signature: signature}
+				},
+				wantErr: ErrInvalidResponse,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(string(schemeName)+" "+tt.name, func(t *testing.T) {
+				//given
+				issued, err := NewChallenge(nodeScheme.PublicKeyHex())
+				assert.NoError(t, err)
+				response := tt.respond(t, issued)
+
+				//when
+				err = Verify(issued, response, time.Minute)
+
+				//then
+				assert.ErrorIs(t, err, tt.wantErr)
+			})
+		}
+	}
+}
+
+func TestVerifyExpired(t *testing.T) {
+	//given
+	scheme, err := crypto.CreateScheme(crypto.Sr25519, nodeSeed)
+	assert.NoError(t, err)
+	issued, err := NewChallenge(scheme.PublicKeyHex())
+	assert.NoError(t, err)
+	issued.Timestamp = time.Now().Add(-2 * time.Minute).UnixMilli()
+	response, err := Respond(scheme, issued)
+	assert.NoError(t, err)
+
+	//when
+	err = Verify(issued, response, time.Minute)
+
+	//then
+	assert.ErrorIs(t, err, ErrExpired)
+}
+
+func TestRespondWrongNode(t *testing.T) {
+	//given
+	scheme, err := crypto.CreateScheme(crypto.Sr25519, nodeSeed)
+	assert.NoError(t, err)
+	otherScheme, err := crypto.CreateScheme(crypto.Sr25519, otherSeed)
+	assert.NoError(t, err)
+	issued, err := NewChallenge(otherScheme.PublicKeyHex())
+	assert.NoError(t, err)
+
+	//when
+	_, err = Respond(scheme, issued)
+
+	//then
+	assert.ErrorIs(t, err, ErrWrongNode)
+}
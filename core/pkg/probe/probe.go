@@ -0,0 +1,255 @@
+// Package probe measures quality of CDN nodes: time to first byte, throughput and correctness of
+// sample pieces served by a node. Probe results are signed with the prober's key so they can be
+// collected from many probers and verified by anyone, e.g. for validator-style quality reporting.
+package probe
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/core/pkg/crypto"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/core/pkg/utils"
+	"github.com/ipfs/go-cid"
+)
+
+type (
+	// Sample is a piece known to be stored in DDC which CDN nodes are probed with.
+	Sample struct {
+		BucketId uint32 `json:"bucketId"`
+		Cid      string `json:"cid"`
+	}
+
+	Result struct {
+		NodeUrl  string `json:"nodeUrl"`
+		BucketId uint32 `json:"bucketId"`
+		Cid      string `json:"cid"`
+		// Timestamp is the probe start time in milliseconds since epoch.
+		Timestamp int64 `json:"timestamp"`
+		// Ttfb is the time from sending the request to receiving the first byte of the piece.
+		Ttfb time.Duration `json:"ttfb"`
+		// Duration is the time from sending the request to receiving the whole piece.
+		Duration time.Duration `json:"duration"`
+		Bytes    int64         `json:"bytes"`
+		// Throughput is in bytes per second.
+		Throughput float64 `json:"throughput"`
+		// Valid is true if the node returned the piece matching the sample CID.
+		Valid bool   `json:"valid"`
+		Error string `json:"error,omitempty"`
+	}
+
+	SignedResult struct {
+		Result          Result `json:"result"`
+		Scheme          string `json:"scheme"`
+		ProberPublicKey string `json:"proberPublicKey"`
+		Signature       []byte `json:"signature"`
+	}
+
+	Prober struct {
+		scheme     crypto.Scheme
+		httpClient *http.Client
+		pieceUrl   func(nodeUrl string, sample Sample) string
+		publisher  Publisher
+		timeout    time.Duration
+		now        func() time.Time
+	}
+
+	Option func(p *Prober)
+)
+
+const defaultProbeTimeout = 30 * time.Second
+
+// WithHttpClient sets the HTTP client used to request pieces from CDN nodes.
+func WithHttpClient(httpClient *http.Client) Option {
+	return func(p *Prober) {
+		p.httpClient = httpClient
+	}
+}
+
+// WithPieceUrl sets how the URL of the sample piece on a CDN node is built.
+func WithPieceUrl(pieceUrl func(nodeUrl string, sample Sample) string) Option {
+	return func(p *Prober) {
+		p.pieceUrl = pieceUrl
+	}
+}
+
+// WithPublisher publishes results of ProbeAll, e.g. to a quality reporting endpoint.
+func WithPublisher(publisher Publisher) Option {
+	return func(p *Prober) {
+		p.publisher = publisher
+	}
+}
+
+// WithTimeout limits time of a single probe.
+func WithTimeout(timeout time.Duration) Option {
+	return func(p *Prober) {
+		p.timeout = timeout
+	}
+}
+
+func CreateProber(scheme crypto.Scheme, opts ...Option) *Prober {
+	p := &Prober{
+		scheme:     scheme,
+		httpClient: http.DefaultClient,
+		pieceUrl:   DefaultPieceUrl,
+		timeout:    defaultProbeTimeout,
+		now:        time.Now,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// DefaultPieceUrl is the URL of the piece in the CDN node REST API.
+func DefaultPieceUrl(nodeUrl string, sample Sample) string {
+	return fmt.Sprintf("%s/api/rest/pieces/%s?bucketId=%d", strings.TrimSuffix(nodeUrl, "/"), sample.Cid, sample.BucketId)
+}
+
+// Probe requests the sample piece from the CDN node and returns the signed result.
+// Node failures are reported in Result.Error, the error is returned only if the result can't be signed.
+func (p *Prober) Probe(ctx context.Context, nodeUrl string, sample Sample) (*SignedResult, error) {
+	result := p.measure(ctx, nodeUrl, sample)
+	return p.Sign(result)
+}
+
+// ProbeAll probes every node with every sample and publishes the results if a publisher is set.
+func (p *Prober) ProbeAll(ctx context.Context, nodeUrls []string, samples []Sample) ([]SignedResult, error) {
+	results := make([]SignedResult, 0, len(nodeUrls)*len(samples))
+	for _, nodeUrl := range nodeUrls {
+		for _, sample := range samples {
+			if err := ctx.Err(); err != nil {
+				return results, err
+			}
+
+			result, err := p.Probe(ctx, nodeUrl, sample)
+			if err != nil {
+				return results, err
+			}
+			results = append(results, *result)
+		}
+	}
+
+	if p.publisher != nil && len(results) > 0 {
+		if err := p.publisher.Publish(ctx, results); err != nil {
+			return results, fmt.Errorf("publish probe results: %w", err)
+		}
+	}
+
+	return results, nil
+}
+
+// Sign signs the JSON encoded result with the prober's key.
+func (p *Prober) Sign(result Result) (*SignedResult, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := p.scheme.Sign(data)
+	if err != nil {
+		return nil, fmt.Errorf("sign probe result: %w", err)
+	}
+
+	return &SignedResult{
+		Result:          result,
+		Scheme:          p.scheme.Name(),
+		ProberPublicKey: p.scheme.PublicKeyHex(),
+		Signature:       signature,
+	}, nil
+}
+
+// Verify checks the result was signed by the key of ProberPublicKey.
+func (r *SignedResult) Verify() (bool, error) {
+	publicKey, err := hex.DecodeString(strings.TrimPrefix(r.ProberPublicKey, "0x"))
+	if err != nil {
+		return false, fmt.Errorf("decode prober public key: %w", err)
+	}
+
+	data, err := json.Marshal(r.Result)
+	if err != nil {
+		return false, err
+	}
+
+	return crypto.Verify(crypto.SchemeName(r.Scheme), publicKey, data, r.Signature)
+}
+
+func (p *Prober) measure(ctx context.Context, nodeUrl string, sample Sample) Result {
+	result := Result{NodeUrl: nodeUrl, BucketId: sample.BucketId, Cid: sample.Cid}
+
+	expected, err := cid.Decode(sample.Cid)
+	if err != nil {
+		result.Error = "invalid sample CID: " + err.Error()
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.pieceUrl(nodeUrl, sample), nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	start := p.now()
+	result.Timestamp = start.UnixMilli()
+
+	response, err := p.httpClient.Do(req)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer response.Body.Close()
+
+	if !utils.IsSuccessHttpStatus(response.StatusCode) {
+		result.Error = fmt.Sprintf("unexpected status: %d", response.StatusCode)
+		return result
+	}
+
+	body := &ttfbReader{reader: response.Body, now: p.now}
+	data, err := io.ReadAll(body)
+	result.Duration = p.now().Sub(start)
+	result.Bytes = int64(len(data))
+	if !body.first.IsZero() {
+		result.Ttfb = body.first.Sub(start)
+	}
+	if result.Duration > 0 {
+		result.Throughput = float64(result.Bytes) / result.Duration.Seconds()
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	actual, err := expected.Prefix().Sum(data)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Valid = actual.Equals(expected)
+
+	return result
+}
+
+// ttfbReader records the time the first byte is read.
+type ttfbReader struct {
+	reader io.Reader
+	now    func() time.Time
+	first  time.Time
+}
+
+func (r *ttfbReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 && r.first.IsZero() {
+		r.first = r.now()
+	}
+
+	return n, err
+}
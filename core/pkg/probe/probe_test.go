@@ -0,0 +1,146 @@
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/core/pkg/cid"
+	"github.com/cerebellum-network/cere-ddc-sdk-go/core/pkg/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+const proberSeed = "6e40d467e86ec447ae0088c81072feff8c860eebcff7dc44017b1b15746cce0d"
+
+func testProber(t *testing.T, opts ...Option) *Prober {
+	scheme, err := crypto.CreateScheme(crypto.Sr25519, proberSeed)
+	assert.NoError(t, err)
+
+	return CreateProber(scheme, opts...)
+}
+
+func testSample(t *testing.T, piece []byte) Sample {
+	pieceCid, err := cid.CreateBuilder(0).Build(piece)
+	assert.NoError(t, err)
+
+	return Sample{BucketId: 1, Cid: pieceCid}
+}
+
+func TestProbe(t *testing.T) {
+	piece := []byte("piece content")
+	sample := testSample(t, piece)
+
+	tests := []struct {
+		name      string
+		handler   http.HandlerFunc
+		wantValid bool
+		wantError bool
+	}{
+		{
+			name: "valid piece",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/rest/pieces/"+sample.Cid, r.URL.Path)
+				assert.Equal(t, "1", r.URL.Query().Get("bucketId"))
+				_, _ = w.Write(piece)
+			},
+			wantValid: true,
+		},
+		{
+			name: "corrupted piece",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("corrupted content"))
+			},
+		},
+		{
+			name: "node error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//given
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+			prober := testProber(t)
+
+			//when
+			result, err := prober.Probe(context.Background(), server.URL, sample)
+
+			//then
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantValid, result.Result.Valid)
+			assert.Equal(t, tt.wantError, result.Result.Error != "")
+			valid, err := result.Verify()
+			assert.NoError(t, err)
+			assert.True(t, valid)
+		})
+	}
+}
+
+func TestProbeMeasures(t *testing.T) {
+	//given
+	piece := []byte("piece content")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(piece)
+	}))
+	defer server.Close()
+	prober := testProber(t)
+
+	//when
+	result, err := prober.Probe(context.Background(), server.URL, testSample(t, piece))
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(piece)), result.Result.Bytes)
+	assert.Positive(t, int64(result.Result.Ttfb))
+	assert.GreaterOrEqual(t, int64(result.Result.Duration), int64(result.Result.Ttfb))
+	assert.Positive(t, result.Result.Throughput)
+}
+
+func TestSignedResultVerifyTampered(t *testing.T) {
+	//given
+	prober := testProber(t)
+	result, err := prober.Sign(Result{NodeUrl: "https://cdn.example.com", Valid: false})
+	assert.NoError(t, err)
+
+	//when
+	result.Result.Valid = true
+	valid, err := result.Verify()
+
+	//then
+	assert.NoError(t, err)
+	assert.False(t, valid)
+}
+
+func TestProbeAllPublishes(t *testing.T) {
+	//given
+	piece := []byte("piece content")
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(piece)
+	}))
+	defer node.Close()
+
+	var published []SignedResult
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&published))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer endpoint.Close()
+	endpointUrl, _ := url.Parse(endpoint.URL)
+
+	prober := testProber(t, WithPublisher(CreateHttpPublisher(*endpointUrl, http.Client{})))
+
+	//when
+	results, err := prober.ProbeAll(context.Background(), []string{node.URL, node.URL}, []Sample{testSample(t, piece)})
+
+	//then
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, results, published)
+}
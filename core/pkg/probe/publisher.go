@@ -0,0 +1,64 @@
+package probe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/cerebellum-network/cere-ddc-sdk-go/core/pkg/utils"
+)
+
+type (
+	Publisher interface {
+		Publish(ctx context.Context, results []SignedResult) error
+	}
+
+	httpPublisher struct {
+		url        url.URL
+		httpClient http.Client
+	}
+)
+
+const publishTimeout = 10 * time.Second
+
+// CreateHttpPublisher creates a publisher posting signed results as a JSON array to the URL.
+func CreateHttpPublisher(url url.URL, httpClient http.Client) Publisher {
+	return &httpPublisher{
+		url:        url,
+		httpClient: httpClient,
+	}
+}
+
+func (h *httpPublisher) Publish(ctx context.Context, results []SignedResult) error {
+	body, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url.String(), bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	response, err := h.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if !utils.IsSuccessHttpStatus(response.StatusCode) {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("probe results post: %d %s", response.StatusCode, string(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package topology
+
+import (
+	"errors"
+	"sort"
+)
+
+var (
+	ErrNodeExists   = errors.New("node is already in the topology")
+	ErrNodeNotFound = errors.New("node is not in the topology")
+	ErrLastNode     = errors.New("the last node can't leave the topology")
+)
+
+type (
+	// Move is a virtual node reassigned from one node to another.
+	Move struct {
+		Token uint64
+		From  string
+		To    string
+	}
+
+	// Migration is a range of tokens which pieces the target node has to copy from the sources,
+	// the nodes which replicated the range before the rebalance.
+	Migration struct {
+		From    uint64
+		To      uint64
+		Target  string
+		Sources []string
+	}
+
+	// RebalancePlan is the minimal reassignment of virtual nodes keeping the topology balanced
+	// after a node joins or leaves, the contract calls applying it and the pieces to migrate.
+	RebalancePlan struct {
+		// Nodes are the virtual nodes of the nodes after the rebalance.
+		Nodes NodesVNodes
+		Moves []Move
+		// Replace are the virtual nodes to reassign to the joining node with ClusterReplaceNode.
+		Replace *NodeVNodes
+		// Reset are the nodes to set the virtual nodes of with ClusterResetNode, ordered by node key.
+		Reset NodesVNodes
+		// Migrations are ordered by target node and token.
+		Migrations []Migration
+	}
+)
+
+// PlanJoin moves virtual nodes of the most loaded nodes to the joining node until it has its fair
+// share of them.
+func PlanJoin(nodes NodesVNodes, nodeKey string, replicationFactor uint) (*RebalancePlan, error) {
+	assignment := newAssignment(nodes)
+	if _, ok := assignment[nodeKey]; ok {
+		return nil, ErrNodeExists
+	}
+
+	total := 0
+	for _, tokens := range assignment {
+		total += len(tokens)
+	}
+	share := total / (len(assignment) + 1)
+
+	var moves []Move
+	joined := &NodeVNodes{NodeKey: nodeKey}
+	for len(joined.VNodes) < share {
+		donor := assignment.mostLoaded()
+		token := assignment[donor][0]
+		assignment[donor] = assignment[donor][1:]
+		joined.VNodes = append(joined.VNodes, token)
+		moves = append(moves, Move{Token: token, From: donor, To: nodeKey})
+	}
+	assignment[nodeKey] = joined.VNodes
+
+	plan := newPlan(nodes, assignment, moves, replicationFactor)
+	if len(moves) > 0 {
+		plan.Replace = joined
+	}
+
+	return plan, nil
+}
+
+// PlanLeave moves virtual nodes of the leaving node to the least loaded nodes. The node can be
+// removed from the cluster once its pieces are migrated.
+func PlanLeave(nodes NodesVNodes, nodeKey string, replicationFactor uint) (*RebalancePlan, error) {
+	assignment := newAssignment(nodes)
+	tokens, ok := assignment[nodeKey]
+	if !ok {
+		return nil, ErrNodeNotFound
+	}
+	if len(assignment) == 1 {
+		return nil, ErrLastNode
+	}
+	delete(assignment, nodeKey)
+
+	moves := make([]Move, 0, len(tokens))
+	reset := make(map[string]bool)
+	for _, token := range tokens {
+		receiver := assignment.leastLoaded()
+		assignment[receiver] = append(assignment[receiver], token)
+		moves = append(moves, Move{Token: token, From: nodeKey, To: receiver})
+		reset[receiver] = true
+	}
+
+	plan := newPlan(nodes, assignment, moves, replicationFactor)
+	for _, node := range plan.Nodes {
+		if reset[node.NodeKey] {
+			plan.Reset = append(plan.Reset, node)
+		}
+	}
+
+	return plan, nil
+}
+
+// assignment is the sorted tokens of virtual nodes by node key.
+type assignment map[string][]uint64
+
+func newAssignment(nodes NodesVNodes) assignment {
+	a := make(assignment, len(nodes))
+	for _, node := range nodes {
+		tokens := append([]uint64(nil), node.VNodes...)
+		sort.Slice(tokens, func(i, j int) bool { return tokens[i] < tokens[j] })
+		a[node.NodeKey] = tokens
+	}
+
+	return a
+}
+
+func (a assignment) mostLoaded() string {
+	return a.pick(func(tokens, best int) bool { return tokens > best })
+}
+
+func (a assignment) leastLoaded() string {
+	return a.pick(func(tokens, best int) bool { return tokens < best })
+}
+
+// pick returns the first node by key which number of tokens is better than of all others.
+func (a assignment) pick(better func(tokens, best int) bool) string {
+	best := ""
+	for _, nodeKey := range a.nodeKeys() {
+		if best == "" || better(len(a[nodeKey]), len(a[best])) {
+			best = nodeKey
+		}
+	}
+
+	return best
+}
+
+func (a assignment) nodeKeys() []string {
+	keys := make([]string, 0, len(a))
+	for nodeKey := range a {
+		keys = append(keys, nodeKey)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (a assignment) nodes() NodesVNodes {
+	nodes := make(NodesVNodes, 0, len(a))
+	for _, nodeKey := range a.nodeKeys() {
+		tokens := append([]uint64(nil), a[nodeKey]...)
+		sort.Slice(tokens, func(i, j int) bool { return tokens[i] < tokens[j] })
+		nodes = append(nodes, NodeVNodes{NodeKey: nodeKey, VNodes: tokens})
+	}
+
+	return nodes
+}
+
+func newPlan(before NodesVNodes, after assignment, moves []Move, replicationFactor uint) *RebalancePlan {
+	nodes := after.nodes()
+
+	return &RebalancePlan{
+		Nodes:      nodes,
+		Moves:      moves,
+		Migrations: migrations(NewTopology(before, replicationFactor), NewTopology(nodes, replicationFactor)),
+	}
+}
+
+// migrations compares replicas of ranges between tokens of both rings and returns the ranges each
+// node replicates after the rebalance but didn't before.
+func migrations(before Ring, after Ring) []Migration {
+	var starts []uint64
+	seen := make(map[uint64]bool)
+	for _, ring := range []Ring{before, after} {
+		for _, vNode := range ring.VNodes() {
+			if !seen[vNode.Token()] {
+				seen[vNode.Token()] = true
+				starts = append(starts, vNode.Token())
+			}
+		}
+	}
+	if len(starts) == 0 || len(before.VNodes()) == 0 {
+		return nil
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+
+	var result []Migration
+	for i, start := range starts {
+		end := starts[nextIndex(i, len(starts))] - 1
+		sources := replicaNodes(before, start)
+		for _, target := range replicaNodes(after, start) {
+			if contains(sources, target) {
+				continue
+			}
+			result = appendMigration(result, Migration{From: start, To: end, Target: target, Sources: sources})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Target != result[j].Target {
+			return result[i].Target < result[j].Target
+		}
+		return result[i].From < result[j].From
+	})
+
+	return result
+}
+
+// appendMigration extends the last migration of the target if the range continues it.
+func appendMigration(migrations []Migration, migration Migration) []Migration {
+	for i := len(migrations) - 1; i >= 0; i-- {
+		last := &migrations[i]
+		if last.Target == migration.Target && last.To+1 == migration.From && equal(last.Sources, migration.Sources) {
+			last.To = migration.To
+			return migrations
+		}
+	}
+
+	return append(migrations, migration)
+}
+
+// replicaNodes returns the sorted distinct keys of the nodes replicating the token.
+func replicaNodes(ring Ring, token uint64) []string {
+	var keys []string
+	for _, vNode := range ring.Replicas(token) {
+		if !contains(keys, vNode.NodeKey()) {
+			keys = append(keys, vNode.NodeKey())
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func contains(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,74 @@
+package topology
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanJoin(t *testing.T) {
+	//given
+	nodes := NodesVNodes{
+		{NodeKey: "a", VNodes: []uint64{0, 300, 600}},
+		{NodeKey: "b", VNodes: []uint64{100, 400, 700}},
+		{NodeKey: "c", VNodes: []uint64{200, 500, 800}},
+	}
+
+	//when
+	plan, err := PlanJoin(nodes, "d", 1)
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, []Move{{Token: 0, From: "a", To: "d"}, {Token: 100, From: "b", To: "d"}}, plan.Moves)
+	assert.Equal(t, &NodeVNodes{NodeKey: "d", VNodes: []uint64{0, 100}}, plan.Replace)
+	assert.Empty(t, plan.Reset)
+	assert.Equal(t, NodesVNodes{
+		{NodeKey: "a", VNodes: []uint64{300, 600}},
+		{NodeKey: "b", VNodes: []uint64{400, 700}},
+		{NodeKey: "c", VNodes: []uint64{200, 500, 800}},
+		{NodeKey: "d", VNodes: []uint64{0, 100}},
+	}, plan.Nodes)
+	assert.Equal(t, []Migration{
+		{From: 0, To: 99, Target: "d", Sources: []string{"a"}},
+		{From: 100, To: 199, Target: "d", Sources: []string{"b"}},
+	}, plan.Migrations)
+}
+
+func TestPlanLeave(t *testing.T) {
+	//given
+	nodes := NodesVNodes{
+		{NodeKey: "a", VNodes: []uint64{0, 400}},
+		{NodeKey: "b", VNodes: []uint64{100, 500}},
+		{NodeKey: "c", VNodes: []uint64{200, 600, 800}},
+		{NodeKey: "d", VNodes: []uint64{300, 700}},
+	}
+
+	//when
+	plan, err := PlanLeave(nodes, "c", 2)
+
+	//then
+	assert.NoError(t, err)
+	assert.Equal(t, []Move{{Token: 200, From: "c", To: "a"}, {Token: 600, From: "c", To: "b"}, {Token: 800, From: "c", To: "d"}}, plan.Moves)
+	assert.Nil(t, plan.Replace)
+	assert.Equal(t, NodesVNodes{
+		{NodeKey: "a", VNodes: []uint64{0, 200, 400}},
+		{NodeKey: "b", VNodes: []uint64{100, 500, 600}},
+		{NodeKey: "d", VNodes: []uint64{300, 700, 800}},
+	}, plan.Reset)
+	for _, migration := range plan.Migrations {
+		assert.NotEqual(t, "c", migration.Target)
+		assert.NotContains(t, migration.Sources, migration.Target)
+	}
+	assert.Contains(t, plan.Migrations, Migration{From: 200, To: 299, Target: "a", Sources: []string{"c", "d"}})
+}
+
+func TestPlanErrors(t *testing.T) {
+	nodes := NodesVNodes{{NodeKey: "a", VNodes: []uint64{0}}}
+
+	_, err := PlanJoin(nodes, "a", 1)
+	assert.ErrorIs(t, err, ErrNodeExists)
+	_, err = PlanLeave(nodes, "b", 1)
+	assert.ErrorIs(t, err, ErrNodeNotFound)
+	_, err = PlanLeave(nodes, "a", 1)
+	assert.ErrorIs(t, err, ErrLastNode)
+}
@@ -57,6 +57,14 @@ func (r *ring) ExcessPartitions(nodeKey string) []topology.Partition {
 	return result
 }
 
+func (r *ring) Without(token uint64) (topology.Ring, bool) {
+	r.mutex.RLock()
+	result, ok := r.ring.Without(token)
+	r.mutex.RUnlock()
+
+	return &ring{ring: result}, ok
+}
+
 func (r *ring) RemoveVNode(token uint64) bool {
 	r.mutex.Lock()
 	result := r.ring.RemoveVNode(token)
@@ -7,6 +7,8 @@ import (
 )
 
 type (
+	// Ring is a snapshot of the cluster topology. Returned slices are copies, so callers may keep
+	// and change them while the ring is used by other goroutines.
 	Ring interface {
 		Tokens(nodeKey string) []uint64
 		Neighbours(token uint64) (VNode, VNode)
@@ -15,6 +17,10 @@ type (
 		Partitions(nodeKey string) []Partition
 		ExcessPartitions(nodeKey string) []Partition
 
+		// Without returns a new ring without the virtual node, the ring itself doesn't change.
+		Without(token uint64) (Ring, bool)
+		// RemoveVNode removes the virtual node from the ring. Rings shared by goroutines should be
+		// replaced with the ring returned by Without instead.
 		RemoveVNode(token uint64) bool
 
 		VNodes() []VNode
@@ -134,19 +140,31 @@ func (r *ring) ExcessPartitions(nodeKey string) []Partition {
 	return result
 }
 
-func (r *ring) RemoveVNode(token uint64) bool {
-	vNodeId := r.search(token)
-	if vNodeId >= len(r.vNodes) || r.vNodes[vNodeId].Token() != token {
-		return false
-	}
+func (r *ring) Without(token uint64) (Ring, bool) {
+	vNodes, ok := r.without(token)
+	return &ring{vNodes: vNodes, replicationFactor: r.replicationFactor}, ok
+}
 
-	r.vNodes = utils.RemoveSorted(r.vNodes, vNodeId)
+func (r *ring) RemoveVNode(token uint64) bool {
+	vNodes, ok := r.without(token)
+	r.vNodes = vNodes
 
-	return true
+	return ok
 }
 
 func (r *ring) VNodes() []VNode {
-	return r.vNodes
+	return append([]VNode(nil), r.vNodes...)
+}
+
+// without returns virtual nodes without the one with the token in a new slice, so slices shared
+// before are never changed.
+func (r *ring) without(token uint64) ([]VNode, bool) {
+	vNodeId := r.search(token)
+	if vNodeId >= len(r.vNodes) || r.vNodes[vNodeId].Token() != token {
+		return r.vNodes, false
+	}
+
+	return utils.RemoveSorted(append([]VNode(nil), r.vNodes...), vNodeId), true
 }
 
 func (r *ring) ReplicationFactor() uint {
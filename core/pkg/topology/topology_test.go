@@ -346,3 +346,36 @@ func TestRemoveVNode(t *testing.T) {
 		})
 	}
 }
+
+func TestWithout(t *testing.T) {
+	//given
+	cluster := clusters[0]
+	testSubject := NewTopology(cluster.nodesVNodes, cluster.replicaFactor)
+	before := testSubject.VNodes()
+
+	//when
+	result, ok := testSubject.Without(9223372036854775806)
+	_, missing := testSubject.Without(100)
+
+	//then
+	assert.True(t, ok)
+	assert.False(t, missing)
+	assert.Equal(t, before, testSubject.VNodes())
+	assert.Len(t, result.VNodes(), len(before)-1)
+	assert.Equal(t, []uint64{3074457345618258602, 15372286728091293010}, result.Tokens(NodeKey1))
+	assert.Equal(t, cluster.replicaFactor, result.ReplicationFactor())
+}
+
+func TestVNodesSnapshot(t *testing.T) {
+	//given
+	cluster := clusters[0]
+	testSubject := NewTopology(cluster.nodesVNodes, cluster.replicaFactor)
+	snapshot := testSubject.VNodes()
+	expected := append([]VNode(nil), snapshot...)
+
+	//when
+	testSubject.RemoveVNode(3074457345618258602)
+
+	//then
+	assert.Equal(t, expected, snapshot)
+}
@@ -0,0 +1,158 @@
+// Package ddcerrors classifies errors of the SDK by codes, so applications can handle them and label
+// metrics uniformly whichever layer they come from: the contract, the pallets or the events.
+//
+// Errors are matched by code with Is, or with errors.Is for errors created by this package:
+//
+//	if ddcerrors.Is(err, ddcerrors.CodeNotFound) { ... }
+//	if errors.Is(err, ddcerrors.ErrNotFound) { ... }
+//
+// and labeled with CodeOf(err).String().
+package ddcerrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Code is a stable numeric error code. Codes are never renumbered, new codes are appended.
+type Code int
+
+const (
+	CodeUnknown Code = iota
+	CodeNotFound
+	CodeUnauthorized
+	CodeRpc
+	CodeDecoding
+	CodeInsufficientFunds
+	CodeTimeout
+	CodeInvalidArgument
+	CodeAlreadyExists
+	CodeExecution
+)
+
+var codeNames = map[Code]string{
+	CodeUnknown:           "unknown",
+	CodeNotFound:          "not_found",
+	CodeUnauthorized:      "unauthorized",
+	CodeRpc:               "rpc",
+	CodeDecoding:          "decoding",
+	CodeInsufficientFunds: "insufficient_funds",
+	CodeTimeout:           "timeout",
+	CodeInvalidArgument:   "invalid_argument",
+	CodeAlreadyExists:     "already_exists",
+	CodeExecution:         "execution",
+}
+
+// Errors matching any error with the code, e.g. errors.Is(bucket.ErrBucketDoesNotExist, ErrNotFound).
+var (
+	ErrNotFound          error = CodeNotFound
+	ErrUnauthorized      error = CodeUnauthorized
+	ErrRpc               error = CodeRpc
+	ErrDecoding          error = CodeDecoding
+	ErrInsufficientFunds error = CodeInsufficientFunds
+	ErrTimeout           error = CodeTimeout
+	ErrInvalidArgument   error = CodeInvalidArgument
+	ErrAlreadyExists     error = CodeAlreadyExists
+	ErrExecution         error = CodeExecution
+)
+
+// String returns the code name, suitable as a metrics label.
+func (c Code) String() string {
+	if name, ok := codeNames[c]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("code_%d", int(c))
+}
+
+func (c Code) Error() string {
+	return c.String()
+}
+
+// Coder is implemented by errors which know their code without being an Error.
+type Coder interface {
+	ErrorCode() Code
+}
+
+// Error is an error with a code. It matches the code with errors.Is and unwraps to the cause.
+type Error struct {
+	Code    Code
+	Message string
+	Err     error
+}
+
+// New creates an error with the code, use it for sentinel errors of packages.
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Wrap assigns the code to the error, it returns nil if err is nil.
+func Wrap(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{Code: code, Err: err}
+}
+
+// Wrapf assigns the code to the error and prefixes its message, it returns nil if err is nil.
+func Wrapf(code Code, err error, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...), Err: err}
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Err == nil:
+		return e.Message
+	case e.Message == "":
+		return e.Err.Error()
+	default:
+		return e.Message + ": " + e.Err.Error()
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Is(target error) bool {
+	code, ok := target.(Code)
+	return ok && code == e.Code
+}
+
+func (e *Error) ErrorCode() Code {
+	return e.Code
+}
+
+// CodeOf returns the code of the first error in the chain which has one. Context deadline errors
+// are CodeTimeout, errors without a code are CodeUnknown.
+func CodeOf(err error) Code {
+	if err == nil {
+		return CodeUnknown
+	}
+
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if coder, ok := e.(Coder); ok {
+			return coder.ErrorCode()
+		}
+		if code, ok := e.(Code); ok {
+			return code
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return CodeTimeout
+	}
+
+	return CodeUnknown
+}
+
+// Is reports whether the error has the code.
+func Is(err error, code Code) bool {
+	return err != nil && CodeOf(err) == code
+}
@@ -0,0 +1,71 @@
+package ddcerrors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string { return "timeout" }
+
+func (timeoutError) ErrorCode() Code { return CodeTimeout }
+
+func TestCodeOf(t *testing.T) {
+	errBucketNotFound := New(CodeNotFound, "bucket doesn't exist")
+
+	tests := []struct {
+		name string
+		err  error
+		want Code
+	}{
+		{name: "nil", err: nil, want: CodeUnknown},
+		{name: "plain", err: errors.New("boom"), want: CodeUnknown},
+		{name: "sentinel", err: errBucketNotFound, want: CodeNotFound},
+		{name: "wrapped sentinel", err: fmt.Errorf("get bucket 1: %w", errBucketNotFound), want: CodeNotFound},
+		{name: "wrapped", err: Wrap(CodeRpc, errors.New("connection refused")), want: CodeRpc},
+		{name: "coder", err: fmt.Errorf("call: %w", timeoutError{}), want: CodeTimeout},
+		{name: "context deadline", err: fmt.Errorf("call: %w", context.DeadlineExceeded), want: CodeTimeout},
+		{name: "code", err: ErrDecoding, want: CodeDecoding},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodeOf(tt.err); got != tt.want {
+				t.Errorf("CodeOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorsIs(t *testing.T) {
+	//given
+	errBucketNotFound := New(CodeNotFound, "bucket doesn't exist")
+	errAccountNotFound := New(CodeNotFound, "account doesn't exist")
+	cause := errors.New("connection refused")
+	wrapped := Wrapf(CodeRpc, cause, "read bucket %d", 1)
+
+	//then
+	checks := []struct {
+		name string
+		ok   bool
+	}{
+		{name: "wrapped sentinel is ErrNotFound", ok: errors.Is(fmt.Errorf("get: %w", errBucketNotFound), ErrNotFound)},
+		{name: "sentinels of a code differ", ok: !errors.Is(errBucketNotFound, errAccountNotFound)},
+		{name: "sentinel isn't ErrRpc", ok: !errors.Is(errBucketNotFound, ErrRpc)},
+		{name: "wrapped is ErrRpc", ok: errors.Is(wrapped, ErrRpc)},
+		{name: "wrapped is the cause", ok: errors.Is(wrapped, cause)},
+		{name: "wrapped has CodeRpc", ok: Is(wrapped, CodeRpc)},
+		{name: "wrapped nil is nil", ok: Wrap(CodeRpc, nil) == nil},
+		{name: "wrapped message", ok: wrapped.Error() == "read bucket 1: connection refused"},
+		{name: "code name", ok: CodeInsufficientFunds.String() == "insufficient_funds"},
+		{name: "unknown code name", ok: Code(99).String() == "code_99"},
+	}
+	for _, check := range checks {
+		if !check.ok {
+			t.Errorf("%s: failed", check.name)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/cerebellum-network/cere-ddc-sdk-go/ddcerrors
+
+go 1.18